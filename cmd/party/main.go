@@ -1,32 +1,105 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/alesr/getground/internal/app"
 	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/app/partygql"
+	"github.com/alesr/getground/internal/app/partygrpc"
+	"github.com/alesr/getground/internal/pkg/metrics"
 	"github.com/alesr/getground/internal/pkg/party"
 	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/internal/pkg/tracing"
 	"github.com/alesr/getground/pkg/database"
 	fiber "github.com/gofiber/fiber/v2"
 	envars "github.com/netflix/go-env"
 	"go.uber.org/zap"
 )
 
+// Build information, set at build time with -ldflags "-X main.version=..."
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildTime = "unknown"
+)
+
 type config struct {
-	Env            string `env:"ENV,default=dev"`
-	AppName        string `env:"APP_NAME,default=getground"`
-	Port           string `env:"PORT,default=3000"`
-	AllowHeaders   string `env:"ALLOW_HEADERS,default=Origin, Content-Type, Accept"`
-	PartyTableSize int    `env:"PARTY_TABLE_SIZE,default=12"`
-	DB             dbConfig
+	Env             string        `env:"ENV,default=dev"`
+	AppName         string        `env:"APP_NAME,default=getground"`
+	Port            string        `env:"PORT,default=3000"`
+	GRPCPort        string        `env:"GRPC_PORT,default=9090"`
+	AllowHeaders    string        `env:"ALLOW_HEADERS,default=Origin, Content-Type, Accept"`
+	PartyTableSize  int           `env:"PARTY_TABLE_SIZE,default=12"`
+	OrganiserKey    string        `env:"ORGANISER_API_KEY"`
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT,default=15s"`
+	ValidateRequest bool          `env:"VALIDATE_REQUESTS,default=false"`
+	Invitation      invitationConfig
+	Tracing         tracingConfig
+	DB              dbConfig
+}
+
+type tracingConfig struct {
+	Exporter     string  `env:"TRACING_EXPORTER,default=none"`
+	OTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT,default=localhost:4317"`
+	OTLPInsecure bool    `env:"TRACING_OTLP_INSECURE,default=false"`
+	SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
+}
+
+type invitationConfig struct {
+	Secret string        `env:"INVITATION_SECRET"`
+	TTL    time.Duration `env:"INVITATION_TTL,default=336h"`
 }
 
 type dbConfig struct {
-	Host     string `env:"MYSQL_HOST,default=127.0.0.1"`
-	User     string `env:"MYSQL_USER,default=user"`
-	Password string `env:"MYSQL_PASSWORD,default=password"`
-	Name     string `env:"MYSQL_DB,default=party_db"`
+	Host            string        `env:"MYSQL_HOST,default=127.0.0.1"`
+	User            string        `env:"MYSQL_USER,default=user"`
+	Password        string        `env:"MYSQL_PASSWORD,default=password"`
+	Name            string        `env:"MYSQL_DB,default=party_db"`
+	Params          string        `env:"MYSQL_PARAMS"`
+	TLS             string        `env:"MYSQL_TLS,default=false"`
+	TLSCACert       string        `env:"MYSQL_TLS_CA_CERT"`
+	MaxOpenConns    int           `env:"MYSQL_MAX_OPEN_CONNS,default=20"`
+	MaxIdleConns    int           `env:"MYSQL_MAX_IDLE_CONNS,default=10"`
+	ConnMaxLifetime time.Duration `env:"MYSQL_CONN_MAX_LIFETIME,default=5m"`
+	ConnMaxIdleTime time.Duration `env:"MYSQL_CONN_MAX_IDLE_TIME,default=1m"`
+	ConnectTimeout  time.Duration `env:"MYSQL_CONNECT_TIMEOUT,default=30s"`
+}
+
+// config parses the extra DSN parameters, given in query string format, e.g. charset=utf8mb4&readTimeout=5s.
+func (c dbConfig) config() (database.Config, error) {
+	values, err := url.ParseQuery(c.Params)
+	if err != nil {
+		return database.Config{}, fmt.Errorf("could not parse MYSQL_PARAMS: %w", err)
+	}
+
+	params := make(map[string]string, len(values))
+	for k := range values {
+		params[k] = values.Get(k)
+	}
+
+	return database.Config{
+		Host:            c.Host,
+		User:            c.User,
+		Password:        c.Password,
+		Name:            c.Name,
+		Params:          params,
+		TLS:             c.TLS,
+		TLSCACert:       c.TLSCACert,
+		MaxOpenConns:    c.MaxOpenConns,
+		MaxIdleConns:    c.MaxIdleConns,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
+		ConnectTimeout:  c.ConnectTimeout,
+	}, nil
 }
 
 func newConfig() *config {
@@ -48,43 +121,199 @@ func main() {
 		log.Fatalln("failed to initialize production logger:", err)
 	}
 
+	logger.Named(cfg.AppName)
+
+	logger.Info("initializing app", zap.String("env", cfg.Env), zap.String("version", version), zap.String("commit", commit))
+
+	// Resources are released by run before the logger is flushed
+	err = run(cfg, logger)
+	if err != nil {
+		logger.Error("failed to run app", zap.Error(err))
+	}
+
+	_ = logger.Sync()
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(cfg *config, logger *zap.Logger) error {
+	if cfg.OrganiserKey == "" {
+		logger.Warn("ORGANISER_API_KEY not set, organiser routes are unprotected")
+	}
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		ServiceName:  cfg.AppName,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
 	defer func() {
-		_ = logger.Sync()
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", zap.Error(err))
+		}
 	}()
 
-	logger.Named(cfg.AppName)
+	dbCfg, err := cfg.DB.config()
+	if err != nil {
+		return err
+	}
 
-	logger.Info("initializing app", zap.String("env", cfg.Env))
+	logger.Info("connecting to database", zap.String("host", dbCfg.Host), zap.Duration("timeout", dbCfg.ConnectTimeout))
 
-	dbConn, err := database.Connection(cfg.DB.Host, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	dbConn, err := database.Connect(context.Background(), dbCfg)
 	if err != nil {
-		logger.Fatal("failed to get database connection", zap.Error(err))
+		return fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	defer func() {
+		if err := dbConn.Close(); err != nil {
+			logger.Error("failed to close database connection", zap.Error(err))
+		}
+	}()
+
+	health := app.NewHealth(dbConn, app.BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+	})
+
 	logger.Info("applying database migrations")
 
-	dbConn.Table("guests").AutoMigrate(&repository.Guest{})
-	dbConn.Table("tables").AutoMigrate(&repository.Table{})
+	// Failed migrations are reported by the readiness probe
+	if err := repository.Migrate(dbConn); err != nil {
+		logger.Error("failed to apply database migrations", zap.Error(err))
+		health.SetMigrated(err)
+	} else {
+		logger.Info("database migrations DONE")
+		health.SetMigrated(nil)
+	}
 
-	logger.Info("database migrations DONE")
+	// Initialize metrics
+	appMetrics := metrics.New()
 
 	// Initialize repository
-	partyRepo := repository.New(logger, dbConn)
+	partyRepo := appMetrics.InstrumentRepository(repository.New(logger, dbConn))
 
-	// Initialize service
-	partyService := party.New(logger, partyRepo, cfg.PartyTableSize)
+	if err := appMetrics.Seed(context.Background(), partyRepo); err != nil {
+		return fmt.Errorf("failed to seed party metrics: %w", err)
+	}
 
-	// Initialize HTTP router
+	// Initialize service
+	if cfg.Invitation.Secret == "" {
+		logger.Warn("INVITATION_SECRET not set, invitations are disabled")
+	}
 
-	fiberApp := fiber.New()
+	// Events are fanned out to the gRPC and HTTP event streams
+	events := party.NewBroadcaster(0)
 
-	restApp := app.New(
+	partyService := party.New(
 		logger,
-		fiberApp,
-		partyctrl.New(logger, partyService),
+		partyRepo,
+		cfg.PartyTableSize,
+		party.WithInvitationSecret([]byte(cfg.Invitation.Secret)),
+		party.WithInvitationTTL(cfg.Invitation.TTL),
+		party.WithListener(appMetrics.PartyListener()),
+		party.WithListener(events.Listener()),
 	)
 
-	if err := restApp.Run(cfg.Port); err != nil {
-		logger.Fatal("failed to run rest app", zap.Error(err))
+	// Initialize HTTP router
+
+	// Guest names are path parameters, e.g. /v1/guests/John%20Doe
+	fiberApp := fiber.New(fiber.Config{UnescapePath: true})
+
+	graphqlHandler, err := partygql.New(logger, partyService)
+	if err != nil {
+		return fmt.Errorf("failed to set up graphql: %w", err)
 	}
+
+	appOpts := []app.Option{
+		app.WithOrganiserKey(cfg.OrganiserKey),
+		app.WithMetrics(appMetrics),
+		app.WithTracing(),
+		app.WithHealth(health),
+		app.WithGraphQL(graphqlHandler),
+		app.WithEvents(app.NewEvents(events)),
+		app.WithDashboard(),
+	}
+
+	if cfg.ValidateRequest {
+		appOpts = append(appOpts, app.WithRequestValidation())
+	}
+
+	restApp := app.New(logger, fiberApp, partyctrl.New(logger, partyService), appOpts...)
+
+	// Initialize gRPC server
+
+	grpcLis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return fmt.Errorf("failed to listen on grpc port: %w", err)
+	}
+
+	grpcApp := partygrpc.New(logger, partyService, events)
+	grpcServer := partygrpc.NewGRPCServer(grpcApp)
+
+	// Serve until the app fails or a termination signal is received
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- restApp.Run(cfg.Port)
+	}()
+
+	grpcErr := make(chan error, 1)
+	go func() {
+		logger.Info("grpc server listening", zap.String("port", cfg.GRPCPort))
+		grpcErr <- grpcServer.Serve(grpcLis)
+	}()
+
+	select {
+	case err := <-runErr:
+		grpcServer.Stop()
+		return fmt.Errorf("failed to run rest app: %w", err)
+	case err := <-grpcErr:
+		_ = restApp.Shutdown(context.Background())
+		return fmt.Errorf("failed to run grpc server: %w", err)
+	case <-ctx.Done():
+	}
+
+	// A second signal terminates the app immediately
+	stop()
+
+	logger.Info("shutting down, draining in-flight requests", zap.Duration("timeout", cfg.ShutdownTimeout))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Event streams never complete on their own, they are ended before the graceful stop
+	grpcApp.Close()
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	if err := restApp.Shutdown(shutdownCtx); err != nil {
+		grpcServer.Stop()
+		return fmt.Errorf("failed to shut down rest app: %w", err)
+	}
+
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		logger.Warn("grpc calls still in flight after shutdown timeout, stopping")
+		grpcServer.Stop()
+	}
+
+	logger.Info("shutdown DONE")
+	return nil
 }
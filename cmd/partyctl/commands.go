@@ -0,0 +1,961 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alesr/getground/pkg/client"
+)
+
+// errImportRejected is returned when the import has rejected rows, none of the rows is imported then.
+var errImportRejected = errors.New("import rejected, no guest was imported")
+
+// errSeatingUnsatisfied is returned when a seating plan leaves guests without a table or breaks constraints.
+var errSeatingUnsatisfied = errors.New("seating plan does not meet the tables or the constraints")
+
+type (
+	// command runs the partyctl commands against the party API.
+	command struct {
+		client *client.Client
+		out    *printer
+		stdin  io.Reader
+		stderr io.Writer
+	}
+
+	// commandFunc runs a command with the arguments following its name.
+	commandFunc func(cmd *command, ctx context.Context, args []string) error
+
+	// nameResult is the JSON output of the commands without a result of their own.
+	nameResult struct {
+		Name string `json:"name"`
+	}
+)
+
+// commands are the commands by name, subcommands are named after their parent command.
+var commands = map[string]commandFunc{
+	"guests add":     (*command).guestsAdd,
+	"guests list":    (*command).guestsList,
+	"guests remove":  (*command).guestsRemove,
+	"checkin":        (*command).checkin,
+	"checkout":       (*command).checkout,
+	"tables list":    (*command).tablesList,
+	"tables create":  (*command).tablesCreate,
+	"tables reserve": (*command).tablesReserve,
+	"tables release": (*command).tablesRelease,
+	"seats":          (*command).seats,
+
+	"groups list":     (*command).groupsList,
+	"groups add":      (*command).groupsAdd,
+	"groups show":     (*command).groupsShow,
+	"groups checkin":  (*command).groupsCheckin,
+	"groups checkout": (*command).groupsCheckout,
+
+	"requirements show": (*command).requirementsShow,
+	"requirements set":  (*command).requirementsSet,
+	"fields list":       (*command).fieldsList,
+	"fields set":        (*command).fieldsSet,
+	"catering":          (*command).catering,
+
+	"constraints list":   (*command).constraintsList,
+	"constraints add":    (*command).constraintsAdd,
+	"constraints delete": (*command).constraintsDelete,
+	"seating plan":       (*command).seatingPlan,
+	"seating check":      (*command).seatingCheck,
+
+	"import": (*command).importGuests,
+	"export": (*command).export,
+	"door":   (*command).door,
+}
+
+// run runs the command named by the first arguments.
+func (cmd *command) run(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		if fn, ok := commands[args[0]+" "+args[1]]; ok {
+			return fn(cmd, ctx, args[2:])
+		}
+	}
+
+	if fn, ok := commands[args[0]]; ok {
+		return fn(cmd, ctx, args[1:])
+	}
+	return usageErrorf("unknown command %q", args[0])
+}
+
+func (cmd *command) guestsAdd(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("guests add", "NAME")
+	tableNumber := fs.Int("table", 0, "table number")
+	companions := fs.Int("companions", 0, "number of accompanying guests")
+	tier := fs.String("tier", "", "guest tier, vip, staff or general, tiers other than general are organiser only")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cmd.client.AddGuestToGuestList(ctx, &client.AddGuestToGuestListInput{
+		Name:               name,
+		Table:              *tableNumber,
+		AccompanyingGuests: *companions,
+		Tier:               *tier,
+	}); err != nil {
+		return err
+	}
+
+	guest := client.Guest{Name: name, Table: *tableNumber, AccompanyingGuests: *companions, Tier: *tier}
+	return cmd.out.message(guest, "Added %s to table %d with %d accompanying guests", name, *tableNumber, *companions)
+}
+
+func (cmd *command) guestsList(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("guests list", "")
+
+	var in client.ListGuestsInput
+	fs.IntVar(&in.Table, "table", 0, "only the guests of a table")
+	arrived := fs.String("arrived", "", "only the guests at the party, true, or that did not arrive yet, false")
+	fs.StringVar(&in.NamePrefix, "prefix", "", "only the guests whose name starts with the prefix")
+	fs.StringVar(&in.Dietary, "dietary", "", "only the guests with the dietary requirement in their party, organiser only")
+	fs.StringVar(&in.Allergy, "allergy", "", "only the guests with the allergy in their party, organiser only")
+	fs.StringVar(&in.Accessibility, "accessibility", "", "only the guests with the accessibility need in their party, organiser only")
+	fs.StringVar(&in.Custom, "custom", "", "only the guests with the custom field value in their party, as field:value, organiser only")
+	fs.StringVar(&in.Sort, "sort", "", "sort by name, table or time_arrived")
+	fs.StringVar(&in.Order, "order", "", "sort order, asc or desc")
+	fs.IntVar(&in.Limit, "limit", 0, "maximum number of guests, 0 lists them all")
+	fs.StringVar(&in.Cursor, "cursor", "", "next cursor of the previous page")
+
+	if err := parseNoArgs(fs, args); err != nil {
+		return err
+	}
+
+	if *arrived != "" {
+		b, err := strconv.ParseBool(*arrived)
+		if err != nil {
+			return usageErrorf("invalid arrived filter %q", *arrived)
+		}
+		in.Arrived = &b
+	}
+
+	out, err := cmd.client.GetGuestList(ctx, &in)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"NAME", "TABLE", "COMPANIONS", "ARRIVED"}}
+	for _, guest := range out.Guests {
+		t.rows = append(t.rows, []string{guest.Name, itoa(guest.Table), itoa(guest.AccompanyingGuests), formatTime(guest.TimeArrival)})
+	}
+
+	if err := cmd.out.print(out, t); err != nil {
+		return err
+	}
+
+	if out.NextCursor != "" && cmd.out.format == outputTable {
+		fmt.Fprintf(cmd.out.w, "\nMore guests with -cursor %s\n", out.NextCursor)
+	}
+	return nil
+}
+
+func (cmd *command) guestsRemove(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("guests remove", "NAME")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.client.RemoveGuestFromGuestList(ctx, &client.RemoveGuestFromGuestListInput{Name: name}); err != nil {
+		return err
+	}
+	return cmd.out.message(nameResult{Name: name}, "Removed %s from the guest list", name)
+}
+
+func (cmd *command) checkin(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("checkin", "[NAME]")
+	code := fs.String("code", "", "check-in code, instead of the guest name")
+	companions := fs.Int("companions", 0, "number of accompanying guests arriving, defaults to the booked number")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	companionsSet := false
+	fs.Visit(func(f *flag.Flag) {
+		companionsSet = companionsSet || f.Name == "companions"
+	})
+
+	var out client.CheckInOutput
+
+	switch {
+	case *code != "" && len(positional) == 0:
+		in := client.CheckInInput{Code: *code}
+		if companionsSet {
+			in.AccompanyingGuests = companions
+		}
+
+		resp, err := cmd.client.CheckIn(ctx, &in)
+		if err != nil {
+			return err
+		}
+		out = *resp
+
+	case *code == "" && len(positional) == 1:
+		out.Name = positional[0]
+		out.AccompanyingGuests = *companions
+
+		if !companionsSet {
+			guest, err := cmd.findGuest(ctx, out.Name)
+			if err != nil {
+				return err
+			}
+			out.AccompanyingGuests = guest.AccompanyingGuests
+		}
+
+		if _, err := cmd.client.WelcomeGuest(ctx, &client.WelcomeGuestInput{
+			Name:               out.Name,
+			AccompanyingGuests: out.AccompanyingGuests,
+		}); err != nil {
+			return err
+		}
+
+	default:
+		fs.Usage()
+		return usageErrorf("checkin takes a guest name or a check-in code")
+	}
+	return cmd.out.message(out, "Welcomed %s with %d accompanying guests", out.Name, out.AccompanyingGuests)
+}
+
+func (cmd *command) checkout(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("checkout", "NAME")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.client.GoodbyeGuest(ctx, &client.GoodbyeGuestInput{Name: name}); err != nil {
+		return err
+	}
+	return cmd.out.message(nameResult{Name: name}, "Said goodbye to %s", name)
+}
+
+func (cmd *command) tablesList(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("tables list", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.ListTables(ctx)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, tablesTable(out.Tables...))
+}
+
+func (cmd *command) tablesCreate(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("tables create", "NUMBER")
+	size := fs.Int("size", 0, "number of seats")
+
+	number, err := parseTableNumber(fs, args)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.client.CreateTable(ctx, &client.CreateTableInput{Number: number, Size: *size})
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, tablesTable(*out))
+}
+
+func (cmd *command) tablesReserve(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("tables reserve", "NUMBER")
+	tiers := fs.String("tiers", "", "comma separated tiers the table is restricted to, empty for every tier")
+	held := fs.Int("hold", 0, "number of empty seats held back from booking")
+
+	number, err := parseTableNumber(fs, args)
+	if err != nil {
+		return err
+	}
+
+	in := client.ReserveTableInput{Number: number, HeldSeats: *held}
+	if *tiers != "" {
+		in.Tiers = strings.Split(*tiers, ",")
+	}
+
+	out, err := cmd.client.ReserveTable(ctx, &in)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, tablesTable(*out))
+}
+
+func (cmd *command) tablesRelease(ctx context.Context, args []string) error {
+	number, err := parseTableNumber(cmd.flagSet("tables release", "NUMBER"), args)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.client.ReleaseTable(ctx, number)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, tablesTable(*out))
+}
+
+// parseTableNumber parses the flags and the table number argument.
+func parseTableNumber(fs *flag.FlagSet, args []string) (int, error) {
+	arg, err := parseName(fs, args)
+	if err != nil {
+		return 0, err
+	}
+
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, usageErrorf("invalid table number %q", arg)
+	}
+	return number, nil
+}
+
+func (cmd *command) seats(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("seats", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.GetEmptySeats(ctx)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, table{
+		header: []string{"EMPTY SEATS"},
+		rows:   [][]string{{itoa(out.EmptySeats)}},
+	})
+}
+
+func (cmd *command) groupsList(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("groups list", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.ListGroups(ctx)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, groupsTable(out.Groups...))
+}
+
+func (cmd *command) groupsAdd(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("groups add", "NAME GUEST[:COMPANIONS]...")
+	tableNumber := fs.Int("table", 0, "table of the group, 0 seats the group together at the tables with room")
+	tier := fs.String("tier", "", "tier of the guests of the group, tiers other than general are organiser only")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) < 2 {
+		fs.Usage()
+		return usageErrorf("%s takes the group name and its guests as arguments", fs.Name())
+	}
+
+	in := client.AddGroupToGuestListInput{Name: positional[0], Table: *tableNumber}
+	for _, arg := range positional[1:] {
+		guest, err := parseGroupGuest(arg)
+		if err != nil {
+			return err
+		}
+		guest.Tier = *tier
+		in.Guests = append(in.Guests, guest)
+	}
+
+	out, err := cmd.client.AddGroupToGuestList(ctx, &in)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, groupsTable(*out))
+}
+
+func (cmd *command) groupsShow(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("groups show", "NAME")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.client.GetGroup(ctx, name)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, groupsTable(*out))
+}
+
+func (cmd *command) groupsCheckin(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("groups checkin", "NAME")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.client.WelcomeGroup(ctx, &client.WelcomeGroupInput{Name: name})
+	if err != nil {
+		return err
+	}
+	return cmd.out.message(out, "Welcomed the %s group with %d guests", name, len(out.Guests))
+}
+
+func (cmd *command) groupsCheckout(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("groups checkout", "NAME")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.client.GoodbyeGroup(ctx, &client.GoodbyeGroupInput{Name: name}); err != nil {
+		return err
+	}
+	return cmd.out.message(nameResult{Name: name}, "Said goodbye to the %s group", name)
+}
+
+// parseGroupGuest parses a group guest argument, the guest name followed by their number of accompanying guests,
+// e.g. "Jane Doe:2", or the guest name alone without accompanying guests.
+func parseGroupGuest(arg string) (client.GroupGuest, error) {
+	i := strings.LastIndex(arg, ":")
+	if i < 0 {
+		return client.GroupGuest{Name: arg}, nil
+	}
+
+	companions, err := strconv.Atoi(arg[i+1:])
+	if err != nil {
+		return client.GroupGuest{}, usageErrorf("invalid number of accompanying guests in %q", arg)
+	}
+	return client.GroupGuest{Name: arg[:i], AccompanyingGuests: companions}, nil
+}
+
+func (cmd *command) requirementsShow(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("requirements show", "NAME")
+
+	name, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.client.GetGuestRequirements(ctx, name)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, requirementsTable(out.People))
+}
+
+func (cmd *command) requirementsSet(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("requirements set", "NAME FILE")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 2 {
+		fs.Usage()
+		return usageErrorf("%s takes the guest name and a file as arguments", fs.Name())
+	}
+
+	b, err := cmd.readFile(positional[1])
+	if err != nil {
+		return fmt.Errorf("could not read requirements file: %w", err)
+	}
+
+	in := client.SetGuestRequirementsInput{Guest: positional[0]}
+	if err := json.Unmarshal(b, &in.People); err != nil {
+		return fmt.Errorf("could not decode requirements file: %w", err)
+	}
+
+	out, err := cmd.client.SetGuestRequirements(ctx, &in)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, requirementsTable(out.People))
+}
+
+func (cmd *command) fieldsList(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("fields list", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.ListCustomFields(ctx)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, fieldsTable(out.Fields))
+}
+
+func (cmd *command) fieldsSet(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("fields set", "FILE")
+
+	path, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	b, err := cmd.readFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read fields file: %w", err)
+	}
+
+	var in client.SetCustomFieldsInput
+	if err := json.Unmarshal(b, &in.Fields); err != nil {
+		return fmt.Errorf("could not decode fields file: %w", err)
+	}
+
+	out, err := cmd.client.SetCustomFields(ctx, &in)
+	if err != nil {
+		return err
+	}
+	return cmd.out.print(out, fieldsTable(out.Fields))
+}
+
+func (cmd *command) catering(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("catering", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.GetCateringSummary(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"TABLE", "PEOPLE", "DIETARY", "ALLERGIES", "ACCESSIBILITY", "CUSTOM"}}
+	for _, tbl := range append(out.Tables, out.Total) {
+		number := itoa(tbl.Table)
+		if tbl.Table == 0 {
+			number = "total"
+		}
+		t.rows = append(t.rows, []string{
+			number, itoa(tbl.People), formatCounts(tbl.Dietary), formatCounts(tbl.Allergies), formatCounts(tbl.Accessibility), formatCounts(tbl.Custom),
+		})
+	}
+	return cmd.out.print(out, t)
+}
+
+func (cmd *command) constraintsList(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("constraints list", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.ListConstraints(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"ID", "KIND", "TABLE", "GUESTS"}}
+	for _, constraint := range out.Constraints {
+		tableNumber := "-"
+		if constraint.Table > 0 {
+			tableNumber = itoa(constraint.Table)
+		}
+		t.rows = append(t.rows, []string{constraint.ID, constraint.Kind, tableNumber, strings.Join(constraint.Guests, ", ")})
+	}
+	return cmd.out.print(out, t)
+}
+
+func (cmd *command) constraintsAdd(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("constraints add", "GUEST...")
+	kind := fs.String("kind", "", "together, apart or pinned")
+	tableNumber := fs.Int("table", 0, "table of the pinned guests")
+
+	guests, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(guests) == 0 {
+		fs.Usage()
+		return usageErrorf("%s takes the guests as arguments", fs.Name())
+	}
+
+	out, err := cmd.client.CreateConstraint(ctx, &client.CreateConstraintInput{Kind: *kind, Guests: guests, Table: *tableNumber})
+	if err != nil {
+		return err
+	}
+	return cmd.out.message(out, "Added %s constraint %s on %s", out.Kind, out.ID, strings.Join(out.Guests, ", "))
+}
+
+func (cmd *command) constraintsDelete(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("constraints delete", "ID")
+
+	id, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.client.DeleteConstraint(ctx, id); err != nil {
+		return err
+	}
+	return cmd.out.message(client.Constraint{ID: id}, "Deleted constraint %s", id)
+}
+
+func (cmd *command) seatingPlan(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("seating plan", ""), args); err != nil {
+		return err
+	}
+
+	out, err := cmd.client.PlanSeating(ctx)
+	if err != nil {
+		return err
+	}
+	return cmd.printSeatingPlan(out)
+}
+
+func (cmd *command) seatingCheck(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("seating check", "[FILE]")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) > 1 {
+		fs.Usage()
+		return usageErrorf("%s takes at most one argument", fs.Name())
+	}
+
+	// Without a file the tables of the guest list are checked, a dash reads the plan from the standard input
+	var in client.CheckSeatingInput
+	if len(positional) == 1 {
+		var b []byte
+		if positional[0] == "-" {
+			b, err = ioutil.ReadAll(cmd.stdin)
+		} else {
+			b, err = ioutil.ReadFile(positional[0])
+		}
+		if err != nil {
+			return fmt.Errorf("could not read seating plan file: %w", err)
+		}
+
+		if err := json.Unmarshal(b, &in.Assignments); err != nil {
+			return fmt.Errorf("could not decode seating plan file: %w", err)
+		}
+	}
+
+	out, err := cmd.client.CheckSeating(ctx, &in)
+	if err != nil {
+		return err
+	}
+	return cmd.printSeatingPlan(out)
+}
+
+// printSeatingPlan prints the tables of the guests, followed by the guests left without a table and the broken constraints.
+func (cmd *command) printSeatingPlan(plan *client.SeatingPlan) error {
+	t := table{header: []string{"GUEST", "TABLE", "CURRENT TABLE"}}
+	for _, assignment := range plan.Assignments {
+		t.rows = append(t.rows, []string{assignment.Guest, itoa(assignment.Table), itoa(assignment.CurrentTable)})
+	}
+
+	for _, guest := range plan.Unseated {
+		t.rows = append(t.rows, []string{guest, "-", "-"})
+	}
+
+	if err := cmd.out.print(plan, t); err != nil {
+		return err
+	}
+
+	if len(plan.Unseated) == 0 && len(plan.Violations) == 0 {
+		return nil
+	}
+
+	if cmd.out.format == outputTable && len(plan.Violations) > 0 {
+		t := table{header: []string{"CONSTRAINT", "VIOLATION"}}
+		for _, violation := range plan.Violations {
+			constraint := violation.Constraint
+			if constraint == "" {
+				constraint = "-"
+			}
+			t.rows = append(t.rows, []string{constraint, violation.Reason})
+		}
+
+		fmt.Fprintln(cmd.out.w)
+		if err := cmd.out.print(nil, t); err != nil {
+			return err
+		}
+	}
+	return errSeatingUnsatisfied
+}
+
+func (cmd *command) importGuests(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("import", "FILE")
+	dryRun := fs.Bool("dry-run", false, "only check the rows")
+	format := fs.String("format", "", "file format, csv or json, defaults to the file extension")
+
+	path, err := parseName(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = "csv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			*format = "json"
+		}
+	}
+
+	// A dash reads the rows from the standard input
+	var b []byte
+	if path == "-" {
+		b, err = ioutil.ReadAll(cmd.stdin)
+	} else {
+		b, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not read import file: %w", err)
+	}
+
+	var out *client.ImportGuestsOutput
+
+	switch *format {
+	case "csv":
+		out, err = cmd.client.ImportGuestsCSV(ctx, b, *dryRun)
+	case "json":
+		var rows []client.ImportGuestRow
+		if err := json.Unmarshal(b, &rows); err != nil {
+			return fmt.Errorf("could not decode import file: %w", err)
+		}
+		out, err = cmd.client.ImportGuests(ctx, &client.ImportGuestsInput{Guests: rows, DryRun: *dryRun})
+	default:
+		return usageErrorf("unknown import format %q", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(out.Errors) > 0 {
+		t := table{header: []string{"ROW", "NAME", "ERROR"}}
+		for _, rowErr := range out.Errors {
+			t.rows = append(t.rows, []string{itoa(rowErr.Row), rowErr.Name, rowErr.Error})
+		}
+
+		if err := cmd.out.print(out, t); err != nil {
+			return err
+		}
+		return errImportRejected
+	}
+
+	if out.DryRun {
+		return cmd.out.message(out, "Checked %d guests, nothing was imported", out.Rows)
+	}
+	return cmd.out.message(out, "Imported %d guests", out.Imported)
+}
+
+func (cmd *command) export(ctx context.Context, args []string) error {
+	fs := cmd.flagSet("export", "")
+	report := fs.Bool("report", false, "export the seating report instead of the guest list CSV")
+	file := fs.String("file", "", "write the export to a file instead of the standard output")
+
+	if err := parseNoArgs(fs, args); err != nil {
+		return err
+	}
+
+	out := cmd.out
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return fmt.Errorf("could not create export file: %w", err)
+		}
+		defer f.Close()
+
+		out = &printer{w: f, format: cmd.out.format}
+	}
+
+	if !*report {
+		csv, err := cmd.client.ExportGuestListCSV(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.w.Write(csv)
+		return err
+	}
+
+	resp, err := cmd.client.GetSeatingReport(ctx)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"TABLE", "NAME", "COMPANIONS", "ARRIVED", "LEFT"}}
+	for _, reportTable := range resp.Tables {
+		for _, guest := range reportTable.Guests {
+			t.rows = append(t.rows, []string{
+				itoa(reportTable.Number), guest.Name, itoa(guest.AccompanyingGuests), formatTime(guest.TimeArrival), formatTime(guest.TimeDeparture),
+			})
+		}
+	}
+	return out.print(resp, t)
+}
+
+// readFile reads a file argument, a dash reads the standard input.
+func (cmd *command) readFile(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(cmd.stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// findGuest returns the guest of a name from the guest list.
+func (cmd *command) findGuest(ctx context.Context, name string) (*client.Guest, error) {
+	out, err := cmd.client.GetGuestList(ctx, &client.ListGuestsInput{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, guest := range out.Guests {
+		if guest.Name == name {
+			return &guest, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", client.ErrGuestNotInList, name)
+}
+
+func (cmd *command) flagSet(name, arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(cmd.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(cmd.stderr, "Usage: partyctl %s [flags] %s\n\nFlags:\n", name, arguments)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the flags wherever they are among the positional arguments,
+// e.g. both NAME -table 1 and -table 1 NAME, and returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageFlagError(err)
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseName parses the flags and the single positional argument of a command.
+func parseName(fs *flag.FlagSet, args []string) (string, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return "", err
+	}
+
+	if len(positional) != 1 || positional[0] == "" {
+		fs.Usage()
+		return "", usageErrorf("%s takes a single argument", fs.Name())
+	}
+	return positional[0], nil
+}
+
+// parseNoArgs parses the flags of a command without positional arguments.
+func parseNoArgs(fs *flag.FlagSet, args []string) error {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) > 0 {
+		fs.Usage()
+		return usageErrorf("%s takes no argument", fs.Name())
+	}
+	return nil
+}
+
+// groupsTable is the table view of groups, a row per guest.
+func groupsTable(groups ...client.Group) table {
+	t := table{header: []string{"GROUP", "GUEST", "TABLE", "COMPANIONS", "ARRIVED"}}
+	for _, group := range groups {
+		for _, guest := range group.Guests {
+			t.rows = append(t.rows, []string{group.Name, guest.Name, itoa(guest.Table), itoa(guest.AccompanyingGuests), formatTime(guest.TimeArrival)})
+		}
+	}
+	return t
+}
+
+// tablesTable is the table view of party tables.
+func tablesTable(tables ...client.Table) table {
+	t := table{header: []string{"TABLE", "SIZE", "BOOKED", "ARRIVED", "EMPTY", "HELD", "TIERS"}}
+	for _, tbl := range tables {
+		t.rows = append(t.rows, []string{
+			itoa(tbl.Number), itoa(tbl.Size), itoa(tbl.BookedSeats), itoa(tbl.ArrivedSeats), itoa(tbl.EmptySeats), itoa(tbl.HeldSeats), formatList(tbl.Tiers),
+		})
+	}
+	return t
+}
+
+// requirementsTable is the table view of the needs of a guest party, a row per person.
+func requirementsTable(people []client.Requirements) table {
+	t := table{header: []string{"PERSON", "NAME", "DIETARY", "ALLERGIES", "ACCESSIBILITY", "CUSTOM"}}
+	for _, person := range people {
+		number := itoa(person.Companion)
+		if person.Companion == 0 {
+			number = "guest"
+		}
+
+		custom := make([]string, 0, len(person.Custom))
+		for _, value := range person.Custom {
+			custom = append(custom, value.Field+"="+value.Value)
+		}
+
+		t.rows = append(t.rows, []string{
+			number, formatList([]string{person.Name}), formatList(person.Dietary), formatList(person.Allergies), formatList(person.Accessibility), formatList(custom),
+		})
+	}
+	return t
+}
+
+// fieldsTable is the table view of custom fields.
+func fieldsTable(fields []client.CustomField) table {
+	t := table{header: []string{"NAME", "TYPE", "OPTIONS", "REQUIRED"}}
+	for _, field := range fields {
+		t.rows = append(t.rows, []string{field.Name, field.Type, formatList(field.Options), strconv.FormatBool(field.Required)})
+	}
+	return t
+}
+
+// formatList joins the values of a table cell, or returns a dash when there are none.
+func formatList(values []string) string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+
+	if len(out) == 0 {
+		return "-"
+	}
+	return strings.Join(out, ", ")
+}
+
+// formatCounts formats catering counts such as "2 vegan, 1 meal=fish".
+func formatCounts(counts []client.CateringCount) string {
+	values := make([]string, 0, len(counts))
+	for _, count := range counts {
+		value := count.Value
+		if count.Field != "" {
+			value = count.Field + "=" + value
+		}
+		values = append(values, itoa(count.Count)+" "+value)
+	}
+	return formatList(values)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const defaultURL = "http://localhost:3000"
+
+// Enumerate the environment variables overriding the config file
+const (
+	envConfig       = "PARTYCTL_CONFIG"
+	envURL          = "PARTYCTL_URL"
+	envOrganiserKey = "PARTYCTL_ORGANISER_KEY"
+)
+
+// config defines where the party API is served and the organiser credentials.
+type config struct {
+	URL          string `json:"url"`
+	OrganiserKey string `json:"organiser_key"`
+}
+
+// loadConfig reads the config file at path, then applies the environment overrides.
+// An empty path reads $PARTYCTL_CONFIG or the default config file, which may be missing.
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	cfg := config{URL: defaultURL}
+
+	optional := false
+	if path == "" {
+		path = getenv(envConfig)
+	}
+
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err == nil {
+			path = filepath.Join(dir, "partyctl", "config.json")
+			optional = true
+		}
+	}
+
+	if path != "" {
+		b, err := ioutil.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist) && optional:
+		case err != nil:
+			return nil, fmt.Errorf("could not read config file: %w", err)
+		default:
+			if err := json.Unmarshal(b, &cfg); err != nil {
+				return nil, fmt.Errorf("could not decode config file %s: %w", path, err)
+			}
+		}
+	}
+
+	if url := getenv(envURL); url != "" {
+		cfg.URL = url
+	}
+
+	if key := getenv(envOrganiserKey); key != "" {
+		cfg.OrganiserKey = key
+	}
+	return &cfg, nil
+}
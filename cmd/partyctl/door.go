@@ -0,0 +1,459 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alesr/getground/pkg/client"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doorReconnectDelay is the delay before reconnecting to the event stream once it is lost.
+const doorReconnectDelay = 2 * time.Second
+
+// doorRows is the number of lines of the door view around the guest rows.
+const doorRows = 12
+
+type (
+	// doorAPI is the party API used at the door.
+	doorAPI interface {
+		GetSeatingReport(ctx context.Context) (*client.GetSeatingReportOutput, error)
+		ListTables(ctx context.Context) (client.ListTablesOutput, error)
+		WelcomeGuest(ctx context.Context, in *client.WelcomeGuestInput) (*client.WelcomeGuestOutput, error)
+		GoodbyeGuest(ctx context.Context, in *client.GoodbyeGuestInput) error
+	}
+
+	// eventStream receives the party events.
+	eventStream interface {
+		Recv() (client.Event, error)
+		Close() error
+	}
+
+	// doorModel is the door check-in terminal UI.
+	// The guests and tables are reloaded from the API on every party event.
+	doorModel struct {
+		ctx   context.Context
+		api   doorAPI
+		watch func(ctx context.Context) (eventStream, error)
+
+		guests []client.ReportGuest
+		tables []client.Table
+		loaded bool
+
+		stream       eventStream
+		reconnecting bool
+
+		query  string
+		cursor int
+		height int
+
+		// prompting is set while the companions arriving with the selected guest are entered
+		prompting     bool
+		promptGuest   client.ReportGuest
+		promptInput   string
+		promptTouched bool
+
+		status string
+	}
+
+	doorDataMsg struct {
+		guests []client.ReportGuest
+		tables []client.Table
+	}
+
+	doorStreamMsg struct {
+		stream eventStream
+	}
+
+	doorEventMsg struct {
+		event client.Event
+	}
+
+	doorStreamErrMsg struct {
+		err error
+	}
+
+	doorReconnectMsg struct{}
+
+	doorStatusMsg struct {
+		status string
+	}
+
+	doorErrMsg struct {
+		err error
+	}
+)
+
+func (cmd *command) door(ctx context.Context, args []string) error {
+	if err := parseNoArgs(cmd.flagSet("door", ""), args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m := newDoorModel(ctx, cmd.client, func(ctx context.Context) (eventStream, error) {
+		stream, err := cmd.client.WatchEvents(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return stream, nil
+	})
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithInput(cmd.stdin), tea.WithOutput(cmd.out.w))
+
+	go func() {
+		<-ctx.Done()
+		p.Quit()
+	}()
+	return p.Start()
+}
+
+func newDoorModel(ctx context.Context, api doorAPI, watch func(ctx context.Context) (eventStream, error)) *doorModel {
+	return &doorModel{
+		ctx:   ctx,
+		api:   api,
+		watch: watch,
+	}
+}
+
+// Init loads the guests and connects to the event stream.
+func (m *doorModel) Init() tea.Cmd {
+	return tea.Batch(m.load(), m.connect())
+}
+
+// Update handles the keys and the API results.
+func (m *doorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, m.quit()
+		}
+
+		if m.prompting {
+			return m, m.updatePrompt(msg)
+		}
+		return m, m.updateSearch(msg)
+
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+
+	case doorDataMsg:
+		m.guests = msg.guests
+		m.tables = msg.tables
+		m.loaded = true
+		m.clampCursor()
+
+	case doorStreamMsg:
+		m.stream = msg.stream
+
+		// The events missed while reconnecting are caught up by reloading
+		if m.reconnecting {
+			m.reconnecting = false
+			m.status = "Live updates restored"
+			return m, tea.Batch(m.load(), m.recv(msg.stream))
+		}
+		return m, m.recv(msg.stream)
+
+	case doorEventMsg:
+		return m, tea.Batch(m.load(), m.recv(m.stream))
+
+	case doorStreamErrMsg:
+		m.stream = nil
+		m.reconnecting = true
+		m.status = fmt.Sprintf("Live updates lost, reconnecting: %s", msg.err)
+
+		return m, tea.Tick(doorReconnectDelay, func(time.Time) tea.Msg {
+			return doorReconnectMsg{}
+		})
+
+	case doorReconnectMsg:
+		return m, m.connect()
+
+	case doorStatusMsg:
+		m.status = msg.status
+		return m, m.load()
+
+	case doorErrMsg:
+		m.status = "Error: " + msg.err.Error()
+	}
+	return m, nil
+}
+
+// updateSearch edits the search and moves through the matching guests.
+// Enter checks the selected guest in and ctrl+x checks them out.
+func (m *doorModel) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+		m.cursor = 0
+
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.cursor = 0
+		}
+
+	case tea.KeyEsc:
+		m.query = ""
+		m.cursor = 0
+
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case tea.KeyDown:
+		if m.cursor < len(m.matches())-1 {
+			m.cursor++
+		}
+
+	case tea.KeyEnter:
+		guest, ok := m.selected()
+		if !ok {
+			return nil
+		}
+
+		m.prompting = true
+		m.promptGuest = guest
+		m.promptInput = strconv.Itoa(guest.AccompanyingGuests)
+		m.promptTouched = false
+
+	case tea.KeyCtrlX:
+		guest, ok := m.selected()
+		if !ok {
+			return nil
+		}
+		return m.goodbye(guest.Name)
+	}
+	return nil
+}
+
+// updatePrompt edits the number of companions arriving, prefilled with the booked number.
+func (m *doorModel) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if r < '0' || r > '9' {
+				return nil
+			}
+		}
+
+		// The first digit replaces the booked number
+		if !m.promptTouched {
+			m.promptInput = ""
+			m.promptTouched = true
+		}
+		m.promptInput += string(msg.Runes)
+
+	case tea.KeyBackspace:
+		m.promptTouched = true
+		if len(m.promptInput) > 0 {
+			m.promptInput = m.promptInput[:len(m.promptInput)-1]
+		}
+
+	case tea.KeyEsc:
+		m.prompting = false
+
+	case tea.KeyEnter:
+		companions, err := strconv.Atoi(m.promptInput)
+		if err != nil {
+			return nil
+		}
+
+		m.prompting = false
+		return m.welcome(m.promptGuest.Name, companions)
+	}
+	return nil
+}
+
+// View renders the search, the matching guests, the empty seats per table and the key help.
+func (m *doorModel) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Search: %s_\n\n", m.query)
+
+	matches := m.matches()
+	first, last := m.visibleRows(len(matches))
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  NAME\tTABLE\tCOMPANIONS\tSTATUS")
+	for i := first; i < last; i++ {
+		pointer := " "
+		if i == m.cursor {
+			pointer = ">"
+		}
+
+		guest := matches[i]
+		fmt.Fprintf(tw, "%s %s\t%d\t%d\t%s\n", pointer, guest.Name, guest.Table, guest.AccompanyingGuests, guestStatus(guest))
+	}
+	_ = tw.Flush()
+
+	switch {
+	case !m.loaded:
+		b.WriteString("  Loading the guest list...\n")
+	case len(matches) == 0:
+		b.WriteString("  No guest found\n")
+	}
+
+	b.WriteString("\nEmpty seats:")
+	for _, t := range m.tables {
+		fmt.Fprintf(&b, "  table %d: %d/%d", t.Number, t.EmptySeats, t.Size)
+	}
+	b.WriteString("\n\n")
+
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+
+	if m.prompting {
+		fmt.Fprintf(&b, "Companions arriving with %s: %s_   enter confirm · esc cancel\n", m.promptGuest.Name, m.promptInput)
+	} else {
+		b.WriteString("enter check in · ctrl+x check out · esc clear search · ctrl+c quit\n")
+	}
+	return b.String()
+}
+
+// matches returns the guests whose name contains the search, ignoring case.
+func (m *doorModel) matches() []client.ReportGuest {
+	query := strings.ToLower(m.query)
+
+	var matches []client.ReportGuest
+	for _, guest := range m.guests {
+		if strings.Contains(strings.ToLower(guest.Name), query) {
+			matches = append(matches, guest)
+		}
+	}
+	return matches
+}
+
+func (m *doorModel) selected() (client.ReportGuest, bool) {
+	matches := m.matches()
+	if m.cursor >= len(matches) {
+		return client.ReportGuest{}, false
+	}
+	return matches[m.cursor], true
+}
+
+func (m *doorModel) clampCursor() {
+	if n := len(m.matches()); m.cursor >= n {
+		m.cursor = n - 1
+	}
+
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// visibleRows returns the rows fitting the terminal, keeping the cursor in view.
+func (m *doorModel) visibleRows(n int) (int, int) {
+	rows := m.height - doorRows
+	if m.height == 0 || rows >= n {
+		return 0, n
+	}
+
+	if rows < 1 {
+		rows = 1
+	}
+
+	first := 0
+	if m.cursor >= rows {
+		first = m.cursor - rows + 1
+	}
+	return first, first + rows
+}
+
+func (m *doorModel) quit() tea.Cmd {
+	if m.stream != nil {
+		_ = m.stream.Close()
+	}
+	return tea.Quit
+}
+
+// load gets the guests, with their departures, and the tables.
+func (m *doorModel) load() tea.Cmd {
+	return func() tea.Msg {
+		report, err := m.api.GetSeatingReport(m.ctx)
+		if err != nil {
+			return doorErrMsg{err: err}
+		}
+
+		tables, err := m.api.ListTables(m.ctx)
+		if err != nil {
+			return doorErrMsg{err: err}
+		}
+
+		var guests []client.ReportGuest
+		for _, t := range report.Tables {
+			guests = append(guests, t.Guests...)
+		}
+
+		sort.Slice(guests, func(i, j int) bool {
+			return guests[i].Name < guests[j].Name
+		})
+		return doorDataMsg{guests: guests, tables: tables.Tables}
+	}
+}
+
+func (m *doorModel) connect() tea.Cmd {
+	return func() tea.Msg {
+		stream, err := m.watch(m.ctx)
+		if err != nil {
+			return doorStreamErrMsg{err: err}
+		}
+		return doorStreamMsg{stream: stream}
+	}
+}
+
+// recv waits for the next event of the stream.
+func (m *doorModel) recv(stream eventStream) tea.Cmd {
+	if stream == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		e, err := stream.Recv()
+		if err != nil {
+			return doorStreamErrMsg{err: err}
+		}
+		return doorEventMsg{event: e}
+	}
+}
+
+func (m *doorModel) welcome(name string, companions int) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := m.api.WelcomeGuest(m.ctx, &client.WelcomeGuestInput{
+			Name:               name,
+			AccompanyingGuests: companions,
+		}); err != nil {
+			return doorErrMsg{err: err}
+		}
+		return doorStatusMsg{status: fmt.Sprintf("Welcomed %s with %d accompanying guests", name, companions)}
+	}
+}
+
+func (m *doorModel) goodbye(name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.api.GoodbyeGuest(m.ctx, &client.GoodbyeGuestInput{Name: name}); err != nil {
+			return doorErrMsg{err: err}
+		}
+		return doorStatusMsg{status: fmt.Sprintf("Said goodbye to %s", name)}
+	}
+}
+
+// guestStatus tells whether the guest is expected, at the party or left it.
+func guestStatus(guest client.ReportGuest) string {
+	switch {
+	case guest.TimeArrival == nil:
+		return "booked"
+	case guest.TimeDeparture != nil && !guest.TimeDeparture.Before(*guest.TimeArrival):
+		return "left " + guest.TimeDeparture.Format("15:04")
+	default:
+		return "arrived " + guest.TimeArrival.Format("15:04")
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/pkg/client"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDoorAPI struct {
+	report  *client.GetSeatingReportOutput
+	tables  client.ListTablesOutput
+	err     error
+	welcome *client.WelcomeGuestInput
+	goodbye *client.GoodbyeGuestInput
+}
+
+func (f *fakeDoorAPI) GetSeatingReport(context.Context) (*client.GetSeatingReportOutput, error) {
+	return f.report, f.err
+}
+
+func (f *fakeDoorAPI) ListTables(context.Context) (client.ListTablesOutput, error) {
+	return f.tables, f.err
+}
+
+func (f *fakeDoorAPI) WelcomeGuest(_ context.Context, in *client.WelcomeGuestInput) (*client.WelcomeGuestOutput, error) {
+	f.welcome = in
+	return &client.WelcomeGuestOutput{Name: in.Name}, f.err
+}
+
+func (f *fakeDoorAPI) GoodbyeGuest(_ context.Context, in *client.GoodbyeGuestInput) error {
+	f.goodbye = in
+	return f.err
+}
+
+type fakeEventStream struct {
+	events []client.Event
+	closed bool
+}
+
+func (f *fakeEventStream) Recv() (client.Event, error) {
+	if len(f.events) == 0 {
+		return client.Event{}, io.EOF
+	}
+
+	e := f.events[0]
+	f.events = f.events[1:]
+	return e, nil
+}
+
+func (f *fakeEventStream) Close() error {
+	f.closed = true
+	return nil
+}
+
+func newTestDoorAPI() *fakeDoorAPI {
+	arrival := time.Date(2021, time.November, 23, 20, 15, 0, 0, time.UTC)
+	departure := arrival.Add(2 * time.Hour)
+
+	return &fakeDoorAPI{
+		report: &client.GetSeatingReportOutput{
+			Tables: []client.ReportTable{
+				{Number: 1, Size: 10, Guests: []client.ReportGuest{
+					{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrival},
+					{Name: "Jane", Table: 1, AccompanyingGuests: 1},
+				}},
+				{Number: 2, Size: 4, Guests: []client.ReportGuest{
+					{Name: "Mary", Table: 2, AccompanyingGuests: 0, TimeArrival: &arrival, TimeDeparture: &departure},
+				}},
+			},
+		},
+		tables: client.ListTablesOutput{Tables: []client.Table{
+			{Number: 1, Size: 10, EmptySeats: 5},
+			{Number: 2, Size: 4, EmptySeats: 4},
+		}},
+	}
+}
+
+// typeKeys returns the key messages of typing the text.
+func typeKeys(text string) []tea.KeyMsg {
+	var keys []tea.KeyMsg
+	for _, r := range text {
+		keys = append(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return keys
+}
+
+func keys(keys ...interface{}) []tea.KeyMsg {
+	var msgs []tea.KeyMsg
+	for _, k := range keys {
+		switch k := k.(type) {
+		case string:
+			msgs = append(msgs, typeKeys(k)...)
+		case tea.KeyType:
+			msgs = append(msgs, tea.KeyMsg{Type: k})
+		}
+	}
+	return msgs
+}
+
+// loadedDoorModel returns a door model with the guests of the API loaded.
+func loadedDoorModel(t *testing.T, api doorAPI) *doorModel {
+	t.Helper()
+
+	m := newDoorModel(context.Background(), api, nil)
+	m.Update(m.load()())
+	return m
+}
+
+func TestDoorModel(t *testing.T) {
+	cases := []struct {
+		name            string
+		givenKeys       []tea.KeyMsg
+		expectedWelcome *client.WelcomeGuestInput
+		expectedGoodbye *client.GoodbyeGuestInput
+		expectedStatus  string
+		expectedView    []string
+		unexpectedView  []string
+	}{
+		{
+			name:           "lists the guests and the empty seats",
+			expectedView:   []string{"> Jane", "John", "arrived 20:15", "Mary", "left 22:15", "booked", "table 1: 5/10", "table 2: 4/4"},
+			unexpectedView: []string{"Loading"},
+		},
+		{
+			name:           "searches the guests ignoring case",
+			givenKeys:      keys("JO"),
+			expectedView:   []string{"Search: JO_", "> John"},
+			unexpectedView: []string{"Jane", "Mary"},
+		},
+		{
+			name:           "clears the search",
+			givenKeys:      keys("jo", tea.KeyBackspace, tea.KeyEsc),
+			expectedView:   []string{"Search: _", "Jane", "John", "Mary"},
+			unexpectedView: []string{"No guest found"},
+		},
+		{
+			name:         "reports no match",
+			givenKeys:    keys("zed"),
+			expectedView: []string{"No guest found"},
+		},
+		{
+			name:         "prompts the companions with the booked number",
+			givenKeys:    keys(tea.KeyDown, tea.KeyEnter),
+			expectedView: []string{"Companions arriving with John: 2_"},
+		},
+		{
+			name:            "checks in with the booked companions",
+			givenKeys:       keys(tea.KeyEnter, tea.KeyEnter),
+			expectedWelcome: &client.WelcomeGuestInput{Name: "Jane", AccompanyingGuests: 1},
+			expectedStatus:  "Welcomed Jane with 1 accompanying guests",
+		},
+		{
+			name:            "checks in with the companions arriving",
+			givenKeys:       keys("john", tea.KeyEnter, "x3", tea.KeyEnter),
+			expectedWelcome: &client.WelcomeGuestInput{Name: "John", AccompanyingGuests: 3},
+			expectedStatus:  "Welcomed John with 3 accompanying guests",
+		},
+		{
+			name:         "cancels the check-in",
+			givenKeys:    keys(tea.KeyEnter, tea.KeyEsc, tea.KeyEnter, tea.KeyEsc),
+			expectedView: []string{"enter check in"},
+		},
+		{
+			name:            "checks out",
+			givenKeys:       keys("mar", tea.KeyCtrlX),
+			expectedGoodbye: &client.GoodbyeGuestInput{Name: "Mary"},
+			expectedStatus:  "Said goodbye to Mary",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newTestDoorAPI()
+			m := loadedDoorModel(t, api)
+
+			for _, k := range tc.givenKeys {
+				_, cmd := m.Update(k)
+				if cmd != nil {
+					m.Update(cmd())
+				}
+			}
+
+			assert.Equal(t, tc.expectedWelcome, api.welcome)
+			assert.Equal(t, tc.expectedGoodbye, api.goodbye)
+			assert.Equal(t, tc.expectedStatus, m.status)
+
+			view := m.View()
+			for _, s := range tc.expectedView {
+				assert.Contains(t, view, s)
+			}
+
+			for _, s := range tc.unexpectedView {
+				assert.NotContains(t, view, s)
+			}
+		})
+	}
+
+	t.Run("reports the api errors", func(t *testing.T) {
+		api := newTestDoorAPI()
+		m := loadedDoorModel(t, api)
+
+		api.err = client.ErrGuestNotInList
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+		require.NotNil(t, cmd)
+		m.Update(cmd())
+
+		assert.Contains(t, m.View(), "Error: "+client.ErrGuestNotInList.Error())
+	})
+
+	t.Run("reloads on the party events", func(t *testing.T) {
+		api := newTestDoorAPI()
+		stream := &fakeEventStream{events: []client.Event{{Type: client.EventGuestArrived, Guest: "Jane"}}}
+
+		m := newDoorModel(context.Background(), api, func(context.Context) (eventStream, error) {
+			return stream, nil
+		})
+
+		_, cmd := m.Update(m.connect()())
+		require.NotNil(t, cmd)
+
+		msg := cmd()
+		assert.Equal(t, doorEventMsg{event: client.Event{Type: client.EventGuestArrived, Guest: "Jane"}}, msg)
+
+		_, cmd = m.Update(msg)
+		assert.NotNil(t, cmd)
+
+		// The stream ends, the model reconnects
+		_, cmd = m.Update(m.recv(stream)())
+		assert.NotNil(t, cmd)
+		assert.Contains(t, m.status, "Live updates lost")
+		assert.Nil(t, m.stream)
+	})
+
+	t.Run("reconnects after a stream error", func(t *testing.T) {
+		m := newDoorModel(context.Background(), newTestDoorAPI(), func(context.Context) (eventStream, error) {
+			return nil, errors.New("connection refused")
+		})
+
+		m.Update(m.connect()())
+		assert.Equal(t, "Live updates lost, reconnecting: connection refused", m.status)
+
+		m.watch = func(context.Context) (eventStream, error) {
+			return &fakeEventStream{}, nil
+		}
+
+		_, cmd := m.Update(m.connect()())
+		assert.NotNil(t, cmd)
+		assert.Equal(t, "Live updates restored", m.status)
+	})
+
+	t.Run("closes the stream on quit", func(t *testing.T) {
+		stream := &fakeEventStream{}
+		m := newDoorModel(context.Background(), newTestDoorAPI(), nil)
+		m.stream = stream
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+		require.NotNil(t, cmd)
+		assert.Equal(t, tea.Quit(), cmd())
+		assert.True(t, stream.closed)
+	})
+}
@@ -0,0 +1,162 @@
+// Command partyctl operates the party through its HTTP API.
+//
+// The API URL and organiser key are read from a JSON config file,
+// e.g. {"url": "http://localhost:3000", "organiser_key": "secret"},
+// and overridden by the PARTYCTL_URL and PARTYCTL_ORGANISER_KEY environment variables.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alesr/getground/pkg/client"
+)
+
+const usage = `Usage: partyctl [flags] <command> [arguments]
+
+Commands:
+  guests add -table N [-companions N] [-tier T] NAME
+                                            Add a guest to the guest list, organiser only for tiers other than general
+  guests list [filters]                     List the guest list
+  guests remove NAME                        Remove a guest from the guest list, organiser only
+  checkin [-companions N] NAME              Record the arrival of a guest
+  checkin -code CODE [-companions N]        Record an arrival with a check-in code
+  checkout NAME                             Record the departure of a guest
+  tables list                               List the tables and their seats
+  tables create -size N NUMBER              Create an empty table, organiser only
+  tables reserve [-tiers T,...] [-hold N] NUMBER
+                                            Restrict a table to guest tiers and hold back seats, organiser only
+  tables release NUMBER                     Open a table to every tier and release its held seats, organiser only
+  seats                                     Show the empty seats
+  groups list                               List the groups and their guests
+  groups add [-table N] [-tier T] NAME GUEST[:COMPANIONS]...
+                                            Add a group of guests seated together
+  groups show NAME                          Show a group and its guests
+  groups checkin NAME                       Record the arrival of the guests of a group
+  groups checkout NAME                      Record the departure of the guests of a group
+  requirements show NAME                    Show the needs of a guest and their accompanying guests, organiser only
+  requirements set NAME FILE                Replace the needs of a guest party from a JSON file, organiser only
+  fields list                               List the custom guest attributes
+  fields set FILE                           Replace the custom guest attributes from a JSON file, organiser only
+  catering                                  Count the dietary requirements and needs per table, organiser only
+  constraints list                          List the seating constraints, organiser only
+  constraints add -kind K [-table N] GUEST...
+                                            Keep guests together, apart or pinned to a table, organiser only
+  constraints delete ID                     Delete a seating constraint, organiser only
+  seating plan                              Compute a seating plan meeting the constraints, organiser only
+  seating check [FILE]                      Check a JSON seating plan, or the current tables, organiser only
+  import [-dry-run] FILE                    Import guests from a CSV or JSON file, organiser only
+  export [-report] [-file FILE]             Export the guest list as CSV or the seating report, organiser only
+  door                                      Check guests in and out at the door, organiser only
+
+Run partyctl <command> -h for the command flags.
+
+Flags:
+`
+
+// usageError is returned for invalid command lines.
+// Reported errors were already written along with the usage by the flag package.
+type usageError struct {
+	msg      string
+	reported bool
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv)
+	stop()
+
+	if err != nil {
+		os.Exit(exitCode(err, os.Stderr))
+	}
+}
+
+// exitCode reports the error and returns the exit code matching it.
+func exitCode(err error, stderr io.Writer) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		if !usageErr.reported {
+			fmt.Fprintln(stderr, "partyctl:", err)
+		}
+		return 2
+	}
+
+	fmt.Fprintln(stderr, "partyctl:", err)
+	return 1
+}
+
+// run parses the global flags and runs the command of the arguments.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) error {
+	fs := flag.NewFlagSet("partyctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	configPath := fs.String("config", "", "config file, defaults to $PARTYCTL_CONFIG or partyctl/config.json in the user config directory")
+	serverURL := fs.String("url", "", "party API URL, overrides the config")
+	output := fs.String("o", outputTable, "output format, table or json")
+
+	if err := fs.Parse(args); err != nil {
+		return usageFlagError(err)
+	}
+
+	if *output != outputTable && *output != outputJSON {
+		return usageErrorf("unknown output format %q", *output)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return usageErrorf("missing command")
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		return err
+	}
+
+	if *serverURL != "" {
+		cfg.URL = *serverURL
+	}
+
+	c, err := client.New(cfg.URL, client.WithOrganiserKey(cfg.OrganiserKey))
+	if err != nil {
+		return err
+	}
+
+	cmd := command{
+		client: c,
+		out:    &printer{w: stdout, format: *output},
+		stdin:  stdin,
+		stderr: stderr,
+	}
+	return cmd.run(ctx, fs.Args())
+}
+
+// usageFlagError returns the flag parsing errors as usage errors, except the help request.
+func usageFlagError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return &usageError{msg: err.Error(), reported: true}
+}
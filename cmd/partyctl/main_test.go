@@ -0,0 +1,611 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/app"
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testOrganiserKey = "organiser-key"
+
+// startTestApp serves the party app on a free port until the test ends and returns its URL.
+func startTestApp(t *testing.T, service party.Service) string {
+	t.Helper()
+
+	fiberApp := fiber.New(fiber.Config{DisableStartupMessage: true, UnescapePath: true})
+
+	a := app.New(zap.NewNop(), fiberApp, partyctrl.New(zap.NewNop(), service),
+		app.WithOrganiserKey(testOrganiserKey),
+	)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, ln.Close())
+
+	go func() {
+		_ = a.Run(port)
+	}()
+
+	t.Cleanup(func() {
+		_ = a.Shutdown(context.Background())
+	})
+
+	addr := "127.0.0.1:" + port
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return "http://" + addr
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`{"url": "http://party:3000", "organiser_key": "file-key"}`), 0o600))
+
+	invalidPath := filepath.Join(dir, "invalid.json")
+	require.NoError(t, ioutil.WriteFile(invalidPath, []byte(`{`), 0o600))
+
+	cases := []struct {
+		name           string
+		givenPath      string
+		givenEnv       map[string]string
+		expectedConfig *config
+		expectedErr    bool
+	}{
+		{
+			name:           "reads the config file",
+			givenPath:      configPath,
+			expectedConfig: &config{URL: "http://party:3000", OrganiserKey: "file-key"},
+		},
+		{
+			name:           "reads the config file of the environment",
+			givenEnv:       map[string]string{envConfig: configPath},
+			expectedConfig: &config{URL: "http://party:3000", OrganiserKey: "file-key"},
+		},
+		{
+			name:           "overrides the config file with the environment",
+			givenPath:      configPath,
+			givenEnv:       map[string]string{envURL: "https://party.example.com", envOrganiserKey: "env-key"},
+			expectedConfig: &config{URL: "https://party.example.com", OrganiserKey: "env-key"},
+		},
+		{
+			name:        "fails on a missing config file",
+			givenPath:   filepath.Join(dir, "missing.json"),
+			expectedErr: true,
+		},
+		{
+			name:        "fails on an invalid config file",
+			givenPath:   invalidPath,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				return tc.givenEnv[key]
+			}
+
+			observed, err := loadConfig(tc.givenPath, getenv)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, observed)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	var welcomed *party.WelcomeGuestInput
+
+	service := party.Mock{
+		AddGuestToGuestListFunc: func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+			if in.Name == "John" {
+				return nil, party.ErrGuestAlreadyInList
+			}
+			if in.Tier == party.TierStaff {
+				return nil, party.ErrTableTierRestricted
+			}
+			return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+		},
+		GetGuestListFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
+			out := party.GetGuestListOutput{
+				Guests: []party.Guest{
+					{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived},
+					{Name: "John Doe", Table: 2, AccompanyingGuests: 1},
+				},
+			}
+			if in.Limit == 1 {
+				out.Guests, out.NextCursor = out.Guests[:1], "next"
+			}
+			return out, nil
+		},
+		WelcomeGuestFunc: func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			welcomed = in
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		},
+		GoodbyeGuestFunc: func(ctx context.Context, in *party.GoodbyeGuestInput) error {
+			return nil
+		},
+		RemoveGuestFromGuestListFunc: func(ctx context.Context, in *party.RemoveGuestFromGuestListInput) error {
+			return nil
+		},
+		GetEmptySeatsFunc: func(ctx context.Context) (party.GetEmptySeatsOutput, error) {
+			return party.GetEmptySeatsOutput{EmptySeats: 7}, nil
+		},
+		ListTablesFunc: func(ctx context.Context) (party.ListTablesOutput, error) {
+			return party.ListTablesOutput{Tables: []party.Table{{Number: 1, Size: 10, BookedSeats: 3, ArrivedSeats: 3, EmptySeats: 7}}}, nil
+		},
+		CreateTableFunc: func(ctx context.Context, in *party.CreateTableInput) (*party.Table, error) {
+			seats := make([]party.Seat, in.Size)
+			for i := range seats {
+				seats[i].Number = i + 1
+			}
+			return &party.Table{Number: in.Number, Size: in.Size, EmptySeats: in.Size, Seats: seats}, nil
+		},
+		ReserveTableFunc: func(ctx context.Context, in *party.ReserveTableInput) (*party.Table, error) {
+			if in.HeldSeats > 7 {
+				return nil, party.ErrTableNotEnoughSeats
+			}
+			return &party.Table{Number: in.Number, Size: 10, BookedSeats: 3, EmptySeats: 7, Tiers: in.Tiers, HeldSeats: in.HeldSeats}, nil
+		},
+		ReleaseTableFunc: func(ctx context.Context, number int) (*party.Table, error) {
+			return &party.Table{Number: number, Size: 10, BookedSeats: 3, EmptySeats: 7}, nil
+		},
+		CheckInFunc: func(ctx context.Context, in *party.CheckInInput) (*party.CheckInOutput, error) {
+			return &party.CheckInOutput{Name: "John", AccompanyingGuests: 2}, nil
+		},
+		ListGroupsFunc: func(ctx context.Context) (party.ListGroupsOutput, error) {
+			return party.ListGroupsOutput{Groups: []party.Group{{
+				Name:   "Doe",
+				Tables: []int{2},
+				Guests: []party.Guest{{Name: "Jane Doe", Table: 2, AccompanyingGuests: 1, TimeArrival: &arrived}, {Name: "Jim Doe", Table: 2}},
+			}}}, nil
+		},
+		AddGroupToGuestListFunc: func(ctx context.Context, in *party.AddGroupToGuestListInput) (*party.Group, error) {
+			group := party.Group{Name: in.Name, Tables: []int{3}}
+			for _, guest := range in.Guests {
+				group.Guests = append(group.Guests, party.Guest{Name: guest.Name, Table: 3, AccompanyingGuests: guest.AccompanyingGuests})
+			}
+			return &group, nil
+		},
+		GetGroupFunc: func(ctx context.Context, name string) (*party.Group, error) {
+			if name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			return &party.Group{Name: name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane Doe", Table: 2, AccompanyingGuests: 1}}}, nil
+		},
+		WelcomeGroupFunc: func(ctx context.Context, in *party.WelcomeGroupInput) (*party.Group, error) {
+			return &party.Group{Name: in.Name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane Doe", Table: 2}, {Name: "Jim Doe", Table: 2}}}, nil
+		},
+		GoodbyeGroupFunc: func(ctx context.Context, in *party.GoodbyeGroupInput) (*party.Group, error) {
+			return &party.Group{Name: in.Name}, nil
+		},
+		GetGuestRequirementsFunc: func(ctx context.Context, name string) (*party.GuestRequirements, error) {
+			if name != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.GuestRequirements{Guest: name, People: []party.Requirements{
+				{Dietary: []string{party.DietaryVegan}, Custom: []party.CustomValue{{Field: "meal", Value: "fish"}}},
+				{Companion: 1, Name: "Mary", Allergies: []string{"nuts", "shellfish"}},
+			}}, nil
+		},
+		SetGuestRequirementsFunc: func(ctx context.Context, in *party.SetGuestRequirementsInput) (*party.GuestRequirements, error) {
+			return &party.GuestRequirements{Guest: in.Guest, People: in.People}, nil
+		},
+		ListCustomFieldsFunc: func(ctx context.Context) (party.ListCustomFieldsOutput, error) {
+			return party.ListCustomFieldsOutput{Fields: []party.CustomField{
+				{Name: "meal", Type: party.CustomFieldChoice, Options: []string{"fish", "meat"}, Required: true},
+				{Name: "shirt", Type: party.CustomFieldText},
+			}}, nil
+		},
+		SetCustomFieldsFunc: func(ctx context.Context, in *party.SetCustomFieldsInput) (party.ListCustomFieldsOutput, error) {
+			return party.ListCustomFieldsOutput{Fields: in.Fields}, nil
+		},
+		GetCateringSummaryFunc: func(ctx context.Context) (*party.CateringSummary, error) {
+			return &party.CateringSummary{
+				Total: party.CateringTable{
+					People:    4,
+					Dietary:   []party.CateringCount{{Value: party.DietaryVegan, Count: 2}},
+					Allergies: []party.CateringCount{{Value: "nuts", Count: 1}},
+					Custom:    []party.CateringCount{{Field: "meal", Value: "fish", Count: 3}},
+				},
+				Tables: []party.CateringTable{
+					{Table: 1, People: 3, Dietary: []party.CateringCount{{Value: party.DietaryVegan, Count: 2}}, Custom: []party.CateringCount{{Field: "meal", Value: "fish", Count: 3}}},
+					{Table: 2, People: 1, Allergies: []party.CateringCount{{Value: "nuts", Count: 1}}},
+				},
+			}, nil
+		},
+		ListConstraintsFunc: func(ctx context.Context) (party.ListConstraintsOutput, error) {
+			return party.ListConstraintsOutput{Constraints: []party.Constraint{
+				{ID: "c1", Kind: party.ConstraintApart, Guests: []string{"John", "Jane"}},
+				{ID: "c2", Kind: party.ConstraintPinned, Guests: []string{"Jane"}, Table: 2},
+			}}, nil
+		},
+		CreateConstraintFunc: func(ctx context.Context, in *party.CreateConstraintInput) (*party.Constraint, error) {
+			return &party.Constraint{ID: "c3", Kind: in.Kind, Guests: in.Guests, Table: in.Table}, nil
+		},
+		DeleteConstraintFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+		PlanSeatingFunc: func(ctx context.Context) (*party.SeatingPlan, error) {
+			return &party.SeatingPlan{Assignments: []party.SeatingAssignment{{Guest: "Jane", Table: 2, CurrentTable: 1}, {Guest: "John", Table: 1, CurrentTable: 1}}}, nil
+		},
+		CheckSeatingFunc: func(ctx context.Context, in *party.CheckSeatingInput) (*party.SeatingPlan, error) {
+			if len(in.Assignments) == 0 {
+				in.Assignments = []party.SeatingAssignment{{Guest: "Jane", Table: 1, CurrentTable: 1}, {Guest: "John", Table: 1, CurrentTable: 1}}
+			}
+			return &party.SeatingPlan{
+				Assignments: in.Assignments,
+				Unseated:    []string{"Bob"},
+				Violations:  []party.SeatingViolation{{Constraint: "c1", Reason: `guests "John" and "Jane" sit at table 1`}},
+			}, nil
+		},
+		ImportGuestsFunc: func(ctx context.Context, in *party.ImportGuestsInput) (*party.ImportGuestsOutput, error) {
+			out := party.ImportGuestsOutput{Rows: len(in.Guests), DryRun: in.DryRun}
+			for i, guest := range in.Guests {
+				if guest.Table == 0 {
+					out.Errors = append(out.Errors, party.ImportRowError{Row: i + 1, Name: guest.Name, Error: party.ErrTableNumberRequired.Error()})
+				}
+			}
+			if len(out.Errors) == 0 && !in.DryRun {
+				out.Imported = len(in.Guests)
+			}
+			return &out, nil
+		},
+	}
+
+	baseURL := startTestApp(t, &service)
+
+	dir := t.TempDir()
+
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`{"url": "`+baseURL+`", "organiser_key": "`+testOrganiserKey+`"}`), 0o600))
+
+	importPath := filepath.Join(dir, "guests.json")
+	require.NoError(t, ioutil.WriteFile(importPath, []byte(`[{"name": "Jane", "table": 1}, {"name": "Bob"}]`), 0o600))
+
+	cases := []struct {
+		name             string
+		givenArgs        []string
+		givenStdin       string
+		givenEnv         map[string]string
+		expectedOutput   string
+		expectedExitCode int
+	}{
+		{
+			name:           "adds a guest",
+			givenArgs:      []string{"guests", "add", "Jane", "-table", "1", "-companions", "2"},
+			expectedOutput: "Added Jane to table 1 with 2 accompanying guests\n",
+		},
+		{
+			name:           "adds a guest with json output",
+			givenArgs:      []string{"-o", "json", "guests", "add", "-table", "1", "Jane"},
+			expectedOutput: "{\n  \"name\": \"Jane\",\n  \"table\": 1,\n  \"accompanying_guests\": 0\n}\n",
+		},
+		{
+			name:           "adds a guest of a tier with json output",
+			givenArgs:      []string{"-o", "json", "guests", "add", "-table", "1", "-tier", "vip", "Jane"},
+			expectedOutput: "{\n  \"name\": \"Jane\",\n  \"table\": 1,\n  \"accompanying_guests\": 0,\n  \"tier\": \"vip\"\n}\n",
+		},
+		{
+			name:             "reports a table restricted to other tiers",
+			givenArgs:        []string{"guests", "add", "-table", "1", "-tier", "staff", "Jane"},
+			expectedExitCode: 1,
+		},
+		{
+			name:             "reports the party errors",
+			givenArgs:        []string{"guests", "add", "-table", "1", "John"},
+			expectedExitCode: 1,
+		},
+		{
+			name:      "lists the guests as a table",
+			givenArgs: []string{"guests", "list", "-limit", "1"},
+			expectedOutput: "NAME  TABLE  COMPANIONS  ARRIVED\n" +
+				"John  1      2           2021-11-23 20:00\n" +
+				"\nMore guests with -cursor next\n",
+		},
+		{
+			name:             "rejects an invalid filter",
+			givenArgs:        []string{"guests", "list", "-arrived", "maybe"},
+			expectedExitCode: 2,
+		},
+		{
+			name:           "removes a guest",
+			givenArgs:      []string{"guests", "remove", "John"},
+			expectedOutput: "Removed John from the guest list\n",
+		},
+		{
+			name:             "requires the organiser key",
+			givenArgs:        []string{"-config", filepath.Join(dir, "missing.json"), "guests", "remove", "John"},
+			givenEnv:         map[string]string{envURL: baseURL},
+			expectedExitCode: 1,
+		},
+		{
+			name:           "checks in with the booked accompanying guests",
+			givenArgs:      []string{"checkin", "John Doe"},
+			expectedOutput: "Welcomed John Doe with 1 accompanying guests\n",
+		},
+		{
+			name:           "checks in with a code",
+			givenArgs:      []string{"checkin", "-code", "code"},
+			expectedOutput: "Welcomed John with 2 accompanying guests\n",
+		},
+		{
+			name:             "rejects a check-in with both a name and a code",
+			givenArgs:        []string{"checkin", "-code", "code", "John"},
+			expectedExitCode: 2,
+		},
+		{
+			name:           "checks out",
+			givenArgs:      []string{"checkout", "John"},
+			expectedOutput: "Said goodbye to John\n",
+		},
+		{
+			name:      "lists the tables",
+			givenArgs: []string{"tables", "list"},
+			expectedOutput: "TABLE  SIZE  BOOKED  ARRIVED  EMPTY  HELD  TIERS\n" +
+				"1      10    3       3        7      0     -\n",
+		},
+		{
+			name:      "reserves a table",
+			givenArgs: []string{"tables", "reserve", "-tiers", "vip,staff", "-hold", "2", "1"},
+			expectedOutput: "TABLE  SIZE  BOOKED  ARRIVED  EMPTY  HELD  TIERS\n" +
+				"1      10    3       0        7      2     vip, staff\n",
+		},
+		{
+			name:             "rejects holding more seats than available",
+			givenArgs:        []string{"tables", "reserve", "-hold", "8", "1"},
+			expectedExitCode: 1,
+		},
+		{
+			name:             "rejects an invalid table number",
+			givenArgs:        []string{"tables", "reserve", "-tiers", "vip", "one"},
+			expectedExitCode: 2,
+		},
+		{
+			name:      "releases a table",
+			givenArgs: []string{"tables", "release", "1"},
+			expectedOutput: "TABLE  SIZE  BOOKED  ARRIVED  EMPTY  HELD  TIERS\n" +
+				"1      10    3       0        7      0     -\n",
+		},
+		{
+			name:      "creates a table with json output",
+			givenArgs: []string{"-o", "json", "tables", "create", "-size", "2", "2"},
+			expectedOutput: "{\n  \"number\": 2,\n  \"size\": 2,\n  \"booked_seats\": 0,\n  \"arrived_seats\": 0,\n  \"empty_seats\": 2,\n" +
+				"  \"seats\": [\n    {\n      \"number\": 1\n    },\n    {\n      \"number\": 2\n    }\n  ]\n}\n",
+		},
+		{
+			name:           "gets the empty seats",
+			givenArgs:      []string{"seats"},
+			expectedOutput: "EMPTY SEATS\n7\n",
+		},
+		{
+			name:      "lists the groups",
+			givenArgs: []string{"groups", "list"},
+			expectedOutput: "GROUP  GUEST     TABLE  COMPANIONS  ARRIVED\n" +
+				"Doe    Jane Doe  2      1           2021-11-23 20:00\n" +
+				"Doe    Jim Doe   2      0           -\n",
+		},
+		{
+			name:      "adds a group",
+			givenArgs: []string{"groups", "add", "Smith", "Amy Smith:2", "Joe"},
+			expectedOutput: "GROUP  GUEST      TABLE  COMPANIONS  ARRIVED\n" +
+				"Smith  Amy Smith  3      2           -\n" +
+				"Smith  Joe        3      0           -\n",
+		},
+		{
+			name:             "rejects a group without guests",
+			givenArgs:        []string{"groups", "add", "Smith"},
+			expectedExitCode: 2,
+		},
+		{
+			name:             "rejects an invalid number of accompanying guests",
+			givenArgs:        []string{"groups", "add", "Smith", "Amy:two"},
+			expectedExitCode: 2,
+		},
+		{
+			name:      "shows a group",
+			givenArgs: []string{"groups", "show", "Doe"},
+			expectedOutput: "GROUP  GUEST     TABLE  COMPANIONS  ARRIVED\n" +
+				"Doe    Jane Doe  2      1           -\n",
+		},
+		{
+			name:             "fails for an unknown group",
+			givenArgs:        []string{"groups", "show", "Smith"},
+			expectedExitCode: 1,
+		},
+		{
+			name:           "checks a group in",
+			givenArgs:      []string{"groups", "checkin", "Doe"},
+			expectedOutput: "Welcomed the Doe group with 2 guests\n",
+		},
+		{
+			name:           "checks a group out",
+			givenArgs:      []string{"groups", "checkout", "Doe"},
+			expectedOutput: "Said goodbye to the Doe group\n",
+		},
+		{
+			name:      "shows the requirements of a guest",
+			givenArgs: []string{"requirements", "show", "John"},
+			expectedOutput: "PERSON  NAME  DIETARY  ALLERGIES        ACCESSIBILITY  CUSTOM\n" +
+				"guest   -     vegan    -                -              meal=fish\n" +
+				"1       Mary  -        nuts, shellfish  -              -\n",
+		},
+		{
+			name:             "fails for the requirements of an unknown guest",
+			givenArgs:        []string{"requirements", "show", "Jane"},
+			expectedExitCode: 1,
+		},
+		{
+			name:       "sets the requirements of a guest",
+			givenArgs:  []string{"requirements", "set", "John", "-"},
+			givenStdin: `[{"companion": 0, "accessibility": ["wheelchair"]}]`,
+			expectedOutput: "PERSON  NAME  DIETARY  ALLERGIES  ACCESSIBILITY  CUSTOM\n" +
+				"guest   -     -        -          wheelchair     -\n",
+		},
+		{
+			name:             "rejects requirements without a file",
+			givenArgs:        []string{"requirements", "set", "John"},
+			expectedExitCode: 2,
+		},
+		{
+			name:      "lists the custom fields",
+			givenArgs: []string{"fields", "list"},
+			expectedOutput: "NAME   TYPE    OPTIONS     REQUIRED\n" +
+				"meal   choice  fish, meat  true\n" +
+				"shirt  text    -           false\n",
+		},
+		{
+			name:       "sets the custom fields",
+			givenArgs:  []string{"fields", "set", "-"},
+			givenStdin: `[{"name": "shuttle", "type": "boolean"}]`,
+			expectedOutput: "NAME     TYPE     OPTIONS  REQUIRED\n" +
+				"shuttle  boolean  -        false\n",
+		},
+		{
+			name:      "summarises the catering",
+			givenArgs: []string{"catering"},
+			expectedOutput: "TABLE  PEOPLE  DIETARY  ALLERGIES  ACCESSIBILITY  CUSTOM\n" +
+				"1      3       2 vegan  -          -              3 meal=fish\n" +
+				"2      1       -        1 nuts     -              -\n" +
+				"total  4       2 vegan  1 nuts     -              3 meal=fish\n",
+		},
+		{
+			name:      "lists the constraints",
+			givenArgs: []string{"constraints", "list"},
+			expectedOutput: "ID  KIND    TABLE  GUESTS\n" +
+				"c1  apart   -      John, Jane\n" +
+				"c2  pinned  2      Jane\n",
+		},
+		{
+			name:           "adds a constraint",
+			givenArgs:      []string{"constraints", "add", "-kind", "together", "John", "Jane"},
+			expectedOutput: "Added together constraint c3 on John, Jane\n",
+		},
+		{
+			name:             "rejects a constraint without guests",
+			givenArgs:        []string{"constraints", "add", "-kind", "pinned", "-table", "1"},
+			expectedExitCode: 2,
+		},
+		{
+			name:           "deletes a constraint",
+			givenArgs:      []string{"constraints", "delete", "c1"},
+			expectedOutput: "Deleted constraint c1\n",
+		},
+		{
+			name:      "plans the seating",
+			givenArgs: []string{"seating", "plan"},
+			expectedOutput: "GUEST  TABLE  CURRENT TABLE\n" +
+				"Jane   2      1\n" +
+				"John   1      1\n",
+		},
+		{
+			name:       "reports the broken constraints of a seating plan",
+			givenArgs:  []string{"seating", "check", "-"},
+			givenStdin: `[{"guest": "John", "table": 1}]`,
+			expectedOutput: "GUEST  TABLE  CURRENT TABLE\n" +
+				"John   1      0\n" +
+				"Bob    -      -\n" +
+				"\n" +
+				"CONSTRAINT  VIOLATION\n" +
+				"c1          guests \"John\" and \"Jane\" sit at table 1\n",
+			expectedExitCode: 1,
+		},
+		{
+			name:             "checks the current tables",
+			givenArgs:        []string{"seating", "check"},
+			expectedExitCode: 1,
+		},
+		{
+			name:             "reports the rejected import rows",
+			givenArgs:        []string{"import", importPath},
+			expectedOutput:   "ROW  NAME  ERROR\n2    Bob   table number required\n",
+			expectedExitCode: 1,
+		},
+		{
+			name:           "imports csv from the standard input",
+			givenArgs:      []string{"import", "-dry-run", "-"},
+			givenStdin:     "name,table\nJane,1\n",
+			expectedOutput: "Checked 1 guests, nothing was imported\n",
+		},
+		{
+			name:             "rejects an unknown command",
+			givenArgs:        []string{"guests", "invite"},
+			expectedExitCode: 2,
+		},
+		{
+			name:             "rejects an unknown output format",
+			givenArgs:        []string{"-o", "yaml", "seats"},
+			expectedExitCode: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			getenv := func(key string) string {
+				if key == envConfig {
+					return configPath
+				}
+				return tc.givenEnv[key]
+			}
+
+			err := run(context.Background(), tc.givenArgs, strings.NewReader(tc.givenStdin), &stdout, &stderr, getenv)
+
+			code := 0
+			if err != nil {
+				code = exitCode(err, &stderr)
+			}
+
+			require.Equal(t, tc.expectedExitCode, code, stderr.String())
+
+			if tc.expectedOutput != "" || code == 0 {
+				assert.Equal(t, tc.expectedOutput, stdout.String())
+			}
+		})
+	}
+
+	t.Run("sends the booked accompanying guests unless given", func(t *testing.T) {
+		getenv := func(key string) string {
+			return map[string]string{envURL: baseURL}[key]
+		}
+
+		err := run(context.Background(), []string{"checkin", "-companions", "0", "John"}, nil, ioutil.Discard, ioutil.Discard, getenv)
+		require.NoError(t, err)
+		assert.Equal(t, &party.WelcomeGuestInput{Name: "John"}, welcomed)
+
+		err = run(context.Background(), []string{"checkin", "John"}, nil, ioutil.Discard, ioutil.Discard, getenv)
+		require.NoError(t, err)
+		assert.Equal(t, &party.WelcomeGuestInput{Name: "John", AccompanyingGuests: 2}, welcomed)
+	})
+}
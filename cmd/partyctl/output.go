@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Enumerate output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer writes command results as JSON or as an aligned table.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// table is the tabular view of a command result.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// print writes v as indented JSON, or its table view.
+func (p *printer) print(v interface{}, t table) error {
+	if p.format == outputJSON {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	if len(t.header) > 0 {
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	}
+
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// message writes a confirmation in table output, or v as JSON.
+func (p *printer) message(v interface{}, format string, args ...interface{}) error {
+	if p.format == outputJSON {
+		return p.print(v, table{})
+	}
+
+	_, err := fmt.Fprintf(p.w, format+"\n", args...)
+	return err
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
@@ -1,39 +1,272 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 
 	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/app/partygql"
+	"github.com/alesr/getground/internal/pkg/metrics"
+	"github.com/alesr/getground/internal/pkg/tracing"
 	fiber "github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
-// Create app struct
-type App struct {
-	logger    *zap.Logger
-	fiberApp  *fiber.App
-	partyCtrl partyctrl.PartyController
+const headerDeprecation = "Deprecation"
+
+type (
+	// Create app struct
+	App struct {
+		logger       *zap.Logger
+		fiberApp     *fiber.App
+		partyCtrl    partyctrl.PartyController
+		organiserKey string
+		metrics      *metrics.Metrics
+		tracing      bool
+		health       *Health
+		validation   bool
+		graphql      *partygql.Handler
+		events       *Events
+		dashboard    bool
+	}
+
+	// Option configures optional App behaviour.
+	Option func(*App)
+)
+
+// WithOrganiserKey sets the API key required on organiser-only routes.
+// When no key is set, organiser routes are left open.
+func WithOrganiserKey(key string) Option {
+	return func(a *App) {
+		a.organiserKey = key
+	}
+}
+
+// WithMetrics records the HTTP requests metrics and serves the collected metrics on /metrics.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(a *App) {
+		a.metrics = m
+	}
+}
+
+// WithTracing starts a span for each request, continuing the trace of the W3C trace-context headers.
+func WithTracing() Option {
+	return func(a *App) {
+		a.tracing = true
+	}
+}
+
+// WithHealth serves the liveness and readiness probes on /healthz and /readyz.
+func WithHealth(h *Health) Option {
+	return func(a *App) {
+		a.health = h
+	}
+}
+
+// WithRequestValidation rejects requests that do not match the OpenAPI specification served on /openapi.json.
+func WithRequestValidation() Option {
+	return func(a *App) {
+		a.validation = true
+	}
 }
 
-func New(logger *zap.Logger, fiberApp *fiber.App, partyCtrl partyctrl.PartyController) *App {
-	return &App{
+// WithGraphQL serves the GraphQL queries and mutations on /graphql.
+func WithGraphQL(h *partygql.Handler) Option {
+	return func(a *App) {
+		a.graphql = h
+	}
+}
+
+// WithEvents streams the party events as server-sent events on /v1/events.
+func WithEvents(e *Events) Option {
+	return func(a *App) {
+		a.events = e
+	}
+}
+
+// WithDashboard serves the organiser dashboard on /dashboard.
+// The dashboard updates live when the events are streamed with WithEvents.
+func WithDashboard() Option {
+	return func(a *App) {
+		a.dashboard = true
+	}
+}
+
+func New(logger *zap.Logger, fiberApp *fiber.App, partyCtrl partyctrl.PartyController, opts ...Option) *App {
+	a := App{
 		logger:    logger.Named("party_app"),
 		fiberApp:  fiberApp,
 		partyCtrl: partyCtrl,
 	}
+
+	for _, opt := range opts {
+		opt(&a)
+	}
+	return &a
 }
 
+// Run registers the routes and serves requests until Shutdown is called.
 func (a *App) Run(port string) error {
-	a.fiberApp.Post("/guest_list/:name", a.partyCtrl.AddGuestToGuestList)
-	a.fiberApp.Get("/guest_list", a.partyCtrl.GetGuestList)
-	a.fiberApp.Put("/guests/:name", a.partyCtrl.WelcomeGuest)
-	a.fiberApp.Delete("/guests/:name", a.partyCtrl.GoodbyeGuest)
-	a.fiberApp.Get("/guests", a.partyCtrl.ListArrivedGuests)
-	a.fiberApp.Get("/seats_empty", a.partyCtrl.GetEmptySeats)
+	organiser := requireOrganiser(a.organiserKey)
+
+	// Guest needs are private, only organisers may filter the guest lists by them
+	private := requireOrganiserForQuery(organiser, "dietary", "allergy", "accessibility", "custom")
+
+	// Only organisers may add guests of the tiers that tables are kept for
+	tiered := requireOrganiserForTier(organiser)
+
+	// Probes are registered first so they are neither traced nor measured
+	if a.health != nil {
+		a.fiberApp.Get("/healthz", a.health.Healthz)
+		a.fiberApp.Get("/readyz", a.health.Readyz)
+	}
+
+	if a.tracing {
+		a.fiberApp.Use(tracing.Middleware())
+	}
+
+	// Registered after tracing so access logs carry the trace ID
+	a.fiberApp.Use(requestLogger(a.logger))
+
+	if a.metrics != nil {
+		a.fiberApp.Use(a.metrics.Middleware())
+		a.fiberApp.Get("/metrics", a.metrics.Handler())
+	}
+
+	a.fiberApp.Get("/openapi.json", openAPI)
+	a.fiberApp.Get("/docs", swaggerUI)
+
+	if a.validation {
+		validator, err := requestValidator()
+		if err != nil {
+			return fmt.Errorf("failed to set up request validation: %w", err)
+		}
+		a.fiberApp.Use(validator)
+	}
+
+	a.routesV1(a.fiberApp.Group("/v1"), organiser, private, tiered)
+
+	// The unversioned routes predate /v1 and are kept for existing clients
+	a.routesV1(a.fiberApp, organiser, private, tiered, deprecated("/v1"))
+
+	a.routesV2(a.fiberApp.Group("/v2"), organiser, private, tiered)
+
+	if a.events != nil {
+		a.fiberApp.Get("/v1/events", a.events.Stream)
+	}
+
+	if a.graphql != nil {
+		a.fiberApp.Post("/graphql", a.graphql.Serve)
+	}
+
+	if a.dashboard {
+		a.fiberApp.Get("/dashboard", dashboard)
+		a.fiberApp.Get("/dashboard/:file", dashboardAsset)
+	}
 
 	if err := a.fiberApp.Listen(net.JoinHostPort("", port)); err != nil {
 		return fmt.Errorf("failed to serve http request: %w", err)
 	}
 	return nil
 }
+
+// routesV1 registers the original routes, each behind the given handlers.
+// The guest lists are also behind private, which guards their requirement filters,
+// and adding a guest behind tiered, which guards their tier.
+func (a *App) routesV1(r fiber.Router, organiser, private, tiered fiber.Handler, handlers ...fiber.Handler) {
+	h := func(route ...fiber.Handler) []fiber.Handler {
+		return append(append([]fiber.Handler{}, handlers...), route...)
+	}
+
+	r.Post("/guest_list/import", h(organiser, a.partyCtrl.ImportGuests)...)
+	r.Post("/guest_list/:name", h(tiered, a.partyCtrl.AddGuestToGuestList)...)
+	r.Delete("/guest_list/:name", h(organiser, a.partyCtrl.RemoveGuestFromGuestList)...)
+	r.Get("/guest_list", h(private, a.partyCtrl.GetGuestList)...)
+	r.Put("/guests/:name", h(a.partyCtrl.WelcomeGuest)...)
+	r.Delete("/guests/:name", h(a.partyCtrl.GoodbyeGuest)...)
+	r.Get("/guests", h(private, a.partyCtrl.ListArrivedGuests)...)
+	r.Get("/seats_empty", h(a.partyCtrl.GetEmptySeats)...)
+
+	r.Post("/invitations", h(organiser, a.partyCtrl.CreateInvitation)...)
+	r.Get("/rsvp/:token", h(a.partyCtrl.GetInvitation)...)
+	r.Post("/rsvp/:token", h(a.partyCtrl.RespondToInvitation)...)
+
+	r.Post("/guest_list/:name/checkin_code", h(organiser, a.partyCtrl.IssueCheckInCode)...)
+	r.Get("/checkin/:code/qr", h(organiser, a.partyCtrl.GetCheckInCodeQR)...)
+	r.Delete("/checkin/:code", h(organiser, a.partyCtrl.RevokeCheckInCode)...)
+	r.Put("/checkin/:code", h(a.partyCtrl.CheckIn)...)
+
+	r.Get("/export/guest_list", h(organiser, a.partyCtrl.ExportGuestList)...)
+	r.Get("/export/seating_report", h(organiser, a.partyCtrl.ExportSeatingReport)...)
+}
+
+// routesV2 registers the resource routes.
+func (a *App) routesV2(r fiber.Router, organiser, private, tiered fiber.Handler) {
+	r.Get("/guests", private, a.partyCtrl.ListGuests)
+	r.Post("/guests", tiered, a.partyCtrl.CreateGuest)
+	r.Put("/guests/:id/arrival", a.partyCtrl.RecordArrival)
+	r.Delete("/guests/:id/arrival", a.partyCtrl.RecordDeparture)
+	r.Put("/guests/:id/seats", organiser, a.partyCtrl.AssignSeats)
+	r.Get("/guests/:id/requirements", organiser, a.partyCtrl.GetGuestRequirements)
+	r.Put("/guests/:id/requirements", organiser, a.partyCtrl.SetGuestRequirements)
+	r.Get("/groups", a.partyCtrl.ListGroups)
+	r.Post("/groups", tiered, a.partyCtrl.CreateGroup)
+	r.Get("/groups/:id", a.partyCtrl.GetGroup)
+	r.Put("/groups/:id/arrival", a.partyCtrl.RecordGroupArrival)
+	r.Delete("/groups/:id/arrival", a.partyCtrl.RecordGroupDeparture)
+	r.Get("/tables", a.partyCtrl.ListTables)
+	r.Post("/tables", organiser, a.partyCtrl.CreateTable)
+	r.Put("/tables/:number/reservation", organiser, a.partyCtrl.ReserveTable)
+	r.Delete("/tables/:number/reservation", organiser, a.partyCtrl.ReleaseTable)
+	r.Post("/tables/:number/swap_seats", organiser, a.partyCtrl.SwapSeats)
+	r.Get("/constraints", organiser, a.partyCtrl.ListConstraints)
+	r.Post("/constraints", organiser, a.partyCtrl.CreateConstraint)
+	r.Delete("/constraints/:id", organiser, a.partyCtrl.DeleteConstraint)
+	r.Get("/seating_plan", organiser, a.partyCtrl.PlanSeating)
+	r.Post("/seating_plan/check", organiser, a.partyCtrl.CheckSeating)
+	r.Get("/layout", a.partyCtrl.GetLayout)
+	r.Put("/layout", organiser, a.partyCtrl.SetLayout)
+	r.Get("/layout/seating_chart", a.partyCtrl.GetSeatingChart)
+	r.Get("/custom_fields", a.partyCtrl.ListCustomFields)
+	r.Put("/custom_fields", organiser, a.partyCtrl.SetCustomFields)
+	r.Get("/catering", organiser, a.partyCtrl.GetCateringSummary)
+	r.Get("/stats", a.partyCtrl.GetStats)
+}
+
+// deprecated marks the responses of a route as deprecated in favour of the same route under prefix.
+func deprecated(prefix string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(headerDeprecation, "true")
+		c.Set(fiber.HeaderLink, fmt.Sprintf(`<%s%s>; rel="successor-version"`, prefix, c.Path()))
+		return c.Next()
+	}
+}
+
+// Shutdown stops accepting requests and waits for the in-flight requests to complete
+// until the context is done. The readiness probe reports the app as not ready from the start
+// and the event streams are ended.
+func (a *App) Shutdown(ctx context.Context) error {
+	if a.health != nil {
+		a.health.SetShuttingDown()
+	}
+
+	if a.events != nil {
+		a.events.Close()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- a.fiberApp.Shutdown()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("could not shut down http server: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("could not drain in-flight requests: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// startTestApp runs an app whose WelcomeGuest handler blocks until release is closed.
+// started receives a value once the handler is in flight.
+func startTestApp(t *testing.T) (a *App, addr string, started <-chan struct{}, release chan struct{}, runErr <-chan error) {
+	t.Helper()
+
+	startedCh := make(chan struct{}, 1)
+	release = make(chan struct{})
+
+	service := party.Mock{}
+	service.WelcomeGuestFunc = func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+		startedCh <- struct{}{}
+		<-release
+		return &party.WelcomeGuestOutput{Name: in.Name}, nil
+	}
+
+	health := NewHealth(pingerFunc(func(ctx context.Context) error { return nil }), BuildInfo{})
+	health.SetMigrated(nil)
+
+	a = New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), &service), WithHealth(health))
+
+	addr, runErrCh := listenTestApp(t, a)
+
+	return a, addr, startedCh, release, runErrCh
+}
+
+// listenTestApp runs the app on a free local port and waits until it accepts connections.
+func listenTestApp(t *testing.T, a *App) (addr string, runErr <-chan error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, ln.Close())
+
+	runErrCh := make(chan error, 1)
+	go func() {
+		runErrCh <- a.Run(port)
+	}()
+
+	addr = "127.0.0.1:" + port
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return addr, runErrCh
+}
+
+func welcomeGuest(addr string) <-chan *http.Response {
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodPut, "http://"+addr+"/guests/John", bytes.NewBufferString(`{"accompanying_guests": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			respCh <- nil
+			return
+		}
+		respCh <- resp
+	}()
+	return respCh
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("completes the in-flight requests and stops accepting new ones", func(t *testing.T) {
+		a, addr, started, release, runErr := startTestApp(t)
+
+		respCh := welcomeGuest(addr)
+		<-started
+
+		shutdownErr := make(chan error, 1)
+		go func() {
+			shutdownErr <- a.Shutdown(context.Background())
+		}()
+
+		// New connections are refused while the in-flight request is still running
+		require.Eventually(t, func() bool {
+			conn, err := net.Dial("tcp", addr)
+			if err != nil {
+				return true
+			}
+			_ = conn.Close()
+			return false
+		}, 2*time.Second, 10*time.Millisecond)
+
+		select {
+		case err := <-shutdownErr:
+			t.Fatalf("shutdown returned before the in-flight request completed: %v", err)
+		default:
+		}
+
+		close(release)
+
+		resp := <-respCh
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		_ = resp.Body.Close()
+
+		assert.NoError(t, <-shutdownErr)
+		assert.NoError(t, <-runErr)
+
+		_, ready := a.health.check(context.Background())
+		assert.False(t, ready)
+	})
+
+	t.Run("returns an error when in-flight requests outlast the timeout", func(t *testing.T) {
+		a, addr, started, release, _ := startTestApp(t)
+		defer close(release)
+
+		welcomeGuest(addr)
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := a.Shutdown(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	service := party.Mock{}
+	service.GetEmptySeatsFunc = func(ctx context.Context) (party.GetEmptySeatsOutput, error) {
+		return party.GetEmptySeatsOutput{EmptySeats: 7}, nil
+	}
+
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), &service))
+
+	addr, _ := listenTestApp(t, a)
+	defer func() { _ = a.Shutdown(context.Background()) }()
+
+	cases := []struct {
+		name               string
+		givenPath          string
+		expectedStatus     int
+		expectedDeprecated bool
+	}{
+		{
+			name:           "serves the v1 routes",
+			givenPath:      "/v1/seats_empty",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:               "serves the unversioned routes as deprecated aliases",
+			givenPath:          "/seats_empty",
+			expectedStatus:     http.StatusOK,
+			expectedDeprecated: true,
+		},
+		{
+			name:           "does not serve the v1 routes under v2",
+			givenPath:      "/v2/seats_empty",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get("http://" + addr + tc.givenPath)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			if tc.expectedDeprecated {
+				assert.Equal(t, "true", resp.Header.Get(headerDeprecation))
+				assert.Equal(t, `</v1/seats_empty>; rel="successor-version"`, resp.Header.Get(fiber.HeaderLink))
+				return
+			}
+			assert.Empty(t, resp.Header.Get(headerDeprecation))
+		})
+	}
+}
+
+func TestUnversionedRoutesAcceptFormBodies(t *testing.T) {
+	var (
+		added   *party.AddGuestToGuestListInput
+		welcome *party.WelcomeGuestInput
+	)
+
+	service := party.Mock{}
+	service.AddGuestToGuestListFunc = func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+		added = in
+		return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+	}
+	service.WelcomeGuestFunc = func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+		welcome = in
+		return &party.WelcomeGuestOutput{Name: in.Name}, nil
+	}
+
+	// Request validation is off by default, existing clients send form bodies to the unversioned routes
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), &service))
+
+	addr, _ := listenTestApp(t, a)
+	defer func() { _ = a.Shutdown(context.Background()) }()
+
+	send := func(method, path, body string) int {
+		req, err := http.NewRequest(method, "http://"+addr+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/guest_list/bob", "table=3"))
+	require.NotNil(t, added)
+	assert.Equal(t, &party.AddGuestToGuestListInput{Name: "bob", Table: 3}, added)
+
+	assert.Equal(t, http.StatusOK, send(http.MethodPut, "/guests/bob", "accompanyingguests=2"))
+	require.NotNil(t, welcome)
+	assert.Equal(t, &party.WelcomeGuestInput{Name: "bob", AccompanyingGuests: 2}, welcome)
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+const bearerPrefix = "Bearer "
+
+// requireOrganiser rejects requests that do not carry the organiser key as a bearer token.
+func requireOrganiser(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if key == "" {
+			c.Locals(localsRole, roleOrganiser)
+			return c.Next()
+		}
+
+		auth := c.Get(fiber.HeaderAuthorization)
+		if !strings.HasPrefix(auth, bearerPrefix) ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, bearerPrefix)), []byte(key)) != 1 {
+			return c.Status(http.StatusUnauthorized).JSON(partyctrl.ErrorResponse{Error: "organiser credentials required"})
+		}
+
+		c.Locals(localsRole, roleOrganiser)
+		return c.Next()
+	}
+}
+
+// requireOrganiserForQuery runs the organiser check only on requests that set one of the query parameters,
+// so that public routes can offer filters that reveal private details to organisers alone.
+func requireOrganiserForQuery(organiser fiber.Handler, params ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, param := range params {
+			if c.Query(param) != "" {
+				return organiser(c)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// requireOrganiserForTier runs the organiser check only on requests whose body gives the guest, or one of
+// the guests of a group, a tier other than general, so that guests cannot book the tables kept for other tiers.
+// The body is parsed as the handlers parse it, whatever its content type.
+func requireOrganiserForTier(organiser fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body struct {
+			Tier   string `json:"tier"`
+			Guests []struct {
+				Tier string `json:"tier"`
+			} `json:"guests"`
+		}
+
+		// Bodies that do not parse are rejected by the handlers
+		if err := c.BodyParser(&body); err != nil {
+			return c.Next()
+		}
+
+		tiers := []string{body.Tier}
+		for _, guest := range body.Guests {
+			tiers = append(tiers, guest.Tier)
+		}
+
+		for _, tier := range tiers {
+			if tier != "" && tier != party.TierGeneral {
+				return organiser(c)
+			}
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRequireOrganiserForTier(t *testing.T) {
+	var called bool
+
+	service := party.Mock{}
+	service.AddGuestToGuestListFunc = func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+		called = true
+		return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+	}
+	service.AddGroupToGuestListFunc = func(ctx context.Context, in *party.AddGroupToGuestListInput) (*party.Group, error) {
+		called = true
+		return &party.Group{Name: in.Name}, nil
+	}
+
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), &service),
+		WithOrganiserKey(testOrganiserKey),
+	)
+
+	addr, _ := listenTestApp(t, a)
+	defer func() { _ = a.Shutdown(context.Background()) }()
+
+	cases := []struct {
+		name           string
+		givenPath      string
+		givenBody      string
+		givenType      string
+		givenOrganiser bool
+		expectedStatus int
+		expectedCalled bool
+	}{
+		{
+			name:           "json tier without credentials",
+			givenPath:      "/guest_list/bob",
+			givenBody:      `{"table": 1, "tier": "vip"}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "form tier without credentials",
+			givenPath:      "/guest_list/bob",
+			givenBody:      "table=1&tier=vip",
+			givenType:      fiber.MIMEApplicationForm,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "form group tier without credentials",
+			givenPath:      "/v2/groups",
+			givenBody:      "name=smith&guests.0.name=amy&guests.0.tier=vip",
+			givenType:      fiber.MIMEApplicationForm,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "form tier with credentials",
+			givenPath:      "/guest_list/bob",
+			givenBody:      "table=1&tier=vip",
+			givenType:      fiber.MIMEApplicationForm,
+			givenOrganiser: true,
+			expectedStatus: http.StatusCreated,
+			expectedCalled: true,
+		},
+		{
+			name:           "form general guest without credentials",
+			givenPath:      "/guest_list/bob",
+			givenBody:      "table=1",
+			givenType:      fiber.MIMEApplicationForm,
+			expectedStatus: http.StatusCreated,
+			expectedCalled: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			called = false
+
+			req, err := http.NewRequest(http.MethodPost, "http://"+addr+tc.givenPath, strings.NewReader(tc.givenBody))
+			require.NoError(t, err)
+
+			req.Header.Set(fiber.HeaderContentType, tc.givenType)
+
+			if tc.givenOrganiser {
+				req.Header.Set(fiber.HeaderAuthorization, bearerPrefix+testOrganiserKey)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedCalled, called)
+		})
+	}
+}
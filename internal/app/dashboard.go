@@ -0,0 +1,41 @@
+package app
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"path"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+// dashboardFiles is the organiser dashboard, a single page calling the REST API.
+//
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// dashboard serves the dashboard page.
+func dashboard(c *fiber.Ctx) error {
+	return sendDashboardFile(c, "index.html")
+}
+
+// dashboardAsset serves the scripts and styles of the dashboard page.
+func dashboardAsset(c *fiber.Ctx) error {
+	return sendDashboardFile(c, c.Params("file"))
+}
+
+func sendDashboardFile(c *fiber.Ctx, name string) error {
+	if !fs.ValidPath(name) {
+		return c.Status(http.StatusNotFound).JSON(partyctrl.ErrorResponse{Error: http.StatusText(http.StatusNotFound)})
+	}
+
+	data, err := dashboardFiles.ReadFile(path.Join("dashboard", name))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(partyctrl.ErrorResponse{Error: http.StatusText(http.StatusNotFound)})
+	}
+
+	c.Type(path.Ext(name), "utf-8")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	return c.Send(data)
+}
@@ -0,0 +1,277 @@
+'use strict';
+
+// The dashboard only calls the REST API and reloads on the server-sent party events.
+
+const keyStorage = 'party-organiser-key';
+
+const eventTypes = ['guest_added', 'guest_arrived', 'guest_left', 'guest_removed', 'table_created'];
+
+const state = {
+  stats: null,
+  tables: [],
+  guests: [],
+  query: '',
+};
+
+const $ = (id) => document.getElementById(id);
+
+function organiserKey() {
+  return sessionStorage.getItem(keyStorage) || '';
+}
+
+// api sends a JSON request and returns the decoded response, or throws the API error.
+async function api(method, path, body) {
+  const headers = { Accept: 'application/json' };
+
+  const key = organiserKey();
+  if (key) {
+    headers.Authorization = 'Bearer ' + key;
+  }
+
+  const init = { method, headers };
+  if (body !== undefined) {
+    headers['Content-Type'] = 'application/json';
+    init.body = JSON.stringify(body);
+  }
+
+  const resp = await fetch(path, init);
+  const text = await resp.text();
+
+  let data = null;
+  try {
+    data = text ? JSON.parse(text) : null;
+  } catch (err) {
+    data = null;
+  }
+
+  if (!resp.ok) {
+    if (resp.status === 401) {
+      throw new Error('Organiser key required');
+    }
+    throw new Error((data && data.error) || resp.statusText);
+  }
+  return data;
+}
+
+function showMessage(text, isError) {
+  const message = $('message');
+  message.textContent = text;
+  message.classList.toggle('error', Boolean(isError));
+  message.hidden = !text;
+}
+
+async function load() {
+  try {
+    const [stats, tables, report] = await Promise.all([
+      api('GET', '/v2/stats'),
+      api('GET', '/v2/tables'),
+      api('GET', '/v1/export/seating_report'),
+    ]);
+
+    state.stats = stats;
+    state.tables = tables.tables || [];
+    state.guests = (report.tables || [])
+      .flatMap((table) => table.guests || [])
+      .sort((a, b) => a.name.localeCompare(b.name));
+
+    render();
+  } catch (err) {
+    showMessage(err.message, true);
+  }
+}
+
+// reload coalesces the reloads of bursts of events.
+let reloadTimer = null;
+
+function reload() {
+  clearTimeout(reloadTimer);
+  reloadTimer = setTimeout(load, 200);
+}
+
+function element(tag, props, ...children) {
+  const el = document.createElement(tag);
+  Object.assign(el, props);
+  el.append(...children);
+  return el;
+}
+
+function render() {
+  renderStats();
+  renderTables();
+  renderGuests();
+}
+
+function renderStats() {
+  const stats = state.stats;
+  if (!stats) {
+    return;
+  }
+
+  const tiles = [
+    ['Guests booked', stats.guests_booked],
+    ['Guests arrived', stats.guests_arrived],
+    ['Guests present', stats.guests_present],
+    ['Guests left', stats.guests_left],
+    ['Seats booked', stats.booked_seats],
+    ['Seats arrived', stats.arrived_seats],
+    ['Seats empty', stats.empty_seats],
+  ];
+
+  $('stats').replaceChildren(...tiles.map(([label, value]) =>
+    element('div', { className: 'stat' }, element('strong', { textContent: value }), label)));
+}
+
+function renderTables() {
+  $('tables').replaceChildren(...state.tables.map((table) => {
+    const percent = (seats) => (table.size ? Math.max(0, seats) / table.size * 100 : 0) + '%';
+
+    const arrived = element('div', { className: 'arrived' });
+    arrived.style.width = percent(table.arrived_seats);
+
+    const booked = element('div', { className: 'booked' });
+    booked.style.width = percent(table.booked_seats - table.arrived_seats);
+
+    return element('div', { className: 'table-card' },
+      element('h3', { textContent: 'Table ' + table.number }),
+      element('div', { className: 'seats', title: 'Arrived, booked and free seats' }, arrived, booked),
+      element('p', {
+        textContent: `${table.booked_seats} booked · ${table.arrived_seats} arrived · ${table.empty_seats} empty of ${table.size}`,
+      }));
+  }));
+}
+
+// isPresent tells whether the guest arrived and did not leave since.
+function isPresent(guest) {
+  if (!guest.time_arrived) {
+    return false;
+  }
+  return !guest.time_departed || new Date(guest.time_departed) < new Date(guest.time_arrived);
+}
+
+function formatTime(value) {
+  return new Date(value).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' });
+}
+
+function guestStatus(guest) {
+  if (isPresent(guest)) {
+    return ['Arrived ' + formatTime(guest.time_arrived), 'status-arrived'];
+  }
+  if (guest.time_arrived) {
+    return ['Left ' + formatTime(guest.time_departed), 'status-left'];
+  }
+  return ['Booked', ''];
+}
+
+function renderGuests() {
+  const query = state.query.toLowerCase();
+  const guests = state.guests.filter((guest) => guest.name.toLowerCase().includes(query));
+
+  if (guests.length === 0) {
+    const empty = element('td', { textContent: state.guests.length ? 'No guest found' : 'No guest on the list' });
+    empty.colSpan = 5;
+    $('guests').replaceChildren(element('tr', {}, empty));
+    return;
+  }
+
+  $('guests').replaceChildren(...guests.map((guest) => {
+    const [status, statusClass] = guestStatus(guest);
+
+    const action = isPresent(guest)
+      ? element('button', { type: 'button', textContent: 'Check out', onclick: () => checkOut(guest) })
+      : element('button', { type: 'button', textContent: 'Check in', onclick: () => checkIn(guest) });
+
+    return element('tr', {},
+      element('td', { textContent: guest.name }),
+      element('td', { textContent: guest.table }),
+      element('td', { textContent: guest.accompanying_guests }),
+      element('td', { textContent: status, className: statusClass }),
+      element('td', {}, action));
+  }));
+}
+
+async function run(action, done) {
+  try {
+    await action();
+    showMessage(done, false);
+    reload();
+  } catch (err) {
+    showMessage(err.message, true);
+  }
+}
+
+function guestPath(prefix, name) {
+  return prefix + encodeURIComponent(name);
+}
+
+function checkIn(guest) {
+  const answer = prompt(`Accompanying guests arriving with ${guest.name}`, guest.accompanying_guests);
+  if (answer === null) {
+    return;
+  }
+
+  const companions = Number.parseInt(answer, 10);
+  if (Number.isNaN(companions) || companions < 0) {
+    showMessage('Invalid number of accompanying guests', true);
+    return;
+  }
+
+  run(() => api('PUT', guestPath('/v1/guests/', guest.name), { accompanying_guests: companions }),
+    `Welcomed ${guest.name} with ${companions} accompanying guests`);
+}
+
+function checkOut(guest) {
+  run(() => api('DELETE', guestPath('/v1/guests/', guest.name)), `Said goodbye to ${guest.name}`);
+}
+
+function addGuest(event) {
+  event.preventDefault();
+
+  const form = event.target;
+  const name = form.elements.name.value.trim();
+  const table = Number.parseInt(form.elements.table.value, 10);
+  const companions = Number.parseInt(form.elements.companions.value || '0', 10);
+
+  run(async () => {
+    await api('POST', guestPath('/v1/guest_list/', name), { table, accompanying_guests: companions });
+    form.reset();
+  }, `Added ${name} to table ${table}`);
+}
+
+function watchEvents() {
+  const live = $('live');
+  const source = new EventSource('/v1/events');
+
+  source.onopen = () => {
+    live.textContent = 'Live';
+    live.classList.add('connected');
+
+    // Events may have been missed while disconnected
+    reload();
+  };
+
+  source.onerror = () => {
+    live.textContent = 'Reconnecting';
+    live.classList.remove('connected');
+  };
+
+  eventTypes.forEach((type) => source.addEventListener(type, reload));
+}
+
+$('key').value = organiserKey();
+
+$('key-form').addEventListener('submit', (event) => {
+  event.preventDefault();
+  sessionStorage.setItem(keyStorage, $('key').value);
+  showMessage('', false);
+  load();
+});
+
+$('search').addEventListener('input', (event) => {
+  state.query = event.target.value;
+  renderGuests();
+});
+
+$('add-form').addEventListener('submit', addGuest);
+
+load();
+watchEvents();
@@ -0,0 +1,43 @@
+package app
+
+import (
+	_ "embed"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+// openAPISpec describes every route registered by Run.
+// It is validated against the handlers by the app tests.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// swaggerUIPage renders the OpenAPI specification with Swagger UI.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>GetGround Party API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@4/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@4/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+// openAPI serves the OpenAPI specification of the API.
+func openAPI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(openAPISpec)
+}
+
+// swaggerUI serves the API documentation.
+func swaggerUI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(swaggerUIPage)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+const (
+	mimeEventStream = "text/event-stream"
+
+	defaultEventsHeartbeat = 15 * time.Second
+)
+
+// Events streams the party events to HTTP clients as server-sent events.
+type Events struct {
+	broadcaster *party.Broadcaster
+	heartbeat   time.Duration
+
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewEvents creates the event stream of the events published by the broadcaster.
+func NewEvents(broadcaster *party.Broadcaster) *Events {
+	return &Events{
+		broadcaster: broadcaster,
+		heartbeat:   defaultEventsHeartbeat,
+		closing:     make(chan struct{}),
+	}
+}
+
+// Close ends the event streams, which would otherwise hold the shutdown of the server.
+func (e *Events) Close() {
+	e.closeOnce.Do(func() {
+		close(e.closing)
+	})
+}
+
+// Stream sends the party events until the client disconnects or the stream is closed.
+// The types query parameter filters the events by type, e.g. ?types=guest_arrived,guest_left.
+// Heartbeat comments keep idle connections open and detect the disconnected clients.
+func (e *Events) Stream(c *fiber.Ctx) error {
+	types := make(map[string]bool)
+	for _, t := range strings.Split(c.Query("types"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = true
+		}
+	}
+
+	events, unsubscribe := e.broadcaster.Subscribe()
+
+	c.Set(fiber.HeaderContentType, mimeEventStream)
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Disables the buffering of reverse proxies
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		ticker := time.NewTicker(e.heartbeat)
+		defer ticker.Stop()
+
+		// Sending the first comment tells the client it is subscribed, no later event is missed
+		fmt.Fprint(w, ": subscribed\n\n")
+
+		for {
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			select {
+			case <-e.closing:
+				return
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				if len(types) > 0 && !types[event.Type] {
+					continue
+				}
+
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			}
+		}
+	})
+	return nil
+}
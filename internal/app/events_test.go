@@ -0,0 +1,81 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// readEvent reads the next event of a server-sent event stream, skipping the comments.
+func readEvent(t *testing.T, r *bufio.Reader) (eventType, data string) {
+	t.Helper()
+
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && eventType != "":
+			return eventType, data
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	broadcaster := party.NewBroadcaster(0)
+	events := NewEvents(broadcaster)
+	events.heartbeat = 10 * time.Millisecond
+
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), &party.Mock{}),
+		WithEvents(events),
+	)
+
+	addr, runErr := listenTestApp(t, a)
+
+	resp, err := http.Get("http://" + addr + "/v1/events?types=guest_arrived,guest_left")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, mimeEventStream, resp.Header.Get(fiber.HeaderContentType))
+
+	body := bufio.NewReader(resp.Body)
+
+	// The first comment is sent once subscribed
+	line, err := body.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": subscribed\n", line)
+
+	publish := broadcaster.Listener()
+	eventTime := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	publish(party.Event{Type: party.EventGuestAdded, Guest: "Jane", Table: 1, Time: eventTime})
+	publish(party.Event{Type: party.EventGuestArrived, Guest: "John", Table: 1, AccompanyingGuests: 2, AvailableSeats: 7, Time: eventTime})
+
+	eventType, data := readEvent(t, body)
+	assert.Equal(t, party.EventGuestArrived, eventType)
+	assert.JSONEq(t, `{"type":"guest_arrived","guest":"John","table":1,"accompanying_guests":2,"available_seats":7,"time":"2021-11-23T20:00:00Z"}`, data)
+
+	t.Run("ends the streams on shutdown", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		require.NoError(t, a.Shutdown(ctx))
+		require.NoError(t, <-runErr)
+	})
+}
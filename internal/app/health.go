@@ -0,0 +1,161 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+
+	migrationStatusPending = "pending"
+	migrationStatusApplied = "applied"
+	migrationStatusFailed  = "failed"
+
+	defaultPingTimeout = 2 * time.Second
+)
+
+type (
+	// Pinger checks the connectivity to a dependency.
+	Pinger interface {
+		Ping(ctx context.Context) error
+	}
+
+	// BuildInfo identifies the running build.
+	BuildInfo struct {
+		Version   string `json:"version"`
+		Commit    string `json:"commit"`
+		BuildTime string `json:"build_time"`
+		GoVersion string `json:"go_version"`
+	}
+
+	// Health tracks the liveness and readiness of the app.
+	Health struct {
+		db          Pinger
+		build       BuildInfo
+		pingTimeout time.Duration
+
+		mu           sync.RWMutex
+		migration    string
+		migrationErr error
+		shuttingDown bool
+	}
+
+	// HealthCheck defines the result of a single check.
+	HealthCheck struct {
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	// HealthChecks defines the results of the health checks.
+	HealthChecks struct {
+		Database   HealthCheck  `json:"database"`
+		Migrations HealthCheck  `json:"migrations"`
+		Shutdown   *HealthCheck `json:"shutdown,omitempty"`
+	}
+
+	// HealthResponse defines the body of the health endpoints.
+	HealthResponse struct {
+		Status string       `json:"status"`
+		Checks HealthChecks `json:"checks"`
+		Build  BuildInfo    `json:"build"`
+	}
+)
+
+// NewHealth creates the health state of the app.
+// The app is not ready until the migrations are reported as applied.
+func NewHealth(db Pinger, build BuildInfo) *Health {
+	if build.GoVersion == "" {
+		build.GoVersion = runtime.Version()
+	}
+
+	return &Health{
+		db:          db,
+		build:       build,
+		pingTimeout: defaultPingTimeout,
+		migration:   migrationStatusPending,
+	}
+}
+
+// SetMigrated reports the result of the database migrations.
+func (h *Health) SetMigrated(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.migrationErr = err
+	if err != nil {
+		h.migration = migrationStatusFailed
+		return
+	}
+	h.migration = migrationStatusApplied
+}
+
+// SetShuttingDown marks the app as not ready so that no new traffic is routed to it.
+func (h *Health) SetShuttingDown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.shuttingDown = true
+}
+
+// Healthz reports whether the app is alive along with the state of its dependencies.
+// It always succeeds while the app serves requests, so an unreachable database does not restart the app.
+func (h *Health) Healthz(c *fiber.Ctx) error {
+	resp, _ := h.check(c.UserContext())
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// Readyz reports whether the app can serve traffic.
+// It fails while shutting down, when the database is unreachable or the migrations are not applied.
+func (h *Health) Readyz(c *fiber.Ctx) error {
+	resp, ready := h.check(c.UserContext())
+	if !ready {
+		return c.Status(http.StatusServiceUnavailable).JSON(resp)
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+func (h *Health) check(ctx context.Context) (HealthResponse, bool) {
+	h.mu.RLock()
+	migration, migrationErr, shuttingDown := h.migration, h.migrationErr, h.shuttingDown
+	h.mu.RUnlock()
+
+	ready := !shuttingDown
+
+	resp := HealthResponse{
+		Status: healthStatusOK,
+		Build:  h.build,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.pingTimeout)
+	defer cancel()
+
+	resp.Checks.Database = HealthCheck{Status: healthStatusOK}
+	if err := h.db.Ping(ctx); err != nil {
+		resp.Checks.Database = HealthCheck{Status: healthStatusUnavailable, Error: err.Error()}
+		ready = false
+	}
+
+	resp.Checks.Migrations = HealthCheck{Status: migration}
+	if migrationErr != nil {
+		resp.Checks.Migrations.Error = migrationErr.Error()
+	}
+	if migration != migrationStatusApplied {
+		ready = false
+	}
+
+	if shuttingDown {
+		resp.Checks.Shutdown = &HealthCheck{Status: "shutting_down"}
+	}
+
+	if !ready {
+		resp.Status = healthStatusUnavailable
+	}
+	return resp, ready
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+func TestHealth(t *testing.T) {
+	errPing := errors.New("connection refused")
+
+	cases := []struct {
+		name                  string
+		givenPingErr          error
+		givenMigrated         bool
+		givenMigrationErr     error
+		givenShuttingDown     bool
+		expectedReadyStatus   int
+		expectedStatus        string
+		expectedDatabase      HealthCheck
+		expectedMigrations    HealthCheck
+		expectedShutdownCheck bool
+	}{
+		{
+			name:                "ready when the database is reachable and migrated",
+			givenMigrated:       true,
+			expectedReadyStatus: http.StatusOK,
+			expectedStatus:      healthStatusOK,
+			expectedDatabase:    HealthCheck{Status: healthStatusOK},
+			expectedMigrations:  HealthCheck{Status: migrationStatusApplied},
+		},
+		{
+			name:                "not ready when the database is unreachable",
+			givenPingErr:        errPing,
+			givenMigrated:       true,
+			expectedReadyStatus: http.StatusServiceUnavailable,
+			expectedStatus:      healthStatusUnavailable,
+			expectedDatabase:    HealthCheck{Status: healthStatusUnavailable, Error: errPing.Error()},
+			expectedMigrations:  HealthCheck{Status: migrationStatusApplied},
+		},
+		{
+			name:                "not ready before the migrations are applied",
+			expectedReadyStatus: http.StatusServiceUnavailable,
+			expectedStatus:      healthStatusUnavailable,
+			expectedDatabase:    HealthCheck{Status: healthStatusOK},
+			expectedMigrations:  HealthCheck{Status: migrationStatusPending},
+		},
+		{
+			name:                "not ready when the migrations failed",
+			givenMigrated:       true,
+			givenMigrationErr:   errors.New("table locked"),
+			expectedReadyStatus: http.StatusServiceUnavailable,
+			expectedStatus:      healthStatusUnavailable,
+			expectedDatabase:    HealthCheck{Status: healthStatusOK},
+			expectedMigrations:  HealthCheck{Status: migrationStatusFailed, Error: "table locked"},
+		},
+		{
+			name:                  "not ready while shutting down",
+			givenMigrated:         true,
+			givenShuttingDown:     true,
+			expectedReadyStatus:   http.StatusServiceUnavailable,
+			expectedStatus:        healthStatusUnavailable,
+			expectedDatabase:      HealthCheck{Status: healthStatusOK},
+			expectedMigrations:    HealthCheck{Status: migrationStatusApplied},
+			expectedShutdownCheck: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			health := NewHealth(pingerFunc(func(ctx context.Context) error {
+				return c.givenPingErr
+			}), BuildInfo{Version: "1.0.0", Commit: "abc"})
+
+			if c.givenMigrated {
+				health.SetMigrated(c.givenMigrationErr)
+			}
+
+			if c.givenShuttingDown {
+				health.SetShuttingDown()
+			}
+
+			fiberApp := fiber.New()
+			fiberApp.Get("/healthz", health.Healthz)
+			fiberApp.Get("/readyz", health.Readyz)
+
+			resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodGet, "/healthz", nil))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			resp, err = fiberApp.Test(httptest.NewRequest(fiber.MethodGet, "/readyz", nil))
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedReadyStatus, resp.StatusCode)
+
+			var observed HealthResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&observed))
+
+			assert.Equal(t, c.expectedStatus, observed.Status)
+			assert.Equal(t, c.expectedDatabase, observed.Checks.Database)
+			assert.Equal(t, c.expectedMigrations, observed.Checks.Migrations)
+			assert.Equal(t, c.expectedShutdownCheck, observed.Checks.Shutdown != nil)
+			assert.Equal(t, "1.0.0", observed.Build.Version)
+			assert.NotEmpty(t, observed.Build.GoVersion)
+		})
+	}
+}
@@ -0,0 +1,1078 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/app/partygql"
+	"github.com/alesr/getground/internal/pkg/metrics"
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testOrganiserKey = "organiser-key"
+
+func mustLoadOpenAPISpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	doc, err := loadOpenAPISpec()
+	require.NoError(t, err)
+	return doc
+}
+
+// specService answers every call with a fixed result.
+// Guests named Unknown, tokens and codes equal to unknown are not found.
+func specService() *party.Mock {
+	arrived := time.Date(2021, 12, 24, 20, 0, 0, 0, time.UTC)
+
+	return &party.Mock{
+		AddGuestToGuestListFunc: func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+			if in.Table > 10 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+		},
+		GetGuestListFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
+			return party.GetGuestListOutput{
+				Guests:     []party.Guest{{Name: "John", Table: 1, AccompanyingGuests: 2, Tier: party.TierVIP, TimeArrival: &arrived}, {Name: "Jane", Table: 2}},
+				NextCursor: "cursor",
+			}, nil
+		},
+		WelcomeGuestFunc: func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			if in.Name == "Unknown" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		},
+		GoodbyeGuestFunc: func(ctx context.Context, in *party.GoodbyeGuestInput) error {
+			return nil
+		},
+		RemoveGuestFromGuestListFunc: func(ctx context.Context, in *party.RemoveGuestFromGuestListInput) error {
+			if in.Name == "Unknown" {
+				return party.ErrGuestNotInList
+			}
+			return nil
+		},
+		ListArrivedGuestsFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.ListArrivedGuestsOutput, error) {
+			return party.ListArrivedGuestsOutput{
+				Guests: []party.GuestArrived{{Name: "John", AccompanyingGuests: 2, TimeArrival: arrived}},
+			}, nil
+		},
+		GetEmptySeatsFunc: func(ctx context.Context) (party.GetEmptySeatsOutput, error) {
+			return party.GetEmptySeatsOutput{EmptySeats: 7}, nil
+		},
+		ListTablesFunc: func(ctx context.Context) (party.ListTablesOutput, error) {
+			return party.ListTablesOutput{Tables: []party.Table{specTable()}}, nil
+		},
+		CreateTableFunc: func(ctx context.Context, in *party.CreateTableInput) (*party.Table, error) {
+			if in.Number == 1 {
+				return nil, party.ErrTableAlreadyExists
+			}
+
+			table := party.Table{Number: in.Number, Size: in.Size, EmptySeats: in.Size}
+			for number := 1; number <= in.Size; number++ {
+				table.Seats = append(table.Seats, party.Seat{Number: number})
+			}
+			return &table, nil
+		},
+		AssignSeatsFunc: func(ctx context.Context, in *party.AssignSeatsInput) (*party.Table, error) {
+			if in.Name != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			if len(in.Seats) != 3 {
+				return nil, party.ErrSeatsCountInvalid
+			}
+			table := specTable()
+			return &table, nil
+		},
+		SwapSeatsFunc: func(ctx context.Context, in *party.SwapSeatsInput) (*party.Table, error) {
+			if in.Table != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			table := specTable()
+			return &table, nil
+		},
+		ReserveTableFunc: func(ctx context.Context, in *party.ReserveTableInput) (*party.Table, error) {
+			if in.Number != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			if in.HeldSeats > 7 {
+				return nil, party.ErrTableNotEnoughSeats
+			}
+			table := specTable()
+			table.Tiers, table.HeldSeats = in.Tiers, in.HeldSeats
+			return &table, nil
+		},
+		ReleaseTableFunc: func(ctx context.Context, number int) (*party.Table, error) {
+			if number != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			table := specTable()
+			return &table, nil
+		},
+		ListGroupsFunc: func(ctx context.Context) (party.ListGroupsOutput, error) {
+			return party.ListGroupsOutput{Groups: []party.Group{specGroup("Doe", &arrived)}}, nil
+		},
+		AddGroupToGuestListFunc: func(ctx context.Context, in *party.AddGroupToGuestListInput) (*party.Group, error) {
+			if len(in.Guests) == 0 {
+				return nil, party.ErrGroupGuestsRequired
+			}
+			if in.Name == "Doe" {
+				return nil, party.ErrGroupAlreadyExists
+			}
+			group := specGroup(in.Name, nil)
+			return &group, nil
+		},
+		GetGroupFunc: func(ctx context.Context, name string) (*party.Group, error) {
+			if name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			group := specGroup(name, &arrived)
+			return &group, nil
+		},
+		WelcomeGroupFunc: func(ctx context.Context, in *party.WelcomeGroupInput) (*party.Group, error) {
+			if in.Name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			group := specGroup(in.Name, &arrived)
+			return &group, nil
+		},
+		GoodbyeGroupFunc: func(ctx context.Context, in *party.GoodbyeGroupInput) (*party.Group, error) {
+			if in.Name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			group := specGroup(in.Name, nil)
+			return &group, nil
+		},
+		GetGuestRequirementsFunc: func(ctx context.Context, name string) (*party.GuestRequirements, error) {
+			if name == "Unknown" {
+				return nil, party.ErrGuestNotInList
+			}
+			return specRequirements(name), nil
+		},
+		SetGuestRequirementsFunc: func(ctx context.Context, in *party.SetGuestRequirementsInput) (*party.GuestRequirements, error) {
+			for _, person := range in.People {
+				if person.Companion > 2 {
+					return nil, party.ErrCompanionNumberInvalid
+				}
+			}
+			return specRequirements(in.Guest), nil
+		},
+		ListCustomFieldsFunc: func(ctx context.Context) (party.ListCustomFieldsOutput, error) {
+			return party.ListCustomFieldsOutput{
+				Fields: []party.CustomField{{Name: "meal", Type: party.CustomFieldChoice, Options: []string{"fish", "meat"}, Required: true}},
+			}, nil
+		},
+		SetCustomFieldsFunc: func(ctx context.Context, in *party.SetCustomFieldsInput) (party.ListCustomFieldsOutput, error) {
+			for _, field := range in.Fields {
+				if field.Type == party.CustomFieldChoice && len(field.Options) == 0 {
+					return party.ListCustomFieldsOutput{}, party.ErrCustomFieldInvalid
+				}
+			}
+			return party.ListCustomFieldsOutput{Fields: in.Fields}, nil
+		},
+		GetCateringSummaryFunc: func(ctx context.Context) (*party.CateringSummary, error) {
+			table := party.CateringTable{
+				Table:         1,
+				People:        3,
+				Dietary:       []party.CateringCount{{Value: party.DietaryVegetarian, Count: 2}},
+				Allergies:     []party.CateringCount{{Value: "nuts", Count: 1}},
+				Accessibility: []party.CateringCount{},
+				Custom:        []party.CateringCount{{Field: "meal", Value: "fish", Count: 3}},
+			}
+			total := table
+			total.Table = 0
+			return &party.CateringSummary{Total: total, Tables: []party.CateringTable{table}}, nil
+		},
+		ListConstraintsFunc: func(ctx context.Context) (party.ListConstraintsOutput, error) {
+			return party.ListConstraintsOutput{
+				Constraints: []party.Constraint{{ID: "c1", Kind: party.ConstraintPinned, Guests: []string{"John"}, Table: 1}},
+			}, nil
+		},
+		CreateConstraintFunc: func(ctx context.Context, in *party.CreateConstraintInput) (*party.Constraint, error) {
+			if in.Kind != party.ConstraintTogether && in.Kind != party.ConstraintApart && in.Kind != party.ConstraintPinned {
+				return nil, party.ErrConstraintKindInvalid
+			}
+			for _, name := range in.Guests {
+				if name == "Unknown" {
+					return nil, party.ErrGuestNotInList
+				}
+			}
+			return &party.Constraint{ID: "c2", Kind: in.Kind, Guests: in.Guests, Table: in.Table}, nil
+		},
+		DeleteConstraintFunc: func(ctx context.Context, id string) error {
+			if id == "unknown" {
+				return party.ErrConstraintNotFound
+			}
+			return nil
+		},
+		PlanSeatingFunc: func(ctx context.Context) (*party.SeatingPlan, error) {
+			return &party.SeatingPlan{
+				Assignments: []party.SeatingAssignment{{Guest: "John", Table: 1, CurrentTable: 1}},
+				Unseated:    []string{"Jane"},
+			}, nil
+		},
+		CheckSeatingFunc: func(ctx context.Context, in *party.CheckSeatingInput) (*party.SeatingPlan, error) {
+			return &party.SeatingPlan{
+				Assignments: []party.SeatingAssignment{{Guest: "John", Table: 2, CurrentTable: 1}},
+				Violations:  []party.SeatingViolation{{Constraint: "c1", Reason: `guest "John" does not sit at table 1`}},
+			}, nil
+		},
+		GetStatsFunc: func(ctx context.Context) (party.GetStatsOutput, error) {
+			return party.GetStatsOutput{Tables: 1, Seats: 10, BookedSeats: 3, ArrivedSeats: 3, EmptySeats: 7, GuestsBooked: 1, GuestsArrived: 1, GuestsPresent: 1}, nil
+		},
+		GetLayoutFunc: func(ctx context.Context) (party.GetLayoutOutput, error) {
+			return party.GetLayoutOutput{
+				Tables: []party.TableLayout{{Number: 1, Size: 10, Shape: party.TableShapeRound, Width: 100, Height: 100}},
+			}, nil
+		},
+		SetLayoutFunc: func(ctx context.Context, in *party.SetLayoutInput) (*party.GetLayoutOutput, error) {
+			out := party.GetLayoutOutput{Tables: []party.TableLayout{}}
+			for _, table := range in.Tables {
+				if table.Number > 10 {
+					return nil, party.ErrTableNumberNotFound
+				}
+
+				table.Size = 10
+				if table.Shape == party.TableShapeRound {
+					table.Height = table.Width
+				}
+				out.Tables = append(out.Tables, table)
+			}
+			return &out, nil
+		},
+		GetSeatingChartFunc: func(ctx context.Context, in *party.GetSeatingChartInput) (*party.SeatingChart, error) {
+			if in.Highlight != "" && in.Highlight != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.SeatingChart{
+				Tables: []party.ChartTable{{
+					TableLayout:  party.TableLayout{Number: 1, Size: 10, Shape: party.TableShapeRound, Width: 100, Height: 100},
+					BookedSeats:  3,
+					ArrivedSeats: 3,
+					Highlighted:  in.Highlight != "",
+				}},
+				Highlight: in.Highlight,
+			}, nil
+		},
+		CreateInvitationFunc: func(ctx context.Context, in *party.CreateInvitationInput) (*party.CreateInvitationOutput, error) {
+			return &party.CreateInvitationOutput{Name: in.Name, Token: "token", ExpiresAt: arrived}, nil
+		},
+		GetInvitationFunc: func(ctx context.Context, token string) (*party.GetInvitationOutput, error) {
+			if token == "unknown" {
+				return nil, party.ErrInvitationTokenInvalid
+			}
+			return &party.GetInvitationOutput{Name: "John", Table: 1, MaxPartySize: 3, ExpiresAt: arrived, Status: party.InvitationStatusPending}, nil
+		},
+		RespondToInvitationFunc: func(ctx context.Context, in *party.RespondToInvitationInput) (*party.RespondToInvitationOutput, error) {
+			return &party.RespondToInvitationOutput{Name: "John", Status: party.InvitationStatusAccepted}, nil
+		},
+		IssueCheckInCodeFunc: func(ctx context.Context, in *party.IssueCheckInCodeInput) (*party.IssueCheckInCodeOutput, error) {
+			return &party.IssueCheckInCodeOutput{Name: in.Name, Code: "code"}, nil
+		},
+		GetCheckInCodeFunc: func(ctx context.Context, code string) (*party.GetCheckInCodeOutput, error) {
+			return &party.GetCheckInCodeOutput{Code: code, Name: "John", Status: party.CheckInCodeStatusActive}, nil
+		},
+		RevokeCheckInCodeFunc: func(ctx context.Context, code string) error {
+			return nil
+		},
+		CheckInFunc: func(ctx context.Context, in *party.CheckInInput) (*party.CheckInOutput, error) {
+			if in.Code == "unknown" {
+				return nil, party.ErrCheckInCodeNotFound
+			}
+			return &party.CheckInOutput{Name: "John", AccompanyingGuests: 2}, nil
+		},
+		ImportGuestsFunc: func(ctx context.Context, in *party.ImportGuestsInput) (*party.ImportGuestsOutput, error) {
+			return &party.ImportGuestsOutput{Rows: len(in.Guests), Imported: len(in.Guests), DryRun: in.DryRun}, nil
+		},
+		GetSeatingReportFunc: func(ctx context.Context) (*party.GetSeatingReportOutput, error) {
+			return &party.GetSeatingReportOutput{
+				GeneratedAt: arrived,
+				Tables: []party.ReportTable{{
+					Number:       1,
+					Size:         10,
+					BookedSeats:  3,
+					ArrivedSeats: 3,
+					Guests:       []party.ReportGuest{{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived}},
+				}},
+				GuestsBooked:  1,
+				GuestsArrived: 1,
+				BookedSeats:   3,
+				ArrivedSeats:  3,
+			}, nil
+		},
+	}
+}
+
+// specTable is table 1, with John and his two accompanying guests on the first seats.
+func specTable() party.Table {
+	table := party.Table{Number: 1, Size: 10, BookedSeats: 3, ArrivedSeats: 3, EmptySeats: 7}
+	for number := 1; number <= table.Size; number++ {
+		seat := party.Seat{Number: number}
+		if number <= 3 {
+			seat.Guest, seat.Companion = "John", number-1
+		}
+		table.Seats = append(table.Seats, seat)
+	}
+	return table
+}
+
+func specGroup(name string, arrived *time.Time) party.Group {
+	return party.Group{
+		Name:   name,
+		Tables: []int{2},
+		Guests: []party.Guest{
+			{Name: name + " Jane", Table: 2, AccompanyingGuests: 1, TimeArrival: arrived},
+			{Name: name + " Jim", Table: 2, TimeArrival: arrived},
+		},
+	}
+}
+
+// specRequirements are the needs of a guest and their accompanying guest.
+func specRequirements(name string) *party.GuestRequirements {
+	return &party.GuestRequirements{
+		Guest: name,
+		People: []party.Requirements{
+			{
+				Dietary:       []string{party.DietaryVegetarian},
+				Allergies:     []string{},
+				Accessibility: []string{},
+				Custom:        []party.CustomValue{{Field: "meal", Value: "fish"}},
+			},
+			{
+				Companion:     1,
+				Name:          "Mary",
+				Dietary:       []string{},
+				Allergies:     []string{"nuts"},
+				Accessibility: []string{party.AccessibilityWheelchair},
+				Custom:        []party.CustomValue{{Field: "meal", Value: "meat"}},
+			},
+		},
+	}
+}
+
+func mustNewGraphQL(t *testing.T, service party.Service) *partygql.Handler {
+	t.Helper()
+
+	h, err := partygql.New(zap.NewNop(), service)
+	require.NoError(t, err)
+	return h
+}
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	doc := mustLoadOpenAPISpec(t)
+
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), specService()),
+		WithHealth(NewHealth(pingerFunc(func(ctx context.Context) error { return nil }), BuildInfo{})),
+		WithMetrics(metrics.New()),
+		WithGraphQL(mustNewGraphQL(t, specService())),
+		WithEvents(NewEvents(party.NewBroadcaster(0))),
+		WithDashboard(),
+	)
+
+	listenTestApp(t, a)
+	defer func() { _ = a.Shutdown(context.Background()) }()
+
+	pathParam := regexp.MustCompile(`:(\w+)`)
+
+	var routes []string
+	for _, stack := range a.fiberApp.Stack() {
+		for _, route := range stack {
+			// Middleware is mounted on the root path, HEAD is registered along with GET
+			if route.Path == "/" || route.Method == fiber.MethodHead {
+				continue
+			}
+			routes = append(routes, route.Method+" "+pathParam.ReplaceAllString(route.Path, "{$1}"))
+		}
+	}
+
+	// The unversioned routes are undocumented aliases of the /v1 routes
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route] = true
+	}
+
+	var versioned []string
+	for _, route := range routes {
+		method, path := splitRoute(route)
+		if registered[method+" /v1"+path] {
+			continue
+		}
+		versioned = append(versioned, route)
+	}
+	routes = versioned
+
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	assert.Equal(t, routes, documented)
+}
+
+func splitRoute(route string) (method, path string) {
+	parts := strings.SplitN(route, " ", 2)
+	return parts[0], parts[1]
+}
+
+func TestOpenAPISpecMatchesHandlers(t *testing.T) {
+	doc := mustLoadOpenAPISpec(t)
+
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	health := NewHealth(pingerFunc(func(ctx context.Context) error { return nil }), BuildInfo{Version: "test"})
+	health.SetMigrated(nil)
+
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), specService()),
+		WithOrganiserKey(testOrganiserKey),
+		WithHealth(health),
+		WithMetrics(metrics.New()),
+		WithGraphQL(mustNewGraphQL(t, specService())),
+		WithDashboard(),
+	)
+
+	addr, _ := listenTestApp(t, a)
+	defer func() { _ = a.Shutdown(context.Background()) }()
+
+	cases := []struct {
+		name           string
+		givenMethod    string
+		givenPath      string
+		givenBody      string
+		givenType      string
+		givenAccept    string
+		givenOrganiser bool
+		givenInvalid   bool
+		expectedStatus int
+	}{
+		{name: "healthz", givenMethod: http.MethodGet, givenPath: "/healthz", expectedStatus: http.StatusOK},
+		{name: "readyz", givenMethod: http.MethodGet, givenPath: "/readyz", expectedStatus: http.StatusOK},
+		{name: "metrics", givenMethod: http.MethodGet, givenPath: "/metrics", expectedStatus: http.StatusOK},
+		{name: "openapi", givenMethod: http.MethodGet, givenPath: "/openapi.json", expectedStatus: http.StatusOK},
+		{name: "docs", givenMethod: http.MethodGet, givenPath: "/docs", expectedStatus: http.StatusOK},
+		{name: "dashboard", givenMethod: http.MethodGet, givenPath: "/dashboard", expectedStatus: http.StatusOK},
+		{name: "dashboard script", givenMethod: http.MethodGet, givenPath: "/dashboard/dashboard.js", expectedStatus: http.StatusOK},
+		{name: "dashboard style", givenMethod: http.MethodGet, givenPath: "/dashboard/dashboard.css", expectedStatus: http.StatusOK},
+		{name: "unknown dashboard file", givenMethod: http.MethodGet, givenPath: "/dashboard/missing.js", expectedStatus: http.StatusNotFound},
+		{
+			name:           "import guests csv",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/import",
+			givenBody:      "name,table,accompanying_guests\nJohn,1,2\n",
+			givenType:      "text/csv",
+			givenOrganiser: true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "import guests json dry run",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/import?dry_run=true",
+			givenBody:      `[{"name": "John", "table": 1, "accompanying_guests": 2}]`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "import guests with invalid rows",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/import",
+			givenBody:      "name,table\nJohn,one\n",
+			givenType:      "text/csv",
+			givenOrganiser: true,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "import guests without credentials",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/import",
+			givenBody:      "name,table\nJohn,1\n",
+			givenType:      "text/csv",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "add guest",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/John",
+			givenBody:      `{"table": 1, "accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "add guest to unknown table",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/John",
+			givenBody:      `{"table": 11}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "get guest list", givenMethod: http.MethodGet, givenPath: "/v1/guest_list?table=1&sort=name&order=desc&limit=2", expectedStatus: http.StatusOK},
+		{name: "get guest list with invalid query", givenMethod: http.MethodGet, givenPath: "/v1/guest_list?arrived=maybe", givenInvalid: true, expectedStatus: http.StatusBadRequest},
+		{
+			name:           "welcome guest",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v1/guests/John",
+			givenBody:      `{"accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "welcome unknown guest",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v1/guests/Unknown",
+			givenBody:      `{"accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "goodbye guest", givenMethod: http.MethodDelete, givenPath: "/v1/guests/John", expectedStatus: http.StatusOK},
+		{name: "remove guest", givenMethod: http.MethodDelete, givenPath: "/v1/guest_list/John", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "remove unknown guest", givenMethod: http.MethodDelete, givenPath: "/v1/guest_list/Unknown", givenOrganiser: true, expectedStatus: http.StatusNotFound},
+		{name: "remove guest without credentials", givenMethod: http.MethodDelete, givenPath: "/v1/guest_list/John", expectedStatus: http.StatusUnauthorized},
+		{name: "list arrived guests", givenMethod: http.MethodGet, givenPath: "/v1/guests?arrived=true", expectedStatus: http.StatusOK},
+		{name: "get empty seats", givenMethod: http.MethodGet, givenPath: "/v1/seats_empty", expectedStatus: http.StatusOK},
+		{
+			name:           "create invitation",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/invitations",
+			givenBody:      `{"name": "John", "table": 1, "max_party_size": 3}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusCreated,
+		},
+		{name: "get invitation", givenMethod: http.MethodGet, givenPath: "/v1/rsvp/token", expectedStatus: http.StatusOK},
+		{name: "get invitation with invalid token", givenMethod: http.MethodGet, givenPath: "/v1/rsvp/unknown", expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "respond to invitation",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/rsvp/token",
+			givenBody:      `{"attending": true, "accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "issue check-in code", givenMethod: http.MethodPost, givenPath: "/v1/guest_list/John/checkin_code", givenOrganiser: true, expectedStatus: http.StatusCreated},
+		{name: "get check-in qr code", givenMethod: http.MethodGet, givenPath: "/v1/checkin/code/qr?size=64", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "revoke check-in code", givenMethod: http.MethodDelete, givenPath: "/v1/checkin/code", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "check in without body", givenMethod: http.MethodPut, givenPath: "/v1/checkin/code", expectedStatus: http.StatusOK},
+		{
+			name:           "check in with companions",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v1/checkin/code",
+			givenBody:      `{"accompanying_guests": 1}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "check in with unknown code", givenMethod: http.MethodPut, givenPath: "/v1/checkin/unknown", expectedStatus: http.StatusNotFound},
+		{name: "export guest list csv", givenMethod: http.MethodGet, givenPath: "/v1/export/guest_list", givenAccept: "text/csv", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "export guest list json", givenMethod: http.MethodGet, givenPath: "/v1/export/guest_list", givenAccept: fiber.MIMEApplicationJSON, givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "export guest list not acceptable", givenMethod: http.MethodGet, givenPath: "/v1/export/guest_list", givenAccept: "image/png", givenOrganiser: true, expectedStatus: http.StatusNotAcceptable},
+		{name: "export seating report html", givenMethod: http.MethodGet, givenPath: "/v1/export/seating_report", givenAccept: fiber.MIMETextHTML, givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "export seating report csv", givenMethod: http.MethodGet, givenPath: "/v1/export/seating_report", givenAccept: "text/csv", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "export seating report json", givenMethod: http.MethodGet, givenPath: "/v1/export/seating_report", givenAccept: fiber.MIMEApplicationJSON, givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "list guests v2", givenMethod: http.MethodGet, givenPath: "/v2/guests?table=1&limit=10", expectedStatus: http.StatusOK},
+		{name: "list guests by requirements v2", givenMethod: http.MethodGet, givenPath: "/v2/guests?dietary=vegan&allergy=nuts&accessibility=wheelchair&custom=meal:fish", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "list guests by requirements without credentials v2", givenMethod: http.MethodGet, givenPath: "/v2/guests?dietary=vegan", expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "create guest v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/guests",
+			givenBody:      `{"name": "John", "table": 1, "accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create vip guest v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/guests",
+			givenBody:      `{"name": "John", "table": 1, "tier": "vip"}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create vip guest without credentials v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/guests",
+			givenBody:      `{"name": "John", "table": 1, "tier": "vip"}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "create guest at unknown table v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/guests",
+			givenBody:      `{"name": "John", "table": 11}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "record arrival v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/guests/John/arrival",
+			givenBody:      `{"accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "record arrival of unknown guest v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/guests/Unknown/arrival",
+			givenBody:      `{"accompanying_guests": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusNotFound,
+		},
+		{name: "record departure v2", givenMethod: http.MethodDelete, givenPath: "/v2/guests/John/arrival", expectedStatus: http.StatusNoContent},
+		{name: "list tables v2", givenMethod: http.MethodGet, givenPath: "/v2/tables", expectedStatus: http.StatusOK},
+		{
+			name:           "create table v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/tables",
+			givenBody:      `{"number": 2, "size": 4}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create existing table v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/tables",
+			givenBody:      `{"number": 1, "size": 4}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "assign seats v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/guests/John/seats",
+			givenBody:      `{"seats": [4, 5, 6]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "assign seats missing for accompanying guests v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/guests/John/seats",
+			givenBody:      `{"seats": [4]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "swap seats v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/tables/1/swap_seats",
+			givenBody:      `{"first": 1, "second": 4}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "swap seats of unknown table v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/tables/2/swap_seats",
+			givenBody:      `{"first": 1, "second": 4}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "reserve table v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/tables/1/reservation",
+			givenBody:      `{"tiers": ["vip", "staff"], "held_seats": 2}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "reserve table with too many held seats v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/tables/1/reservation",
+			givenBody:      `{"held_seats": 8}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "reserve table without credentials v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/tables/1/reservation",
+			givenBody:      `{"tiers": ["vip"]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{name: "release table v2", givenMethod: http.MethodDelete, givenPath: "/v2/tables/1/reservation", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "release unknown table v2", givenMethod: http.MethodDelete, givenPath: "/v2/tables/2/reservation", givenOrganiser: true, expectedStatus: http.StatusNotFound},
+		{name: "get guest requirements v2", givenMethod: http.MethodGet, givenPath: "/v2/guests/John/requirements", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "get requirements of unknown guest v2", givenMethod: http.MethodGet, givenPath: "/v2/guests/Unknown/requirements", givenOrganiser: true, expectedStatus: http.StatusNotFound},
+		{name: "get guest requirements without credentials v2", givenMethod: http.MethodGet, givenPath: "/v2/guests/John/requirements", expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "set guest requirements v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/guests/John/requirements",
+			givenBody:      `{"people": [{"companion": 0, "dietary": ["vegetarian"]}, {"companion": 1, "name": "Mary", "allergies": ["nuts"]}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "set requirements of too many accompanying guests v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/guests/John/requirements",
+			givenBody:      `{"people": [{"companion": 3}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "list groups v2", givenMethod: http.MethodGet, givenPath: "/v2/groups", expectedStatus: http.StatusOK},
+		{
+			name:           "create group v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/groups",
+			givenBody:      `{"name": "Smith", "table": 2, "guests": [{"name": "Amy", "accompanying_guests": 1}, {"name": "Joe"}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create existing group v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/groups",
+			givenBody:      `{"name": "Doe", "guests": [{"name": "Amy"}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "create group without guests v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/groups",
+			givenBody:      `{"name": "Smith", "guests": []}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenInvalid:   true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "get group v2", givenMethod: http.MethodGet, givenPath: "/v2/groups/Doe", expectedStatus: http.StatusOK},
+		{name: "get unknown group v2", givenMethod: http.MethodGet, givenPath: "/v2/groups/Unknown", expectedStatus: http.StatusNotFound},
+		{name: "record group arrival v2", givenMethod: http.MethodPut, givenPath: "/v2/groups/Doe/arrival", expectedStatus: http.StatusOK},
+		{name: "record arrival of unknown group v2", givenMethod: http.MethodPut, givenPath: "/v2/groups/Unknown/arrival", expectedStatus: http.StatusNotFound},
+		{name: "record group departure v2", givenMethod: http.MethodDelete, givenPath: "/v2/groups/Doe/arrival", expectedStatus: http.StatusNoContent},
+		{name: "list constraints v2", givenMethod: http.MethodGet, givenPath: "/v2/constraints", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "list constraints without credentials v2", givenMethod: http.MethodGet, givenPath: "/v2/constraints", expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "create constraint v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/constraints",
+			givenBody:      `{"kind": "pinned", "guests": ["John"], "table": 1}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "create constraint on unknown guest v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/constraints",
+			givenBody:      `{"kind": "apart", "guests": ["John", "Unknown"]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "create constraint of unknown kind v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/constraints",
+			givenBody:      `{"kind": "near", "guests": ["John", "Jane"]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			givenInvalid:   true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{name: "delete constraint v2", givenMethod: http.MethodDelete, givenPath: "/v2/constraints/c1", givenOrganiser: true, expectedStatus: http.StatusNoContent},
+		{name: "delete unknown constraint v2", givenMethod: http.MethodDelete, givenPath: "/v2/constraints/unknown", givenOrganiser: true, expectedStatus: http.StatusNotFound},
+		{name: "plan seating v2", givenMethod: http.MethodGet, givenPath: "/v2/seating_plan", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "check seating of the guest list v2", givenMethod: http.MethodPost, givenPath: "/v2/seating_plan/check", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{
+			name:           "check seating plan v2",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v2/seating_plan/check",
+			givenBody:      `{"assignments": [{"guest": "John", "table": 2}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{name: "get stats v2", givenMethod: http.MethodGet, givenPath: "/v2/stats", expectedStatus: http.StatusOK},
+		{name: "get layout v2", givenMethod: http.MethodGet, givenPath: "/v2/layout", expectedStatus: http.StatusOK},
+		{
+			name:           "set layout v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/layout",
+			givenBody:      `{"tables": [{"number": 1, "x": 0, "y": 0, "shape": "round", "width": 100}, {"number": 2, "x": 200, "y": 0, "shape": "rectangle", "width": 160, "height": 80}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "set layout of unknown table v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/layout",
+			givenBody:      `{"tables": [{"number": 11, "shape": "round", "width": 100}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "set layout without credentials v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/layout",
+			givenBody:      `{"tables": []}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{name: "seating chart v2", givenMethod: http.MethodGet, givenPath: "/v2/layout/seating_chart?highlight=John", expectedStatus: http.StatusOK},
+		{name: "seating chart of unknown guest v2", givenMethod: http.MethodGet, givenPath: "/v2/layout/seating_chart?highlight=Jane", expectedStatus: http.StatusNotFound},
+		{name: "list custom fields v2", givenMethod: http.MethodGet, givenPath: "/v2/custom_fields", expectedStatus: http.StatusOK},
+		{
+			name:           "set custom fields v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/custom_fields",
+			givenBody:      `{"fields": [{"name": "meal", "type": "choice", "options": ["fish", "meat"], "required": true}, {"name": "shuttle", "type": "boolean"}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "set choice field without options v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/custom_fields",
+			givenBody:      `{"fields": [{"name": "meal", "type": "choice"}]}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenOrganiser: true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "set custom fields without credentials v2",
+			givenMethod:    http.MethodPut,
+			givenPath:      "/v2/custom_fields",
+			givenBody:      `{"fields": []}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{name: "catering summary v2", givenMethod: http.MethodGet, givenPath: "/v2/catering", givenOrganiser: true, expectedStatus: http.StatusOK},
+		{name: "catering summary without credentials v2", givenMethod: http.MethodGet, givenPath: "/v2/catering", expectedStatus: http.StatusUnauthorized},
+		{
+			name:           "graphql query",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/graphql",
+			givenBody:      `{"query": "{ tables { number guests { name } } stats { emptySeats } }"}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "graphql mutation error",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/graphql",
+			givenBody:      `{"query": "mutation($name: String!) { welcomeGuest(name: $name) { name } }", "variables": {"name": "Unknown"}}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "graphql without query",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/graphql",
+			givenBody:      `{}`,
+			givenType:      fiber.MIMEApplicationJSON,
+			givenInvalid:   true,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.givenMethod, "http://"+addr+tc.givenPath, strings.NewReader(tc.givenBody))
+			require.NoError(t, err)
+
+			if tc.givenType != "" {
+				req.Header.Set(fiber.HeaderContentType, tc.givenType)
+			}
+
+			if tc.givenAccept != "" {
+				req.Header.Set(fiber.HeaderAccept, tc.givenAccept)
+			}
+
+			if tc.givenOrganiser {
+				req.Header.Set(fiber.HeaderAuthorization, bearerPrefix+testOrganiserKey)
+			}
+
+			route, pathParams, err := router.FindRoute(req)
+			require.NoError(t, err)
+
+			reqInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+
+			// Requests the spec rejects must be rejected by the handlers too
+			if err := openapi3filter.ValidateRequest(context.Background(), reqInput); tc.givenInvalid {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedStatus, resp.StatusCode, string(body))
+
+			assert.NoError(t, validateResponse(reqInput, resp, body))
+		})
+	}
+}
+
+func validateResponse(reqInput *openapi3filter.RequestValidationInput, resp *http.Response, body []byte) error {
+	reqInput.Options = &openapi3filter.Options{
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+	}
+
+	return openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: reqInput,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Body:                   ioutil.NopCloser(bytes.NewReader(body)),
+		Options:                reqInput.Options,
+	})
+}
+
+func TestRequestValidation(t *testing.T) {
+	var called bool
+
+	service := specService()
+	service.AddGuestToGuestListFunc = func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+		called = true
+		return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+	}
+
+	a := New(zap.NewNop(), fiber.New(fiber.Config{DisableStartupMessage: true}), partyctrl.New(zap.NewNop(), service), WithRequestValidation())
+
+	addr, _ := listenTestApp(t, a)
+	defer func() { _ = a.Shutdown(context.Background()) }()
+
+	cases := []struct {
+		name           string
+		givenMethod    string
+		givenPath      string
+		givenBody      string
+		expectedStatus int
+		expectedCalled bool
+	}{
+		{
+			name:           "valid request",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/John",
+			givenBody:      `{"table": 1, "accompanying_guests": 2}`,
+			expectedStatus: http.StatusCreated,
+			expectedCalled: true,
+		},
+		{
+			name:           "missing required property",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/John",
+			givenBody:      `{"accompanying_guests": 2}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "property out of range",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/v1/guest_list/John",
+			givenBody:      `{"table": 1, "accompanying_guests": -1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid query parameter",
+			givenMethod:    http.MethodGet,
+			givenPath:      "/v1/guest_list?limit=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "valid request to an unversioned route",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/guest_list/John",
+			givenBody:      `{"table": 1, "accompanying_guests": 2}`,
+			expectedStatus: http.StatusCreated,
+			expectedCalled: true,
+		},
+		{
+			name:           "invalid request to an unversioned route",
+			givenMethod:    http.MethodPost,
+			givenPath:      "/guest_list/John",
+			givenBody:      `{"table": 1, "accompanying_guests": -1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "head request",
+			givenMethod:    http.MethodHead,
+			givenPath:      "/v1/seats_empty",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "undocumented route",
+			givenMethod:    http.MethodGet,
+			givenPath:      "/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "undocumented method",
+			givenMethod:    http.MethodPatch,
+			givenPath:      "/v1/guest_list/John",
+			givenBody:      `{"table": 1}`,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			called = false
+
+			req, err := http.NewRequest(tc.givenMethod, "http://"+addr+tc.givenPath, strings.NewReader(tc.givenBody))
+			require.NoError(t, err)
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedCalled, called)
+
+			if tc.expectedStatus == http.StatusBadRequest {
+				var observed partyctrl.ErrorResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&observed))
+				assert.NotEmpty(t, observed.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package partyctrl
+
+import (
+	"bytes"
+	"html/template"
+	"math"
+
+	"github.com/alesr/getground/internal/pkg/party"
+)
+
+const mimeImageSVG = "image/svg+xml"
+
+// Seating chart dimensions, around the positions and sizes of the venue layout
+const (
+	chartMargin       = 40 // Leaves room for the seats around the tables
+	chartLegendHeight = 40
+	chartMinWidth     = 320
+	chartMinHighlight = 600 // Fits the legend of the highlighted guest
+	chartEmptyHeight  = 80
+	chartSeatRadius   = 8
+	chartSeatGap      = 14 // Distance from the edge of the table to the center of its seats
+)
+
+// Enumerate seat states, the classes of the seats on the seating chart
+const (
+	seatFree    = "free"
+	seatBooked  = "booked"
+	seatArrived = "arrived"
+)
+
+var seatingChartTemplate = template.Must(template.ParseFS(templates, "templates/seating_chart.svg"))
+
+type (
+	// chartView is the seating chart drawn by the template.
+	chartView struct {
+		Width      int
+		Height     int
+		Tables     []chartTable
+		Highlight  string
+		SeatRadius int
+		CenterX    int
+		CenterY    int
+		LegendX    int
+		LegendY    int
+	}
+
+	// chartTable is a table of the seating chart in canvas coordinates.
+	chartTable struct {
+		party.ChartTable
+		FreeSeats int
+		Round     bool
+		Left      int
+		Top       int
+		CX        int
+		CY        int
+		Radius    int
+		Seats     []chartSeat
+	}
+
+	chartSeat struct {
+		X     int
+		Y     int
+		State string
+	}
+)
+
+// renderSeatingChart draws the tables of the chart with their seats coloured by occupancy.
+func renderSeatingChart(chart *party.SeatingChart) ([]byte, error) {
+	view := chartView{
+		Width:      chartMinWidth,
+		Height:     chartEmptyHeight,
+		Highlight:  chart.Highlight,
+		SeatRadius: chartSeatRadius,
+	}
+
+	if chart.Highlight != "" {
+		view.Width = chartMinHighlight
+	}
+
+	for _, table := range chart.Tables {
+		t := newChartTable(table)
+		view.Tables = append(view.Tables, t)
+
+		if right := table.X + table.Width + 2*chartMargin; right > view.Width {
+			view.Width = right
+		}
+
+		if bottom := table.Y + table.Height + 2*chartMargin; bottom > view.Height {
+			view.Height = bottom
+		}
+	}
+
+	view.CenterX = view.Width / 2
+	view.CenterY = view.Height / 2
+	view.LegendX = chartMargin / 2
+	view.LegendY = view.Height + chartLegendHeight/2
+	view.Height += chartLegendHeight
+
+	var buf bytes.Buffer
+	if err := seatingChartTemplate.Execute(&buf, view); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// newChartTable places the table and its seats on the canvas, arrived seats first, then booked and free seats.
+func newChartTable(table party.ChartTable) chartTable {
+	t := chartTable{
+		ChartTable: table,
+		FreeSeats:  table.Size - table.ArrivedSeats - table.BookedSeats,
+		Round:      table.Shape == party.TableShapeRound,
+		Left:       table.X + chartMargin,
+		Top:        table.Y + chartMargin,
+	}
+
+	t.CX = t.Left + table.Width/2
+	t.CY = t.Top + table.Height/2
+	t.Radius = table.Width / 2
+
+	var positions [][2]int
+	if t.Round {
+		positions = roundSeats(t.CX, t.CY, t.Radius+chartSeatGap, table.Size)
+	} else {
+		positions = rectangleSeats(t.Left, t.Top, table.Width, table.Height, table.Size)
+	}
+
+	for i, pos := range positions {
+		state := seatFree
+		switch {
+		case i < table.ArrivedSeats:
+			state = seatArrived
+		case i < table.ArrivedSeats+table.BookedSeats:
+			state = seatBooked
+		}
+		t.Seats = append(t.Seats, chartSeat{X: pos[0], Y: pos[1], State: state})
+	}
+	return t
+}
+
+// roundSeats spreads the seats evenly around the table, clockwise from the top.
+func roundSeats(cx, cy, r, n int) [][2]int {
+	seats := make([][2]int, 0, n)
+	for i := 0; i < n; i++ {
+		angle := 2*math.Pi*float64(i)/float64(n) - math.Pi/2
+		seats = append(seats, [2]int{
+			cx + int(math.Round(float64(r)*math.Cos(angle))),
+			cy + int(math.Round(float64(r)*math.Sin(angle))),
+		})
+	}
+	return seats
+}
+
+// rectangleSeats spreads the seats along the long sides of the table, the first side taking the odd seat.
+func rectangleSeats(left, top, width, height, n int) [][2]int {
+	first := (n + 1) / 2
+
+	seats := make([][2]int, 0, n)
+	for i := 0; i < n; i++ {
+		side, count := 0, first
+		if i >= first {
+			side, count = 1, n-first
+		}
+		pos := i - side*first
+
+		if width >= height {
+			x := left + width*(2*pos+1)/(2*count)
+			y := top - chartSeatGap
+			if side == 1 {
+				y = top + height + chartSeatGap
+			}
+			seats = append(seats, [2]int{x, y})
+			continue
+		}
+
+		y := top + height*(2*pos+1)/(2*count)
+		x := left - chartSeatGap
+		if side == 1 {
+			x = left + width + chartSeatGap
+		}
+		seats = append(seats, [2]int{x, y})
+	}
+	return seats
+}
@@ -0,0 +1,89 @@
+package partyctrl
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/internal/pkg/tracing"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorResponse defines the body returned for failed requests.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorStatuses maps party service errors to HTTP status codes.
+var errorStatuses = []struct {
+	err    error
+	status int
+}{
+	{party.ErrAccessibilityNeedInvalid, http.StatusBadRequest},
+	{party.ErrAccompanyingGuestsNumberInvalid, http.StatusBadRequest},
+	{party.ErrAllergyInvalid, http.StatusBadRequest},
+	{party.ErrCompanionDuplicated, http.StatusBadRequest},
+	{party.ErrCompanionNameInvalid, http.StatusBadRequest},
+	{party.ErrCompanionNumberInvalid, http.StatusBadRequest},
+	{party.ErrConstraintGuestsInvalid, http.StatusBadRequest},
+	{party.ErrConstraintKindInvalid, http.StatusBadRequest},
+	{party.ErrCursorInvalid, http.StatusBadRequest},
+	{party.ErrCustomFieldInvalid, http.StatusBadRequest},
+	{party.ErrCustomFieldNotFound, http.StatusBadRequest},
+	{party.ErrCustomFieldRequired, http.StatusBadRequest},
+	{party.ErrCustomFieldValueInvalid, http.StatusBadRequest},
+	{party.ErrDietaryRequirementInvalid, http.StatusBadRequest},
+	{party.ErrGroupGuestsRequired, http.StatusBadRequest},
+	{party.ErrGroupNameRequired, http.StatusBadRequest},
+	{party.ErrGuestDuplicatedInGroup, http.StatusBadRequest},
+	{party.ErrGuestNameRequired, http.StatusBadRequest},
+	{party.ErrHeldSeatsInvalid, http.StatusBadRequest},
+	{party.ErrImportEmpty, http.StatusBadRequest},
+	{party.ErrLimitInvalid, http.StatusBadRequest},
+	{party.ErrMaxPartySizeInvalid, http.StatusBadRequest},
+	{party.ErrInvitationPartyTooLarge, http.StatusBadRequest},
+	{party.ErrSeatNumberInvalid, http.StatusBadRequest},
+	{party.ErrSeatsCountInvalid, http.StatusBadRequest},
+	{party.ErrSortInvalid, http.StatusBadRequest},
+	{party.ErrTableDuplicatedInLayout, http.StatusBadRequest},
+	{party.ErrTableLayoutInvalid, http.StatusBadRequest},
+	{party.ErrTableNumberInvalid, http.StatusBadRequest},
+	{party.ErrTableNumberRequired, http.StatusBadRequest},
+	{party.ErrTableShapeInvalid, http.StatusBadRequest},
+	{party.ErrTableSizeInvalid, http.StatusBadRequest},
+	{party.ErrTierInvalid, http.StatusBadRequest},
+	{party.ErrInvitationTokenInvalid, http.StatusUnauthorized},
+	{party.ErrCheckInCodeNotFound, http.StatusNotFound},
+	{party.ErrConstraintNotFound, http.StatusNotFound},
+	{party.ErrGroupNotFound, http.StatusNotFound},
+	{party.ErrGuestNotInList, http.StatusNotFound},
+	{party.ErrInvitationNotFound, http.StatusNotFound},
+	{party.ErrTableNumberNotFound, http.StatusNotFound},
+	{party.ErrCheckInCodeUsed, http.StatusConflict},
+	{party.ErrGroupAlreadyExists, http.StatusConflict},
+	{party.ErrGuestAlreadyInList, http.StatusConflict},
+	{party.ErrGuestPresent, http.StatusConflict},
+	{party.ErrGuestNotPresent, http.StatusConflict},
+	{party.ErrInvitationAlreadyAnswered, http.StatusConflict},
+	{party.ErrSeatTaken, http.StatusConflict},
+	{party.ErrTableAlreadyExists, http.StatusConflict},
+	{party.ErrTableNotEnoughSeats, http.StatusConflict},
+	{party.ErrTableTierRestricted, http.StatusConflict},
+	{party.ErrCheckInCodeRevoked, http.StatusGone},
+	{party.ErrInvitationExpired, http.StatusGone},
+}
+
+// errorResponse writes the status and body matching a party service error.
+// Unknown errors are reported as internal server errors without leaking details.
+func errorResponse(c *fiber.Ctx, err error) error {
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return c.Status(e.status).JSON(ErrorResponse{Error: e.err.Error()})
+		}
+	}
+
+	// Only unexpected errors fail the span, domain errors are client errors
+	tracing.RecordError(trace.SpanFromContext(c.UserContext()), err)
+	return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Error: http.StatusText(http.StatusInternalServerError)})
+}
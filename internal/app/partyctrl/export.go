@@ -0,0 +1,97 @@
+package partyctrl
+
+import (
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+)
+
+const mimeTextCSV = "text/csv"
+
+//go:embed templates/seating_report.html templates/seating_chart.svg
+var templates embed.FS
+
+var seatingReportTemplate = template.Must(
+	template.New("seating_report.html").
+		Funcs(template.FuncMap{"datetime": formatReportTime}).
+		ParseFS(templates, "templates/seating_report.html"),
+)
+
+// ExportGuestListOutput defines the JSON body of the guest list export.
+type ExportGuestListOutput struct {
+	Guests []party.ReportGuest `json:"guests"`
+}
+
+var guestCSVHeader = []string{"table", "name", "accompanying_guests", "time_arrived", "time_departed"}
+
+// encodeGuestsCSV writes the report guests as CSV rows with RFC 3339 times.
+// Names are escaped so that spreadsheets do not run them as formulas.
+func encodeGuestsCSV(guests []party.ReportGuest) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(guestCSVHeader); err != nil {
+		return nil, fmt.Errorf("could not write csv header: %w", err)
+	}
+
+	for _, guest := range guests {
+		if err := w.Write([]string{
+			strconv.Itoa(guest.Table),
+			escapeCSVFormula(guest.Name),
+			strconv.Itoa(guest.AccompanyingGuests),
+			formatCSVTime(guest.TimeArrival),
+			formatCSVTime(guest.TimeDeparture),
+		}); err != nil {
+			return nil, fmt.Errorf("could not write csv row: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("could not flush csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// reportGuests flattens the seating report in table order.
+func reportGuests(report *party.GetSeatingReportOutput) []party.ReportGuest {
+	guests := make([]party.ReportGuest, 0, report.GuestsBooked)
+	for _, table := range report.Tables {
+		guests = append(guests, table.Guests...)
+	}
+	return guests
+}
+
+// escapeCSVFormula prefixes the values spreadsheets read as formulas with a quote, so that they are read as text.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatReportTime(v interface{}) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format("2006-01-02 15:04")
+	case *time.Time:
+		if t != nil {
+			return t.Format("2006-01-02 15:04")
+		}
+	}
+	return ""
+}
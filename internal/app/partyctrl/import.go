@@ -0,0 +1,114 @@
+package partyctrl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Enumerate guest list import columns
+const (
+	importColumnName               = "name"
+	importColumnTable              = "table"
+	importColumnAccompanyingGuests = "accompanying_guests"
+	importColumnTier               = "tier"
+)
+
+var (
+	errImportColumnMissing     = errors.New("missing required column")
+	errImportFormatUnsupported = errors.New("unsupported import format, use text/csv or application/json")
+)
+
+// decodeImportRows decodes the import body according to its content type.
+// Rows that cannot be decoded are reported as row errors rather than failing the whole import.
+func decodeImportRows(contentType string, body []byte) ([]party.ImportGuestRow, []party.ImportRowError, error) {
+	switch {
+	case strings.HasPrefix(contentType, mimeTextCSV):
+		return decodeImportCSV(body)
+	case strings.HasPrefix(contentType, fiber.MIMEApplicationJSON):
+		var rows []party.ImportGuestRow
+		if err := json.Unmarshal(body, &rows); err != nil {
+			return nil, nil, fmt.Errorf("could not decode json import: %w", err)
+		}
+		return rows, nil, nil
+	default:
+		return nil, nil, errImportFormatUnsupported
+	}
+}
+
+// decodeImportCSV decodes a CSV import with a header row.
+// Columns may come in any order, unknown columns are ignored, accompanying_guests defaults to 0 and tier to general.
+func decodeImportCSV(body []byte) ([]party.ImportGuestRow, []party.ImportRowError, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, required := range []string{importColumnName, importColumnTable} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: %s", errImportColumnMissing, required)
+		}
+	}
+
+	var (
+		rows    []party.ImportGuestRow
+		rowErrs []party.ImportRowError
+	)
+
+	for rowNumber := 1; ; rowNumber++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not read csv row %d: %w", rowNumber, err)
+		}
+
+		field := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := party.ImportGuestRow{Name: field(importColumnName), Tier: strings.ToLower(field(importColumnTier))}
+
+		if row.Table, err = parseImportInt(field(importColumnTable)); err != nil {
+			rowErrs = append(rowErrs, party.ImportRowError{Row: rowNumber, Name: row.Name, Error: "invalid table: " + field(importColumnTable)})
+		}
+
+		if row.AccompanyingGuests, err = parseImportInt(field(importColumnAccompanyingGuests)); err != nil {
+			rowErrs = append(rowErrs, party.ImportRowError{Row: rowNumber, Name: row.Name, Error: "invalid accompanying guests: " + field(importColumnAccompanyingGuests)})
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, rowErrs, nil
+}
+
+// parseImportInt parses an integer field, treating empty fields as zero
+// so they are reported by the service validation.
+func parseImportInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
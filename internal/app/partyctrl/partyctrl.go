@@ -1,21 +1,76 @@
 package partyctrl
 
 import (
-	"context"
+	"bytes"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/alesr/getground/internal/pkg/logging"
 	"github.com/alesr/getground/internal/pkg/party"
 	"github.com/gofiber/fiber/v2"
+	qrcode "github.com/skip2/go-qrcode"
 	"go.uber.org/zap"
 )
 
+const (
+	defaultQRCodeSize = 256
+	minQRCodeSize     = 64
+	maxQRCodeSize     = 1024
+)
+
 type PartyController interface {
 	AddGuestToGuestList(c *fiber.Ctx) error
 	GetGuestList(c *fiber.Ctx) error
 	WelcomeGuest(c *fiber.Ctx) error
 	GoodbyeGuest(c *fiber.Ctx) error
+	RemoveGuestFromGuestList(c *fiber.Ctx) error
 	ListArrivedGuests(c *fiber.Ctx) error
 	GetEmptySeats(c *fiber.Ctx) error
+
+	CreateInvitation(c *fiber.Ctx) error
+	GetInvitation(c *fiber.Ctx) error
+	RespondToInvitation(c *fiber.Ctx) error
+
+	IssueCheckInCode(c *fiber.Ctx) error
+	GetCheckInCodeQR(c *fiber.Ctx) error
+	RevokeCheckInCode(c *fiber.Ctx) error
+	CheckIn(c *fiber.Ctx) error
+
+	ImportGuests(c *fiber.Ctx) error
+
+	ExportGuestList(c *fiber.Ctx) error
+	ExportSeatingReport(c *fiber.Ctx) error
+
+	ListGuests(c *fiber.Ctx) error
+	CreateGuest(c *fiber.Ctx) error
+	RecordArrival(c *fiber.Ctx) error
+	RecordDeparture(c *fiber.Ctx) error
+	AssignSeats(c *fiber.Ctx) error
+	GetGuestRequirements(c *fiber.Ctx) error
+	SetGuestRequirements(c *fiber.Ctx) error
+	ListCustomFields(c *fiber.Ctx) error
+	SetCustomFields(c *fiber.Ctx) error
+	GetCateringSummary(c *fiber.Ctx) error
+	ListGroups(c *fiber.Ctx) error
+	CreateGroup(c *fiber.Ctx) error
+	GetGroup(c *fiber.Ctx) error
+	RecordGroupArrival(c *fiber.Ctx) error
+	RecordGroupDeparture(c *fiber.Ctx) error
+	ListTables(c *fiber.Ctx) error
+	CreateTable(c *fiber.Ctx) error
+	ReserveTable(c *fiber.Ctx) error
+	ReleaseTable(c *fiber.Ctx) error
+	SwapSeats(c *fiber.Ctx) error
+	ListConstraints(c *fiber.Ctx) error
+	CreateConstraint(c *fiber.Ctx) error
+	DeleteConstraint(c *fiber.Ctx) error
+	PlanSeating(c *fiber.Ctx) error
+	CheckSeating(c *fiber.Ctx) error
+	GetStats(c *fiber.Ctx) error
+	GetLayout(c *fiber.Ctx) error
+	SetLayout(c *fiber.Ctx) error
+	GetSeatingChart(c *fiber.Ctx) error
 }
 
 type Controller struct {
@@ -30,79 +85,377 @@ func New(logger *zap.Logger, service party.Service) *Controller {
 	}
 }
 
+// log returns the logger of the request, annotated with its request ID.
+func (ctrl *Controller) log(c *fiber.Ctx) *zap.Logger {
+	return logging.FromContext(c.UserContext(), ctrl.logger)
+}
+
 func (ctrl *Controller) AddGuestToGuestList(c *fiber.Ctx) error {
+	span := startSpan(c, "AddGuestToGuestList")
+	defer span.End()
+
 	var req party.AddGuestToGuestListInput
 	if err := c.BodyParser(&req); err != nil {
-		ctrl.logger.Error("could not parse request body", zap.Error(err))
-		return c.Status(http.StatusBadRequest).JSON(err)
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
 	}
 
 	req.Name = c.Params("name")
 
-	resp, err := ctrl.service.AddGuestToGuestList(context.TODO(), &req)
+	resp, err := ctrl.service.AddGuestToGuestList(c.UserContext(), &req)
 	if err != nil {
-		ctrl.logger.Error("could not add guest to guest list", zap.Error(err))
-		return c.Status(http.StatusInternalServerError).JSON(err)
+		ctrl.log(c).Error("could not add guest to guest list", zap.Error(err))
+		return errorResponse(c, err)
 	}
 	return c.Status(http.StatusCreated).JSON(resp)
 }
 
 func (ctrl *Controller) GetGuestList(c *fiber.Ctx) error {
-	resp, err := ctrl.service.GetGuestList(context.TODO())
+	span := startSpan(c, "GetGuestList")
+	defer span.End()
+
+	req, err := parseListGuestsInput(c)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.GetGuestList(c.UserContext(), req)
 	if err != nil {
-		ctrl.logger.Error("could not get guest list", zap.Error(err))
-		return c.Status(http.StatusInternalServerError).JSON(err)
+		ctrl.log(c).Error("could not get guest list", zap.Error(err))
+		return errorResponse(c, err)
 	}
 	return c.JSON(resp)
 }
 
 func (ctrl *Controller) WelcomeGuest(c *fiber.Ctx) error {
+	span := startSpan(c, "WelcomeGuest")
+	defer span.End()
+
 	var req party.WelcomeGuestInput
 	if err := c.BodyParser(&req); err != nil {
-		ctrl.logger.Error("could not parse request body", zap.Error(err))
-		return c.Status(http.StatusBadRequest).JSON(err)
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
 	}
 
 	req.Name = c.Params("name")
 
-	resp, err := ctrl.service.WelcomeGuest(context.TODO(), &req)
+	resp, err := ctrl.service.WelcomeGuest(c.UserContext(), &req)
 	if err != nil {
-		ctrl.logger.Error("could not welcome guest", zap.Error(err))
-		return c.Status(http.StatusInternalServerError).JSON(err)
+		ctrl.log(c).Error("could not welcome guest", zap.Error(err))
+		return errorResponse(c, err)
 	}
 	return c.JSON(resp)
 }
 
 func (ctrl *Controller) GoodbyeGuest(c *fiber.Ctx) error {
+	span := startSpan(c, "GoodbyeGuest")
+	defer span.End()
+
+	// The guest is identified by the path, the body is optional
 	var req party.GoodbyeGuestInput
-	if err := c.BodyParser(&req); err != nil {
-		ctrl.logger.Error("could not parse request body", zap.Error(err))
-		return c.Status(http.StatusBadRequest).JSON(err)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			ctrl.log(c).Error("could not parse request body", zap.Error(err))
+			return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
 	}
 
 	req.Name = c.Params("name")
 
-	if err := ctrl.service.GoodbyeGuest(context.TODO(), &req); err != nil {
-		ctrl.logger.Error("could not goodbye guest", zap.Error(err))
-		return c.Status(http.StatusInternalServerError).JSON(err)
+	if err := ctrl.service.GoodbyeGuest(c.UserContext(), &req); err != nil {
+		ctrl.log(c).Error("could not goodbye guest", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(nil)
+}
+
+func (ctrl *Controller) RemoveGuestFromGuestList(c *fiber.Ctx) error {
+	span := startSpan(c, "RemoveGuestFromGuestList")
+	defer span.End()
+
+	if err := ctrl.service.RemoveGuestFromGuestList(c.UserContext(), &party.RemoveGuestFromGuestListInput{
+		Name: c.Params("name"),
+	}); err != nil {
+		ctrl.log(c).Error("could not remove guest from guest list", zap.Error(err))
+		return errorResponse(c, err)
 	}
 	return c.Status(http.StatusOK).JSON(nil)
 }
 
 func (ctrl *Controller) ListArrivedGuests(c *fiber.Ctx) error {
-	resp, err := ctrl.service.ListArrivedGuests(context.TODO())
+	span := startSpan(c, "ListArrivedGuests")
+	defer span.End()
+
+	req, err := parseListGuestsInput(c)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.ListArrivedGuests(c.UserContext(), req)
 	if err != nil {
-		ctrl.logger.Error("could not list arrived guests", zap.Error(err))
-		return c.Status(http.StatusInternalServerError).JSON(err)
+		ctrl.log(c).Error("could not list arrived guests", zap.Error(err))
+		return errorResponse(c, err)
 	}
 	return c.JSON(resp)
 }
 
 func (ctrl *Controller) GetEmptySeats(c *fiber.Ctx) error {
-	resp, err := ctrl.service.GetEmptySeats(context.TODO())
+	span := startSpan(c, "GetEmptySeats")
+	defer span.End()
+
+	resp, err := ctrl.service.GetEmptySeats(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not get empty seats", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) CreateInvitation(c *fiber.Ctx) error {
+	span := startSpan(c, "CreateInvitation")
+	defer span.End()
+
+	var req party.CreateInvitationInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.CreateInvitation(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not create invitation", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+func (ctrl *Controller) GetInvitation(c *fiber.Ctx) error {
+	span := startSpan(c, "GetInvitation")
+	defer span.End()
+
+	resp, err := ctrl.service.GetInvitation(c.UserContext(), c.Params("token"))
+	if err != nil {
+		ctrl.log(c).Error("could not get invitation", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) RespondToInvitation(c *fiber.Ctx) error {
+	span := startSpan(c, "RespondToInvitation")
+	defer span.End()
+
+	var req party.RespondToInvitationInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	req.Token = c.Params("token")
+
+	resp, err := ctrl.service.RespondToInvitation(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not respond to invitation", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) IssueCheckInCode(c *fiber.Ctx) error {
+	span := startSpan(c, "IssueCheckInCode")
+	defer span.End()
+
+	resp, err := ctrl.service.IssueCheckInCode(c.UserContext(), &party.IssueCheckInCodeInput{
+		Name: c.Params("name"),
+	})
+	if err != nil {
+		ctrl.log(c).Error("could not issue check-in code", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+func (ctrl *Controller) GetCheckInCodeQR(c *fiber.Ctx) error {
+	span := startSpan(c, "GetCheckInCodeQR")
+	defer span.End()
+
+	qrSize := defaultQRCodeSize
+	if size := c.Query("size"); size != "" {
+		var err error
+		if qrSize, err = strconv.Atoi(size); err != nil || qrSize < minQRCodeSize || qrSize > maxQRCodeSize {
+			return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: "invalid qr code size"})
+		}
+	}
+
+	resp, err := ctrl.service.GetCheckInCode(c.UserContext(), c.Params("code"))
 	if err != nil {
-		ctrl.logger.Error("could not get empty seats", zap.Error(err))
-		return c.Status(http.StatusInternalServerError).JSON(err)
+		ctrl.log(c).Error("could not get check-in code", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	if resp.Status != party.CheckInCodeStatusActive {
+		return errorResponse(c, checkInCodeStatusError(resp.Status))
+	}
+
+	png, err := qrcode.Encode(resp.Code, qrcode.Medium, qrSize)
+	if err != nil {
+		ctrl.log(c).Error("could not encode check-in qr code", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Send(png)
+}
+
+func (ctrl *Controller) RevokeCheckInCode(c *fiber.Ctx) error {
+	span := startSpan(c, "RevokeCheckInCode")
+	defer span.End()
+
+	if err := ctrl.service.RevokeCheckInCode(c.UserContext(), c.Params("code")); err != nil {
+		ctrl.log(c).Error("could not revoke check-in code", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(nil)
+}
+
+func (ctrl *Controller) CheckIn(c *fiber.Ctx) error {
+	span := startSpan(c, "CheckIn")
+	defer span.End()
+
+	var req party.CheckInInput
+
+	// The body is optional, door staff usually only scan the code
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			ctrl.log(c).Error("could not parse request body", zap.Error(err))
+			return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+	}
+
+	req.Code = c.Params("code")
+
+	resp, err := ctrl.service.CheckIn(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not check in guest", zap.Error(err))
+		return errorResponse(c, err)
 	}
 	return c.JSON(resp)
 }
+
+func (ctrl *Controller) ImportGuests(c *fiber.Ctx) error {
+	span := startSpan(c, "ImportGuests")
+	defer span.End()
+
+	rows, rowErrs, err := decodeImportRows(c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		ctrl.log(c).Error("could not decode guest list import", zap.Error(err))
+
+		status := http.StatusBadRequest
+		if errors.Is(err, errImportFormatUnsupported) {
+			status = http.StatusUnsupportedMediaType
+		}
+		return c.Status(status).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	if len(rowErrs) > 0 {
+		return c.Status(http.StatusUnprocessableEntity).JSON(party.ImportGuestsOutput{
+			Rows:   len(rows),
+			DryRun: dryRun,
+			Errors: rowErrs,
+		})
+	}
+
+	resp, err := ctrl.service.ImportGuests(c.UserContext(), &party.ImportGuestsInput{
+		Guests: rows,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		ctrl.log(c).Error("could not import guests", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	switch {
+	case len(resp.Errors) > 0:
+		return c.Status(http.StatusUnprocessableEntity).JSON(resp)
+	case resp.DryRun:
+		return c.JSON(resp)
+	default:
+		return c.Status(http.StatusCreated).JSON(resp)
+	}
+}
+
+func (ctrl *Controller) ExportGuestList(c *fiber.Ctx) error {
+	span := startSpan(c, "ExportGuestList")
+	defer span.End()
+
+	format := c.Accepts(mimeTextCSV, fiber.MIMEApplicationJSON)
+	if format == "" {
+		return c.Status(http.StatusNotAcceptable).JSON(ErrorResponse{Error: http.StatusText(http.StatusNotAcceptable)})
+	}
+
+	report, err := ctrl.service.GetSeatingReport(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not get seating report", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	guests := reportGuests(report)
+
+	if format == fiber.MIMEApplicationJSON {
+		return c.JSON(ExportGuestListOutput{Guests: guests})
+	}
+	return ctrl.sendCSV(c, "guest_list.csv", guests)
+}
+
+func (ctrl *Controller) ExportSeatingReport(c *fiber.Ctx) error {
+	span := startSpan(c, "ExportSeatingReport")
+	defer span.End()
+
+	format := c.Accepts(fiber.MIMETextHTML, mimeTextCSV, fiber.MIMEApplicationJSON)
+	if format == "" {
+		return c.Status(http.StatusNotAcceptable).JSON(ErrorResponse{Error: http.StatusText(http.StatusNotAcceptable)})
+	}
+
+	report, err := ctrl.service.GetSeatingReport(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not get seating report", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	switch format {
+	case fiber.MIMEApplicationJSON:
+		return c.JSON(report)
+	case mimeTextCSV:
+		return ctrl.sendCSV(c, "seating_report.csv", reportGuests(report))
+	}
+
+	var buf bytes.Buffer
+	if err := seatingReportTemplate.Execute(&buf, report); err != nil {
+		ctrl.log(c).Error("could not render seating report", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Send(buf.Bytes())
+}
+
+func (ctrl *Controller) sendCSV(c *fiber.Ctx, filename string, guests []party.ReportGuest) error {
+	body, err := encodeGuestsCSV(guests)
+	if err != nil {
+		ctrl.log(c).Error("could not encode csv", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	c.Attachment(filename)
+	c.Set(fiber.HeaderContentType, mimeTextCSV)
+	return c.Send(body)
+}
+
+func checkInCodeStatusError(status string) error {
+	if status == party.CheckInCodeStatusUsed {
+		return party.ErrCheckInCodeUsed
+	}
+	return party.ErrCheckInCodeRevoked
+}
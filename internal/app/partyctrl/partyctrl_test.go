@@ -4,14 +4,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/internal/pkg/tracing"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -61,7 +70,7 @@ func TestGetGuestList(t *testing.T) {
 		service := party.Mock{}
 
 		var wasCalled bool
-		service.GetGuestListFunc = func(ctx context.Context) (party.GetGuestListOutput, error) {
+		service.GetGuestListFunc = func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
 			wasCalled = true
 			return party.GetGuestListOutput{}, nil
 		}
@@ -81,7 +90,7 @@ func TestGetGuestList(t *testing.T) {
 	t.Run("returns the expected result given the party mock returned value", func(t *testing.T) {
 		service := party.Mock{}
 
-		service.GetGuestListFunc = func(ctx context.Context) (party.GetGuestListOutput, error) {
+		service.GetGuestListFunc = func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
 			return party.GetGuestListOutput{
 				Guests: []party.Guest{
 					{
@@ -116,3 +125,530 @@ func TestGetGuestList(t *testing.T) {
 
 	})
 }
+
+func TestRespondToInvitation(t *testing.T) {
+	t.Run("passes the token and body to the service", func(t *testing.T) {
+		service := party.Mock{}
+
+		var observedInput *party.RespondToInvitationInput
+		service.RespondToInvitationFunc = func(ctx context.Context, in *party.RespondToInvitationInput) (*party.RespondToInvitationOutput, error) {
+			observedInput = in
+			return &party.RespondToInvitationOutput{
+				Name:   "John",
+				Status: party.InvitationStatusAccepted,
+			}, nil
+		}
+
+		controller := New(zap.NewNop(), &service)
+
+		req := httptest.NewRequest(http.MethodPost, "/rsvp/abc.def", bytes.NewBufferString(`{"attending": true, "accompanying_guests": 2}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		fiberApp := fiber.New()
+		fiberApp.Post("/rsvp/:token", controller.RespondToInvitation)
+
+		resp, err := fiberApp.Test(req, testReqTimeoutMs)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, &party.RespondToInvitationInput{
+			Token:              "abc.def",
+			Attending:          true,
+			AccompanyingGuests: 2,
+		}, observedInput)
+	})
+
+	t.Run("maps service errors to status codes", func(t *testing.T) {
+		cases := []struct {
+			name               string
+			givenError         error
+			expectedStatusCode int
+			expectedBody       ErrorResponse
+		}{
+			{
+				name:               "invalid token",
+				givenError:         party.ErrInvitationTokenInvalid,
+				expectedStatusCode: http.StatusUnauthorized,
+				expectedBody:       ErrorResponse{Error: party.ErrInvitationTokenInvalid.Error()},
+			},
+			{
+				name:               "expired invitation",
+				givenError:         party.ErrInvitationExpired,
+				expectedStatusCode: http.StatusGone,
+				expectedBody:       ErrorResponse{Error: party.ErrInvitationExpired.Error()},
+			},
+			{
+				name:               "wrapped seat check error",
+				givenError:         fmt.Errorf("could not add invited guest to guest list: %w", party.ErrTableNotEnoughSeats),
+				expectedStatusCode: http.StatusConflict,
+				expectedBody:       ErrorResponse{Error: party.ErrTableNotEnoughSeats.Error()},
+			},
+			{
+				name:               "unknown error",
+				givenError:         errors.New("some error"),
+				expectedStatusCode: http.StatusInternalServerError,
+				expectedBody:       ErrorResponse{Error: http.StatusText(http.StatusInternalServerError)},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				service := party.Mock{}
+				service.RespondToInvitationFunc = func(ctx context.Context, in *party.RespondToInvitationInput) (*party.RespondToInvitationOutput, error) {
+					return nil, c.givenError
+				}
+
+				controller := New(zap.NewNop(), &service)
+
+				req := httptest.NewRequest(http.MethodPost, "/rsvp/abc.def", bytes.NewBufferString(`{"attending": false}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				fiberApp := fiber.New()
+				fiberApp.Post("/rsvp/:token", controller.RespondToInvitation)
+
+				resp, err := fiberApp.Test(req, testReqTimeoutMs)
+				require.NoError(t, err)
+
+				var observed ErrorResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&observed))
+
+				assert.Equal(t, c.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, c.expectedBody, observed)
+			})
+		}
+	})
+}
+
+func TestGetCheckInCodeQR(t *testing.T) {
+	cases := []struct {
+		name                string
+		givenPath           string
+		givenStatus         string
+		expectedStatusCode  int
+		expectedContentType string
+	}{
+		{
+			name:                "renders active codes as png",
+			givenPath:           "/checkin/CODE/qr",
+			givenStatus:         party.CheckInCodeStatusActive,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "image/png",
+		},
+		{
+			name:                "rejects used codes",
+			givenPath:           "/checkin/CODE/qr",
+			givenStatus:         party.CheckInCodeStatusUsed,
+			expectedStatusCode:  http.StatusConflict,
+			expectedContentType: fiber.MIMEApplicationJSON,
+		},
+		{
+			name:                "rejects invalid sizes",
+			givenPath:           "/checkin/CODE/qr?size=1",
+			givenStatus:         party.CheckInCodeStatusActive,
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: fiber.MIMEApplicationJSON,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			service := party.Mock{}
+			service.GetCheckInCodeFunc = func(ctx context.Context, code string) (*party.GetCheckInCodeOutput, error) {
+				return &party.GetCheckInCodeOutput{Code: code, Name: "John", Status: c.givenStatus}, nil
+			}
+
+			controller := New(zap.NewNop(), &service)
+
+			fiberApp := fiber.New()
+			fiberApp.Get("/checkin/:code/qr", controller.GetCheckInCodeQR)
+
+			resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodGet, c.givenPath, nil), testReqTimeoutMs)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, c.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+		})
+	}
+}
+
+func TestCheckIn(t *testing.T) {
+	cases := []struct {
+		name          string
+		givenBody     string
+		expectedInput *party.CheckInInput
+	}{
+		{
+			name:          "without body",
+			givenBody:     "",
+			expectedInput: &party.CheckInInput{Code: "CODE"},
+		},
+		{
+			name:      "with accompanying guests",
+			givenBody: `{"accompanying_guests": 1}`,
+			expectedInput: &party.CheckInInput{
+				Code:               "CODE",
+				AccompanyingGuests: func() *int { i := 1; return &i }(),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			service := party.Mock{}
+
+			var observedInput *party.CheckInInput
+			service.CheckInFunc = func(ctx context.Context, in *party.CheckInInput) (*party.CheckInOutput, error) {
+				observedInput = in
+				return &party.CheckInOutput{Name: "John"}, nil
+			}
+
+			controller := New(zap.NewNop(), &service)
+
+			req := httptest.NewRequest(http.MethodPut, "/checkin/CODE", bytes.NewBufferString(c.givenBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			fiberApp := fiber.New()
+			fiberApp.Put("/checkin/:code", controller.CheckIn)
+
+			resp, err := fiberApp.Test(req, testReqTimeoutMs)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, c.expectedInput, observedInput)
+		})
+	}
+}
+
+func TestImportGuests(t *testing.T) {
+	cases := []struct {
+		name               string
+		givenContentType   string
+		givenBody          string
+		givenPath          string
+		expectedStatusCode int
+		expectedInput      *party.ImportGuestsInput
+	}{
+		{
+			name:               "imports csv rows in any column order",
+			givenContentType:   "text/csv",
+			givenBody:          "table,Name,accompanying_guests,notes,tier\n1,John,2,vegan,VIP\n2, Jane,,\n",
+			givenPath:          "/guest_list/import",
+			expectedStatusCode: http.StatusCreated,
+			expectedInput: &party.ImportGuestsInput{
+				Guests: []party.ImportGuestRow{
+					{Name: "John", Table: 1, AccompanyingGuests: 2, Tier: party.TierVIP},
+					{Name: "Jane", Table: 2},
+				},
+			},
+		},
+		{
+			name:               "imports json rows as a dry run",
+			givenContentType:   "application/json",
+			givenBody:          `[{"name": "John", "table": 1, "accompanying_guests": 2}]`,
+			givenPath:          "/guest_list/import?dry_run=true",
+			expectedStatusCode: http.StatusOK,
+			expectedInput: &party.ImportGuestsInput{
+				Guests: []party.ImportGuestRow{{Name: "John", Table: 1, AccompanyingGuests: 2}},
+				DryRun: true,
+			},
+		},
+		{
+			name:               "reports csv rows that cannot be decoded",
+			givenContentType:   "text/csv",
+			givenBody:          "name,table\nJohn,one\n",
+			givenPath:          "/guest_list/import",
+			expectedStatusCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:               "rejects csv without required columns",
+			givenContentType:   "text/csv",
+			givenBody:          "name\nJohn\n",
+			givenPath:          "/guest_list/import",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "rejects unsupported formats",
+			givenContentType:   "application/xml",
+			givenBody:          "<guests/>",
+			givenPath:          "/guest_list/import",
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			service := party.Mock{}
+
+			var observedInput *party.ImportGuestsInput
+			service.ImportGuestsFunc = func(ctx context.Context, in *party.ImportGuestsInput) (*party.ImportGuestsOutput, error) {
+				observedInput = in
+				return &party.ImportGuestsOutput{Rows: len(in.Guests), DryRun: in.DryRun}, nil
+			}
+
+			controller := New(zap.NewNop(), &service)
+
+			req := httptest.NewRequest(http.MethodPost, c.givenPath, bytes.NewBufferString(c.givenBody))
+			req.Header.Set("Content-Type", c.givenContentType)
+
+			fiberApp := fiber.New()
+			fiberApp.Post("/guest_list/import", controller.ImportGuests)
+
+			resp, err := fiberApp.Test(req, testReqTimeoutMs)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, c.expectedInput, observedInput)
+		})
+	}
+
+	t.Run("returns the row errors reported by the service", func(t *testing.T) {
+		service := party.Mock{}
+		service.ImportGuestsFunc = func(ctx context.Context, in *party.ImportGuestsInput) (*party.ImportGuestsOutput, error) {
+			return &party.ImportGuestsOutput{
+				Rows:   1,
+				Errors: []party.ImportRowError{{Row: 1, Name: "John", Error: party.ErrTableNotEnoughSeats.Error()}},
+			}, nil
+		}
+
+		controller := New(zap.NewNop(), &service)
+
+		req := httptest.NewRequest(http.MethodPost, "/guest_list/import", bytes.NewBufferString("name,table\nJohn,1\n"))
+		req.Header.Set("Content-Type", "text/csv")
+
+		fiberApp := fiber.New()
+		fiberApp.Post("/guest_list/import", controller.ImportGuests)
+
+		resp, err := fiberApp.Test(req, testReqTimeoutMs)
+		require.NoError(t, err)
+
+		var observed party.ImportGuestsOutput
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&observed))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Equal(t, []party.ImportRowError{{Row: 1, Name: "John", Error: party.ErrTableNotEnoughSeats.Error()}}, observed.Errors)
+	})
+}
+
+func TestExportSeatingReport(t *testing.T) {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	report := &party.GetSeatingReportOutput{
+		GeneratedAt: arrived,
+		Tables: []party.ReportTable{
+			{
+				Number:       1,
+				Size:         12,
+				BookedSeats:  3,
+				ArrivedSeats: 3,
+				Guests: []party.ReportGuest{
+					{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived},
+				},
+			},
+		},
+		GuestsBooked:  1,
+		GuestsArrived: 1,
+		BookedSeats:   3,
+		ArrivedSeats:  3,
+	}
+
+	cases := []struct {
+		name                string
+		givenAccept         string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "renders html by default",
+			givenAccept:         "",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: fiber.MIMETextHTMLCharsetUTF8,
+			expectedBody:        "<h2>Table 1",
+		},
+		{
+			name:                "renders csv",
+			givenAccept:         "text/csv",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedBody:        "table,name,accompanying_guests,time_arrived,time_departed\n1,John,2,2021-11-23T20:00:00Z,\n",
+		},
+		{
+			name:                "renders json",
+			givenAccept:         "application/json",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			expectedBody:        `"booked_seats":3`,
+		},
+		{
+			name:                "rejects unsupported formats",
+			givenAccept:         "application/pdf",
+			expectedStatusCode:  http.StatusNotAcceptable,
+			expectedContentType: fiber.MIMEApplicationJSON,
+			expectedBody:        "Not Acceptable",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			service := party.Mock{}
+			service.GetSeatingReportFunc = func(ctx context.Context) (*party.GetSeatingReportOutput, error) {
+				return report, nil
+			}
+
+			controller := New(zap.NewNop(), &service)
+
+			req := httptest.NewRequest(http.MethodGet, "/export/seating_report", nil)
+			if c.givenAccept != "" {
+				req.Header.Set("Accept", c.givenAccept)
+			}
+
+			fiberApp := fiber.New()
+			fiberApp.Get("/export/seating_report", controller.ExportSeatingReport)
+
+			resp, err := fiberApp.Test(req, testReqTimeoutMs)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, c.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+			assert.Contains(t, string(body), c.expectedBody)
+		})
+	}
+}
+
+func TestEncodeGuestsCSV(t *testing.T) {
+	guests := []party.ReportGuest{
+		{Name: "=HYPERLINK(\"http://example.com\")", Table: 1},
+		{Name: "+1", Table: 1},
+		{Name: "-1", Table: 1},
+		{Name: "@SUM(A1)", Table: 2},
+		{Name: "John=Doe", Table: 2},
+	}
+
+	observed, err := encodeGuestsCSV(guests)
+	require.NoError(t, err)
+
+	expected := "table,name,accompanying_guests,time_arrived,time_departed\n" +
+		"1,\"'=HYPERLINK(\"\"http://example.com\"\")\",0,,\n" +
+		"1,'+1,0,,\n" +
+		"1,'-1,0,,\n" +
+		"2,'@SUM(A1),0,,\n" +
+		"2,John=Doe,0,,\n"
+	assert.Equal(t, expected, string(observed))
+}
+
+func TestListArrivedGuests(t *testing.T) {
+	cases := []struct {
+		name               string
+		givenQuery         string
+		expectedStatusCode int
+		expectedInput      *party.ListGuestsInput
+	}{
+		{
+			name:               "passes the query parameters to the service",
+			givenQuery:         "?table=2&name_prefix=jo&sort=time_arrived&order=desc&cursor=abc&limit=10",
+			expectedStatusCode: http.StatusOK,
+			expectedInput: &party.ListGuestsInput{
+				Table:      2,
+				NamePrefix: "jo",
+				Sort:       party.SortByTimeArrival,
+				Order:      party.SortOrderDesc,
+				Cursor:     "abc",
+				Limit:      10,
+			},
+		},
+		{
+			name:               "rejects invalid numbers",
+			givenQuery:         "?limit=ten",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "rejects invalid booleans",
+			givenQuery:         "?arrived=maybe",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			service := party.Mock{}
+
+			var observedInput *party.ListGuestsInput
+			service.ListArrivedGuestsFunc = func(ctx context.Context, in *party.ListGuestsInput) (party.ListArrivedGuestsOutput, error) {
+				observedInput = in
+				return party.ListArrivedGuestsOutput{}, nil
+			}
+
+			controller := New(zap.NewNop(), &service)
+
+			fiberApp := fiber.New()
+			fiberApp.Get("/guests", controller.ListArrivedGuests)
+
+			resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodGet, "/guests"+c.givenQuery, nil), testReqTimeoutMs)
+			require.NoError(t, err)
+
+			assert.Equal(t, c.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, c.expectedInput, observedInput)
+		})
+	}
+
+	t.Run("maps invalid queries to bad request", func(t *testing.T) {
+		service := party.Mock{}
+		service.ListArrivedGuestsFunc = func(ctx context.Context, in *party.ListGuestsInput) (party.ListArrivedGuestsOutput, error) {
+			return party.ListArrivedGuestsOutput{}, fmt.Errorf("could not query guests: %w", party.ErrCursorInvalid)
+		}
+
+		controller := New(zap.NewNop(), &service)
+
+		fiberApp := fiber.New()
+		fiberApp.Get("/guests", controller.ListArrivedGuests)
+
+		resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodGet, "/guests?cursor=abc", nil), testReqTimeoutMs)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestRequestContext(t *testing.T) {
+	t.Run("passes the request trace to the service within the handler span", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+		_, err := tracing.Setup(context.TODO(), tracing.Config{Exporter: tracing.ExporterNone})
+		require.NoError(t, err)
+
+		var serviceSpan trace.SpanContext
+
+		service := party.Mock{}
+		service.WelcomeGuestFunc = func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			serviceSpan = trace.SpanContextFromContext(ctx)
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		}
+
+		controller := New(zap.NewNop(), &service)
+
+		req := httptest.NewRequest(http.MethodPut, "/guests/John", bytes.NewBufferString(`{"accompanying_guests": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		fiberApp := fiber.New()
+		fiberApp.Use(tracing.Middleware())
+		fiberApp.Put("/guests/:name", controller.WelcomeGuest)
+
+		resp, err := fiberApp.Test(req, testReqTimeoutMs)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		handlerSpan, serverSpan := spans[0], spans[1]
+		assert.Equal(t, "partyctrl.WelcomeGuest", handlerSpan.Name())
+		assert.Equal(t, serverSpan.SpanContext().SpanID(), handlerSpan.Parent().SpanID())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", serviceSpan.TraceID().String())
+		assert.Equal(t, handlerSpan.SpanContext().SpanID(), serviceSpan.SpanID())
+	})
+}
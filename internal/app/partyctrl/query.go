@@ -0,0 +1,56 @@
+package partyctrl
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseListGuestsInput reads the guest list filters, sort order and page from the query string:
+// table, arrived, name_prefix, dietary, allergy, accessibility, custom, sort, order, cursor and limit.
+func parseListGuestsInput(c *fiber.Ctx) (*party.ListGuestsInput, error) {
+	in := party.ListGuestsInput{
+		NamePrefix:    c.Query("name_prefix"),
+		Dietary:       c.Query("dietary"),
+		Allergy:       c.Query("allergy"),
+		Accessibility: c.Query("accessibility"),
+		Custom:        c.Query("custom"),
+		Sort:          c.Query("sort"),
+		Order:         c.Query("order"),
+		Cursor:        c.Query("cursor"),
+	}
+
+	var err error
+
+	if in.Table, err = queryInt(c, "table"); err != nil {
+		return nil, err
+	}
+
+	if in.Limit, err = queryInt(c, "limit"); err != nil {
+		return nil, err
+	}
+
+	if arrived := c.Query("arrived"); arrived != "" {
+		b, err := strconv.ParseBool(arrived)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arrived query parameter: %s", arrived)
+		}
+		in.Arrived = &b
+	}
+	return &in, nil
+}
+
+func queryInt(c *fiber.Ctx, key string) (int, error) {
+	v := c.Query(key)
+	if v == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s query parameter: %s", key, v)
+	}
+	return i, nil
+}
@@ -0,0 +1,17 @@
+package partyctrl
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/alesr/getground/internal/app/partyctrl")
+
+// startSpan starts the span of a handler and sets it on the request user context,
+// so the service calls made with c.UserContext() are traced as its children.
+func startSpan(c *fiber.Ctx, handler string) trace.Span {
+	ctx, span := tracer.Start(c.UserContext(), "partyctrl."+handler)
+	c.SetUserContext(ctx)
+	return span
+}
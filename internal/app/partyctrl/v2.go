@@ -0,0 +1,559 @@
+package partyctrl
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// The v2 handlers expose the party as resources: guests, their arrival, groups, tables and stats.
+// Guests and groups are identified by their name.
+
+type (
+
+	// GuestResource defines a guest of the v2 API.
+	GuestResource struct {
+		ID                 string     `json:"id"`
+		Name               string     `json:"name"`
+		Table              int        `json:"table"`
+		AccompanyingGuests int        `json:"accompanying_guests"`
+		Tier               string     `json:"tier,omitempty"`
+		ArrivedAt          *time.Time `json:"arrived_at,omitempty"`
+	}
+
+	// GuestCollection defines a page of guests of the v2 API.
+	// NextCursor is set when more guests match the query.
+	GuestCollection struct {
+		Guests     []GuestResource `json:"guests"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+	}
+
+	// CreateGuestInput defines the body for adding a guest with the v2 API.
+	// Seats are optional, the seat numbers of the guest then of each accompanying guest.
+	// Guests without a tier are general guests.
+	CreateGuestInput struct {
+		Name               string `json:"name"`
+		Table              int    `json:"table"`
+		AccompanyingGuests int    `json:"accompanying_guests"`
+		Tier               string `json:"tier,omitempty"`
+		Seats              []int  `json:"seats,omitempty"`
+	}
+
+	// ArrivalInput defines the body for recording the arrival of a guest with the v2 API.
+	ArrivalInput struct {
+		AccompanyingGuests int `json:"accompanying_guests"`
+	}
+
+	// ArrivalResource defines the arrival of a guest of the v2 API.
+	ArrivalResource struct {
+		GuestID            string `json:"guest_id"`
+		AccompanyingGuests int    `json:"accompanying_guests"`
+	}
+)
+
+func (ctrl *Controller) ListGuests(c *fiber.Ctx) error {
+	span := startSpan(c, "ListGuests")
+	defer span.End()
+
+	req, err := parseListGuestsInput(c)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.GetGuestList(c.UserContext(), req)
+	if err != nil {
+		ctrl.log(c).Error("could not list guests", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	out := GuestCollection{
+		Guests:     make([]GuestResource, 0, len(resp.Guests)),
+		NextCursor: resp.NextCursor,
+	}
+
+	for _, guest := range resp.Guests {
+		out.Guests = append(out.Guests, GuestResource{
+			ID:                 guest.Name,
+			Name:               guest.Name,
+			Table:              guest.Table,
+			AccompanyingGuests: guest.AccompanyingGuests,
+			Tier:               guest.Tier,
+			ArrivedAt:          guest.TimeArrival,
+		})
+	}
+	return c.JSON(out)
+}
+
+func (ctrl *Controller) CreateGuest(c *fiber.Ctx) error {
+	span := startSpan(c, "CreateGuest")
+	defer span.End()
+
+	var req CreateGuestInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	if _, err := ctrl.service.AddGuestToGuestList(c.UserContext(), &party.AddGuestToGuestListInput{
+		Name:               req.Name,
+		Table:              req.Table,
+		AccompanyingGuests: req.AccompanyingGuests,
+		Tier:               req.Tier,
+		Seats:              req.Seats,
+	}); err != nil {
+		ctrl.log(c).Error("could not create guest", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(GuestResource{
+		ID:                 req.Name,
+		Name:               req.Name,
+		Table:              req.Table,
+		AccompanyingGuests: req.AccompanyingGuests,
+		Tier:               req.Tier,
+	})
+}
+
+func (ctrl *Controller) RecordArrival(c *fiber.Ctx) error {
+	span := startSpan(c, "RecordArrival")
+	defer span.End()
+
+	var req ArrivalInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.WelcomeGuest(c.UserContext(), &party.WelcomeGuestInput{
+		Name:               c.Params("id"),
+		AccompanyingGuests: req.AccompanyingGuests,
+	})
+	if err != nil {
+		ctrl.log(c).Error("could not record arrival", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(ArrivalResource{
+		GuestID:            resp.Name,
+		AccompanyingGuests: req.AccompanyingGuests,
+	})
+}
+
+func (ctrl *Controller) RecordDeparture(c *fiber.Ctx) error {
+	span := startSpan(c, "RecordDeparture")
+	defer span.End()
+
+	if err := ctrl.service.GoodbyeGuest(c.UserContext(), &party.GoodbyeGuestInput{Name: c.Params("id")}); err != nil {
+		ctrl.log(c).Error("could not record departure", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func (ctrl *Controller) ListGroups(c *fiber.Ctx) error {
+	span := startSpan(c, "ListGroups")
+	defer span.End()
+
+	resp, err := ctrl.service.ListGroups(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not list groups", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// CreateGroup adds a group and its guests to the guest list, seated together when the body has no table.
+func (ctrl *Controller) CreateGroup(c *fiber.Ctx) error {
+	span := startSpan(c, "CreateGroup")
+	defer span.End()
+
+	var req party.AddGroupToGuestListInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.AddGroupToGuestList(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not create group", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+func (ctrl *Controller) GetGroup(c *fiber.Ctx) error {
+	span := startSpan(c, "GetGroup")
+	defer span.End()
+
+	resp, err := ctrl.service.GetGroup(c.UserContext(), c.Params("id"))
+	if err != nil {
+		ctrl.log(c).Error("could not get group", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// RecordGroupArrival records the arrival of the guests of the group, with their booked accompanying guests.
+func (ctrl *Controller) RecordGroupArrival(c *fiber.Ctx) error {
+	span := startSpan(c, "RecordGroupArrival")
+	defer span.End()
+
+	resp, err := ctrl.service.WelcomeGroup(c.UserContext(), &party.WelcomeGroupInput{Name: c.Params("id")})
+	if err != nil {
+		ctrl.log(c).Error("could not record group arrival", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) RecordGroupDeparture(c *fiber.Ctx) error {
+	span := startSpan(c, "RecordGroupDeparture")
+	defer span.End()
+
+	if _, err := ctrl.service.GoodbyeGroup(c.UserContext(), &party.GoodbyeGroupInput{Name: c.Params("id")}); err != nil {
+		ctrl.log(c).Error("could not record group departure", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+func (ctrl *Controller) ListTables(c *fiber.Ctx) error {
+	span := startSpan(c, "ListTables")
+	defer span.End()
+
+	resp, err := ctrl.service.ListTables(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not list tables", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) CreateTable(c *fiber.Ctx) error {
+	span := startSpan(c, "CreateTable")
+	defer span.End()
+
+	var req party.CreateTableInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.CreateTable(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not create table", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// ReserveTable restricts the table to the tiers of the body and holds back its held seats.
+func (ctrl *Controller) ReserveTable(c *fiber.Ctx) error {
+	span := startSpan(c, "ReserveTable")
+	defer span.End()
+
+	number, err := strconv.Atoi(c.Params("number"))
+	if err != nil {
+		return errorResponse(c, party.ErrTableNumberInvalid)
+	}
+
+	var req party.ReserveTableInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	req.Number = number
+
+	resp, err := ctrl.service.ReserveTable(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not reserve table", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// ReleaseTable opens the table to every tier and releases its held seats.
+func (ctrl *Controller) ReleaseTable(c *fiber.Ctx) error {
+	span := startSpan(c, "ReleaseTable")
+	defer span.End()
+
+	number, err := strconv.Atoi(c.Params("number"))
+	if err != nil {
+		return errorResponse(c, party.ErrTableNumberInvalid)
+	}
+
+	resp, err := ctrl.service.ReleaseTable(c.UserContext(), number)
+	if err != nil {
+		ctrl.log(c).Error("could not release table", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// AssignSeats moves a guest and their accompanying guests to the seats of the body.
+func (ctrl *Controller) AssignSeats(c *fiber.Ctx) error {
+	span := startSpan(c, "AssignSeats")
+	defer span.End()
+
+	var req party.AssignSeatsInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	req.Name = c.Params("id")
+
+	resp, err := ctrl.service.AssignSeats(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not assign seats", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) GetGuestRequirements(c *fiber.Ctx) error {
+	span := startSpan(c, "GetGuestRequirements")
+	defer span.End()
+
+	resp, err := ctrl.service.GetGuestRequirements(c.UserContext(), c.Params("id"))
+	if err != nil {
+		ctrl.log(c).Error("could not get guest requirements", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// SetGuestRequirements replaces the needs of a guest and their accompanying guests with those of the body.
+func (ctrl *Controller) SetGuestRequirements(c *fiber.Ctx) error {
+	span := startSpan(c, "SetGuestRequirements")
+	defer span.End()
+
+	var req party.SetGuestRequirementsInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	req.Guest = c.Params("id")
+
+	resp, err := ctrl.service.SetGuestRequirements(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not set guest requirements", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) ListCustomFields(c *fiber.Ctx) error {
+	span := startSpan(c, "ListCustomFields")
+	defer span.End()
+
+	resp, err := ctrl.service.ListCustomFields(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not list custom fields", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// SetCustomFields replaces the custom guest attributes of the event with those of the body.
+func (ctrl *Controller) SetCustomFields(c *fiber.Ctx) error {
+	span := startSpan(c, "SetCustomFields")
+	defer span.End()
+
+	var req party.SetCustomFieldsInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.SetCustomFields(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not set custom fields", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) GetCateringSummary(c *fiber.Ctx) error {
+	span := startSpan(c, "GetCateringSummary")
+	defer span.End()
+
+	resp, err := ctrl.service.GetCateringSummary(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not get catering summary", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// SwapSeats swaps the occupants of two seats of the table.
+func (ctrl *Controller) SwapSeats(c *fiber.Ctx) error {
+	span := startSpan(c, "SwapSeats")
+	defer span.End()
+
+	number, err := strconv.Atoi(c.Params("number"))
+	if err != nil {
+		return errorResponse(c, party.ErrTableNumberInvalid)
+	}
+
+	var req party.SwapSeatsInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	req.Table = number
+
+	resp, err := ctrl.service.SwapSeats(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not swap seats", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) ListConstraints(c *fiber.Ctx) error {
+	span := startSpan(c, "ListConstraints")
+	defer span.End()
+
+	resp, err := ctrl.service.ListConstraints(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not list constraints", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) CreateConstraint(c *fiber.Ctx) error {
+	span := startSpan(c, "CreateConstraint")
+	defer span.End()
+
+	var req party.CreateConstraintInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.CreateConstraint(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not create constraint", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+func (ctrl *Controller) DeleteConstraint(c *fiber.Ctx) error {
+	span := startSpan(c, "DeleteConstraint")
+	defer span.End()
+
+	if err := ctrl.service.DeleteConstraint(c.UserContext(), c.Params("id")); err != nil {
+		ctrl.log(c).Error("could not delete constraint", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// PlanSeating computes a seating plan for the guest list, without applying it.
+func (ctrl *Controller) PlanSeating(c *fiber.Ctx) error {
+	span := startSpan(c, "PlanSeating")
+	defer span.End()
+
+	resp, err := ctrl.service.PlanSeating(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not plan seating", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// CheckSeating checks the seating plan of the body, or the tables of the guest list without a body.
+func (ctrl *Controller) CheckSeating(c *fiber.Ctx) error {
+	span := startSpan(c, "CheckSeating")
+	defer span.End()
+
+	var req party.CheckSeatingInput
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			ctrl.log(c).Error("could not parse request body", zap.Error(err))
+			return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+		}
+	}
+
+	resp, err := ctrl.service.CheckSeating(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not check seating", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) GetStats(c *fiber.Ctx) error {
+	span := startSpan(c, "GetStats")
+	defer span.End()
+
+	resp, err := ctrl.service.GetStats(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not get stats", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) GetLayout(c *fiber.Ctx) error {
+	span := startSpan(c, "GetLayout")
+	defer span.End()
+
+	resp, err := ctrl.service.GetLayout(c.UserContext())
+	if err != nil {
+		ctrl.log(c).Error("could not get layout", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+func (ctrl *Controller) SetLayout(c *fiber.Ctx) error {
+	span := startSpan(c, "SetLayout")
+	defer span.End()
+
+	var req party.SetLayoutInput
+	if err := c.BodyParser(&req); err != nil {
+		ctrl.log(c).Error("could not parse request body", zap.Error(err))
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Error: err.Error()})
+	}
+
+	resp, err := ctrl.service.SetLayout(c.UserContext(), &req)
+	if err != nil {
+		ctrl.log(c).Error("could not set layout", zap.Error(err))
+		return errorResponse(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// GetSeatingChart draws the venue layout as SVG, with the table of the guest named by the highlight query highlighted.
+func (ctrl *Controller) GetSeatingChart(c *fiber.Ctx) error {
+	span := startSpan(c, "GetSeatingChart")
+	defer span.End()
+
+	chart, err := ctrl.service.GetSeatingChart(c.UserContext(), &party.GetSeatingChartInput{
+		Highlight: c.Query("highlight"),
+	})
+	if err != nil {
+		ctrl.log(c).Error("could not get seating chart", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	svg, err := renderSeatingChart(chart)
+	if err != nil {
+		ctrl.log(c).Error("could not render seating chart", zap.Error(err))
+		return errorResponse(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, mimeImageSVG)
+	return c.Send(svg)
+}
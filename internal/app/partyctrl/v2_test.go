@@ -0,0 +1,735 @@
+package partyctrl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestV2(t *testing.T) {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	service := party.Mock{
+		GetGuestListFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
+			if in.Dietary == "keto" {
+				return party.GetGuestListOutput{}, party.ErrDietaryRequirementInvalid
+			}
+			return party.GetGuestListOutput{
+				Guests:     []party.Guest{{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived}},
+				NextCursor: "next",
+			}, nil
+		},
+		AddGuestToGuestListFunc: func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+			if in.Name == "" {
+				return nil, party.ErrGuestNameRequired
+			}
+			if in.Tier == party.TierStaff {
+				return nil, party.ErrTableTierRestricted
+			}
+			return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+		},
+		WelcomeGuestFunc: func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			if in.Name != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		},
+		GoodbyeGuestFunc: func(ctx context.Context, in *party.GoodbyeGuestInput) error {
+			if in.Name != "John" {
+				return party.ErrGuestNotInList
+			}
+			return nil
+		},
+		ListGroupsFunc: func(ctx context.Context) (party.ListGroupsOutput, error) {
+			return party.ListGroupsOutput{
+				Groups: []party.Group{{Name: "Doe", Tables: []int{2}, Guests: []party.Guest{{Name: "Jane", Table: 2}}}},
+			}, nil
+		},
+		AddGroupToGuestListFunc: func(ctx context.Context, in *party.AddGroupToGuestListInput) (*party.Group, error) {
+			if len(in.Guests) == 0 {
+				return nil, party.ErrGroupGuestsRequired
+			}
+			return &party.Group{Name: in.Name, Tables: []int{2}, Guests: []party.Guest{{Name: in.Guests[0].Name, Table: 2}}}, nil
+		},
+		GetGroupFunc: func(ctx context.Context, name string) (*party.Group, error) {
+			if name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			return &party.Group{Name: name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane", Table: 2}}}, nil
+		},
+		WelcomeGroupFunc: func(ctx context.Context, in *party.WelcomeGroupInput) (*party.Group, error) {
+			if in.Name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			return &party.Group{Name: in.Name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane", Table: 2, TimeArrival: &arrived}}}, nil
+		},
+		GoodbyeGroupFunc: func(ctx context.Context, in *party.GoodbyeGroupInput) (*party.Group, error) {
+			if in.Name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			return &party.Group{Name: in.Name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane", Table: 2}}}, nil
+		},
+		GetGuestRequirementsFunc: func(ctx context.Context, name string) (*party.GuestRequirements, error) {
+			if name != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.GuestRequirements{Guest: name, People: []party.Requirements{{
+				Dietary:       []string{party.DietaryVegan},
+				Allergies:     []string{},
+				Accessibility: []string{},
+				Custom:        []party.CustomValue{},
+			}}}, nil
+		},
+		SetGuestRequirementsFunc: func(ctx context.Context, in *party.SetGuestRequirementsInput) (*party.GuestRequirements, error) {
+			if len(in.People) > 1 {
+				return nil, party.ErrCompanionNumberInvalid
+			}
+			return &party.GuestRequirements{Guest: in.Guest, People: in.People}, nil
+		},
+		ListCustomFieldsFunc: func(ctx context.Context) (party.ListCustomFieldsOutput, error) {
+			return party.ListCustomFieldsOutput{Fields: []party.CustomField{{Name: "shuttle", Type: party.CustomFieldBoolean}}}, nil
+		},
+		SetCustomFieldsFunc: func(ctx context.Context, in *party.SetCustomFieldsInput) (party.ListCustomFieldsOutput, error) {
+			for _, field := range in.Fields {
+				if field.Type == "" {
+					return party.ListCustomFieldsOutput{}, party.ErrCustomFieldInvalid
+				}
+			}
+			return party.ListCustomFieldsOutput{Fields: in.Fields}, nil
+		},
+		GetCateringSummaryFunc: func(ctx context.Context) (*party.CateringSummary, error) {
+			return &party.CateringSummary{
+				Total: party.CateringTable{
+					People:        3,
+					Dietary:       []party.CateringCount{{Value: party.DietaryVegan, Count: 1}},
+					Allergies:     []party.CateringCount{},
+					Accessibility: []party.CateringCount{},
+					Custom:        []party.CateringCount{},
+				},
+				Tables: []party.CateringTable{},
+			}, nil
+		},
+		ListTablesFunc: func(ctx context.Context) (party.ListTablesOutput, error) {
+			return party.ListTablesOutput{
+				Tables: []party.Table{{
+					Number:       1,
+					Size:         3,
+					BookedSeats:  2,
+					ArrivedSeats: 2,
+					EmptySeats:   1,
+					Seats:        []party.Seat{{Number: 1, Guest: "John"}, {Number: 2, Guest: "John", Companion: 1}, {Number: 3}},
+				}},
+			}, nil
+		},
+		CreateTableFunc: func(ctx context.Context, in *party.CreateTableInput) (*party.Table, error) {
+			if in.Size <= 0 {
+				return nil, party.ErrTableSizeInvalid
+			}
+			return &party.Table{Number: in.Number, Size: in.Size, EmptySeats: in.Size, Seats: []party.Seat{{Number: 1}}}, nil
+		},
+		AssignSeatsFunc: func(ctx context.Context, in *party.AssignSeatsInput) (*party.Table, error) {
+			if in.Name != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			if len(in.Seats) != 2 {
+				return nil, party.ErrSeatsCountInvalid
+			}
+			return &party.Table{
+				Number: 1,
+				Size:   2,
+				Seats:  []party.Seat{{Number: in.Seats[0], Guest: "John"}, {Number: in.Seats[1], Guest: "John", Companion: 1}},
+			}, nil
+		},
+		ReserveTableFunc: func(ctx context.Context, in *party.ReserveTableInput) (*party.Table, error) {
+			if in.Number != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			if in.HeldSeats < 0 {
+				return nil, party.ErrHeldSeatsInvalid
+			}
+			return &party.Table{Number: in.Number, Size: 4, EmptySeats: 4, Tiers: in.Tiers, HeldSeats: in.HeldSeats, Seats: []party.Seat{{Number: 1}}}, nil
+		},
+		ReleaseTableFunc: func(ctx context.Context, number int) (*party.Table, error) {
+			if number != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			return &party.Table{Number: number, Size: 4, EmptySeats: 4, Seats: []party.Seat{{Number: 1}}}, nil
+		},
+		SwapSeatsFunc: func(ctx context.Context, in *party.SwapSeatsInput) (*party.Table, error) {
+			if in.Table != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			return &party.Table{Number: in.Table, Size: 1, Seats: []party.Seat{{Number: 1, Guest: "John"}}}, nil
+		},
+		ListConstraintsFunc: func(ctx context.Context) (party.ListConstraintsOutput, error) {
+			return party.ListConstraintsOutput{
+				Constraints: []party.Constraint{{ID: "c1", Kind: party.ConstraintPinned, Guests: []string{"John"}, Table: 1}},
+			}, nil
+		},
+		CreateConstraintFunc: func(ctx context.Context, in *party.CreateConstraintInput) (*party.Constraint, error) {
+			if in.Kind != party.ConstraintApart {
+				return nil, party.ErrConstraintKindInvalid
+			}
+			return &party.Constraint{ID: "c2", Kind: in.Kind, Guests: in.Guests}, nil
+		},
+		DeleteConstraintFunc: func(ctx context.Context, id string) error {
+			if id != "c1" {
+				return party.ErrConstraintNotFound
+			}
+			return nil
+		},
+		PlanSeatingFunc: func(ctx context.Context) (*party.SeatingPlan, error) {
+			return &party.SeatingPlan{
+				Assignments: []party.SeatingAssignment{{Guest: "John", Table: 2, CurrentTable: 1}},
+				Violations:  []party.SeatingViolation{{Constraint: "c1", Reason: "conflicts with the table sizes or the constraints before it"}},
+			}, nil
+		},
+		CheckSeatingFunc: func(ctx context.Context, in *party.CheckSeatingInput) (*party.SeatingPlan, error) {
+			out := party.SeatingPlan{Assignments: []party.SeatingAssignment{{Guest: "John", Table: 1, CurrentTable: 1}}}
+			for _, assignment := range in.Assignments {
+				out.Assignments = []party.SeatingAssignment{{Guest: assignment.Guest, Table: assignment.Table, CurrentTable: 1}}
+				out.Violations = []party.SeatingViolation{{Constraint: "c1", Reason: `guest "John" does not sit at table 1`}}
+			}
+			return &out, nil
+		},
+		GetStatsFunc: func(ctx context.Context) (party.GetStatsOutput, error) {
+			return party.GetStatsOutput{Tables: 1, Seats: 10, BookedSeats: 3, ArrivedSeats: 3, EmptySeats: 7, GuestsBooked: 1, GuestsArrived: 1, GuestsPresent: 1}, nil
+		},
+		GetLayoutFunc: func(ctx context.Context) (party.GetLayoutOutput, error) {
+			return party.GetLayoutOutput{
+				Tables: []party.TableLayout{{Number: 1, Size: 10, X: 0, Y: 0, Shape: party.TableShapeRound, Width: 100, Height: 100}},
+			}, nil
+		},
+		SetLayoutFunc: func(ctx context.Context, in *party.SetLayoutInput) (*party.GetLayoutOutput, error) {
+			for _, table := range in.Tables {
+				if table.Shape != party.TableShapeRectangle {
+					return nil, party.ErrTableShapeInvalid
+				}
+			}
+			return &party.GetLayoutOutput{Tables: in.Tables}, nil
+		},
+	}
+
+	controller := New(zap.NewNop(), &service)
+
+	fiberApp := fiber.New()
+	fiberApp.Get("/v2/guests", controller.ListGuests)
+	fiberApp.Post("/v2/guests", controller.CreateGuest)
+	fiberApp.Put("/v2/guests/:id/arrival", controller.RecordArrival)
+	fiberApp.Delete("/v2/guests/:id/arrival", controller.RecordDeparture)
+	fiberApp.Get("/v2/groups", controller.ListGroups)
+	fiberApp.Post("/v2/groups", controller.CreateGroup)
+	fiberApp.Get("/v2/groups/:id", controller.GetGroup)
+	fiberApp.Put("/v2/groups/:id/arrival", controller.RecordGroupArrival)
+	fiberApp.Delete("/v2/groups/:id/arrival", controller.RecordGroupDeparture)
+	fiberApp.Get("/v2/tables", controller.ListTables)
+	fiberApp.Post("/v2/tables", controller.CreateTable)
+	fiberApp.Put("/v2/guests/:id/seats", controller.AssignSeats)
+	fiberApp.Get("/v2/guests/:id/requirements", controller.GetGuestRequirements)
+	fiberApp.Put("/v2/guests/:id/requirements", controller.SetGuestRequirements)
+	fiberApp.Get("/v2/custom_fields", controller.ListCustomFields)
+	fiberApp.Put("/v2/custom_fields", controller.SetCustomFields)
+	fiberApp.Get("/v2/catering", controller.GetCateringSummary)
+	fiberApp.Put("/v2/tables/:number/reservation", controller.ReserveTable)
+	fiberApp.Delete("/v2/tables/:number/reservation", controller.ReleaseTable)
+	fiberApp.Post("/v2/tables/:number/swap_seats", controller.SwapSeats)
+	fiberApp.Get("/v2/constraints", controller.ListConstraints)
+	fiberApp.Post("/v2/constraints", controller.CreateConstraint)
+	fiberApp.Delete("/v2/constraints/:id", controller.DeleteConstraint)
+	fiberApp.Get("/v2/seating_plan", controller.PlanSeating)
+	fiberApp.Post("/v2/seating_plan/check", controller.CheckSeating)
+	fiberApp.Get("/v2/stats", controller.GetStats)
+	fiberApp.Get("/v2/layout", controller.GetLayout)
+	fiberApp.Put("/v2/layout", controller.SetLayout)
+
+	cases := []struct {
+		name               string
+		givenMethod        string
+		givenPath          string
+		givenBody          string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "lists guests",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/guests?table=1",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"guests":[{"id":"John","name":"John","table":1,"accompanying_guests":2,"arrived_at":"2021-11-23T20:00:00Z"}],"next_cursor":"next"}`,
+		},
+		{
+			name:               "rejects an invalid guest query",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/guests?table=one",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid table query parameter: one"}`,
+		},
+		{
+			name:               "rejects an unknown dietary requirement filter",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/guests?dietary=keto",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrDietaryRequirementInvalid.Error() + `"}`,
+		},
+		{
+			name:               "creates a guest",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/guests",
+			givenBody:          `{"name": "John", "table": 1, "accompanying_guests": 2}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"id":"John","name":"John","table":1,"accompanying_guests":2}`,
+		},
+		{
+			name:               "creates a guest of a tier",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/guests",
+			givenBody:          `{"name": "John", "table": 1, "accompanying_guests": 2, "tier": "vip"}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"id":"John","name":"John","table":1,"accompanying_guests":2,"tier":"vip"}`,
+		},
+		{
+			name:               "rejects a guest at a table restricted to other tiers",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/guests",
+			givenBody:          `{"name": "John", "table": 1, "tier": "staff"}`,
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"error":"` + party.ErrTableTierRestricted.Error() + `"}`,
+		},
+		{
+			name:               "rejects a guest without name",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/guests",
+			givenBody:          `{"table": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrGuestNameRequired.Error() + `"}`,
+		},
+		{
+			name:               "records an arrival",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/guests/John/arrival",
+			givenBody:          `{"accompanying_guests": 1}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"guest_id":"John","accompanying_guests":1}`,
+		},
+		{
+			name:               "returns not found for the arrival of an unknown guest",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/guests/Jane/arrival",
+			givenBody:          `{"accompanying_guests": 1}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrGuestNotInList.Error() + `"}`,
+		},
+		{
+			name:               "records a departure",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/guests/John/arrival",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "lists groups",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/groups",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"groups":[{"name":"Doe","tables":[2],"guests":[{"name":"Jane","table":2,"accompanying_guests":0}]}]}`,
+		},
+		{
+			name:               "creates a group",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/groups",
+			givenBody:          `{"name": "Smith", "guests": [{"name": "Amy"}]}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"name":"Smith","tables":[2],"guests":[{"name":"Amy","table":2,"accompanying_guests":0}]}`,
+		},
+		{
+			name:               "rejects a group without guests",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/groups",
+			givenBody:          `{"name": "Smith"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrGroupGuestsRequired.Error() + `"}`,
+		},
+		{
+			name:               "gets a group",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/groups/Doe",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"name":"Doe","tables":[2],"guests":[{"name":"Jane","table":2,"accompanying_guests":0}]}`,
+		},
+		{
+			name:               "returns not found for an unknown group",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/groups/Smith",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrGroupNotFound.Error() + `"}`,
+		},
+		{
+			name:               "records a group arrival",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/groups/Doe/arrival",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"name":"Doe","tables":[2],"guests":[{"name":"Jane","table":2,"accompanying_guests":0,"time_arrived":"2021-11-23T20:00:00Z"}]}`,
+		},
+		{
+			name:               "records a group departure",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/groups/Doe/arrival",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "returns not found for the departure of an unknown group",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/groups/Smith/arrival",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrGroupNotFound.Error() + `"}`,
+		},
+		{
+			name:               "gets the requirements of a guest",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/guests/John/requirements",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"guest":"John","people":[{"companion":0,"dietary":["vegan"],"allergies":[],"accessibility":[],"custom":[]}]}`,
+		},
+		{
+			name:               "returns not found for the requirements of an unknown guest",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/guests/Jane/requirements",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrGuestNotInList.Error() + `"}`,
+		},
+		{
+			name:               "sets the requirements of a guest",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/guests/John/requirements",
+			givenBody:          `{"people": [{"companion": 0, "allergies": ["nuts"], "custom": [{"field": "shuttle", "value": "true"}]}]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"guest":"John","people":[{"companion":0,"dietary":null,"allergies":["nuts"],"accessibility":null,"custom":[{"field":"shuttle","value":"true"}]}]}`,
+		},
+		{
+			name:               "rejects the requirements of too many accompanying guests",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/guests/John/requirements",
+			givenBody:          `{"people": [{"companion": 0}, {"companion": 1}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrCompanionNumberInvalid.Error() + `"}`,
+		},
+		{
+			name:               "lists custom fields",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/custom_fields",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"fields":[{"name":"shuttle","type":"boolean","required":false}]}`,
+		},
+		{
+			name:               "sets custom fields",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/custom_fields",
+			givenBody:          `{"fields": [{"name": "meal", "type": "choice", "options": ["fish", "meat"], "required": true}]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"fields":[{"name":"meal","type":"choice","options":["fish","meat"],"required":true}]}`,
+		},
+		{
+			name:               "rejects invalid custom fields",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/custom_fields",
+			givenBody:          `{"fields": [{"name": "meal"}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrCustomFieldInvalid.Error() + `"}`,
+		},
+		{
+			name:               "gets the catering summary",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/catering",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"total":{"people":3,"dietary":[{"value":"vegan","count":1}],"allergies":[],"accessibility":[],"custom":[]},"tables":[]}`,
+		},
+		{
+			name:               "lists tables",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/tables",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"tables":[{"number":1,"size":3,"booked_seats":2,"arrived_seats":2,"empty_seats":1,"seats":[{"number":1,"guest":"John"},{"number":2,"guest":"John","companion":1},{"number":3}]}]}`,
+		},
+		{
+			name:               "creates a table",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/tables",
+			givenBody:          `{"number": 2, "size": 4}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"number":2,"size":4,"booked_seats":0,"arrived_seats":0,"empty_seats":4,"seats":[{"number":1}]}`,
+		},
+		{
+			name:               "rejects a table without seats",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/tables",
+			givenBody:          `{"number": 2}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrTableSizeInvalid.Error() + `"}`,
+		},
+		{
+			name:               "assigns seats",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/guests/John/seats",
+			givenBody:          `{"seats": [2, 1]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"number":1,"size":2,"booked_seats":0,"arrived_seats":0,"empty_seats":0,"seats":[{"number":2,"guest":"John"},{"number":1,"guest":"John","companion":1}]}`,
+		},
+		{
+			name:               "rejects seats missing for the accompanying guests",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/guests/John/seats",
+			givenBody:          `{"seats": [2]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrSeatsCountInvalid.Error() + `"}`,
+		},
+		{
+			name:               "reserves a table",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/tables/1/reservation",
+			givenBody:          `{"tiers": ["vip", "staff"], "held_seats": 2}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"number":1,"size":4,"booked_seats":0,"arrived_seats":0,"empty_seats":4,"tiers":["vip","staff"],"held_seats":2,"seats":[{"number":1}]}`,
+		},
+		{
+			name:               "rejects negative held seats",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/tables/1/reservation",
+			givenBody:          `{"held_seats": -1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrHeldSeatsInvalid.Error() + `"}`,
+		},
+		{
+			name:               "returns not found for the reservation of an unknown table",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/tables/2/reservation",
+			givenBody:          `{"tiers": ["vip"]}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrTableNumberNotFound.Error() + `"}`,
+		},
+		{
+			name:               "releases a table",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/tables/1/reservation",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"number":1,"size":4,"booked_seats":0,"arrived_seats":0,"empty_seats":4,"seats":[{"number":1}]}`,
+		},
+		{
+			name:               "rejects an invalid table number to release",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/tables/one/reservation",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrTableNumberInvalid.Error() + `"}`,
+		},
+		{
+			name:               "swaps seats",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/tables/1/swap_seats",
+			givenBody:          `{"first": 1, "second": 3}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"number":1,"size":1,"booked_seats":0,"arrived_seats":0,"empty_seats":0,"seats":[{"number":1,"guest":"John"}]}`,
+		},
+		{
+			name:               "rejects an invalid table number",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/tables/one/swap_seats",
+			givenBody:          `{"first": 1, "second": 3}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrTableNumberInvalid.Error() + `"}`,
+		},
+		{
+			name:               "returns not found for the seats of an unknown table",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/tables/2/swap_seats",
+			givenBody:          `{"first": 1, "second": 2}`,
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrTableNumberNotFound.Error() + `"}`,
+		},
+		{
+			name:               "lists constraints",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/constraints",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"constraints":[{"id":"c1","kind":"pinned","guests":["John"],"table":1}]}`,
+		},
+		{
+			name:               "creates a constraint",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/constraints",
+			givenBody:          `{"kind": "apart", "guests": ["John", "Jane"]}`,
+			expectedStatusCode: http.StatusCreated,
+			expectedBody:       `{"id":"c2","kind":"apart","guests":["John","Jane"]}`,
+		},
+		{
+			name:               "rejects an unknown constraint kind",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/constraints",
+			givenBody:          `{"kind": "near", "guests": ["John", "Jane"]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrConstraintKindInvalid.Error() + `"}`,
+		},
+		{
+			name:               "deletes a constraint",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/constraints/c1",
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "returns not found for an unknown constraint",
+			givenMethod:        http.MethodDelete,
+			givenPath:          "/v2/constraints/c3",
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"error":"` + party.ErrConstraintNotFound.Error() + `"}`,
+		},
+		{
+			name:               "plans the seating",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/seating_plan",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"assignments":[{"guest":"John","table":2,"current_table":1}],` +
+				`"violations":[{"constraint":"c1","reason":"conflicts with the table sizes or the constraints before it"}]}`,
+		},
+		{
+			name:               "checks the seating of the guest list",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/seating_plan/check",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"assignments":[{"guest":"John","table":1,"current_table":1}]}`,
+		},
+		{
+			name:               "checks a seating plan",
+			givenMethod:        http.MethodPost,
+			givenPath:          "/v2/seating_plan/check",
+			givenBody:          `{"assignments": [{"guest": "John", "table": 2}]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"assignments":[{"guest":"John","table":2,"current_table":1}],` +
+				`"violations":[{"constraint":"c1","reason":"guest \"John\" does not sit at table 1"}]}`,
+		},
+		{
+			name:               "gets stats",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/stats",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"tables":1,"seats":10,"booked_seats":3,"arrived_seats":3,"empty_seats":7,"guests_booked":1,"guests_arrived":1,"guests_present":1,"guests_left":0}`,
+		},
+		{
+			name:               "gets the layout",
+			givenMethod:        http.MethodGet,
+			givenPath:          "/v2/layout",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"tables":[{"number":1,"size":10,"x":0,"y":0,"shape":"round","width":100,"height":100}]}`,
+		},
+		{
+			name:               "sets the layout",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/layout",
+			givenBody:          `{"tables": [{"number": 2, "x": 10, "y": 20, "shape": "rectangle", "width": 160, "height": 80}]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"tables":[{"number":2,"size":0,"x":10,"y":20,"shape":"rectangle","width":160,"height":80}]}`,
+		},
+		{
+			name:               "rejects an invalid table shape",
+			givenMethod:        http.MethodPut,
+			givenPath:          "/v2/layout",
+			givenBody:          `{"tables": [{"number": 2, "shape": "oval", "width": 160}]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"error":"` + party.ErrTableShapeInvalid.Error() + `"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.givenMethod, tc.givenPath, bytes.NewBufferString(tc.givenBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := fiberApp.Test(req, testReqTimeoutMs)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedBody == "" {
+				assert.Empty(t, body)
+				return
+			}
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestGetSeatingChart(t *testing.T) {
+	service := party.Mock{
+		GetSeatingChartFunc: func(ctx context.Context, in *party.GetSeatingChartInput) (*party.SeatingChart, error) {
+			if in.Highlight != "" && in.Highlight != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+
+			return &party.SeatingChart{
+				Highlight: in.Highlight,
+				Tables: []party.ChartTable{
+					{
+						TableLayout:  party.TableLayout{Number: 1, Size: 4, Shape: party.TableShapeRound, Width: 100, Height: 100},
+						ArrivedSeats: 1,
+						BookedSeats:  2,
+						Highlighted:  in.Highlight != "",
+					},
+					{
+						TableLayout: party.TableLayout{Number: 2, Size: 3, X: 200, Shape: party.TableShapeRectangle, Width: 160, Height: 80},
+					},
+				},
+			}, nil
+		},
+	}
+
+	fiberApp := fiber.New()
+	fiberApp.Get("/v2/layout/seating_chart", New(zap.NewNop(), &service).GetSeatingChart)
+
+	t.Run("draws the seats coloured by occupancy", func(t *testing.T) {
+		resp, err := fiberApp.Test(httptest.NewRequest(http.MethodGet, "/v2/layout/seating_chart", nil), testReqTimeoutMs)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, mimeImageSVG, resp.Header.Get(fiber.HeaderContentType))
+
+		svg := string(body)
+		assert.Equal(t, 1, strings.Count(svg, `class="seat arrived"`)-1, "one arrived seat besides the legend")
+		assert.Equal(t, 2, strings.Count(svg, `class="seat booked"`)-1, "two booked seats besides the legend")
+		assert.Equal(t, 4, strings.Count(svg, `class="seat free"`)-1, "four free seats besides the legend")
+		assert.Contains(t, svg, `<circle class="table" cx="90" cy="90" r="50"/>`)
+		assert.Contains(t, svg, `<rect class="table" x="240" y="40" width="160" height="80" rx="4"/>`)
+		assert.NotContains(t, svg, `class="table highlighted"`)
+	})
+
+	t.Run("highlights the table of the guest", func(t *testing.T) {
+		resp, err := fiberApp.Test(httptest.NewRequest(http.MethodGet, "/v2/layout/seating_chart?highlight=John", nil), testReqTimeoutMs)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `<circle class="table highlighted"`)
+		assert.Contains(t, string(body), "John sits at the highlighted table")
+	})
+
+	t.Run("returns not found for an unknown guest", func(t *testing.T) {
+		resp, err := fiberApp.Test(httptest.NewRequest(http.MethodGet, "/v2/layout/seating_chart?highlight=Jane", nil), testReqTimeoutMs)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,73 @@
+package partygql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/internal/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Enumerate the error codes set in the extensions of the errors
+const (
+	codeBadUserInput = "BAD_USER_INPUT"
+	codeNotFound     = "NOT_FOUND"
+	codeConflict     = "CONFLICT"
+	codeInternal     = "INTERNAL"
+)
+
+// errorCodes maps party service errors to error codes.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{party.ErrAccompanyingGuestsNumberInvalid, codeBadUserInput},
+	{party.ErrCursorInvalid, codeBadUserInput},
+	{party.ErrGuestNameRequired, codeBadUserInput},
+	{party.ErrLimitInvalid, codeBadUserInput},
+	{party.ErrSeatNumberInvalid, codeBadUserInput},
+	{party.ErrSeatsCountInvalid, codeBadUserInput},
+	{party.ErrSortInvalid, codeBadUserInput},
+	{party.ErrTableNumberInvalid, codeBadUserInput},
+	{party.ErrTableNumberRequired, codeBadUserInput},
+	{party.ErrGuestNotInList, codeNotFound},
+	{party.ErrTableNumberNotFound, codeNotFound},
+	{party.ErrGuestAlreadyInList, codeConflict},
+	{party.ErrGuestPresent, codeConflict},
+	{party.ErrGuestNotPresent, codeConflict},
+	{party.ErrTableNotEnoughSeats, codeConflict},
+	{party.ErrTableTierRestricted, codeConflict},
+	{party.ErrSeatTaken, codeConflict},
+}
+
+// resolverError is returned by the resolvers, its code is set in the extensions of the response error.
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// error logs a party service error and returns the resolver error matching it.
+// Unknown errors are reported as internal errors without leaking details.
+func (h *Handler) error(ctx context.Context, msg string, err error) error {
+	h.log(ctx).Error(msg, zap.Error(err))
+
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return &resolverError{message: e.err.Error(), code: e.code}
+		}
+	}
+
+	// Only unexpected errors fail the span, domain errors are client errors
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+	return &resolverError{message: "internal error", code: codeInternal}
+}
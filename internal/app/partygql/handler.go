@@ -0,0 +1,108 @@
+package partygql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/gofiber/fiber/v2"
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"go.uber.org/zap"
+)
+
+// maxDepth bounds the nesting of the queries, deeper than any query of the schema.
+const maxDepth = 8
+
+//go:embed schema.graphql
+var schema string
+
+// Handler serves the party queries and mutations over GraphQL.
+type Handler struct {
+	logger  *zap.Logger
+	service party.Service
+	schema  *graphql.Schema
+}
+
+// Request defines a GraphQL request posted as JSON.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// New creates the GraphQL handler of the party service.
+func New(logger *zap.Logger, service party.Service) (*Handler, error) {
+	h := Handler{
+		logger:  logger.Named("party_graphql"),
+		service: service,
+	}
+
+	s, err := graphql.ParseSchema(schema, &resolver{h: &h},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		graphql.Tracer(otelTracer{}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse graphql schema: %w", err)
+	}
+
+	h.schema = s
+	return &h, nil
+}
+
+// Serve executes the request and answers with its data and errors.
+// Requests that can not be parsed are answered with 400 Bad Request, and executed requests with 200 OK.
+func (h *Handler) Serve(c *fiber.Ctx) error {
+	var req Request
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		h.log(c.UserContext()).Error("could not parse request body", zap.Error(err))
+		return respond(c, http.StatusBadRequest, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("could not parse request body: %s", err)},
+		})
+	}
+
+	if req.Query == "" {
+		return respond(c, http.StatusBadRequest, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("query is required")},
+		})
+	}
+
+	resp := h.schema.Exec(c.UserContext(), req.Query, req.OperationName, req.Variables)
+	return respond(c, http.StatusOK, resp)
+}
+
+// respond encodes the response with encoding/json, as its error extensions are maps.
+func respond(c *fiber.Ctx, status int, resp *graphql.Response) error {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("could not encode graphql response: %w", err)
+	}
+
+	c.Status(status)
+	c.Type("json")
+	return c.Send(body)
+}
+
+func (h *Handler) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, h.logger)
+}
+
+// findGuest returns the guest of the guest list with the given name, or nil when not in the list.
+func (h *Handler) findGuest(ctx context.Context, name string) (*party.Guest, error) {
+	out, err := h.service.GetGuestList(ctx, &party.ListGuestsInput{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, guest := range out.Guests {
+		if guest.Name == name {
+			return &guest, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,223 @@
+package partygql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testReqTimeoutMs = 2000
+
+func TestHandler(t *testing.T) {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+	departed := arrived.Add(time.Hour)
+
+	var guestListCalls int32
+
+	guests := []party.Guest{
+		{Name: "Jane", Table: 2, AccompanyingGuests: 1},
+		{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived},
+		{Name: "Johnny", Table: 1},
+		{Name: "Josh", Table: 1, TimeArrival: &arrived, TimeDeparture: &departed},
+	}
+
+	service := party.Mock{
+		GetGuestListFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
+			atomic.AddInt32(&guestListCalls, 1)
+
+			if in.Sort == party.SortByTable && in.Order == party.SortOrderDesc && in.Limit == 1 && in.Cursor == "after" {
+				return party.GetGuestListOutput{Guests: guests[:1], NextCursor: "next"}, nil
+			}
+
+			var out party.GetGuestListOutput
+			for _, guest := range guests {
+				if in.Table != 0 && guest.Table != in.Table {
+					continue
+				}
+				if len(guest.Name) < len(in.NamePrefix) || guest.Name[:len(in.NamePrefix)] != in.NamePrefix {
+					continue
+				}
+				out.Guests = append(out.Guests, guest)
+			}
+			return out, nil
+		},
+		ListTablesFunc: func(ctx context.Context) (party.ListTablesOutput, error) {
+			return party.ListTablesOutput{Tables: []party.Table{
+				{Number: 1, Size: 10, BookedSeats: 4, ArrivedSeats: 3, EmptySeats: 7},
+				{Number: 2, Size: 10, BookedSeats: 2, EmptySeats: 10},
+			}}, nil
+		},
+		GetStatsFunc: func(ctx context.Context) (party.GetStatsOutput, error) {
+			return party.GetStatsOutput{Tables: 2, Seats: 20, BookedSeats: 6, ArrivedSeats: 3, EmptySeats: 17, GuestsBooked: 3, GuestsArrived: 1, GuestsPresent: 1}, nil
+		},
+		AddGuestToGuestListFunc: func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+			if in.Name == "John" {
+				return nil, party.ErrGuestAlreadyInList
+			}
+			return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+		},
+		WelcomeGuestFunc: func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			if in.Name == "Jack" {
+				return nil, party.ErrSeatTaken
+			}
+			if in.Name != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		},
+		GoodbyeGuestFunc: func(ctx context.Context, in *party.GoodbyeGuestInput) error {
+			return errors.New("connection refused")
+		},
+	}
+
+	h, err := New(zap.NewNop(), &service)
+	require.NoError(t, err)
+
+	fiberApp := fiber.New()
+	fiberApp.Post("/graphql", h.Serve)
+
+	cases := []struct {
+		name                   string
+		givenBody              string
+		expectedStatusCode     int
+		expectedBody           string
+		expectedGuestListCalls int32
+	}{
+		{
+			name:                   "queries tables with their guests",
+			givenBody:              `{"query": "{ tables { number emptySeats guests { name arrived } } }"}`,
+			expectedStatusCode:     http.StatusOK,
+			expectedBody:           `{"data":{"tables":[{"number":1,"emptySeats":7,"guests":[{"name":"John","arrived":true},{"name":"Johnny","arrived":false},{"name":"Josh","arrived":true}]},{"number":2,"emptySeats":10,"guests":[{"name":"Jane","arrived":false}]}]}}`,
+			expectedGuestListCalls: 1,
+		},
+		{
+			name:                   "filters the guests of a table",
+			givenBody:              `{"query": "query($number: Int!) { table(number: $number) { number guests(arrived: true) { name arrivedAt } } }", "variables": {"number": 1}}`,
+			expectedStatusCode:     http.StatusOK,
+			expectedBody:           `{"data":{"table":{"number":1,"guests":[{"name":"John","arrivedAt":"2021-11-23T20:00:00Z"}]}}}`,
+			expectedGuestListCalls: 1,
+		},
+		{
+			name:               "returns null for an unknown table",
+			givenBody:          `{"query": "{ table(number: 3) { number } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"table":null}}`,
+		},
+		{
+			name:                   "queries a page of guests",
+			givenBody:              `{"query": "{ guests(sort: TABLE, order: DESC, first: 1, after: \"after\") { guests { name table accompanyingGuests } nextCursor } }"}`,
+			expectedStatusCode:     http.StatusOK,
+			expectedBody:           `{"data":{"guests":{"guests":[{"name":"Jane","table":2,"accompanyingGuests":1}],"nextCursor":"next"}}}`,
+			expectedGuestListCalls: 1,
+		},
+		{
+			name:               "rejects an empty page",
+			givenBody:          `{"query": "{ guests(first: 0) { nextCursor } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"` + party.ErrLimitInvalid.Error() + `","path":["guests"],"extensions":{"code":"BAD_USER_INPUT"}}],"data":null}`,
+		},
+		{
+			name:                   "queries a guest by name",
+			givenBody:              `{"query": "{ guest(name: \"John\") { name table } }"}`,
+			expectedStatusCode:     http.StatusOK,
+			expectedBody:           `{"data":{"guest":{"name":"John","table":1}}}`,
+			expectedGuestListCalls: 1,
+		},
+		{
+			name:                   "returns null for a guest not in the list",
+			givenBody:              `{"query": "{ guest(name: \"Jo\") { name } }"}`,
+			expectedStatusCode:     http.StatusOK,
+			expectedBody:           `{"data":{"guest":null}}`,
+			expectedGuestListCalls: 1,
+		},
+		{
+			name:               "queries the stats",
+			givenBody:          `{"query": "{ stats { tables seats emptySeats guestsPresent guestsLeft } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"stats":{"tables":2,"seats":20,"emptySeats":17,"guestsPresent":1,"guestsLeft":0}}}`,
+		},
+		{
+			name:               "adds a guest",
+			givenBody:          `{"query": "mutation { addGuest(name: \"Jim\", table: 3, accompanyingGuests: 1) { name table accompanyingGuests arrived } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"addGuest":{"name":"Jim","table":3,"accompanyingGuests":1,"arrived":false}}}`,
+		},
+		{
+			name:               "returns the code of a conflict",
+			givenBody:          `{"query": "mutation { addGuest(name: \"John\", table: 1) { name } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"` + party.ErrGuestAlreadyInList.Error() + `","path":["addGuest"],"extensions":{"code":"CONFLICT"}}],"data":null}`,
+		},
+		{
+			name:                   "welcomes a guest",
+			givenBody:              `{"query": "mutation { welcomeGuest(name: \"John\", accompanyingGuests: 2) { name arrived } }"}`,
+			expectedStatusCode:     http.StatusOK,
+			expectedBody:           `{"data":{"welcomeGuest":{"name":"John","arrived":true}}}`,
+			expectedGuestListCalls: 1,
+		},
+		{
+			name:               "returns the code of a guest not in the list",
+			givenBody:          `{"query": "mutation { welcomeGuest(name: \"Jim\") { name } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"` + party.ErrGuestNotInList.Error() + `","path":["welcomeGuest"],"extensions":{"code":"NOT_FOUND"}}],"data":null}`,
+		},
+		{
+			name:               "returns the code of a taken seat",
+			givenBody:          `{"query": "mutation { welcomeGuest(name: \"Jack\") { name } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"` + party.ErrSeatTaken.Error() + `","path":["welcomeGuest"],"extensions":{"code":"CONFLICT"}}],"data":null}`,
+		},
+		{
+			name:               "does not leak unexpected errors",
+			givenBody:          `{"query": "mutation { goodbyeGuest(name: \"John\") { name } }"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"errors":[{"message":"internal error","path":["goodbyeGuest"],"extensions":{"code":"INTERNAL"}}],"data":null}`,
+		},
+		{
+			name:               "rejects an invalid body",
+			givenBody:          `{"query": `,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "rejects a request without query",
+			givenBody:          `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"errors":[{"message":"query is required"}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&guestListCalls, 0)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(tc.givenBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := fiberApp.Test(req, testReqTimeoutMs)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, fiber.MIMEApplicationJSON, resp.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, tc.expectedGuestListCalls, atomic.LoadInt32(&guestListCalls))
+
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, string(body))
+			}
+		})
+	}
+}
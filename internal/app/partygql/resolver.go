@@ -0,0 +1,337 @@
+package partygql
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// resolver is the root resolver of the queries and mutations.
+type resolver struct {
+	h *Handler
+}
+
+type guestsArgs struct {
+	Table      *int32
+	Arrived    *bool
+	NamePrefix *string
+	Sort       string
+	Order      string
+	First      *int32
+	After      *string
+}
+
+func (r *resolver) Guests(ctx context.Context, args guestsArgs) (*guestConnectionResolver, error) {
+	in := party.ListGuestsInput{
+		Arrived: args.Arrived,
+		Sort:    strings.ToLower(args.Sort),
+		Order:   strings.ToLower(args.Order),
+	}
+
+	if args.Table != nil {
+		in.Table = int(*args.Table)
+	}
+
+	if args.NamePrefix != nil {
+		in.NamePrefix = *args.NamePrefix
+	}
+
+	if args.First != nil {
+		// Limit 0 would return every guest
+		if *args.First <= 0 {
+			return nil, r.h.error(ctx, "invalid first argument", party.ErrLimitInvalid)
+		}
+		in.Limit = int(*args.First)
+	}
+
+	if args.After != nil {
+		in.Cursor = *args.After
+	}
+
+	out, err := r.h.service.GetGuestList(ctx, &in)
+	if err != nil {
+		return nil, r.h.error(ctx, "could not get guest list", err)
+	}
+	return &guestConnectionResolver{out: out}, nil
+}
+
+func (r *resolver) Guest(ctx context.Context, args struct{ Name string }) (*guestResolver, error) {
+	guest, err := r.h.findGuest(ctx, args.Name)
+	if err != nil {
+		return nil, r.h.error(ctx, "could not get guest", err)
+	}
+
+	if guest == nil {
+		return nil, nil
+	}
+	return &guestResolver{guest: *guest}, nil
+}
+
+func (r *resolver) Tables(ctx context.Context) ([]*tableResolver, error) {
+	out, err := r.h.service.ListTables(ctx)
+	if err != nil {
+		return nil, r.h.error(ctx, "could not list tables", err)
+	}
+
+	// The guests of every table are loaded at once, and only when selected
+	loader := guestLoader{h: r.h}
+
+	tables := make([]*tableResolver, 0, len(out.Tables))
+	for _, table := range out.Tables {
+		tables = append(tables, &tableResolver{table: table, guests: &loader})
+	}
+	return tables, nil
+}
+
+func (r *resolver) Table(ctx context.Context, args struct{ Number int32 }) (*tableResolver, error) {
+	out, err := r.h.service.ListTables(ctx)
+	if err != nil {
+		return nil, r.h.error(ctx, "could not list tables", err)
+	}
+
+	for _, table := range out.Tables {
+		if table.Number == int(args.Number) {
+			return &tableResolver{
+				table:  table,
+				guests: &guestLoader{h: r.h, table: table.Number},
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *resolver) Stats(ctx context.Context) (*statsResolver, error) {
+	out, err := r.h.service.GetStats(ctx)
+	if err != nil {
+		return nil, r.h.error(ctx, "could not get stats", err)
+	}
+	return &statsResolver{out: out}, nil
+}
+
+type addGuestArgs struct {
+	Name               string
+	Table              int32
+	AccompanyingGuests int32
+}
+
+func (r *resolver) AddGuest(ctx context.Context, args addGuestArgs) (*guestResolver, error) {
+	in := party.AddGuestToGuestListInput{
+		Name:               args.Name,
+		Table:              int(args.Table),
+		AccompanyingGuests: int(args.AccompanyingGuests),
+	}
+
+	if _, err := r.h.service.AddGuestToGuestList(ctx, &in); err != nil {
+		return nil, r.h.error(ctx, "could not add guest to guest list", err)
+	}
+
+	return &guestResolver{guest: party.Guest{
+		Name:               in.Name,
+		Table:              in.Table,
+		AccompanyingGuests: in.AccompanyingGuests,
+	}}, nil
+}
+
+type welcomeGuestArgs struct {
+	Name               string
+	AccompanyingGuests int32
+}
+
+func (r *resolver) WelcomeGuest(ctx context.Context, args welcomeGuestArgs) (*guestResolver, error) {
+	in := party.WelcomeGuestInput{
+		Name:               args.Name,
+		AccompanyingGuests: int(args.AccompanyingGuests),
+	}
+
+	if _, err := r.h.service.WelcomeGuest(ctx, &in); err != nil {
+		return nil, r.h.error(ctx, "could not welcome guest", err)
+	}
+	return r.updatedGuest(ctx, args.Name)
+}
+
+func (r *resolver) GoodbyeGuest(ctx context.Context, args struct{ Name string }) (*guestResolver, error) {
+	if err := r.h.service.GoodbyeGuest(ctx, &party.GoodbyeGuestInput{Name: args.Name}); err != nil {
+		return nil, r.h.error(ctx, "could not goodbye guest", err)
+	}
+	return r.updatedGuest(ctx, args.Name)
+}
+
+// updatedGuest returns a guest after a mutation, so clients can update their state from the response.
+func (r *resolver) updatedGuest(ctx context.Context, name string) (*guestResolver, error) {
+	guest, err := r.h.findGuest(ctx, name)
+	if err != nil {
+		return nil, r.h.error(ctx, "could not get guest", err)
+	}
+
+	if guest == nil {
+		return nil, r.h.error(ctx, "could not get guest", party.ErrGuestNotInList)
+	}
+	return &guestResolver{guest: *guest}, nil
+}
+
+// guestLoader loads the guests of one or every table once per query,
+// as the tables of a query are resolved concurrently.
+type guestLoader struct {
+	h     *Handler
+	table int // Zero loads every table
+
+	once    sync.Once
+	byTable map[int][]party.Guest
+	err     error
+}
+
+func (l *guestLoader) load(ctx context.Context, table int) ([]party.Guest, error) {
+	l.once.Do(func() {
+		out, err := l.h.service.GetGuestList(ctx, &party.ListGuestsInput{
+			Table: l.table,
+			Sort:  party.SortByName,
+		})
+		if err != nil {
+			l.err = err
+			return
+		}
+
+		l.byTable = make(map[int][]party.Guest)
+		for _, guest := range out.Guests {
+			l.byTable[guest.Table] = append(l.byTable[guest.Table], guest)
+		}
+	})
+	return l.byTable[table], l.err
+}
+
+type guestConnectionResolver struct {
+	out party.GetGuestListOutput
+}
+
+func (r *guestConnectionResolver) Guests() []*guestResolver {
+	return guestResolvers(r.out.Guests)
+}
+
+func (r *guestConnectionResolver) NextCursor() *string {
+	if r.out.NextCursor == "" {
+		return nil
+	}
+	return &r.out.NextCursor
+}
+
+type guestResolver struct {
+	guest party.Guest
+}
+
+func guestResolvers(guests []party.Guest) []*guestResolver {
+	out := make([]*guestResolver, 0, len(guests))
+	for _, guest := range guests {
+		out = append(out, &guestResolver{guest: guest})
+	}
+	return out
+}
+
+func (r *guestResolver) Name() string {
+	return r.guest.Name
+}
+
+func (r *guestResolver) Table() int32 {
+	return int32(r.guest.Table)
+}
+
+func (r *guestResolver) AccompanyingGuests() int32 {
+	return int32(r.guest.AccompanyingGuests)
+}
+
+func (r *guestResolver) Arrived() bool {
+	return r.guest.TimeArrival != nil
+}
+
+func (r *guestResolver) ArrivedAt() *graphql.Time {
+	if r.guest.TimeArrival == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.guest.TimeArrival}
+}
+
+type tableResolver struct {
+	table  party.Table
+	guests *guestLoader
+}
+
+func (r *tableResolver) Number() int32 {
+	return int32(r.table.Number)
+}
+
+func (r *tableResolver) Size() int32 {
+	return int32(r.table.Size)
+}
+
+func (r *tableResolver) BookedSeats() int32 {
+	return int32(r.table.BookedSeats)
+}
+
+func (r *tableResolver) ArrivedSeats() int32 {
+	return int32(r.table.ArrivedSeats)
+}
+
+func (r *tableResolver) EmptySeats() int32 {
+	return int32(r.table.EmptySeats)
+}
+
+func (r *tableResolver) Guests(ctx context.Context, args struct{ Arrived *bool }) ([]*guestResolver, error) {
+	guests, err := r.guests.load(ctx, r.table.Number)
+	if err != nil {
+		return nil, r.guests.h.error(ctx, "could not get guest list", err)
+	}
+
+	if args.Arrived == nil {
+		return guestResolvers(guests), nil
+	}
+
+	filtered := make([]party.Guest, 0, len(guests))
+	for _, guest := range guests {
+		if *args.Arrived && guest.Present() || !*args.Arrived && guest.TimeArrival == nil {
+			filtered = append(filtered, guest)
+		}
+	}
+	return guestResolvers(filtered), nil
+}
+
+type statsResolver struct {
+	out party.GetStatsOutput
+}
+
+func (r *statsResolver) Tables() int32 {
+	return int32(r.out.Tables)
+}
+
+func (r *statsResolver) Seats() int32 {
+	return int32(r.out.Seats)
+}
+
+func (r *statsResolver) BookedSeats() int32 {
+	return int32(r.out.BookedSeats)
+}
+
+func (r *statsResolver) ArrivedSeats() int32 {
+	return int32(r.out.ArrivedSeats)
+}
+
+func (r *statsResolver) EmptySeats() int32 {
+	return int32(r.out.EmptySeats)
+}
+
+func (r *statsResolver) GuestsBooked() int32 {
+	return int32(r.out.GuestsBooked)
+}
+
+func (r *statsResolver) GuestsArrived() int32 {
+	return int32(r.out.GuestsArrived)
+}
+
+func (r *statsResolver) GuestsPresent() int32 {
+	return int32(r.out.GuestsPresent)
+}
+
+func (r *statsResolver) GuestsLeft() int32 {
+	return int32(r.out.GuestsLeft)
+}
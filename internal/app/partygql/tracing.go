@@ -0,0 +1,56 @@
+package partygql
+
+import (
+	"context"
+
+	"github.com/alesr/getground/internal/pkg/tracing"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	gqltrace "github.com/graph-gophers/graphql-go/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/alesr/getground/internal/app/partygql")
+
+// otelTracer traces the queries and their non-trivial fields with OpenTelemetry,
+// so the service calls of the resolvers are children of their field span.
+type otelTracer struct{}
+
+var _ gqltrace.Tracer = otelTracer{}
+
+func (otelTracer) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, varTypes map[string]*introspection.Type) (context.Context, gqltrace.TraceQueryFinishFunc) {
+	name := "partygql.Query"
+	if operationName != "" {
+		name += " " + operationName
+	}
+
+	ctx, span := tracer.Start(ctx, name)
+	span.SetAttributes(attribute.String("graphql.operation.name", operationName))
+
+	return ctx, func(errs []*gqlerrors.QueryError) {
+		// Resolver errors are recorded by the resolvers, only the unexpected ones fail the span
+		for _, err := range errs {
+			if err.ResolverError == nil {
+				span.AddEvent("graphql error", trace.WithAttributes(attribute.String("message", err.Message)))
+			}
+		}
+		span.End()
+	}
+}
+
+func (otelTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, gqltrace.TraceFieldFinishFunc) {
+	if trivial {
+		return ctx, func(*gqlerrors.QueryError) {}
+	}
+
+	ctx, span := tracer.Start(ctx, "partygql."+typeName+"."+fieldName)
+
+	return ctx, func(err *gqlerrors.QueryError) {
+		if err != nil && err.ResolverError == nil {
+			tracing.RecordError(span, err)
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,60 @@
+package partygrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/internal/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps party service errors to gRPC status codes.
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{party.ErrAccompanyingGuestsNumberInvalid, codes.InvalidArgument},
+	{party.ErrCursorInvalid, codes.InvalidArgument},
+	{party.ErrGuestNameRequired, codes.InvalidArgument},
+	{party.ErrImportEmpty, codes.InvalidArgument},
+	{party.ErrLimitInvalid, codes.InvalidArgument},
+	{party.ErrMaxPartySizeInvalid, codes.InvalidArgument},
+	{party.ErrInvitationPartyTooLarge, codes.InvalidArgument},
+	{party.ErrSeatNumberInvalid, codes.InvalidArgument},
+	{party.ErrSeatsCountInvalid, codes.InvalidArgument},
+	{party.ErrSortInvalid, codes.InvalidArgument},
+	{party.ErrTableNumberInvalid, codes.InvalidArgument},
+	{party.ErrTableNumberRequired, codes.InvalidArgument},
+	{party.ErrInvitationTokenInvalid, codes.Unauthenticated},
+	{party.ErrCheckInCodeNotFound, codes.NotFound},
+	{party.ErrGuestNotInList, codes.NotFound},
+	{party.ErrInvitationNotFound, codes.NotFound},
+	{party.ErrTableNumberNotFound, codes.NotFound},
+	{party.ErrGuestAlreadyInList, codes.AlreadyExists},
+	{party.ErrCheckInCodeUsed, codes.FailedPrecondition},
+	{party.ErrInvitationAlreadyAnswered, codes.FailedPrecondition},
+	{party.ErrInvitationExpired, codes.FailedPrecondition},
+	{party.ErrCheckInCodeRevoked, codes.FailedPrecondition},
+	{party.ErrGuestPresent, codes.FailedPrecondition},
+	{party.ErrGuestNotPresent, codes.FailedPrecondition},
+	{party.ErrTableTierRestricted, codes.FailedPrecondition},
+	{party.ErrSeatTaken, codes.FailedPrecondition},
+	{party.ErrTableNotEnoughSeats, codes.ResourceExhausted},
+}
+
+// statusError returns the gRPC status matching a party service error.
+// Unknown errors are reported as internal errors without leaking details.
+func statusError(ctx context.Context, err error) error {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.err.Error())
+		}
+	}
+
+	// Only unexpected errors fail the span, domain errors are client errors
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,98 @@
+package partygrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataRequestID is the metadata key of the request ID, the gRPC counterpart of X-Request-ID
+const metadataRequestID = "x-request-id"
+
+func unaryInterceptors(logger *zap.Logger) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		otelgrpc.UnaryServerInterceptor(),
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			start := time.Now()
+
+			ctx, reqLogger := requestContext(ctx, logger)
+			resp, err := handler(ctx, req)
+
+			logCall(reqLogger, info.FullMethod, start, err)
+			return resp, err
+		},
+	}
+}
+
+func streamInterceptors(logger *zap.Logger) []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		otelgrpc.StreamServerInterceptor(),
+		func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+
+			ctx, reqLogger := requestContext(ss.Context(), logger)
+			err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+
+			logCall(reqLogger, info.FullMethod, start, err)
+			return err
+		},
+	}
+}
+
+// requestContext sets the x-request-id of the call, reusing the one sent by the caller,
+// and returns a context carrying a logger annotated with it.
+func requestContext(ctx context.Context, logger *zap.Logger) (context.Context, *zap.Logger) {
+	var callerID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(metadataRequestID); len(ids) > 0 {
+			callerID = ids[0]
+		}
+	}
+
+	requestID := logging.RequestID(callerID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+
+	fields := []zap.Field{zap.String("request_id", requestID)}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+
+	reqLogger := logger.With(fields...)
+	return logging.WithLogger(ctx, reqLogger), reqLogger
+}
+
+// logCall writes the access log of a call, at error level for server errors.
+func logCall(logger *zap.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("code", code.String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		logger.Error("rpc handled", append(fields, zap.Error(err))...)
+	default:
+		logger.Info("rpc handled", fields...)
+	}
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
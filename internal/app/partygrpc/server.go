@@ -0,0 +1,230 @@
+package partygrpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/pkg/partypb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server serves the party service over gRPC.
+type Server struct {
+	partypb.UnimplementedPartyServiceServer
+
+	logger  *zap.Logger
+	service party.Service
+	events  *party.Broadcaster
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ partypb.PartyServiceServer = (*Server)(nil)
+
+// New creates the gRPC party server, streaming the events published by the broadcaster.
+func New(logger *zap.Logger, service party.Service, events *party.Broadcaster) *Server {
+	return &Server{
+		logger:  logger.Named("party_grpc"),
+		service: service,
+		events:  events,
+		done:    make(chan struct{}),
+	}
+}
+
+// NewGRPCServer creates a gRPC server serving the party server,
+// with request IDs, access logs and tracing on every call.
+func NewGRPCServer(s *Server, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors(s.logger)...),
+		grpc.ChainStreamInterceptor(streamInterceptors(s.logger)...),
+	}, opts...)
+
+	gs := grpc.NewServer(opts...)
+	partypb.RegisterPartyServiceServer(gs, s)
+	return gs
+}
+
+// Close ends the event streams, which would otherwise hold a graceful stop of the gRPC server.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *Server) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+func (s *Server) AddGuestToGuestList(ctx context.Context, req *partypb.AddGuestToGuestListRequest) (*partypb.AddGuestToGuestListResponse, error) {
+	resp, err := s.service.AddGuestToGuestList(ctx, &party.AddGuestToGuestListInput{
+		Name:               req.GetName(),
+		Table:              int(req.GetTable()),
+		AccompanyingGuests: int(req.GetAccompanyingGuests()),
+	})
+	if err != nil {
+		s.log(ctx).Error("could not add guest to guest list", zap.Error(err))
+		return nil, statusError(ctx, err)
+	}
+	return &partypb.AddGuestToGuestListResponse{Name: resp.Name}, nil
+}
+
+func (s *Server) GetGuestList(ctx context.Context, req *partypb.ListGuestsRequest) (*partypb.GetGuestListResponse, error) {
+	resp, err := s.service.GetGuestList(ctx, listGuestsInput(req))
+	if err != nil {
+		s.log(ctx).Error("could not get guest list", zap.Error(err))
+		return nil, statusError(ctx, err)
+	}
+
+	out := partypb.GetGuestListResponse{
+		Guests:     make([]*partypb.Guest, 0, len(resp.Guests)),
+		NextCursor: resp.NextCursor,
+	}
+
+	for _, guest := range resp.Guests {
+		out.Guests = append(out.Guests, &partypb.Guest{
+			Name:               guest.Name,
+			Table:              int32(guest.Table),
+			AccompanyingGuests: int32(guest.AccompanyingGuests),
+			TimeArrived:        timestamp(guest.TimeArrival),
+		})
+	}
+	return &out, nil
+}
+
+func (s *Server) WelcomeGuest(ctx context.Context, req *partypb.WelcomeGuestRequest) (*partypb.WelcomeGuestResponse, error) {
+	resp, err := s.service.WelcomeGuest(ctx, &party.WelcomeGuestInput{
+		Name:               req.GetName(),
+		AccompanyingGuests: int(req.GetAccompanyingGuests()),
+	})
+	if err != nil {
+		s.log(ctx).Error("could not welcome guest", zap.Error(err))
+		return nil, statusError(ctx, err)
+	}
+	return &partypb.WelcomeGuestResponse{Name: resp.Name}, nil
+}
+
+func (s *Server) GoodbyeGuest(ctx context.Context, req *partypb.GoodbyeGuestRequest) (*partypb.GoodbyeGuestResponse, error) {
+	if err := s.service.GoodbyeGuest(ctx, &party.GoodbyeGuestInput{Name: req.GetName()}); err != nil {
+		s.log(ctx).Error("could not goodbye guest", zap.Error(err))
+		return nil, statusError(ctx, err)
+	}
+	return &partypb.GoodbyeGuestResponse{}, nil
+}
+
+func (s *Server) ListArrivedGuests(ctx context.Context, req *partypb.ListGuestsRequest) (*partypb.ListArrivedGuestsResponse, error) {
+	resp, err := s.service.ListArrivedGuests(ctx, listGuestsInput(req))
+	if err != nil {
+		s.log(ctx).Error("could not list arrived guests", zap.Error(err))
+		return nil, statusError(ctx, err)
+	}
+
+	out := partypb.ListArrivedGuestsResponse{
+		Guests:     make([]*partypb.GuestArrived, 0, len(resp.Guests)),
+		NextCursor: resp.NextCursor,
+	}
+
+	for _, guest := range resp.Guests {
+		out.Guests = append(out.Guests, &partypb.GuestArrived{
+			Name:               guest.Name,
+			AccompanyingGuests: int32(guest.AccompanyingGuests),
+			TimeArrived:        timestamppb.New(guest.TimeArrival),
+		})
+	}
+	return &out, nil
+}
+
+func (s *Server) GetEmptySeats(ctx context.Context, req *partypb.GetEmptySeatsRequest) (*partypb.GetEmptySeatsResponse, error) {
+	resp, err := s.service.GetEmptySeats(ctx)
+	if err != nil {
+		s.log(ctx).Error("could not get empty seats", zap.Error(err))
+		return nil, statusError(ctx, err)
+	}
+	return &partypb.GetEmptySeatsResponse{EmptySeats: int32(resp.EmptySeats)}, nil
+}
+
+// WatchEvents streams the events published after the call until the client cancels or the server closes.
+// Events are dropped when the client does not keep up, see party.Broadcaster.
+func (s *Server) WatchEvents(req *partypb.WatchEventsRequest, stream partypb.PartyService_WatchEventsServer) error {
+	events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	// Sending the headers tells the client it is subscribed, no later event is missed
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	types := make(map[partypb.EventType]bool, len(req.GetTypes()))
+	for _, t := range req.GetTypes() {
+		types[t] = true
+	}
+
+	ctx := stream.Context()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return nil
+		case e := <-events:
+			out := eventToProto(e)
+			if len(types) > 0 && !types[out.Type] {
+				continue
+			}
+
+			if err := stream.Send(out); err != nil {
+				s.log(ctx).Warn("could not send event", zap.Error(err))
+				return err
+			}
+		}
+	}
+}
+
+func listGuestsInput(req *partypb.ListGuestsRequest) *party.ListGuestsInput {
+	in := party.ListGuestsInput{
+		Table:      int(req.GetTable()),
+		NamePrefix: req.GetNamePrefix(),
+		Sort:       req.GetSort(),
+		Order:      req.GetOrder(),
+		Cursor:     req.GetCursor(),
+		Limit:      int(req.GetLimit()),
+	}
+
+	if req.Arrived != nil {
+		arrived := req.GetArrived()
+		in.Arrived = &arrived
+	}
+	return &in
+}
+
+var eventTypes = map[string]partypb.EventType{
+	party.EventGuestAdded:   partypb.EventType_EVENT_TYPE_GUEST_ADDED,
+	party.EventGuestArrived: partypb.EventType_EVENT_TYPE_GUEST_ARRIVED,
+	party.EventGuestLeft:    partypb.EventType_EVENT_TYPE_GUEST_LEFT,
+	party.EventGuestRemoved: partypb.EventType_EVENT_TYPE_GUEST_REMOVED,
+	party.EventTableCreated: partypb.EventType_EVENT_TYPE_TABLE_CREATED,
+}
+
+func eventToProto(e party.Event) *partypb.Event {
+	return &partypb.Event{
+		Type:               eventTypes[e.Type],
+		Guest:              e.Guest,
+		Table:              int32(e.Table),
+		AccompanyingGuests: int32(e.AccompanyingGuests),
+		AvailableSeats:     int32(e.AvailableSeats),
+		Time:               timestamppb.New(e.Time),
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
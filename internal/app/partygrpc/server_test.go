@@ -0,0 +1,269 @@
+package partygrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/pkg/partypb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testNow = time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+// newTestClient serves the party server on an in-memory connection and returns a client for it.
+func newTestClient(t *testing.T, service party.Service, events *party.Broadcaster) (partypb.PartyServiceClient, *Server) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	srv := New(zap.NewNop(), service, events)
+	gs := NewGRPCServer(srv)
+
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Close()
+		gs.GracefulStop()
+	})
+	return partypb.NewPartyServiceClient(conn), srv
+}
+
+func TestServer(t *testing.T) {
+	errUnexpected := errors.New("connection refused")
+
+	service := party.Mock{
+		AddGuestToGuestListFunc: func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+			if in.Table == 0 {
+				return nil, party.ErrTableNumberRequired
+			}
+			return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+		},
+		GetGuestListFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
+			if in.Arrived == nil || !*in.Arrived || in.Table != 1 || in.Sort != party.SortByName || in.Limit != 1 {
+				return party.GetGuestListOutput{}, errUnexpected
+			}
+			return party.GetGuestListOutput{
+				Guests:     []party.Guest{{Name: "john", Table: 1, AccompanyingGuests: 2, TimeArrival: &testNow}},
+				NextCursor: "next",
+			}, nil
+		},
+		WelcomeGuestFunc: func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			if in.Name == "jim" {
+				return nil, party.ErrSeatTaken
+			}
+			if in.Name != "john" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		},
+		GoodbyeGuestFunc: func(ctx context.Context, in *party.GoodbyeGuestInput) error {
+			return errUnexpected
+		},
+		ListArrivedGuestsFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.ListArrivedGuestsOutput, error) {
+			return party.ListArrivedGuestsOutput{
+				Guests: []party.GuestArrived{{Name: "john", AccompanyingGuests: 2, TimeArrival: testNow}},
+			}, nil
+		},
+		GetEmptySeatsFunc: func(ctx context.Context) (party.GetEmptySeatsOutput, error) {
+			return party.GetEmptySeatsOutput{EmptySeats: 7}, nil
+		},
+	}
+
+	client, _ := newTestClient(t, &service, party.NewBroadcaster(1))
+
+	arrived := true
+
+	cases := []struct {
+		name             string
+		givenCall        func(ctx context.Context) (proto.Message, error)
+		expectedResponse proto.Message
+		expectedCode     codes.Code
+	}{
+		{
+			name: "adds a guest to the guest list",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.AddGuestToGuestList(ctx, &partypb.AddGuestToGuestListRequest{Name: "john", Table: 1, AccompanyingGuests: 2})
+			},
+			expectedResponse: &partypb.AddGuestToGuestListResponse{Name: "john"},
+		},
+		{
+			name: "maps invalid input to invalid argument",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.AddGuestToGuestList(ctx, &partypb.AddGuestToGuestListRequest{Name: "john"})
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "gets the guest list with the filters",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.GetGuestList(ctx, &partypb.ListGuestsRequest{Table: 1, Arrived: &arrived, Sort: party.SortByName, Limit: 1})
+			},
+			expectedResponse: &partypb.GetGuestListResponse{
+				Guests:     []*partypb.Guest{{Name: "john", Table: 1, AccompanyingGuests: 2, TimeArrived: timestamppb.New(testNow)}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name: "welcomes a guest",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.WelcomeGuest(ctx, &partypb.WelcomeGuestRequest{Name: "john", AccompanyingGuests: 1})
+			},
+			expectedResponse: &partypb.WelcomeGuestResponse{Name: "john"},
+		},
+		{
+			name: "maps unknown guests to not found",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.WelcomeGuest(ctx, &partypb.WelcomeGuestRequest{Name: "jane"})
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "maps taken seats to failed precondition",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.WelcomeGuest(ctx, &partypb.WelcomeGuestRequest{Name: "jim"})
+			},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name: "maps unexpected errors to internal",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.GoodbyeGuest(ctx, &partypb.GoodbyeGuestRequest{Name: "john"})
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "lists the arrived guests",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.ListArrivedGuests(ctx, &partypb.ListGuestsRequest{})
+			},
+			expectedResponse: &partypb.ListArrivedGuestsResponse{
+				Guests: []*partypb.GuestArrived{{Name: "john", AccompanyingGuests: 2, TimeArrived: timestamppb.New(testNow)}},
+			},
+		},
+		{
+			name: "gets the empty seats",
+			givenCall: func(ctx context.Context) (proto.Message, error) {
+				return client.GetEmptySeats(ctx, &partypb.GetEmptySeatsRequest{})
+			},
+			expectedResponse: &partypb.GetEmptySeatsResponse{EmptySeats: 7},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			observed, err := tc.givenCall(context.Background())
+
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tc.expectedResponse, observed), "expected %v, observed %v", tc.expectedResponse, observed)
+		})
+	}
+
+	t.Run("does not leak unexpected error details", func(t *testing.T) {
+		_, err := client.GoodbyeGuest(context.Background(), &partypb.GoodbyeGuestRequest{Name: "john"})
+		assert.NotContains(t, status.Convert(err).Message(), errUnexpected.Error())
+	})
+
+	t.Run("returns the request id", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), metadataRequestID, "abc-123")
+
+		var header metadata.MD
+		_, err := client.GetEmptySeats(ctx, &partypb.GetEmptySeatsRequest{}, grpc.Header(&header))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"abc-123"}, header.Get(metadataRequestID))
+	})
+}
+
+func TestStatusError(t *testing.T) {
+	for _, e := range errorCodes {
+		err := statusError(context.Background(), errors.New("wrapped: "+e.err.Error()))
+		assert.Equal(t, codes.Internal, status.Code(err), "unwrapped errors are not domain errors")
+
+		err = statusError(context.Background(), wrapError{e.err})
+		assert.Equal(t, e.code, status.Code(err))
+		assert.Equal(t, e.err.Error(), status.Convert(err).Message())
+	}
+}
+
+type wrapError struct{ err error }
+
+func (e wrapError) Error() string { return "wrapped: " + e.err.Error() }
+func (e wrapError) Unwrap() error { return e.err }
+
+func TestWatchEvents(t *testing.T) {
+	events := party.NewBroadcaster(8)
+	client, srv := newTestClient(t, &party.Mock{}, events)
+
+	t.Run("streams the events of the requested types", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := client.WatchEvents(ctx, &partypb.WatchEventsRequest{
+			Types: []partypb.EventType{partypb.EventType_EVENT_TYPE_GUEST_ARRIVED},
+		})
+		require.NoError(t, err)
+
+		// The headers are sent once subscribed
+		_, err = stream.Header()
+		require.NoError(t, err)
+
+		events.Listener()(party.Event{Type: party.EventGuestAdded, Guest: "jane", Time: testNow})
+		events.Listener()(party.Event{Type: party.EventGuestArrived, Guest: "john", Table: 1, AccompanyingGuests: 2, AvailableSeats: 7, Time: testNow})
+
+		observed, err := stream.Recv()
+		require.NoError(t, err)
+
+		assert.True(t, proto.Equal(&partypb.Event{
+			Type:               partypb.EventType_EVENT_TYPE_GUEST_ARRIVED,
+			Guest:              "john",
+			Table:              1,
+			AccompanyingGuests: 2,
+			AvailableSeats:     7,
+			Time:               timestamppb.New(testNow),
+		}, observed), "observed %v", observed)
+	})
+
+	t.Run("ends the streams when the server closes", func(t *testing.T) {
+		stream, err := client.WatchEvents(context.Background(), &partypb.WatchEventsRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Header()
+		require.NoError(t, err)
+
+		srv.Close()
+
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	fiber "github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	headerRequestID = "X-Request-ID"
+
+	// localsRole is the fiber locals key of the caller role
+	localsRole = "role"
+
+	roleGuest     = "guest"
+	roleOrganiser = "organiser"
+)
+
+// requestLogger sets the X-Request-ID of the request, reusing the one sent by the caller,
+// carries a logger annotated with it in the request user context and writes an access log once the request is handled.
+func requestLogger(logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		requestID := logging.RequestID(c.Get(headerRequestID))
+		c.Set(headerRequestID, requestID)
+
+		fields := []zap.Field{zap.String("request_id", requestID)}
+		if sc := trace.SpanContextFromContext(c.UserContext()); sc.HasTraceID() {
+			fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+		}
+
+		reqLogger := logger.With(fields...)
+		c.SetUserContext(logging.WithLogger(c.UserContext(), reqLogger))
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			status = fiberErr.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+
+		role, ok := c.Locals(localsRole).(string)
+		if !ok {
+			role = roleGuest
+		}
+
+		accessFields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("route", c.Route().Path),
+			zap.String("path", c.Path()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("role", role),
+			zap.String("ip", c.IP()),
+		}
+
+		if status >= fiber.StatusInternalServerError {
+			reqLogger.Error("request handled", append(accessFields, zap.Error(err))...)
+		} else {
+			reqLogger.Info("request handled", accessFields...)
+		}
+		return err
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestLogger(t *testing.T) {
+	newTestApp := func() (*fiber.App, *observer.ObservedLogs) {
+		core, logs := observer.New(zapcore.InfoLevel)
+
+		fiberApp := fiber.New()
+		fiberApp.Use(requestLogger(zap.New(core)))
+		fiberApp.Get("/guests/:name", func(c *fiber.Ctx) error {
+			logging.FromContext(c.UserContext(), zap.NewNop()).Info("handler log")
+			return c.SendStatus(http.StatusNoContent)
+		})
+		fiberApp.Get("/export", requireOrganiser("secret"), func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+		return fiberApp, logs
+	}
+
+	t.Run("generates a request ID carried by the handler logs and the access log", func(t *testing.T) {
+		fiberApp, logs := newTestApp()
+
+		resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodGet, "/guests/John", nil))
+		require.NoError(t, err)
+
+		requestID := resp.Header.Get(headerRequestID)
+		assert.Len(t, requestID, 32)
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, "handler log", entries[0].Message)
+		assert.Equal(t, requestID, entries[0].ContextMap()["request_id"])
+
+		access := entries[1].ContextMap()
+		assert.Equal(t, "request handled", entries[1].Message)
+		assert.Equal(t, requestID, access["request_id"])
+		assert.Equal(t, "GET", access["method"])
+		assert.Equal(t, "/guests/:name", access["route"])
+		assert.Equal(t, "/guests/John", access["path"])
+		assert.Equal(t, int64(http.StatusNoContent), access["status"])
+		assert.Equal(t, roleGuest, access["role"])
+		assert.Contains(t, access, "latency")
+	})
+
+	t.Run("reuses the caller request ID", func(t *testing.T) {
+		fiberApp, logs := newTestApp()
+
+		req := httptest.NewRequest(fiber.MethodGet, "/guests/John", nil)
+		req.Header.Set(headerRequestID, "abc-123")
+
+		resp, err := fiberApp.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "abc-123", resp.Header.Get(headerRequestID))
+		assert.Equal(t, 2, logs.FilterField(zap.String("request_id", "abc-123")).Len())
+	})
+
+	t.Run("replaces an invalid caller request ID", func(t *testing.T) {
+		fiberApp, _ := newTestApp()
+
+		req := httptest.NewRequest(fiber.MethodGet, "/guests/John", nil)
+		req.Header.Set(headerRequestID, "abc 123")
+
+		resp, err := fiberApp.Test(req)
+		require.NoError(t, err)
+
+		assert.Len(t, resp.Header.Get(headerRequestID), 32)
+	})
+
+	t.Run("logs the organiser role", func(t *testing.T) {
+		fiberApp, logs := newTestApp()
+
+		req := httptest.NewRequest(fiber.MethodGet, "/export", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+		resp, err := fiberApp.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		entries := logs.FilterMessage("request handled").AllUntimed()
+		require.Len(t, entries, 1)
+		assert.Equal(t, roleOrganiser, entries[0].ContextMap()["role"])
+	})
+}
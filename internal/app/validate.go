@@ -0,0 +1,104 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+func init() {
+	// Non JSON bodies are validated as plain strings
+	for _, contentType := range []string{"text/csv", "text/html", "text/css", "application/javascript", "image/png", "image/svg+xml"} {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+// loadOpenAPISpec parses and validates the embedded OpenAPI specification.
+func loadOpenAPISpec() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+
+	doc, err := loader.LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, fmt.Errorf("could not load openapi spec: %w", err)
+	}
+
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	return doc, nil
+}
+
+// requestValidator rejects requests whose parameters or body do not match the OpenAPI specification,
+// and requests to undocumented routes. Credentials are checked by requireOrganiser.
+func requestValidator() (fiber.Handler, error) {
+	doc, err := loadOpenAPISpec()
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("could not build openapi router: %w", err)
+	}
+
+	options := openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+	return func(c *fiber.Ctx) error {
+		var req http.Request
+		if err := fasthttpadaptor.ConvertRequest(c.Context(), &req, true); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(partyctrl.ErrorResponse{Error: err.Error()})
+		}
+
+		route, pathParams, err := findRoute(router, &req)
+		if errors.Is(err, routers.ErrMethodNotAllowed) {
+			return c.Status(http.StatusMethodNotAllowed).JSON(partyctrl.ErrorResponse{Error: http.StatusText(http.StatusMethodNotAllowed)})
+		}
+		if err != nil {
+			return c.Status(http.StatusNotFound).JSON(partyctrl.ErrorResponse{Error: http.StatusText(http.StatusNotFound)})
+		}
+
+		if err := openapi3filter.ValidateRequest(c.UserContext(), &openapi3filter.RequestValidationInput{
+			Request:    &req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &options,
+		}); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(partyctrl.ErrorResponse{Error: err.Error()})
+		}
+		return c.Next()
+	}, nil
+}
+
+// findRoute returns the documented route of a request.
+// The unversioned routes are not documented and are matched as the /v1 routes they alias,
+// and HEAD requests as the GET requests fiber answers them with.
+func findRoute(router routers.Router, req *http.Request) (*routers.Route, map[string]string, error) {
+	lookup := *req
+	if lookup.Method == http.MethodHead {
+		lookup.Method = http.MethodGet
+	}
+
+	route, pathParams, err := router.FindRoute(&lookup)
+	if err == nil || strings.HasPrefix(req.URL.Path, "/v1/") || strings.HasPrefix(req.URL.Path, "/v2/") {
+		return route, pathParams, err
+	}
+
+	aliasURL := *req.URL
+	aliasURL.Path = "/v1" + req.URL.Path
+	aliasURL.RawPath = ""
+	lookup.URL = &aliasURL
+
+	if route, pathParams, aliasErr := router.FindRoute(&lookup); aliasErr == nil {
+		return route, pathParams, nil
+	}
+	return nil, nil, err
+}
@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const maxRequestIDLength = 128
+
+// RequestID returns the request ID sent by the caller when it can be reused safely in headers and logs,
+// or a new random request ID.
+func RequestID(callerID string) string {
+	if validRequestID(callerID) {
+		return callerID
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
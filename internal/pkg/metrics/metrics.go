@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+const namespace = "getground"
+
+// Metrics holds the Prometheus collectors of the application.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	repoQueryDuration   *prometheus.HistogramVec
+
+	guestsOnList    prometheus.Gauge
+	guestsPresent   prometheus.Gauge
+	tableEmptySeats *prometheus.GaugeVec
+}
+
+// New creates the application collectors on their own registry,
+// along with the Go runtime and process collectors.
+func New() *Metrics {
+	m := Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		repoQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "query_duration_seconds",
+			Help:      "Latency of repository queries by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		guestsOnList: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "party",
+			Name:      "guests_on_list",
+			Help:      "Number of guests on the guest list.",
+		}),
+		guestsPresent: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "party",
+			Name:      "guests_present",
+			Help:      "Number of guests on the guest list currently at the party.",
+		}),
+		tableEmptySeats: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "party",
+			Name:      "table_empty_seats",
+			Help:      "Number of empty seats by table.",
+		}, []string{"table"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.repoQueryDuration,
+		m.guestsOnList,
+		m.guestsPresent,
+		m.tableEmptySeats,
+	)
+	return &m
+}
+
+// Registry returns the registry holding the application collectors.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() fiber.Handler {
+	handler := fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+
+	return func(c *fiber.Ctx) error {
+		handler(c.Context())
+		return nil
+	}
+}
+
+// Middleware records the count and latency of the HTTP requests.
+// Requests are labelled with the route pattern rather than the path to keep the cardinality bounded.
+func (m *Metrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			status = fiberErr.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+
+		labels := prometheus.Labels{
+			"method": c.Method(),
+			"route":  c.Route().Path,
+			"status": strconv.Itoa(status),
+		}
+
+		m.httpRequests.With(labels).Inc()
+		m.httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (m *Metrics) observeQuery(method string, start time.Time) {
+	m.repoQueryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	m := New()
+
+	app := fiber.New()
+	app.Use(m.Middleware())
+	app.Get("/guests/:name", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/metrics", m.Handler())
+
+	for _, name := range []string{"foo", "bar"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/guests/"+name, nil))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/guests/:name", "204")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.httpRequestDuration))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/metrics", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `getground_http_requests_total{method="GET",route="/guests/:name",status="204"} 2`)
+}
+
+func TestInstrumentRepository(t *testing.T) {
+	m := New()
+
+	var txRepo repository.Repository
+
+	mock := repository.Mock{}
+	mock.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+		return nil, nil
+	}
+	mock.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(&mock)
+	}
+
+	repo := m.InstrumentRepository(&mock)
+
+	_, err := repo.GetTables(context.TODO())
+	require.NoError(t, err)
+
+	err = repo.Transaction(context.TODO(), func(tx repository.Repository) error {
+		txRepo = tx
+		_, err := tx.GetTables(context.TODO())
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.IsType(t, &instrumentedRepository{}, txRepo)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.repoQueryDuration))
+	assert.Equal(t, uint64(2), sampleCount(t, m, "GetTables"))
+	assert.Equal(t, uint64(1), sampleCount(t, m, "Transaction"))
+}
+
+// sampleCount returns the number of observations of the repository method.
+func sampleCount(t *testing.T, m *Metrics, method string) uint64 {
+	t.Helper()
+
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "getground_repository_query_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "method" && label.GetValue() == method {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestSeed(t *testing.T) {
+	arrival := time.Date(2020, time.January, 1, 20, 0, 0, 0, time.UTC)
+	departure := arrival.Add(time.Hour)
+
+	t.Run("sets the party gauges from the repository", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return []repository.Guest{
+				{Name: "booked", Table: 1},
+				{Name: "arrived", Table: 1, TimeArrival: &arrival},
+				{Name: "left", Table: 2, TimeArrival: &arrival, TimeDeparture: &departure},
+			}, nil
+		}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return []repository.Table{{Number: 1, AvailableSeats: 3}, {Number: 2, AvailableSeats: 10}}, nil
+		}
+
+		m := New()
+		require.NoError(t, m.Seed(context.TODO(), &repo))
+
+		assert.Equal(t, float64(3), testutil.ToFloat64(m.guestsOnList))
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.guestsPresent))
+		assert.Equal(t, float64(3), testutil.ToFloat64(m.tableEmptySeats.WithLabelValues("1")))
+		assert.Equal(t, float64(10), testutil.ToFloat64(m.tableEmptySeats.WithLabelValues("2")))
+	})
+
+	t.Run("returns an error when the repository fails", func(t *testing.T) {
+		errRepo := errors.New("repo error")
+
+		repo := repository.Mock{}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return nil, errRepo
+		}
+
+		m := New()
+		assert.True(t, errors.Is(m.Seed(context.TODO(), &repo), errRepo))
+	})
+}
+
+func TestPartyListener(t *testing.T) {
+	m := New()
+	listener := m.PartyListener()
+
+	listener(party.Event{Type: party.EventGuestAdded, Table: 1, AvailableSeats: 8})
+	listener(party.Event{Type: party.EventGuestAdded, Table: 2, AvailableSeats: 10})
+	listener(party.Event{Type: party.EventGuestArrived, Table: 1, AvailableSeats: 7})
+	listener(party.Event{Type: party.EventGuestArrived, Table: 2, AvailableSeats: 9})
+	listener(party.Event{Type: party.EventGuestLeft, Table: 2, AvailableSeats: 11})
+	listener(party.Event{Type: party.EventGuestRemoved, Table: 2, AvailableSeats: 12})
+	listener(party.Event{Type: party.EventTableCreated, Table: 3, AvailableSeats: 4})
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.guestsOnList))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.guestsPresent))
+	assert.Equal(t, float64(7), testutil.ToFloat64(m.tableEmptySeats.WithLabelValues("1")))
+	assert.Equal(t, float64(12), testutil.ToFloat64(m.tableEmptySeats.WithLabelValues("2")))
+	assert.Equal(t, float64(4), testutil.ToFloat64(m.tableEmptySeats.WithLabelValues("3")))
+}
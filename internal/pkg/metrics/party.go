@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/alesr/getground/internal/pkg/party"
+	"github.com/alesr/getground/internal/pkg/party/repository"
+)
+
+// Seed sets the party gauges from the current state of the repository.
+// It is meant to be called once at startup, the gauges are then kept up to date by PartyListener.
+func (m *Metrics) Seed(ctx context.Context, repo repository.Repository) error {
+	guests, err := repo.ListGuests(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get guest list: %w", err)
+	}
+
+	tables, err := repo.GetTables(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get tables: %w", err)
+	}
+
+	var present int
+	for _, guest := range guests {
+		if party.IsPresent(guest) {
+			present++
+		}
+	}
+
+	m.guestsOnList.Set(float64(len(guests)))
+	m.guestsPresent.Set(float64(present))
+
+	m.tableEmptySeats.Reset()
+	for _, table := range tables {
+		m.tableEmptySeats.WithLabelValues(strconv.Itoa(table.Number)).Set(float64(table.AvailableSeats))
+	}
+	return nil
+}
+
+// PartyListener returns a party listener updating the party gauges.
+func (m *Metrics) PartyListener() party.Listener {
+	return func(e party.Event) {
+		switch e.Type {
+		case party.EventGuestAdded:
+			m.guestsOnList.Inc()
+		case party.EventGuestArrived:
+			m.guestsPresent.Inc()
+		case party.EventGuestLeft:
+			m.guestsPresent.Dec()
+		case party.EventGuestRemoved:
+			m.guestsOnList.Dec()
+		case party.EventTableCreated:
+		default:
+			return
+		}
+		m.tableEmptySeats.WithLabelValues(strconv.Itoa(e.Table)).Set(float64(e.AvailableSeats))
+	}
+}
@@ -0,0 +1,204 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+)
+
+var _ repository.Repository = (*instrumentedRepository)(nil)
+
+// instrumentedRepository records the latency of every repository query.
+type instrumentedRepository struct {
+	repo    repository.Repository
+	metrics *Metrics
+}
+
+// InstrumentRepository wraps the repository to record the latency of its queries.
+func (m *Metrics) InstrumentRepository(repo repository.Repository) repository.Repository {
+	return &instrumentedRepository{repo: repo, metrics: m}
+}
+
+func (r *instrumentedRepository) GetArrivedGuests(ctx context.Context) ([]repository.Guest, error) {
+	defer r.metrics.observeQuery("GetArrivedGuests", time.Now())
+	return r.repo.GetArrivedGuests(ctx)
+}
+
+func (r *instrumentedRepository) GetGuestByName(ctx context.Context, name string) (*repository.Guest, error) {
+	defer r.metrics.observeQuery("GetGuestByName", time.Now())
+	return r.repo.GetGuestByName(ctx, name)
+}
+
+func (r *instrumentedRepository) ListGuests(ctx context.Context) ([]repository.Guest, error) {
+	defer r.metrics.observeQuery("ListGuests", time.Now())
+	return r.repo.ListGuests(ctx)
+}
+
+func (r *instrumentedRepository) QueryGuests(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+	defer r.metrics.observeQuery("QueryGuests", time.Now())
+	return r.repo.QueryGuests(ctx, query)
+}
+
+func (r *instrumentedRepository) UpsertGuest(ctx context.Context, guest *repository.Guest) error {
+	defer r.metrics.observeQuery("UpsertGuest", time.Now())
+	return r.repo.UpsertGuest(ctx, guest)
+}
+
+func (r *instrumentedRepository) DeleteGuest(ctx context.Context, name string) error {
+	defer r.metrics.observeQuery("DeleteGuest", time.Now())
+	return r.repo.DeleteGuest(ctx, name)
+}
+
+func (r *instrumentedRepository) GetTableByNumber(ctx context.Context, number int) (*repository.Table, error) {
+	defer r.metrics.observeQuery("GetTableByNumber", time.Now())
+	return r.repo.GetTableByNumber(ctx, number)
+}
+
+func (r *instrumentedRepository) GetTables(ctx context.Context) ([]repository.Table, error) {
+	defer r.metrics.observeQuery("GetTables", time.Now())
+	return r.repo.GetTables(ctx)
+}
+
+func (r *instrumentedRepository) UpsertTable(ctx context.Context, table *repository.Table) error {
+	defer r.metrics.observeQuery("UpsertTable", time.Now())
+	return r.repo.UpsertTable(ctx, table)
+}
+
+func (r *instrumentedRepository) GetTableReservations(ctx context.Context) ([]repository.TableReservation, error) {
+	defer r.metrics.observeQuery("GetTableReservations", time.Now())
+	return r.repo.GetTableReservations(ctx)
+}
+
+func (r *instrumentedRepository) ReplaceTableReservation(ctx context.Context, reservation *repository.TableReservation) error {
+	defer r.metrics.observeQuery("ReplaceTableReservation", time.Now())
+	return r.repo.ReplaceTableReservation(ctx, reservation)
+}
+
+func (r *instrumentedRepository) DeleteTableReservation(ctx context.Context, table int) error {
+	defer r.metrics.observeQuery("DeleteTableReservation", time.Now())
+	return r.repo.DeleteTableReservation(ctx, table)
+}
+
+func (r *instrumentedRepository) GetTableLayouts(ctx context.Context) ([]repository.TableLayout, error) {
+	defer r.metrics.observeQuery("GetTableLayouts", time.Now())
+	return r.repo.GetTableLayouts(ctx)
+}
+
+func (r *instrumentedRepository) ReplaceTableLayouts(ctx context.Context, layouts []repository.TableLayout) error {
+	defer r.metrics.observeQuery("ReplaceTableLayouts", time.Now())
+	return r.repo.ReplaceTableLayouts(ctx, layouts)
+}
+
+func (r *instrumentedRepository) GetSeats(ctx context.Context) ([]repository.Seat, error) {
+	defer r.metrics.observeQuery("GetSeats", time.Now())
+	return r.repo.GetSeats(ctx)
+}
+
+func (r *instrumentedRepository) GetSeatsByTable(ctx context.Context, table int) ([]repository.Seat, error) {
+	defer r.metrics.observeQuery("GetSeatsByTable", time.Now())
+	return r.repo.GetSeatsByTable(ctx, table)
+}
+
+func (r *instrumentedRepository) CreateSeats(ctx context.Context, seats []repository.Seat) error {
+	defer r.metrics.observeQuery("CreateSeats", time.Now())
+	return r.repo.CreateSeats(ctx, seats)
+}
+
+func (r *instrumentedRepository) DeleteGuestSeats(ctx context.Context, name string) error {
+	defer r.metrics.observeQuery("DeleteGuestSeats", time.Now())
+	return r.repo.DeleteGuestSeats(ctx, name)
+}
+
+func (r *instrumentedRepository) GetConstraints(ctx context.Context) ([]repository.Constraint, error) {
+	defer r.metrics.observeQuery("GetConstraints", time.Now())
+	return r.repo.GetConstraints(ctx)
+}
+
+func (r *instrumentedRepository) CreateConstraint(ctx context.Context, constraint *repository.Constraint) error {
+	defer r.metrics.observeQuery("CreateConstraint", time.Now())
+	return r.repo.CreateConstraint(ctx, constraint)
+}
+
+func (r *instrumentedRepository) DeleteConstraint(ctx context.Context, id string) error {
+	defer r.metrics.observeQuery("DeleteConstraint", time.Now())
+	return r.repo.DeleteConstraint(ctx, id)
+}
+
+func (r *instrumentedRepository) GetGroups(ctx context.Context) ([]repository.Group, error) {
+	defer r.metrics.observeQuery("GetGroups", time.Now())
+	return r.repo.GetGroups(ctx)
+}
+
+func (r *instrumentedRepository) GetGroupByName(ctx context.Context, name string) (*repository.Group, error) {
+	defer r.metrics.observeQuery("GetGroupByName", time.Now())
+	return r.repo.GetGroupByName(ctx, name)
+}
+
+func (r *instrumentedRepository) CreateGroup(ctx context.Context, group *repository.Group) error {
+	defer r.metrics.observeQuery("CreateGroup", time.Now())
+	return r.repo.CreateGroup(ctx, group)
+}
+
+func (r *instrumentedRepository) GetRequirements(ctx context.Context) ([]repository.Requirement, error) {
+	defer r.metrics.observeQuery("GetRequirements", time.Now())
+	return r.repo.GetRequirements(ctx)
+}
+
+func (r *instrumentedRepository) GetGuestRequirements(ctx context.Context, guest string) ([]repository.Requirement, []repository.Companion, error) {
+	defer r.metrics.observeQuery("GetGuestRequirements", time.Now())
+	return r.repo.GetGuestRequirements(ctx, guest)
+}
+
+func (r *instrumentedRepository) ReplaceGuestRequirements(ctx context.Context, guest string, requirements []repository.Requirement, companions []repository.Companion) error {
+	defer r.metrics.observeQuery("ReplaceGuestRequirements", time.Now())
+	return r.repo.ReplaceGuestRequirements(ctx, guest, requirements, companions)
+}
+
+func (r *instrumentedRepository) GetCustomFields(ctx context.Context) ([]repository.CustomField, error) {
+	defer r.metrics.observeQuery("GetCustomFields", time.Now())
+	return r.repo.GetCustomFields(ctx)
+}
+
+func (r *instrumentedRepository) ReplaceCustomFields(ctx context.Context, fields []repository.CustomField) error {
+	defer r.metrics.observeQuery("ReplaceCustomFields", time.Now())
+	return r.repo.ReplaceCustomFields(ctx, fields)
+}
+
+func (r *instrumentedRepository) GetInvitationByID(ctx context.Context, id string) (*repository.Invitation, error) {
+	defer r.metrics.observeQuery("GetInvitationByID", time.Now())
+	return r.repo.GetInvitationByID(ctx, id)
+}
+
+func (r *instrumentedRepository) UpsertInvitation(ctx context.Context, invitation *repository.Invitation) error {
+	defer r.metrics.observeQuery("UpsertInvitation", time.Now())
+	return r.repo.UpsertInvitation(ctx, invitation)
+}
+
+func (r *instrumentedRepository) AnswerInvitation(ctx context.Context, invitation *repository.Invitation, fromStatus string) (bool, error) {
+	defer r.metrics.observeQuery("AnswerInvitation", time.Now())
+	return r.repo.AnswerInvitation(ctx, invitation, fromStatus)
+}
+
+func (r *instrumentedRepository) GetCheckInCode(ctx context.Context, code string) (*repository.CheckInCode, error) {
+	defer r.metrics.observeQuery("GetCheckInCode", time.Now())
+	return r.repo.GetCheckInCode(ctx, code)
+}
+
+func (r *instrumentedRepository) GetCheckInCodesByGuestName(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+	defer r.metrics.observeQuery("GetCheckInCodesByGuestName", time.Now())
+	return r.repo.GetCheckInCodesByGuestName(ctx, name)
+}
+
+func (r *instrumentedRepository) UpsertCheckInCode(ctx context.Context, code *repository.CheckInCode) error {
+	defer r.metrics.observeQuery("UpsertCheckInCode", time.Now())
+	return r.repo.UpsertCheckInCode(ctx, code)
+}
+
+// Transaction records the latency of the whole transaction and of the queries run within it.
+func (r *instrumentedRepository) Transaction(ctx context.Context, fn func(repo repository.Repository) error) error {
+	defer r.metrics.observeQuery("Transaction", time.Now())
+	return r.repo.Transaction(ctx, func(tx repository.Repository) error {
+		return fn(r.metrics.InstrumentRepository(tx))
+	})
+}
@@ -0,0 +1,63 @@
+package party
+
+import "sync"
+
+const defaultSubscriberBuffer = 64
+
+// Broadcaster fans the party events out to subscribers, e.g. streaming API clients.
+// Events are dropped for subscribers whose buffer is full, so a slow subscriber never blocks the party service.
+type Broadcaster struct {
+	mu     sync.Mutex
+	subs   map[chan Event]struct{}
+	buffer int
+}
+
+// NewBroadcaster creates a broadcaster buffering up to buffer events per subscriber.
+func NewBroadcaster(buffer int) *Broadcaster {
+	if buffer <= 0 {
+		buffer = defaultSubscriberBuffer
+	}
+
+	return &Broadcaster{
+		subs:   make(map[chan Event]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Listener returns the party listener publishing the events to the subscribers.
+func (b *Broadcaster) Listener() Listener {
+	return b.publish
+}
+
+// Subscribe returns a channel receiving the events published from now on,
+// and a function that unsubscribes and closes the channel.
+func (b *Broadcaster) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, b.buffer)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+func (b *Broadcaster) publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
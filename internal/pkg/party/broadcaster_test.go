@@ -0,0 +1,52 @@
+package party
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster(t *testing.T) {
+	t.Run("publishes the events to every subscriber", func(t *testing.T) {
+		b := NewBroadcaster(1)
+
+		first, unsubscribeFirst := b.Subscribe()
+		defer unsubscribeFirst()
+
+		second, unsubscribeSecond := b.Subscribe()
+		defer unsubscribeSecond()
+
+		e := Event{Type: EventGuestArrived, Guest: "john"}
+		b.Listener()(e)
+
+		assert.Equal(t, e, <-first)
+		assert.Equal(t, e, <-second)
+	})
+
+	t.Run("drops the events of a full subscriber", func(t *testing.T) {
+		b := NewBroadcaster(1)
+
+		events, unsubscribe := b.Subscribe()
+		defer unsubscribe()
+
+		b.Listener()(Event{Guest: "john"})
+		b.Listener()(Event{Guest: "jane"})
+
+		assert.Equal(t, "john", (<-events).Guest)
+		assert.Empty(t, events)
+	})
+
+	t.Run("closes the channel on unsubscribe", func(t *testing.T) {
+		b := NewBroadcaster(1)
+
+		events, unsubscribe := b.Subscribe()
+		unsubscribe()
+		unsubscribe()
+
+		b.Listener()(Event{Guest: "john"})
+
+		_, ok := <-events
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,219 @@
+package party
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/pkg/database"
+)
+
+// IssueCheckInCode issues a new check-in code for a guest in the guest list.
+// Guests get a code when added, a new code revokes the codes previously issued to the guest and not yet used.
+func (p *Party) IssueCheckInCode(ctx context.Context, in *IssueCheckInCodeInput) (*IssueCheckInCodeOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.IssueCheckInCode")
+	defer span.End()
+
+	if in.Name == "" {
+		return nil, ErrGuestNameRequired
+	}
+
+	var code string
+
+	// Revoke the active codes and issue the new one together, so that the guest always has one active code
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		// Check if guest is in guest list
+		guest, err := tx.GetGuestByName(ctx, in.Name)
+		if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+			return fmt.Errorf("could not get guest by name: %w", err)
+		}
+
+		if guest == nil || guest.Name == "" {
+			return ErrGuestNotInList
+		}
+
+		code, err = issueCheckInCode(ctx, tx, in.Name, p.now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssueCheckInCodeOutput{
+		Name: in.Name,
+		Code: code,
+	}, nil
+}
+
+// issueCheckInCode revokes the active check-in codes of the guest and returns a new one.
+func issueCheckInCode(ctx context.Context, repo repository.Repository, name string, now time.Time) (string, error) {
+	// Revoke active codes
+	codes, err := repo.GetCheckInCodesByGuestName(ctx, name)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return "", fmt.Errorf("could not get check-in codes by guest name: %w", err)
+	}
+
+	for i := range codes {
+		if codes[i].Status != CheckInCodeStatusActive {
+			continue
+		}
+
+		codes[i].Status = CheckInCodeStatusRevoked
+		if err := repo.UpsertCheckInCode(ctx, &codes[i]); err != nil {
+			return "", fmt.Errorf("could not revoke check-in code: %w", err)
+		}
+	}
+
+	// Issue new code
+	code, err := newCheckInCode()
+	if err != nil {
+		return "", fmt.Errorf("could not generate check-in code: %w", err)
+	}
+
+	codeStore := repository.CheckInCode{
+		Code:       code,
+		GuestName:  name,
+		Status:     CheckInCodeStatusActive,
+		TimeIssued: now,
+	}
+
+	if err := repo.UpsertCheckInCode(ctx, &codeStore); err != nil {
+		return "", fmt.Errorf("could not upsert check-in code: %w", err)
+	}
+	return code, nil
+}
+
+// GetCheckInCode returns the check-in code details.
+func (p *Party) GetCheckInCode(ctx context.Context, code string) (*GetCheckInCodeOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetCheckInCode")
+	defer span.End()
+
+	checkInCode, err := getCheckInCode(ctx, p.repo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetCheckInCodeOutput{
+		Code:   checkInCode.Code,
+		Name:   checkInCode.GuestName,
+		Status: checkInCode.Status,
+	}, nil
+}
+
+// RevokeCheckInCode prevents an unused check-in code from being used.
+func (p *Party) RevokeCheckInCode(ctx context.Context, code string) error {
+	ctx, span := tracer.Start(ctx, "party.RevokeCheckInCode")
+	defer span.End()
+
+	checkInCode, err := getCheckInCode(ctx, p.repo, code)
+	if err != nil {
+		return err
+	}
+
+	if checkInCode.Status == CheckInCodeStatusUsed {
+		return ErrCheckInCodeUsed
+	}
+
+	checkInCode.Status = CheckInCodeStatusRevoked
+
+	if err := p.repo.UpsertCheckInCode(ctx, checkInCode); err != nil {
+		return fmt.Errorf("could not upsert check-in code: %w", err)
+	}
+	return nil
+}
+
+// CheckIn resolves the code to its guest and welcomes them.
+// A code can only be used once.
+func (p *Party) CheckIn(ctx context.Context, in *CheckInInput) (*CheckInOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.CheckIn")
+	defer span.End()
+
+	var out CheckInOutput
+
+	// Welcome the guest and use the code together, so that a code is used once, events are only emitted once committed
+	heldParty, releaseEvents := p.holdEvents()
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		checkInCode, err := getCheckInCode(ctx, tx, in.Code)
+		if err != nil {
+			return err
+		}
+
+		switch checkInCode.Status {
+		case CheckInCodeStatusUsed:
+			return ErrCheckInCodeUsed
+		case CheckInCodeStatusRevoked:
+			return ErrCheckInCodeRevoked
+		}
+
+		guest, err := tx.GetGuestByName(ctx, checkInCode.GuestName)
+		if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+			return fmt.Errorf("could not get guest by name: %w", err)
+		}
+
+		if guest == nil || guest.Name == "" {
+			return ErrGuestNotInList
+		}
+
+		if IsPresent(*guest) {
+			return ErrGuestPresent
+		}
+
+		// Default to the booked number of accompanying guests
+		accompanyingGuests := guest.AccompanyingGuests
+		if in.AccompanyingGuests != nil {
+			accompanyingGuests = *in.AccompanyingGuests
+		}
+
+		if _, err := heldParty.withRepository(tx).WelcomeGuest(ctx, &WelcomeGuestInput{
+			Name:               checkInCode.GuestName,
+			AccompanyingGuests: accompanyingGuests,
+		}); err != nil {
+			return fmt.Errorf("could not welcome guest: %w", err)
+		}
+
+		// Mark code as used
+		timeUsed := p.now()
+		checkInCode.Status = CheckInCodeStatusUsed
+		checkInCode.TimeUsed = &timeUsed
+
+		if err := tx.UpsertCheckInCode(ctx, checkInCode); err != nil {
+			return fmt.Errorf("could not upsert check-in code: %w", err)
+		}
+
+		out = CheckInOutput{Name: checkInCode.GuestName, AccompanyingGuests: accompanyingGuests}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releaseEvents(ctx)
+
+	return &out, nil
+}
+
+func getCheckInCode(ctx context.Context, repo repository.Repository, code string) (*repository.CheckInCode, error) {
+	checkInCode, err := repo.GetCheckInCode(ctx, code)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return nil, fmt.Errorf("could not get check-in code: %w", err)
+	}
+
+	if checkInCode == nil || checkInCode.Code == "" {
+		return nil, ErrCheckInCodeNotFound
+	}
+	return checkInCode, nil
+}
+
+// newCheckInCode returns a random code made of QR alphanumeric characters.
+func newCheckInCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,289 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIssueCheckInCode(t *testing.T) {
+	t.Run("returns an error when guest is not in the list", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return nil, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.IssueCheckInCode(context.TODO(), &IssueCheckInCodeInput{Name: "123"})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+	})
+
+	t.Run("revokes active codes and issues a new one", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: name}, nil
+		}
+		repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return []repository.CheckInCode{
+				{Code: "OLD", GuestName: name, Status: CheckInCodeStatusActive},
+				{Code: "USED", GuestName: name, Status: CheckInCodeStatusUsed},
+			}, nil
+		}
+
+		upserted := map[string]string{}
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			upserted[code.Code] = code.Status
+			return nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.IssueCheckInCode(context.TODO(), &IssueCheckInCodeInput{Name: "123"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "123", observed.Name)
+		assert.Len(t, observed.Code, 16)
+		assert.Equal(t, map[string]string{
+			"OLD":         CheckInCodeStatusRevoked,
+			observed.Code: CheckInCodeStatusActive,
+		}, upserted)
+	})
+
+	t.Run("revokes the active codes and issues the new one in one transaction", func(t *testing.T) {
+		var committed bool
+
+		tx := repository.Mock{}
+		tx.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: name}, nil
+		}
+		tx.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return []repository.CheckInCode{{Code: "OLD", GuestName: name, Status: CheckInCodeStatusActive}}, nil
+		}
+		tx.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			if code.Status == CheckInCodeStatusActive {
+				return errTestRepo
+			}
+			return nil
+		}
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			err := fn(&tx)
+			committed = err == nil
+			return err
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.IssueCheckInCode(context.TODO(), &IssueCheckInCodeInput{Name: "123"})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, errTestRepo))
+		assert.False(t, committed)
+	})
+}
+
+func TestCheckIn(t *testing.T) {
+	cases := []struct {
+		name          string
+		givenCode     *repository.CheckInCode
+		givenGuest    *repository.Guest
+		expectedError error
+	}{
+		{
+			name:          "unknown code",
+			givenCode:     nil,
+			expectedError: ErrCheckInCodeNotFound,
+		},
+		{
+			name:          "used code",
+			givenCode:     &repository.CheckInCode{Code: "CODE", GuestName: "123", Status: CheckInCodeStatusUsed},
+			expectedError: ErrCheckInCodeUsed,
+		},
+		{
+			name:          "revoked code",
+			givenCode:     &repository.CheckInCode{Code: "CODE", GuestName: "123", Status: CheckInCodeStatusRevoked},
+			expectedError: ErrCheckInCodeRevoked,
+		},
+		{
+			name:          "guest not in list",
+			givenCode:     &repository.CheckInCode{Code: "CODE", GuestName: "123", Status: CheckInCodeStatusActive},
+			givenGuest:    &repository.Guest{},
+			expectedError: ErrGuestNotInList,
+		},
+		{
+			name:          "guest already present",
+			givenCode:     &repository.CheckInCode{Code: "CODE", GuestName: "123", Status: CheckInCodeStatusActive},
+			givenGuest:    &repository.Guest{Name: "123", Table: 1, TimeArrival: &testNow},
+			expectedError: ErrGuestPresent,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := repository.Mock{}
+			repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+				return fn(&repo)
+			}
+			repo.GetCheckInCodeFunc = func(ctx context.Context, code string) (*repository.CheckInCode, error) {
+				return c.givenCode, nil
+			}
+			repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+				return c.givenGuest, nil
+			}
+
+			party := New(zap.NewNop(), &repo, testTableSize)
+			observed, err := party.CheckIn(context.TODO(), &CheckInInput{Code: "CODE"})
+
+			assert.Nil(t, observed)
+			assert.True(t, errors.Is(err, c.expectedError))
+		})
+	}
+
+	t.Run("welcomes the guest with the booked party and marks the code as used", func(t *testing.T) {
+		var (
+			welcomedGuest *repository.Guest
+			updatedTable  *repository.Table
+			storedCode    *repository.CheckInCode
+			createdSeats  []repository.Seat
+		)
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetCheckInCodeFunc = func(ctx context.Context, code string) (*repository.CheckInCode, error) {
+			return &repository.CheckInCode{Code: code, GuestName: "123", Status: CheckInCodeStatusActive}, nil
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: name, Table: 1, AccompanyingGuests: 2}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 7, Size: 10}, nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error {
+			updatedTable = table
+			return nil
+		}
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+			return nil, nil
+		}
+		repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error {
+			return nil
+		}
+		repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+			createdSeats = seats
+			return nil
+		}
+		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+			welcomedGuest = guest
+			return nil
+		}
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			storedCode = code
+			return nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.CheckIn(context.TODO(), &CheckInInput{Code: "CODE"})
+		require.NoError(t, err)
+
+		assert.Equal(t, &CheckInOutput{Name: "123", AccompanyingGuests: 2}, observed)
+		require.NotNil(t, welcomedGuest)
+		assert.NotNil(t, welcomedGuest.TimeArrival)
+		assert.Equal(t, 2, welcomedGuest.ArrivedAccompanyingGuests)
+		require.NotNil(t, updatedTable)
+		assert.Equal(t, 1, updatedTable.Number)
+		assert.Equal(t, 7, updatedTable.AvailableSeats)
+		assert.Len(t, createdSeats, 3)
+		require.NotNil(t, storedCode)
+		assert.Equal(t, CheckInCodeStatusUsed, storedCode.Status)
+		assert.NotNil(t, storedCode.TimeUsed)
+	})
+
+	t.Run("does not mark the code as used when welcoming fails", func(t *testing.T) {
+		var codeUpserted bool
+
+		accompanyingGuests := 20
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetCheckInCodeFunc = func(ctx context.Context, code string) (*repository.CheckInCode, error) {
+			return &repository.CheckInCode{Code: code, GuestName: "123", Status: CheckInCodeStatusActive}, nil
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: name, Table: 1}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 10, Size: 10}, nil
+		}
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			codeUpserted = true
+			return nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.CheckIn(context.TODO(), &CheckInInput{Code: "CODE", AccompanyingGuests: &accompanyingGuests})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrTableNotEnoughSeats))
+		assert.False(t, codeUpserted)
+	})
+
+	t.Run("does not commit the arrival when the code cannot be marked as used", func(t *testing.T) {
+		var committed bool
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			err := fn(&repo)
+			committed = err == nil
+			return err
+		}
+		repo.GetCheckInCodeFunc = func(ctx context.Context, code string) (*repository.CheckInCode, error) {
+			return &repository.CheckInCode{Code: code, GuestName: "123", Status: CheckInCodeStatusActive}, nil
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: name, Table: 1}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 10, Size: 10}, nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+		repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error { return nil }
+		repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
+		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error { return nil }
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			return errors.New("connection lost")
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.CheckIn(context.TODO(), &CheckInInput{Code: "CODE"})
+
+		assert.Nil(t, observed)
+		assert.Error(t, err)
+		assert.False(t, committed)
+	})
+}
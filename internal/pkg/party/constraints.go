@@ -0,0 +1,261 @@
+package party
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/internal/pkg/seating"
+	"github.com/alesr/getground/pkg/database"
+)
+
+// CreateConstraint adds a seating constraint on guests of the guest list.
+// Constraints are not enforced when adding or seating guests, PlanSeating and CheckSeating apply them.
+// Constraints outlive their guests, they are reported unsatisfiable once a guest leaves the guest list.
+func (p *Party) CreateConstraint(ctx context.Context, in *CreateConstraintInput) (*Constraint, error) {
+	ctx, span := tracer.Start(ctx, "party.CreateConstraint")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return nil, fmt.Errorf("could not validate input for creating constraint: %w", err)
+	}
+
+	id, err := newConstraintID()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate constraint id: %w", err)
+	}
+
+	constraintStore := repository.Constraint{
+		ID:        id,
+		Kind:      in.Kind,
+		Table:     in.Table,
+		TimeAdded: p.now(),
+		Guests:    in.Guests,
+	}
+
+	err = p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		for _, name := range in.Guests {
+			guest, err := tx.GetGuestByName(ctx, name)
+			if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+				return fmt.Errorf("could not get guest by name: %w", err)
+			}
+
+			// Missing guests are found with a zero name
+			if guest == nil || guest.Name == "" {
+				return fmt.Errorf("guest %s: %w", name, ErrGuestNotInList)
+			}
+		}
+
+		if in.Kind == ConstraintPinned {
+			if _, err := getTable(ctx, tx, in.Table); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.CreateConstraint(ctx, &constraintStore); err != nil {
+			return fmt.Errorf("could not create constraint: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	constraint := constraintFromStore(constraintStore)
+	return &constraint, nil
+}
+
+// ListConstraints returns the seating constraints in the order they were added.
+func (p *Party) ListConstraints(ctx context.Context) (ListConstraintsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.ListConstraints")
+	defer span.End()
+
+	constraints, err := p.repo.GetConstraints(ctx)
+	if err != nil {
+		return ListConstraintsOutput{}, fmt.Errorf("could not get constraints: %w", err)
+	}
+
+	out := ListConstraintsOutput{Constraints: make([]Constraint, 0, len(constraints))}
+	for _, constraint := range constraints {
+		out.Constraints = append(out.Constraints, constraintFromStore(constraint))
+	}
+	return out, nil
+}
+
+// DeleteConstraint deletes a seating constraint.
+func (p *Party) DeleteConstraint(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "party.DeleteConstraint")
+	defer span.End()
+
+	return p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		constraints, err := tx.GetConstraints(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get constraints: %w", err)
+		}
+
+		found := false
+		for _, constraint := range constraints {
+			found = found || constraint.ID == id
+		}
+
+		if !found {
+			return ErrConstraintNotFound
+		}
+
+		if err := tx.DeleteConstraint(ctx, id); err != nil {
+			return fmt.Errorf("could not delete constraint: %w", err)
+		}
+		return nil
+	})
+}
+
+// PlanSeating computes the table of every guest of the guest list, meeting the table sizes and the constraints.
+// Guests keep their table when they can. The plan is not applied, the constraints it could not meet are reported.
+func (p *Party) PlanSeating(ctx context.Context) (*SeatingPlan, error) {
+	ctx, span := tracer.Start(ctx, "party.PlanSeating")
+	defer span.End()
+
+	problem, current, err := p.seatingProblem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := seating.Solve(problem)
+
+	out := SeatingPlan{
+		Assignments: make([]SeatingAssignment, 0, len(plan.Assignments)),
+		Unseated:    plan.Unseated,
+		Violations:  seatingViolations(plan.Unsatisfied),
+	}
+
+	for _, assignment := range plan.Assignments {
+		out.Assignments = append(out.Assignments, SeatingAssignment{
+			Guest:        assignment.Guest,
+			Table:        assignment.Table,
+			CurrentTable: current[assignment.Guest],
+		})
+	}
+	return &out, nil
+}
+
+// CheckSeating checks a seating plan against the table sizes and the constraints,
+// or the tables of the guest list when the input has no assignments.
+func (p *Party) CheckSeating(ctx context.Context, in *CheckSeatingInput) (*SeatingPlan, error) {
+	ctx, span := tracer.Start(ctx, "party.CheckSeating")
+	defer span.End()
+
+	problem, current, err := p.seatingProblem(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []seating.Assignment
+	if len(in.Assignments) > 0 {
+		for _, assignment := range in.Assignments {
+			assignments = append(assignments, seating.Assignment{Guest: assignment.Guest, Table: assignment.Table})
+		}
+	} else {
+		for _, party := range problem.Parties {
+			assignments = append(assignments, seating.Assignment{Guest: party.Name, Table: party.Table})
+		}
+	}
+
+	out := SeatingPlan{
+		Assignments: make([]SeatingAssignment, 0, len(assignments)),
+		Violations:  seatingViolations(seating.Validate(problem, assignments)),
+	}
+
+	for _, assignment := range assignments {
+		out.Assignments = append(out.Assignments, SeatingAssignment{
+			Guest:        assignment.Guest,
+			Table:        assignment.Table,
+			CurrentTable: current[assignment.Guest],
+		})
+	}
+
+	sort.Slice(out.Assignments, func(i, j int) bool {
+		return out.Assignments[i].Guest < out.Assignments[j].Guest
+	})
+	return &out, nil
+}
+
+// seatingProblem returns the guest list, tables and constraints as a seating problem,
+// along with the current table of each guest.
+func (p *Party) seatingProblem(ctx context.Context) (*seating.Problem, map[string]int, error) {
+	tables, guests, err := p.getTablesAndGuests(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	constraints, err := p.repo.GetConstraints(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get constraints: %w", err)
+	}
+
+	reservations, err := getTableReservations(ctx, p.repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var problem seating.Problem
+	current := make(map[string]int, len(guests))
+
+	for _, guest := range guests {
+		problem.Parties = append(problem.Parties, seating.Party{
+			Name:  guest.Name,
+			Size:  guest.AccompanyingGuests + 1,
+			Table: guest.Table,
+			Tier:  guestTier(guest.Tier),
+		})
+		current[guest.Name] = guest.Table
+	}
+
+	// Held seats are left out of the plan
+	for _, table := range tables {
+		reservation := reservations[table.Number]
+		problem.Tables = append(problem.Tables, seating.Table{
+			Number: table.Number,
+			Size:   table.Size - reservation.HeldSeats,
+			Tiers:  reservationTiers(reservation),
+		})
+	}
+
+	for _, constraint := range constraints {
+		problem.Constraints = append(problem.Constraints, seating.Constraint{
+			ID:     constraint.ID,
+			Kind:   constraint.Kind,
+			Guests: constraint.Guests,
+			Table:  constraint.Table,
+		})
+	}
+	return &problem, current, nil
+}
+
+func seatingViolations(violations []seating.Violation) []SeatingViolation {
+	var out []SeatingViolation
+	for _, violation := range violations {
+		out = append(out, SeatingViolation{Constraint: violation.Constraint, Reason: violation.Reason})
+	}
+	return out
+}
+
+func constraintFromStore(constraint repository.Constraint) Constraint {
+	return Constraint{
+		ID:     constraint.ID,
+		Kind:   constraint.Kind,
+		Guests: constraint.Guests,
+		Table:  constraint.Table,
+	}
+}
+
+func newConstraintID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
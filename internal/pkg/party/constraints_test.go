@@ -0,0 +1,268 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// constraintsTestRepo seats john and his accompanying guest at table 1, jane at table 1 and joe at table 2.
+// Tables 1 and 2 have 4 seats, jane and joe must sit apart.
+func constraintsTestRepo() (*repository.Mock, *[]repository.Constraint) {
+	constraints := []repository.Constraint{
+		{ID: "c1", Kind: ConstraintApart, Guests: []string{"jane", "joe"}},
+	}
+
+	repo := repository.Mock{}
+	repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(&repo)
+	}
+	repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+		switch name {
+		case "john", "jane", "joe":
+			return &repository.Guest{Name: name}, nil
+		}
+		return &repository.Guest{}, nil
+	}
+	repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+		if number > 2 {
+			return &repository.Table{}, nil
+		}
+		return &repository.Table{Number: number, Size: 4}, nil
+	}
+	repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+		return []repository.Table{{Number: 1, Size: 4, AvailableSeats: 1}, {Number: 2, Size: 4, AvailableSeats: 3}}, nil
+	}
+	repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+		return nil, nil
+	}
+	repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+		return []repository.Guest{
+			{Name: "john", Table: 1, AccompanyingGuests: 1},
+			{Name: "jane", Table: 1},
+			{Name: "joe", Table: 2},
+		}, nil
+	}
+	repo.GetConstraintsFunc = func(ctx context.Context) ([]repository.Constraint, error) {
+		return append([]repository.Constraint{}, constraints...), nil
+	}
+	repo.CreateConstraintFunc = func(ctx context.Context, constraint *repository.Constraint) error {
+		constraints = append(constraints, *constraint)
+		return nil
+	}
+	repo.DeleteConstraintFunc = func(ctx context.Context, id string) error {
+		kept := constraints[:0]
+		for _, constraint := range constraints {
+			if constraint.ID != id {
+				kept = append(kept, constraint)
+			}
+		}
+		constraints = kept
+		return nil
+	}
+	return &repo, &constraints
+}
+
+func TestCreateConstraint(t *testing.T) {
+	cases := []struct {
+		name        string
+		given       CreateConstraintInput
+		expectedErr error
+	}{
+		{name: "keeps guests together", given: CreateConstraintInput{Kind: ConstraintTogether, Guests: []string{"john", "joe"}}},
+		{name: "pins guests to a table", given: CreateConstraintInput{Kind: ConstraintPinned, Guests: []string{"john"}, Table: 2}},
+		{
+			name:        "unknown kind",
+			given:       CreateConstraintInput{Kind: "near", Guests: []string{"john", "joe"}},
+			expectedErr: ErrConstraintKindInvalid,
+		},
+		{
+			name:        "a single guest to keep apart",
+			given:       CreateConstraintInput{Kind: ConstraintApart, Guests: []string{"john"}},
+			expectedErr: ErrConstraintGuestsInvalid,
+		},
+		{
+			name:        "guest given twice",
+			given:       CreateConstraintInput{Kind: ConstraintApart, Guests: []string{"john", "john"}},
+			expectedErr: ErrConstraintGuestsInvalid,
+		},
+		{
+			name:        "table of a constraint not pinned",
+			given:       CreateConstraintInput{Kind: ConstraintTogether, Guests: []string{"john", "joe"}, Table: 1},
+			expectedErr: ErrTableNumberInvalid,
+		},
+		{
+			name:        "pinned without table",
+			given:       CreateConstraintInput{Kind: ConstraintPinned, Guests: []string{"john"}},
+			expectedErr: ErrTableNumberRequired,
+		},
+		{
+			name:        "guest not in list",
+			given:       CreateConstraintInput{Kind: ConstraintTogether, Guests: []string{"john", "mallory"}},
+			expectedErr: ErrGuestNotInList,
+		},
+		{
+			name:        "table not found",
+			given:       CreateConstraintInput{Kind: ConstraintPinned, Guests: []string{"john"}, Table: 3},
+			expectedErr: ErrTableNumberNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, constraints := constraintsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+			party.now = func() time.Time { return testNow }
+
+			observed, err := party.CreateConstraint(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				assert.Len(t, *constraints, 1)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, observed.ID)
+			assert.Equal(t, &Constraint{ID: observed.ID, Kind: tc.given.Kind, Guests: tc.given.Guests, Table: tc.given.Table}, observed)
+
+			require.Len(t, *constraints, 2)
+			assert.Equal(t, repository.Constraint{
+				ID:        observed.ID,
+				Kind:      tc.given.Kind,
+				Table:     tc.given.Table,
+				TimeAdded: testNow,
+				Guests:    tc.given.Guests,
+			}, (*constraints)[1])
+		})
+	}
+}
+
+func TestDeleteConstraint(t *testing.T) {
+	t.Run("deletes the constraint", func(t *testing.T) {
+		repo, constraints := constraintsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		require.NoError(t, party.DeleteConstraint(context.TODO(), "c1"))
+		assert.Empty(t, *constraints)
+	})
+
+	t.Run("returns not found for an unknown constraint", func(t *testing.T) {
+		repo, constraints := constraintsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		err := party.DeleteConstraint(context.TODO(), "c2")
+		assert.True(t, errors.Is(err, ErrConstraintNotFound))
+		assert.Len(t, *constraints, 1)
+	})
+}
+
+func TestListConstraints(t *testing.T) {
+	repo, _ := constraintsTestRepo()
+	party := New(zap.NewNop(), repo, testTableSize)
+
+	observed, err := party.ListConstraints(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, ListConstraintsOutput{Constraints: []Constraint{{ID: "c1", Kind: ConstraintApart, Guests: []string{"jane", "joe"}}}}, observed)
+}
+
+func TestPlanSeating(t *testing.T) {
+	t.Run("keeps the guests at their table when the constraints are met", func(t *testing.T) {
+		repo, _ := constraintsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		observed, err := party.PlanSeating(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, &SeatingPlan{Assignments: []SeatingAssignment{
+			{Guest: "jane", Table: 1, CurrentTable: 1},
+			{Guest: "joe", Table: 2, CurrentTable: 2},
+			{Guest: "john", Table: 1, CurrentTable: 1},
+		}}, observed)
+	})
+
+	t.Run("moves guests to meet the constraints and reports those it cannot meet", func(t *testing.T) {
+		repo, constraints := constraintsTestRepo()
+		*constraints = append(*constraints,
+			repository.Constraint{ID: "c2", Kind: ConstraintTogether, Guests: []string{"john", "joe"}},
+			repository.Constraint{ID: "c3", Kind: ConstraintTogether, Guests: []string{"joe", "jane"}},
+		)
+
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		observed, err := party.PlanSeating(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, &SeatingPlan{
+			Assignments: []SeatingAssignment{
+				{Guest: "jane", Table: 2, CurrentTable: 1},
+				{Guest: "joe", Table: 1, CurrentTable: 2},
+				{Guest: "john", Table: 1, CurrentTable: 1},
+			},
+			Violations: []SeatingViolation{{Constraint: "c3", Reason: "conflicts with the table sizes or the constraints before it"}},
+		}, observed)
+	})
+
+	t.Run("returns an error when get constraints fails", func(t *testing.T) {
+		repo, _ := constraintsTestRepo()
+		repo.GetConstraintsFunc = func(ctx context.Context) ([]repository.Constraint, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		_, err := party.PlanSeating(context.TODO())
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+}
+
+func TestCheckSeating(t *testing.T) {
+	cases := []struct {
+		name     string
+		given    CheckSeatingInput
+		expected *SeatingPlan
+	}{
+		{
+			name: "checks the tables of the guest list",
+			expected: &SeatingPlan{Assignments: []SeatingAssignment{
+				{Guest: "jane", Table: 1, CurrentTable: 1},
+				{Guest: "joe", Table: 2, CurrentTable: 2},
+				{Guest: "john", Table: 1, CurrentTable: 1},
+			}},
+		},
+		{
+			name: "checks the plan given",
+			given: CheckSeatingInput{Assignments: []SeatingAssignment{
+				{Guest: "john", Table: 2},
+				{Guest: "joe", Table: 2},
+				{Guest: "jane", Table: 2},
+			}},
+			expected: &SeatingPlan{
+				Assignments: []SeatingAssignment{
+					{Guest: "jane", Table: 2, CurrentTable: 1},
+					{Guest: "joe", Table: 2, CurrentTable: 2},
+					{Guest: "john", Table: 2, CurrentTable: 1},
+				},
+				Violations: []SeatingViolation{
+					{Constraint: "c1", Reason: `guests "jane" and "joe" sit at table 2`},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, _ := constraintsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			observed, err := party.CheckSeating(context.TODO(), &tc.given)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, observed)
+		})
+	}
+}
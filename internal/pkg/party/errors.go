@@ -5,12 +5,58 @@ import "errors"
 // Enumerate service errors
 
 var (
+	ErrAccessibilityNeedInvalid        = errors.New("accessibility need invalid")
 	ErrAccompanyingGuestsNumberInvalid = errors.New("invalid accompanying guests number")
+	ErrAllergyInvalid                  = errors.New("allergy invalid")
+	ErrCheckInCodeNotFound             = errors.New("check-in code not found")
+	ErrCheckInCodeRevoked              = errors.New("check-in code revoked")
+	ErrCheckInCodeUsed                 = errors.New("check-in code already used")
+	ErrCompanionDuplicated             = errors.New("companion duplicated")
+	ErrCompanionNameInvalid            = errors.New("companion name invalid")
+	ErrCompanionNumberInvalid          = errors.New("companion number invalid")
+	ErrConstraintGuestsInvalid         = errors.New("constraint guests invalid")
+	ErrConstraintKindInvalid           = errors.New("constraint kind invalid")
+	ErrConstraintNotFound              = errors.New("constraint not found")
+	ErrCursorInvalid                   = errors.New("cursor invalid")
+	ErrCustomFieldInvalid              = errors.New("custom field invalid")
+	ErrCustomFieldNotFound             = errors.New("custom field not found")
+	ErrCustomFieldRequired             = errors.New("custom field required")
+	ErrCustomFieldValueInvalid         = errors.New("custom field value invalid")
+	ErrDietaryRequirementInvalid       = errors.New("dietary requirement invalid")
+	ErrGroupAlreadyExists              = errors.New("group already exists")
+	ErrGroupGuestsRequired             = errors.New("group guests required")
+	ErrGroupNameRequired               = errors.New("group name required")
+	ErrGroupNotFound                   = errors.New("group not found")
 	ErrGuestAlreadyInList              = errors.New("guest already in list")
+	ErrGuestDuplicatedInGroup          = errors.New("guest duplicated in group")
+	ErrGuestDuplicatedInImport         = errors.New("guest duplicated in import")
 	ErrGuestNameRequired               = errors.New("guest name required")
 	ErrGuestNotInList                  = errors.New("guest not in list")
+	ErrGuestNotPresent                 = errors.New("guest not present at the party")
+	ErrGuestPresent                    = errors.New("guest present at the party")
+	ErrHeldSeatsInvalid                = errors.New("held seats invalid")
+	ErrImportEmpty                     = errors.New("import has no rows")
+	ErrInvitationAlreadyAnswered       = errors.New("invitation already answered")
+	ErrInvitationExpired               = errors.New("invitation expired")
+	ErrInvitationNotFound              = errors.New("invitation not found")
+	ErrInvitationPartyTooLarge         = errors.New("invitation party too large")
+	ErrInvitationSecretMissing         = errors.New("invitation secret missing")
+	ErrInvitationTokenInvalid          = errors.New("invitation token invalid")
+	ErrLimitInvalid                    = errors.New("limit invalid")
+	ErrMaxPartySizeInvalid             = errors.New("max party size invalid")
+	ErrSeatNumberInvalid               = errors.New("seat number invalid")
+	ErrSeatTaken                       = errors.New("seat already taken")
+	ErrSeatsCountInvalid               = errors.New("seats count does not match the party size")
+	ErrSortInvalid                     = errors.New("sort invalid")
+	ErrTableAlreadyExists              = errors.New("table already exists")
+	ErrTableDuplicatedInLayout         = errors.New("table duplicated in layout")
+	ErrTableLayoutInvalid              = errors.New("table layout invalid")
 	ErrTableNotEnoughSeats             = errors.New("table not enough seats")
 	ErrTableNumberInvalid              = errors.New("table number invalid")
 	ErrTableNumberNotFound             = errors.New("table number not found")
 	ErrTableNumberRequired             = errors.New("table number required")
+	ErrTableShapeInvalid               = errors.New("table shape invalid")
+	ErrTableSizeInvalid                = errors.New("table size invalid")
+	ErrTableTierRestricted             = errors.New("table restricted to other tiers")
+	ErrTierInvalid                     = errors.New("tier invalid")
 )
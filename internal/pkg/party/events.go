@@ -0,0 +1,83 @@
+package party
+
+import (
+	"context"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// Enumerate event types
+const (
+	EventGuestAdded   = "guest_added"
+	EventGuestArrived = "guest_arrived"
+	EventGuestLeft    = "guest_left"
+	EventGuestRemoved = "guest_removed"
+	EventTableCreated = "table_created"
+)
+
+// Event describes a change of the party state.
+// AvailableSeats is the number of available seats of the guest table after the change.
+// Table events have no guest.
+type Event struct {
+	Type               string    `json:"type"`
+	Guest              string    `json:"guest"`
+	Table              int       `json:"table"`
+	AccompanyingGuests int       `json:"accompanying_guests"`
+	AvailableSeats     int       `json:"available_seats"`
+	Time               time.Time `json:"time"`
+}
+
+// Listener is called with the events of successful party operations.
+// Listeners are called synchronously and must not block.
+type Listener func(e Event)
+
+// WithListener registers a listener for party events.
+func WithListener(l Listener) Option {
+	return func(p *Party) {
+		p.listeners = append(p.listeners, l)
+	}
+}
+
+// emit logs the event with the request logger and passes it to the listeners.
+// Events of a party holding its events are only buffered, see holdEvents.
+func (p *Party) emit(ctx context.Context, e Event) {
+	if e.Time.IsZero() {
+		e.Time = p.now()
+	}
+
+	if p.heldEvents != nil {
+		*p.heldEvents = append(*p.heldEvents, e)
+		return
+	}
+
+	logging.FromContext(ctx, p.logger).Info("party event",
+		zap.String("type", e.Type),
+		zap.String("guest", e.Guest),
+		zap.Int("table", e.Table),
+		zap.Int("accompanying_guests", e.AccompanyingGuests),
+		zap.Int("available_seats", e.AvailableSeats),
+	)
+
+	for _, l := range p.listeners {
+		l(e)
+	}
+}
+
+// holdEvents returns a copy of the party service buffering its events instead of emitting them,
+// and a function emitting the buffered events through the original service.
+// It is used to hold back the events of operations running within a transaction until it commits.
+func (p *Party) holdEvents() (*Party, func(ctx context.Context)) {
+	var events []Event
+
+	cp := *p
+	cp.heldEvents = &events
+
+	release := func(ctx context.Context) {
+		for _, e := range events {
+			p.emit(ctx, e)
+		}
+	}
+	return &cp, release
+}
@@ -0,0 +1,154 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestEvents(t *testing.T) {
+	newRepo := func() *repository.Mock {
+		guests := map[string]repository.Guest{}
+
+		repo := repository.Mock{}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return nil, nil
+		}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return nil, nil
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			if guest, ok := guests[name]; ok {
+				return &guest, nil
+			}
+			return &repository.Guest{Name: name, Table: 1, AccompanyingGuests: 1}, nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 5, Size: 10}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+			guests[guest.Name] = *guest
+			return nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+		repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
+		repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error { return nil }
+		repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return nil, nil
+		}
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error { return nil }
+		return &repo
+	}
+
+	t.Run("emits an event for each successful operation", func(t *testing.T) {
+		var events []Event
+
+		party := New(zap.NewNop(), newRepo(), 10, WithListener(func(e Event) {
+			events = append(events, e)
+		}))
+		party.now = func() time.Time { return testNow }
+
+		_, err := party.AddGuestToGuestList(context.TODO(), &AddGuestToGuestListInput{Name: "123", Table: 1, AccompanyingGuests: 1})
+		require.NoError(t, err)
+
+		_, err = party.WelcomeGuest(context.TODO(), &WelcomeGuestInput{Name: "123", AccompanyingGuests: 0})
+		require.NoError(t, err)
+
+		_, err = party.WelcomeGuest(context.TODO(), &WelcomeGuestInput{Name: "123", AccompanyingGuests: 0})
+		assert.True(t, errors.Is(err, ErrGuestPresent), err)
+
+		require.NoError(t, party.GoodbyeGuest(context.TODO(), &GoodbyeGuestInput{Name: "123"}))
+
+		err = party.GoodbyeGuest(context.TODO(), &GoodbyeGuestInput{Name: "123"})
+		assert.True(t, errors.Is(err, ErrGuestNotPresent), err)
+
+		// Only the changes of presence are emitted
+		require.Len(t, events, 3)
+
+		assert.Equal(t, Event{
+			Type:               EventGuestAdded,
+			Guest:              "123",
+			Table:              1,
+			AccompanyingGuests: 1,
+			AvailableSeats:     3,
+			Time:               testNow,
+		}, events[0])
+
+		assert.Equal(t, EventGuestArrived, events[1].Type)
+		assert.Equal(t, 6, events[1].AvailableSeats)
+		assert.False(t, events[1].Time.IsZero())
+
+		assert.Equal(t, EventGuestLeft, events[2].Type)
+		assert.Equal(t, 0, events[2].AccompanyingGuests)
+		assert.Equal(t, 4, events[2].AvailableSeats)
+	})
+
+	t.Run("logs events with the request logger", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		ctx := logging.WithLogger(context.TODO(), zap.New(core).With(zap.String("request_id", "abc")))
+
+		party := New(zap.NewNop(), newRepo(), 10)
+
+		_, err := party.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "123", Table: 1})
+		require.NoError(t, err)
+
+		entries := logs.FilterMessage("party event").AllUntimed()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "abc", entries[0].ContextMap()["request_id"])
+		assert.Equal(t, EventGuestAdded, entries[0].ContextMap()["type"])
+	})
+
+	t.Run("does not emit events when the operation fails", func(t *testing.T) {
+		var events []Event
+
+		party := New(zap.NewNop(), newRepo(), 10, WithListener(func(e Event) {
+			events = append(events, e)
+		}))
+
+		_, err := party.WelcomeGuest(context.TODO(), &WelcomeGuestInput{Name: "123", AccompanyingGuests: 10})
+		require.Error(t, err)
+
+		assert.Empty(t, events)
+	})
+
+	t.Run("emits import events only once the transaction commits", func(t *testing.T) {
+		var (
+			events           []Event
+			eventsOnDispatch int
+		)
+
+		repo := newRepo()
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			err := fn(newRepo())
+			eventsOnDispatch = len(events)
+			return err
+		}
+
+		party := New(zap.NewNop(), repo, 10, WithListener(func(e Event) {
+			events = append(events, e)
+		}))
+
+		_, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{{Name: "123", Table: 1}, {Name: "456", Table: 2}},
+		})
+		require.NoError(t, err)
+
+		assert.Zero(t, eventsOnDispatch)
+		require.Len(t, events, 2)
+		assert.Equal(t, "123", events[0].Guest)
+		assert.Equal(t, "456", events[1].Guest)
+	})
+}
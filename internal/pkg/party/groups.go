@@ -0,0 +1,359 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/internal/pkg/seating"
+	"github.com/alesr/getground/pkg/database"
+)
+
+// AddGroupToGuestList adds a group and its guests to the guest list in a single transaction.
+// Guests are added with the same rules as AddGuestToGuestList, at the table of the input when given.
+// Otherwise the group sits at the existing table fitting them all with the fewest empty seats left,
+// or else at the fewest adjacent tables, see seating.PlaceGroup. Held seats and tables restricted to
+// other tiers are left out.
+func (p *Party) AddGroupToGuestList(ctx context.Context, in *AddGroupToGuestListInput) (*Group, error) {
+	ctx, span := tracer.Start(ctx, "party.AddGroupToGuestList")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return nil, fmt.Errorf("could not validate input for adding group to list: %w", err)
+	}
+
+	// Add all guests or none, events are only emitted once committed
+	heldParty, releaseEvents := p.holdEvents()
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		if _, err := getGroup(ctx, tx, in.Name); err == nil {
+			return ErrGroupAlreadyExists
+		} else if !errors.Is(err, ErrGroupNotFound) {
+			return err
+		}
+
+		tables, err := groupTables(ctx, tx, in, p.tableSize)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.CreateGroup(ctx, &repository.Group{Name: in.Name, TimeAdded: p.now()}); err != nil {
+			return fmt.Errorf("could not create group: %w", err)
+		}
+
+		txParty := heldParty.withRepository(tx)
+
+		for i, guest := range in.Guests {
+			if _, err := txParty.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{
+				Name:               guest.Name,
+				Table:              tables[i],
+				AccompanyingGuests: guest.AccompanyingGuests,
+				Tier:               guest.Tier,
+			}); err != nil {
+				return fmt.Errorf("guest %s: %w", guest.Name, err)
+			}
+
+			guestStore, err := tx.GetGuestByName(ctx, guest.Name)
+			if err != nil {
+				return fmt.Errorf("could not get guest by name: %w", err)
+			}
+
+			guestStore.Group = in.Name
+
+			if err := tx.UpsertGuest(ctx, guestStore); err != nil {
+				return fmt.Errorf("could not upsert guest: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releaseEvents(ctx)
+
+	return p.GetGroup(ctx, in.Name)
+}
+
+// GetGroup returns a group with its guests.
+func (p *Party) GetGroup(ctx context.Context, name string) (*Group, error) {
+	ctx, span := tracer.Start(ctx, "party.GetGroup")
+	defer span.End()
+
+	if _, err := getGroup(ctx, p.repo, name); err != nil {
+		return nil, err
+	}
+
+	guests, err := p.repo.QueryGuests(ctx, &repository.GuestQuery{Group: name})
+	if err != nil {
+		return nil, fmt.Errorf("could not get group guests: %w", err)
+	}
+
+	group := groupOutput(name, guests)
+	return &group, nil
+}
+
+// ListGroups returns the groups with their guests.
+func (p *Party) ListGroups(ctx context.Context) (ListGroupsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.ListGroups")
+	defer span.End()
+
+	groups, err := p.repo.GetGroups(ctx)
+	if err != nil {
+		return ListGroupsOutput{}, fmt.Errorf("could not get groups: %w", err)
+	}
+
+	guests, err := p.repo.ListGuests(ctx)
+	if err != nil {
+		return ListGroupsOutput{}, fmt.Errorf("could not get guest list: %w", err)
+	}
+
+	byGroup := make(map[string][]repository.Guest, len(groups))
+	for _, guest := range guests {
+		if guest.Group != "" {
+			byGroup[guest.Group] = append(byGroup[guest.Group], guest)
+		}
+	}
+
+	out := ListGroupsOutput{Groups: make([]Group, 0, len(groups))}
+	for _, group := range groups {
+		out.Groups = append(out.Groups, groupOutput(group.Name, byGroup[group.Name]))
+	}
+
+	sort.Slice(out.Groups, func(i, j int) bool {
+		return out.Groups[i].Name < out.Groups[j].Name
+	})
+	return out, nil
+}
+
+// WelcomeGroup records the arrival of the guests of a group not present yet, with their booked accompanying guests.
+// All guests arrive or none.
+func (p *Party) WelcomeGroup(ctx context.Context, in *WelcomeGroupInput) (*Group, error) {
+	ctx, span := tracer.Start(ctx, "party.WelcomeGroup")
+	defer span.End()
+
+	heldParty, releaseEvents := p.holdEvents()
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		guests, err := groupGuests(ctx, tx, in.Name)
+		if err != nil {
+			return err
+		}
+
+		txParty := heldParty.withRepository(tx)
+
+		for _, guest := range guests {
+			if IsPresent(guest) {
+				continue
+			}
+
+			if _, err := txParty.WelcomeGuest(ctx, &WelcomeGuestInput{
+				Name:               guest.Name,
+				AccompanyingGuests: guest.AccompanyingGuests,
+			}); err != nil {
+				return fmt.Errorf("guest %s: %w", guest.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releaseEvents(ctx)
+
+	return p.GetGroup(ctx, in.Name)
+}
+
+// GoodbyeGroup records the departure of the guests of a group present at the party.
+// All guests leave or none.
+func (p *Party) GoodbyeGroup(ctx context.Context, in *GoodbyeGroupInput) (*Group, error) {
+	ctx, span := tracer.Start(ctx, "party.GoodbyeGroup")
+	defer span.End()
+
+	heldParty, releaseEvents := p.holdEvents()
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		guests, err := groupGuests(ctx, tx, in.Name)
+		if err != nil {
+			return err
+		}
+
+		txParty := heldParty.withRepository(tx)
+
+		for _, guest := range guests {
+			if !IsPresent(guest) {
+				continue
+			}
+
+			if err := txParty.GoodbyeGuest(ctx, &GoodbyeGuestInput{Name: guest.Name}); err != nil {
+				return fmt.Errorf("guest %s: %w", guest.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releaseEvents(ctx)
+
+	return p.GetGroup(ctx, in.Name)
+}
+
+// getGroup returns the group of a name, or ErrGroupNotFound.
+func getGroup(ctx context.Context, repo repository.Repository, name string) (*repository.Group, error) {
+	if name == "" {
+		return nil, ErrGroupNameRequired
+	}
+
+	group, err := repo.GetGroupByName(ctx, name)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return nil, fmt.Errorf("could not get group by name: %w", err)
+	}
+
+	// Missing groups are found with a zero name
+	if group == nil || group.Name == "" {
+		return nil, ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// groupGuests returns the guests of an existing group.
+func groupGuests(ctx context.Context, repo repository.Repository, name string) ([]repository.Guest, error) {
+	if _, err := getGroup(ctx, repo, name); err != nil {
+		return nil, err
+	}
+
+	guests, err := repo.QueryGuests(ctx, &repository.GuestQuery{Group: name})
+	if err != nil {
+		return nil, fmt.Errorf("could not get group guests: %w", err)
+	}
+	return guests, nil
+}
+
+// groupTables returns the table of each guest of the group, the table of the input when given.
+// Groups the tables cannot seat get new tables of the given size, as single guests get a table created on demand.
+func groupTables(ctx context.Context, repo repository.Repository, in *AddGroupToGuestListInput, tableSize int) ([]int, error) {
+	tables := make([]int, len(in.Guests))
+
+	if in.Table != 0 {
+		for i := range tables {
+			tables[i] = in.Table
+		}
+		return tables, nil
+	}
+
+	tableStores, err := repo.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get tables: %w", err)
+	}
+
+	layouts, err := repo.GetTableLayouts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get table layouts: %w", err)
+	}
+
+	reservations, err := getTableReservations(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tableStores, func(i, j int) bool {
+		return tableStores[i].Number < tableStores[j].Number
+	})
+
+	free := make([]seating.Table, 0, len(tableStores))
+	for _, table := range tableStores {
+		reservation := reservations[table.Number]
+		free = append(free, seating.Table{
+			Number: table.Number,
+			Size:   bookableSeats(table, reservation),
+			Tiers:  reservationTiers(reservation),
+		})
+	}
+
+	parties := make([]seating.Party, 0, len(in.Guests))
+	for _, guest := range in.Guests {
+		parties = append(parties, seating.Party{Name: guest.Name, Size: guest.AccompanyingGuests + 1, Tier: guestTier(guest.Tier)})
+	}
+
+	assignments, ok := seating.PlaceGroup(parties, free, tableDistance(tableStores, layouts))
+	if !ok {
+		// Numbered after the existing tables, a table per party is enough when any fits
+		next := 1
+		if len(tableStores) > 0 {
+			next = tableStores[len(tableStores)-1].Number + 1
+		}
+
+		for i := range parties {
+			table := repository.Table{Number: next + i, Size: tableSize, AvailableSeats: tableSize}
+			tableStores = append(tableStores, table)
+			free = append(free, seating.Table{Number: table.Number, Size: tableSize})
+		}
+
+		if assignments, ok = seating.PlaceGroup(parties, free, tableDistance(tableStores, layouts)); !ok {
+			return nil, ErrTableNotEnoughSeats
+		}
+	}
+
+	for i, assignment := range assignments {
+		tables[i] = assignment.Table
+	}
+	return tables, nil
+}
+
+// tableDistance measures how far tables stand by the centres of their layout when every table has one,
+// or else by their numbers.
+func tableDistance(tables []repository.Table, layouts []repository.TableLayout) func(a, b int) int {
+	centres := make(map[int][2]int, len(layouts))
+	for _, layout := range layouts {
+		centres[layout.Number] = [2]int{layout.X + layout.Width/2, layout.Y + layout.Height/2}
+	}
+
+	for _, table := range tables {
+		if _, ok := centres[table.Number]; !ok {
+			return func(a, b int) int {
+				return absInt(a - b)
+			}
+		}
+	}
+
+	return func(a, b int) int {
+		dx, dy := centres[a][0]-centres[b][0], centres[a][1]-centres[b][1]
+		return dx*dx + dy*dy
+	}
+}
+
+func groupOutput(name string, guests []repository.Guest) Group {
+	group := Group{
+		Name:   name,
+		Tables: []int{},
+		Guests: make([]Guest, 0, len(guests)),
+	}
+
+	seen := make(map[int]bool)
+	for _, guest := range guests {
+		group.Guests = append(group.Guests, Guest{
+			Name:               guest.Name,
+			Table:              guest.Table,
+			AccompanyingGuests: guest.AccompanyingGuests,
+			Tier:               guest.Tier,
+			TimeArrival:        guest.TimeArrival,
+			TimeDeparture:      guest.TimeDeparture,
+		})
+
+		if !seen[guest.Table] {
+			seen[guest.Table] = true
+			group.Tables = append(group.Tables, guest.Table)
+		}
+	}
+
+	sort.Ints(group.Tables)
+	sort.Slice(group.Guests, func(i, j int) bool {
+		return group.Guests[i].Name < group.Guests[j].Name
+	})
+	return group
+}
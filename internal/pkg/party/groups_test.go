@@ -0,0 +1,393 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// groupsTestState is the guest list of groupsTestRepo.
+type groupsTestState struct {
+	guests       map[string]repository.Guest
+	tables       map[int]repository.Table
+	reservations []repository.TableReservation
+	groups       map[string]repository.Group
+	seats        []repository.Seat
+}
+
+// groupsTestRepo seats bob and his two accompanying guests at table 1, and the doe group, jane and her
+// accompanying guest then jim, at table 2. Tables 1 and 2 have 4 seats, table 3 has 6.
+func groupsTestRepo() (*repository.Mock, *groupsTestState) {
+	state := groupsTestState{
+		guests: map[string]repository.Guest{
+			"bob":  {Name: "bob", Table: 1, AccompanyingGuests: 2},
+			"jane": {Name: "jane", Table: 2, AccompanyingGuests: 1, Group: "doe"},
+			"jim":  {Name: "jim", Table: 2, Group: "doe"},
+		},
+		tables: map[int]repository.Table{
+			1: {Number: 1, Size: 4, AvailableSeats: 1},
+			2: {Number: 2, Size: 4, AvailableSeats: 1},
+			3: {Number: 3, Size: 6, AvailableSeats: 6},
+		},
+		groups: map[string]repository.Group{
+			"doe": {Name: "doe", TimeAdded: testNow},
+		},
+	}
+
+	repo := repository.Mock{}
+	repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(&repo)
+	}
+	repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+		return state.guestList(""), nil
+	}
+	repo.QueryGuestsFunc = func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+		return state.guestList(query.Group), nil
+	}
+	repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+		guest := state.guests[name]
+		return &guest, nil
+	}
+	repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+		state.guests[guest.Name] = *guest
+		return nil
+	}
+	repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+		table, ok := state.tables[number]
+		if !ok {
+			return nil, nil
+		}
+		return &table, nil
+	}
+	repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+		var tables []repository.Table
+		for _, table := range state.tables {
+			tables = append(tables, table)
+		}
+		return tables, nil
+	}
+	repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+		return state.reservations, nil
+	}
+	repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error {
+		state.tables[table.Number] = *table
+		return nil
+	}
+	repo.GetTableLayoutsFunc = func(ctx context.Context) ([]repository.TableLayout, error) {
+		return nil, nil
+	}
+	repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+		var seats []repository.Seat
+		for _, seat := range state.seats {
+			if seat.Table == table {
+				seats = append(seats, seat)
+			}
+		}
+		return seats, nil
+	}
+	repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+		state.seats = append(state.seats, seats...)
+		return nil
+	}
+	repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error {
+		kept := state.seats[:0]
+		for _, seat := range state.seats {
+			if seat.Guest != name {
+				kept = append(kept, seat)
+			}
+		}
+		state.seats = kept
+		return nil
+	}
+	repo.GetGroupsFunc = func(ctx context.Context) ([]repository.Group, error) {
+		var groups []repository.Group
+		for _, group := range state.groups {
+			groups = append(groups, group)
+		}
+		return groups, nil
+	}
+	repo.GetGroupByNameFunc = func(ctx context.Context, name string) (*repository.Group, error) {
+		group := state.groups[name]
+		return &group, nil
+	}
+	repo.CreateGroupFunc = func(ctx context.Context, group *repository.Group) error {
+		state.groups[group.Name] = *group
+		return nil
+	}
+	repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+		return nil, nil
+	}
+	repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error { return nil }
+	return &repo, &state
+}
+
+// guestList returns the guests sorted by name, those of a group when given.
+func (s *groupsTestState) guestList(group string) []repository.Guest {
+	var guests []repository.Guest
+	for _, guest := range s.guests {
+		if group == "" || guest.Group == group {
+			guests = append(guests, guest)
+		}
+	}
+
+	sort.Slice(guests, func(i, j int) bool {
+		return guests[i].Name < guests[j].Name
+	})
+	return guests
+}
+
+func TestAddGroupToGuestList(t *testing.T) {
+	cases := []struct {
+		name              string
+		givenFreshEvent   bool
+		givenReservations []repository.TableReservation
+		given             AddGroupToGuestListInput
+		expected          *Group
+		expectedErr       error
+	}{
+		{
+			name:  "seats the group at the table fitting them all",
+			given: AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy", AccompanyingGuests: 1}, {Name: "joe"}}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{3},
+				Guests: []Guest{{Name: "amy", Table: 3, AccompanyingGuests: 1}, {Name: "joe", Table: 3}},
+			},
+		},
+		{
+			name: "splits a group too large for a table over adjacent tables",
+			given: AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{
+				{Name: "amy", AccompanyingGuests: 4}, {Name: "joe"}, {Name: "sue"},
+			}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{2, 3},
+				Guests: []Guest{{Name: "amy", Table: 3, AccompanyingGuests: 4}, {Name: "joe", Table: 3}, {Name: "sue", Table: 2}},
+			},
+		},
+		{
+			name:  "seats the group at the table given",
+			given: AddGroupToGuestListInput{Name: "smith", Table: 4, Guests: []GroupGuest{{Name: "amy"}, {Name: "joe"}}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{4},
+				Guests: []Guest{{Name: "amy", Table: 4}, {Name: "joe", Table: 4}},
+			},
+		},
+		{
+			name:              "seats each guest at a table allowing their tier",
+			givenReservations: []repository.TableReservation{{Table: 3, Tiers: TierVIP}},
+			given: AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{
+				{Name: "amy", AccompanyingGuests: 1, Tier: TierVIP}, {Name: "joe"},
+			}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{2, 3},
+				Guests: []Guest{{Name: "amy", Table: 3, AccompanyingGuests: 1, Tier: TierVIP}, {Name: "joe", Table: 2}},
+			},
+		},
+		{
+			name:              "seats the group at a new table when the seats are held",
+			givenReservations: []repository.TableReservation{{Table: 3, HeldSeats: 5}},
+			given:             AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy", AccompanyingGuests: 1}}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{4},
+				Guests: []Guest{{Name: "amy", Table: 4, AccompanyingGuests: 1}},
+			},
+		},
+		{
+			name:            "creates a table for the group on a fresh event",
+			givenFreshEvent: true,
+			given:           AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy", AccompanyingGuests: 1}, {Name: "joe"}}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{1},
+				Guests: []Guest{{Name: "amy", Table: 1, AccompanyingGuests: 1}, {Name: "joe", Table: 1}},
+			},
+		},
+		{
+			name:            "creates adjacent tables for a group too large for a table on a fresh event",
+			givenFreshEvent: true,
+			given: AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{
+				{Name: "amy", AccompanyingGuests: 5}, {Name: "joe", AccompanyingGuests: 3},
+			}},
+			expected: &Group{
+				Name:   "smith",
+				Tables: []int{1, 2},
+				Guests: []Guest{{Name: "amy", Table: 1, AccompanyingGuests: 5}, {Name: "joe", Table: 2, AccompanyingGuests: 3}},
+			},
+		},
+		{
+			name:              "table given restricted to other tiers",
+			givenReservations: []repository.TableReservation{{Table: 3, Tiers: TierStaff}},
+			given:             AddGroupToGuestListInput{Name: "smith", Table: 3, Guests: []GroupGuest{{Name: "amy", Tier: TierVIP}}},
+			expectedErr:       ErrTableTierRestricted,
+		},
+		{
+			name:        "guest with an invalid tier",
+			given:       AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy", Tier: "gold"}}},
+			expectedErr: ErrTierInvalid,
+		},
+		{
+			name:        "table given without enough seats",
+			given:       AddGroupToGuestListInput{Name: "smith", Table: 1, Guests: []GroupGuest{{Name: "amy"}, {Name: "joe"}}},
+			expectedErr: ErrTableNotEnoughSeats,
+		},
+		{
+			name:        "group too large for the tables",
+			given:       AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy", AccompanyingGuests: 8}}},
+			expectedErr: ErrTableNotEnoughSeats,
+		},
+		{
+			name:        "group already exists",
+			given:       AddGroupToGuestListInput{Name: "doe", Guests: []GroupGuest{{Name: "amy"}}},
+			expectedErr: ErrGroupAlreadyExists,
+		},
+		{
+			name:        "guest already in list",
+			given:       AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy"}, {Name: "bob"}}},
+			expectedErr: ErrGuestAlreadyInList,
+		},
+		{
+			name:        "group without name",
+			given:       AddGroupToGuestListInput{Guests: []GroupGuest{{Name: "amy"}}},
+			expectedErr: ErrGroupNameRequired,
+		},
+		{
+			name:        "group without guests",
+			given:       AddGroupToGuestListInput{Name: "smith"},
+			expectedErr: ErrGroupGuestsRequired,
+		},
+		{
+			name:        "guest given twice",
+			given:       AddGroupToGuestListInput{Name: "smith", Guests: []GroupGuest{{Name: "amy"}, {Name: "amy"}}},
+			expectedErr: ErrGuestDuplicatedInGroup,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, state := groupsTestRepo()
+			state.reservations = tc.givenReservations
+
+			if tc.givenFreshEvent {
+				state.guests = map[string]repository.Guest{}
+				state.tables = map[int]repository.Table{}
+				state.groups = map[string]repository.Group{}
+			}
+
+			var events []Event
+			party := New(zap.NewNop(), repo, 8, WithListener(func(e Event) {
+				events = append(events, e)
+			}))
+
+			observed, err := party.AddGroupToGuestList(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				assert.Empty(t, events)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, observed)
+			assert.Contains(t, state.groups, tc.given.Name)
+			assert.Len(t, events, len(tc.given.Guests))
+
+			for _, guest := range tc.given.Guests {
+				assert.Equal(t, tc.given.Name, state.guests[guest.Name].Group)
+			}
+		})
+	}
+}
+
+func TestGetGroup(t *testing.T) {
+	repo, _ := groupsTestRepo()
+	party := New(zap.NewNop(), repo, testTableSize)
+
+	observed, err := party.GetGroup(context.TODO(), "doe")
+	require.NoError(t, err)
+	assert.Equal(t, &Group{
+		Name:   "doe",
+		Tables: []int{2},
+		Guests: []Guest{{Name: "jane", Table: 2, AccompanyingGuests: 1}, {Name: "jim", Table: 2}},
+	}, observed)
+
+	_, err = party.GetGroup(context.TODO(), "smith")
+	assert.True(t, errors.Is(err, ErrGroupNotFound))
+}
+
+func TestListGroups(t *testing.T) {
+	repo, state := groupsTestRepo()
+	state.groups["adams"] = repository.Group{Name: "adams", TimeAdded: testNow}
+
+	party := New(zap.NewNop(), repo, testTableSize)
+
+	observed, err := party.ListGroups(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, ListGroupsOutput{Groups: []Group{
+		{Name: "adams", Tables: []int{}, Guests: []Guest{}},
+		{
+			Name:   "doe",
+			Tables: []int{2},
+			Guests: []Guest{{Name: "jane", Table: 2, AccompanyingGuests: 1}, {Name: "jim", Table: 2}},
+		},
+	}}, observed)
+}
+
+func TestWelcomeAndGoodbyeGroup(t *testing.T) {
+	repo, state := groupsTestRepo()
+
+	var events []Event
+	party := New(zap.NewNop(), repo, testTableSize, WithListener(func(e Event) {
+		events = append(events, e)
+	}))
+
+	group, err := party.WelcomeGroup(context.TODO(), &WelcomeGroupInput{Name: "doe"})
+	require.NoError(t, err)
+
+	for _, guest := range group.Guests {
+		assert.NotNil(t, guest.TimeArrival, guest.Name)
+	}
+	assert.Equal(t, 1, state.tables[2].AvailableSeats)
+	require.Len(t, events, 2)
+	assert.Equal(t, EventGuestArrived, events[0].Type)
+
+	// Guests present are not welcomed twice
+	_, err = party.WelcomeGroup(context.TODO(), &WelcomeGroupInput{Name: "doe"})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, err = party.GoodbyeGroup(context.TODO(), &GoodbyeGroupInput{Name: "doe"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.tables[2].AvailableSeats)
+	require.Len(t, events, 4)
+	assert.Equal(t, EventGuestLeft, events[3].Type)
+
+	_, err = party.WelcomeGroup(context.TODO(), &WelcomeGroupInput{Name: "smith"})
+	assert.True(t, errors.Is(err, ErrGroupNotFound))
+
+	_, err = party.GoodbyeGroup(context.TODO(), &GoodbyeGroupInput{Name: "smith"})
+	assert.True(t, errors.Is(err, ErrGroupNotFound))
+}
+
+func TestTableDistance(t *testing.T) {
+	tables := []repository.Table{{Number: 1}, {Number: 2}, {Number: 5}}
+
+	byNumber := tableDistance(tables, []repository.TableLayout{{Number: 1}, {Number: 2}})
+	assert.Equal(t, 4, byNumber(1, 5))
+
+	byLayout := tableDistance(tables, []repository.TableLayout{
+		{Number: 1, X: 0, Y: 0, Width: 100, Height: 100},
+		{Number: 2, X: 900, Y: 0, Width: 100, Height: 100},
+		{Number: 5, X: 0, Y: 200, Width: 100, Height: 100},
+	})
+	assert.Less(t, byLayout(1, 5), byLayout(1, 2))
+}
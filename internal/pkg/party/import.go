@@ -0,0 +1,174 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+)
+
+// importRowFailure carries the row that failed while applying an import.
+type importRowFailure struct {
+	row  int
+	name string
+	err  error
+}
+
+func (e *importRowFailure) Error() string {
+	return fmt.Sprintf("could not import row %d: %s", e.row, e.err)
+}
+
+func (e *importRowFailure) Unwrap() error { return e.err }
+
+// ImportGuests adds all the given guests to the guest list in a single transaction.
+// Every row is checked with the same rules as AddGuestToGuestList before anything is written,
+// and no guest is added if any row is rejected.
+func (p *Party) ImportGuests(ctx context.Context, in *ImportGuestsInput) (*ImportGuestsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.ImportGuests")
+	defer span.End()
+
+	if len(in.Guests) == 0 {
+		return nil, ErrImportEmpty
+	}
+
+	out := ImportGuestsOutput{
+		Rows:   len(in.Guests),
+		DryRun: in.DryRun,
+	}
+
+	rowErrs, err := p.checkImportRows(ctx, in.Guests)
+	if err != nil {
+		return nil, fmt.Errorf("could not check import rows: %w", err)
+	}
+
+	if len(rowErrs) > 0 || in.DryRun {
+		out.Errors = rowErrs
+		return &out, nil
+	}
+
+	// Apply all rows or none, events are only emitted once committed
+	heldParty, releaseEvents := p.holdEvents()
+
+	err = p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		txParty := heldParty.withRepository(tx)
+
+		for i, row := range in.Guests {
+			if _, err := txParty.AddGuestToGuestList(ctx, row.addGuestInput()); err != nil {
+				return &importRowFailure{row: i + 1, name: row.Name, err: err}
+			}
+		}
+		return nil
+	})
+
+	// Rows were checked beforehand, so seat and duplicate failures here
+	// mean the guest list changed concurrently and are reported as row errors
+	var rowFailure *importRowFailure
+	if errors.As(err, &rowFailure) {
+		for _, rowErr := range []error{ErrGuestAlreadyInList, ErrTableNotEnoughSeats, ErrTableTierRestricted} {
+			if errors.Is(rowFailure.err, rowErr) {
+				out.Errors = []ImportRowError{{Row: rowFailure.row, Name: rowFailure.name, Error: rowErr.Error()}}
+				return &out, nil
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not import guests: %w", err)
+	}
+
+	releaseEvents(ctx)
+
+	out.Imported = len(in.Guests)
+	return &out, nil
+}
+
+// checkImportRows validates the rows and simulates the seat allocation against the current tables.
+func (p *Party) checkImportRows(ctx context.Context, rows []ImportGuestRow) ([]ImportRowError, error) {
+	guests, err := p.repo.ListGuests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get guest list: %w", err)
+	}
+
+	tables, err := p.repo.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get tables: %w", err)
+	}
+
+	reservations, err := getTableReservations(ctx, p.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool, len(guests))
+	for _, guest := range guests {
+		listed[guest.Name] = true
+	}
+
+	availableSeats := make(map[int]int, len(tables))
+	for _, table := range tables {
+		availableSeats[table.Number] = bookableSeats(table, reservations[table.Number])
+	}
+
+	var (
+		rowErrs []ImportRowError
+		seen    = make(map[string]bool, len(rows))
+	)
+
+	for i, row := range rows {
+		rowErr := func(err error) {
+			rowErrs = append(rowErrs, ImportRowError{Row: i + 1, Name: row.Name, Error: err.Error()})
+		}
+
+		if err := row.addGuestInput().validate(); err != nil {
+			rowErr(err)
+			continue
+		}
+
+		if listed[row.Name] {
+			rowErr(ErrGuestAlreadyInList)
+			continue
+		}
+
+		if seen[row.Name] {
+			rowErr(ErrGuestDuplicatedInImport)
+			continue
+		}
+		seen[row.Name] = true
+
+		if !allowsTier(reservations[row.Table], row.Tier) {
+			rowErr(ErrTableTierRestricted)
+			continue
+		}
+
+		// Tables that do not exist yet are created with the default size
+		available, ok := availableSeats[row.Table]
+		if !ok {
+			available = p.tableSize
+		}
+
+		requestedSeats := row.AccompanyingGuests + 1
+		if available < requestedSeats {
+			rowErr(ErrTableNotEnoughSeats)
+			continue
+		}
+		availableSeats[row.Table] = available - requestedSeats
+	}
+	return rowErrs, nil
+}
+
+// withRepository returns a copy of the party service backed by another repository.
+func (p *Party) withRepository(repo repository.Repository) *Party {
+	cp := *p
+	cp.repo = repo
+	return &cp
+}
+
+func (r ImportGuestRow) addGuestInput() *AddGuestToGuestListInput {
+	return &AddGuestToGuestListInput{
+		Name:               r.Name,
+		Table:              r.Table,
+		AccompanyingGuests: r.AccompanyingGuests,
+		Tier:               r.Tier,
+	}
+}
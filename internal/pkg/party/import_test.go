@@ -0,0 +1,180 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestImportGuests(t *testing.T) {
+	newRepo := func() *repository.Mock {
+		repo := repository.Mock{}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return []repository.Guest{{Name: "listed", Table: 1}}, nil
+		}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return []repository.Table{{Number: 1, AvailableSeats: 2, Size: 3}, {Number: 4, AvailableSeats: 3, Size: 3}}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return []repository.TableReservation{{Table: 4, Tiers: TierVIP, HeldSeats: 2}}, nil
+		}
+		return &repo
+	}
+
+	t.Run("returns an error when there are no rows", func(t *testing.T) {
+		party := New(zap.NewNop(), newRepo(), 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrImportEmpty))
+	})
+
+	t.Run("returns an error when the repository fails", func(t *testing.T) {
+		repo := newRepo()
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{{Name: "123", Table: 1}},
+		})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("reports every rejected row without writing anything", func(t *testing.T) {
+		repo := newRepo()
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			t.Fatal("transaction must not start when rows are rejected")
+			return nil
+		}
+
+		party := New(zap.NewNop(), repo, 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{
+				{Name: "", Table: 1},
+				{Name: "listed", Table: 2},
+				{Name: "fits", Table: 1, AccompanyingGuests: 1},
+				{Name: "fits", Table: 2},
+				{Name: "table full", Table: 1},
+				{Name: "too many", Table: 3, AccompanyingGuests: 3},
+				{Name: "invalid", Table: 3, AccompanyingGuests: -1},
+				{Name: "new table", Table: 3, AccompanyingGuests: 2},
+				{Name: "restricted", Table: 4},
+				{Name: "held", Table: 4, AccompanyingGuests: 1, Tier: TierVIP},
+				{Name: "vip", Table: 4, Tier: TierVIP},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, &ImportGuestsOutput{
+			Rows: 11,
+			Errors: []ImportRowError{
+				{Row: 1, Name: "", Error: ErrGuestNameRequired.Error()},
+				{Row: 2, Name: "listed", Error: ErrGuestAlreadyInList.Error()},
+				{Row: 4, Name: "fits", Error: ErrGuestDuplicatedInImport.Error()},
+				{Row: 5, Name: "table full", Error: ErrTableNotEnoughSeats.Error()},
+				{Row: 6, Name: "too many", Error: ErrTableNotEnoughSeats.Error()},
+				{Row: 7, Name: "invalid", Error: ErrAccompanyingGuestsNumberInvalid.Error()},
+				{Row: 9, Name: "restricted", Error: ErrTableTierRestricted.Error()},
+				{Row: 10, Name: "held", Error: ErrTableNotEnoughSeats.Error()},
+			},
+		}, observed)
+	})
+
+	t.Run("does not write anything on dry run", func(t *testing.T) {
+		repo := newRepo()
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			t.Fatal("transaction must not start on dry run")
+			return nil
+		}
+
+		party := New(zap.NewNop(), repo, 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{{Name: "123", Table: 1}},
+			DryRun: true,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, &ImportGuestsOutput{Rows: 1, DryRun: true}, observed)
+	})
+
+	t.Run("adds every row within a transaction", func(t *testing.T) {
+		var added []string
+
+		txRepo := newRepo()
+		txRepo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 2, Size: 3}, nil
+		}
+		txRepo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+			added = append(added, guest.Name)
+			return nil
+		}
+		txRepo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+		txRepo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+		txRepo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
+		txRepo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return nil, nil
+		}
+		txRepo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error { return nil }
+
+		repo := newRepo()
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(txRepo)
+		}
+
+		party := New(zap.NewNop(), repo, 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{{Name: "123", Table: 1}, {Name: "456", Table: 2}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, &ImportGuestsOutput{Rows: 2, Imported: 2}, observed)
+		assert.Equal(t, []string{"123", "456"}, added)
+	})
+
+	t.Run("reports the failing row when the guest list changed during the import", func(t *testing.T) {
+		txRepo := newRepo()
+		txRepo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 0, Size: 3}, nil
+		}
+
+		repo := newRepo()
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(txRepo)
+		}
+
+		party := New(zap.NewNop(), repo, 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{{Name: "123", Table: 1}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, &ImportGuestsOutput{
+			Rows:   1,
+			Errors: []ImportRowError{{Row: 1, Name: "123", Error: ErrTableNotEnoughSeats.Error()}},
+		}, observed)
+	})
+
+	t.Run("returns an error when the transaction fails", func(t *testing.T) {
+		repo := newRepo()
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, 3)
+		observed, err := party.ImportGuests(context.TODO(), &ImportGuestsInput{
+			Guests: []ImportGuestRow{{Name: "123", Table: 1}},
+		})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+}
@@ -0,0 +1,199 @@
+package party
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/pkg/database"
+)
+
+// CreateInvitation reserves an invitation for a guest slot and returns its signed token.
+func (p *Party) CreateInvitation(ctx context.Context, in *CreateInvitationInput) (*CreateInvitationOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.CreateInvitation")
+	defer span.End()
+
+	if len(p.invitationSecret) == 0 {
+		return nil, ErrInvitationSecretMissing
+	}
+
+	now := p.now()
+
+	// Validate input
+	if err := in.validate(now); err != nil {
+		return nil, fmt.Errorf("could not validate input for creating invitation: %w", err)
+	}
+
+	expiresAt := now.Add(p.invitationTTL)
+	if in.ExpiresAt != nil {
+		expiresAt = *in.ExpiresAt
+	}
+
+	id, err := newInvitationID()
+	if err != nil {
+		return nil, fmt.Errorf("could not generate invitation id: %w", err)
+	}
+
+	token, err := signInvitationToken(p.invitationSecret, &invitationClaims{
+		ID:           id,
+		GuestName:    in.Name,
+		Table:        in.Table,
+		MaxPartySize: in.MaxPartySize,
+		ExpiresAt:    expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not sign invitation token: %w", err)
+	}
+
+	// Store invitation so its answer can be tracked
+	invitationStore := repository.Invitation{
+		ID:           id,
+		GuestName:    in.Name,
+		Table:        in.Table,
+		MaxPartySize: in.MaxPartySize,
+		ExpiresAt:    expiresAt,
+		Status:       InvitationStatusPending,
+		Tier:         in.Tier,
+	}
+
+	if err := p.repo.UpsertInvitation(ctx, &invitationStore); err != nil {
+		return nil, fmt.Errorf("could not upsert invitation: %w", err)
+	}
+
+	return &CreateInvitationOutput{
+		Name:      in.Name,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetInvitation returns the invitation details for the token holder.
+func (p *Party) GetInvitation(ctx context.Context, token string) (*GetInvitationOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetInvitation")
+	defer span.End()
+
+	invitation, err := p.invitationFromToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetInvitationOutput{
+		Name:               invitation.GuestName,
+		Table:              invitation.Table,
+		MaxPartySize:       invitation.MaxPartySize,
+		ExpiresAt:          invitation.ExpiresAt,
+		Status:             invitation.Status,
+		AccompanyingGuests: invitation.AccompanyingGuests,
+	}, nil
+}
+
+// RespondToInvitation accepts or declines an invitation.
+// Accepting adds the guest to the guest list through the same checks as AddGuestToGuestList,
+// and answers the check-in code issued to the guest.
+func (p *Party) RespondToInvitation(ctx context.Context, in *RespondToInvitationInput) (*RespondToInvitationOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.RespondToInvitation")
+	defer span.End()
+
+	invitation, err := p.invitationFromToken(ctx, in.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.Status != InvitationStatusPending {
+		return nil, ErrInvitationAlreadyAnswered
+	}
+
+	status := InvitationStatusDeclined
+
+	if in.Attending {
+		if in.AccompanyingGuests < 0 {
+			return nil, ErrAccompanyingGuestsNumberInvalid
+		}
+
+		if in.AccompanyingGuests+1 > invitation.MaxPartySize {
+			return nil, ErrInvitationPartyTooLarge
+		}
+
+		status = InvitationStatusAccepted
+		invitation.AccompanyingGuests = in.AccompanyingGuests
+	}
+
+	// Record the answer and add the guest together, events are only emitted once committed
+	heldParty, releaseEvents := p.holdEvents()
+
+	var checkInCode string
+
+	err = p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		// Record the answer first, so a concurrent answer to the same invitation is turned down
+		// before it touches the guest list
+		respondedAt := p.now()
+		invitation.Status = status
+		invitation.TimeResponded = &respondedAt
+
+		answered, err := tx.AnswerInvitation(ctx, invitation, InvitationStatusPending)
+		if err != nil {
+			return fmt.Errorf("could not answer invitation: %w", err)
+		}
+		if !answered {
+			return ErrInvitationAlreadyAnswered
+		}
+
+		if in.Attending {
+			added, err := heldParty.withRepository(tx).AddGuestToGuestList(ctx, &AddGuestToGuestListInput{
+				Name:               invitation.GuestName,
+				Table:              invitation.Table,
+				AccompanyingGuests: in.AccompanyingGuests,
+				Tier:               invitation.Tier,
+			})
+			if err != nil {
+				return fmt.Errorf("could not add invited guest to guest list: %w", err)
+			}
+			checkInCode = added.CheckInCode
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releaseEvents(ctx)
+
+	return &RespondToInvitationOutput{
+		Name:        invitation.GuestName,
+		Status:      status,
+		CheckInCode: checkInCode,
+	}, nil
+}
+
+// invitationFromToken verifies the token and loads the invitation it refers to.
+func (p *Party) invitationFromToken(ctx context.Context, token string) (*repository.Invitation, error) {
+	if len(p.invitationSecret) == 0 {
+		return nil, ErrInvitationSecretMissing
+	}
+
+	claims, err := parseInvitationToken(p.invitationSecret, token, p.now())
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := p.repo.GetInvitationByID(ctx, claims.ID)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return nil, fmt.Errorf("could not get invitation by id: %w", err)
+	}
+
+	if invitation == nil || invitation.ID == "" {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+func newInvitationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,455 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var (
+	testInvitationSecret = []byte("test secret")
+	testNow              = time.Date(2021, time.November, 23, 12, 0, 0, 0, time.UTC)
+)
+
+func newTestInvitationParty(repo repository.Repository) *Party {
+	p := New(zap.NewNop(), repo, testTableSize, WithInvitationSecret(testInvitationSecret))
+	p.now = func() time.Time { return testNow }
+	return p
+}
+
+func TestCreateInvitation(t *testing.T) {
+	cases := []struct {
+		name          string
+		given         *CreateInvitationInput
+		expectedError error
+	}{
+		{
+			name:          "required name input",
+			given:         &CreateInvitationInput{Table: 1, MaxPartySize: 2},
+			expectedError: ErrGuestNameRequired,
+		},
+		{
+			name:          "required table input",
+			given:         &CreateInvitationInput{Name: "123", MaxPartySize: 2},
+			expectedError: ErrTableNumberRequired,
+		},
+		{
+			name:          "invalid table input",
+			given:         &CreateInvitationInput{Name: "123", Table: -1, MaxPartySize: 2},
+			expectedError: ErrTableNumberInvalid,
+		},
+		{
+			name:          "invalid max party size input",
+			given:         &CreateInvitationInput{Name: "123", Table: 1},
+			expectedError: ErrMaxPartySizeInvalid,
+		},
+		{
+			name:          "invalid tier input",
+			given:         &CreateInvitationInput{Name: "123", Table: 1, MaxPartySize: 2, Tier: "gold"},
+			expectedError: ErrTierInvalid,
+		},
+		{
+			name: "expiry in the past",
+			given: &CreateInvitationInput{
+				Name:         "123",
+				Table:        1,
+				MaxPartySize: 2,
+				ExpiresAt:    func() *time.Time { t := testNow.Add(-time.Hour); return &t }(),
+			},
+			expectedError: ErrInvitationExpired,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			party := newTestInvitationParty(&repository.Mock{})
+			observed, err := party.CreateInvitation(context.TODO(), c.given)
+
+			assert.Nil(t, observed)
+			assert.True(t, errors.Is(err, c.expectedError))
+		})
+	}
+
+	t.Run("returns an error when no secret is configured", func(t *testing.T) {
+		party := New(zap.NewNop(), &repository.Mock{}, testTableSize)
+		observed, err := party.CreateInvitation(context.TODO(), &CreateInvitationInput{Name: "123", Table: 1, MaxPartySize: 2})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrInvitationSecretMissing))
+	})
+
+	t.Run("returns an error when upsert invitation fails", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.UpsertInvitationFunc = func(ctx context.Context, invitation *repository.Invitation) error {
+			return errTestRepo
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.CreateInvitation(context.TODO(), &CreateInvitationInput{Name: "123", Table: 1, MaxPartySize: 2})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("stores a pending invitation and returns a token bound to it", func(t *testing.T) {
+		var stored *repository.Invitation
+
+		repo := repository.Mock{}
+		repo.UpsertInvitationFunc = func(ctx context.Context, invitation *repository.Invitation) error {
+			stored = invitation
+			return nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.CreateInvitation(context.TODO(), &CreateInvitationInput{Name: "123", Table: 1, MaxPartySize: 2})
+		require.NoError(t, err)
+
+		require.NotNil(t, stored)
+		assert.Equal(t, InvitationStatusPending, stored.Status)
+		assert.Equal(t, testNow.Add(defaultInvitationTTL), observed.ExpiresAt)
+
+		claims, err := parseInvitationToken(testInvitationSecret, observed.Token, testNow)
+		require.NoError(t, err)
+
+		assert.Equal(t, stored.ID, claims.ID)
+		assert.Equal(t, "123", claims.GuestName)
+		assert.Equal(t, 2, claims.MaxPartySize)
+	})
+}
+
+func TestParseInvitationToken(t *testing.T) {
+	token, err := signInvitationToken(testInvitationSecret, &invitationClaims{
+		ID:        "abc",
+		ExpiresAt: testNow.Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name          string
+		givenSecret   []byte
+		givenToken    string
+		givenNow      time.Time
+		expectedError error
+	}{
+		{
+			name:          "valid token",
+			givenSecret:   testInvitationSecret,
+			givenToken:    token,
+			givenNow:      testNow,
+			expectedError: nil,
+		},
+		{
+			name:          "malformed token",
+			givenSecret:   testInvitationSecret,
+			givenToken:    "not-a-token",
+			givenNow:      testNow,
+			expectedError: ErrInvitationTokenInvalid,
+		},
+		{
+			name:          "token signed with another secret",
+			givenSecret:   []byte("another secret"),
+			givenToken:    token,
+			givenNow:      testNow,
+			expectedError: ErrInvitationTokenInvalid,
+		},
+		{
+			name:          "tampered payload",
+			givenSecret:   testInvitationSecret,
+			givenToken:    "e30" + token[3:],
+			givenNow:      testNow,
+			expectedError: ErrInvitationTokenInvalid,
+		},
+		{
+			name:          "expired token",
+			givenSecret:   testInvitationSecret,
+			givenToken:    token,
+			givenNow:      testNow.Add(2 * time.Hour),
+			expectedError: ErrInvitationExpired,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := parseInvitationToken(c.givenSecret, c.givenToken, c.givenNow)
+			assert.True(t, errors.Is(err, c.expectedError))
+		})
+	}
+}
+
+func TestRespondToInvitation(t *testing.T) {
+	newToken := func(t *testing.T) string {
+		token, err := signInvitationToken(testInvitationSecret, &invitationClaims{
+			ID:           "abc",
+			GuestName:    "123",
+			Table:        1,
+			MaxPartySize: 3,
+			ExpiresAt:    testNow.Add(time.Hour).Unix(),
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	pendingInvitation := func() *repository.Invitation {
+		return &repository.Invitation{
+			ID:           "abc",
+			GuestName:    "123",
+			Table:        1,
+			MaxPartySize: 3,
+			Status:       InvitationStatusPending,
+			Tier:         TierVIP,
+		}
+	}
+
+	t.Run("returns an error when the invitation is not found", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return nil, nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{Token: newToken(t)})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrInvitationNotFound))
+	})
+
+	t.Run("returns an error when the invitation was already answered", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			invitation := pendingInvitation()
+			invitation.Status = InvitationStatusDeclined
+			return invitation, nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{Token: newToken(t), Attending: true})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrInvitationAlreadyAnswered))
+	})
+
+	t.Run("returns an error when the party exceeds the invitation size", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return pendingInvitation(), nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{
+			Token:              newToken(t),
+			Attending:          true,
+			AccompanyingGuests: 3,
+		})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrInvitationPartyTooLarge))
+	})
+
+	t.Run("returns an error when the table has not enough seats", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return pendingInvitation(), nil
+		}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) { return nil, nil }
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 1}, nil
+		}
+		repo.AnswerInvitationFunc = func(ctx context.Context, invitation *repository.Invitation, fromStatus string) (bool, error) {
+			return true, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{
+			Token:              newToken(t),
+			Attending:          true,
+			AccompanyingGuests: 2,
+		})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrTableNotEnoughSeats))
+	})
+
+	t.Run("accepts the invitation and adds the guest to the guest list", func(t *testing.T) {
+		var (
+			addedGuest *repository.Guest
+			stored     *repository.Invitation
+			issued     *repository.CheckInCode
+		)
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return pendingInvitation(), nil
+		}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) { return nil, nil }
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 3, Size: 3}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+			addedGuest = guest
+			return nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+		repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
+		repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return nil, nil
+		}
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			issued = code
+			return nil
+		}
+		repo.AnswerInvitationFunc = func(ctx context.Context, invitation *repository.Invitation, fromStatus string) (bool, error) {
+			assert.Equal(t, InvitationStatusPending, fromStatus)
+			stored = invitation
+			return true, nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{
+			Token:              newToken(t),
+			Attending:          true,
+			AccompanyingGuests: 2,
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, issued)
+		assert.Equal(t, &RespondToInvitationOutput{Name: "123", Status: InvitationStatusAccepted, CheckInCode: issued.Code}, observed)
+		assert.Equal(t, "123", issued.GuestName)
+		assert.Equal(t, CheckInCodeStatusActive, issued.Status)
+		require.NotNil(t, addedGuest)
+		assert.Equal(t, 2, addedGuest.AccompanyingGuests)
+		assert.Equal(t, TierVIP, addedGuest.Tier)
+		require.NotNil(t, stored)
+		assert.Equal(t, InvitationStatusAccepted, stored.Status)
+	})
+
+	t.Run("declines the invitation without touching the guest list", func(t *testing.T) {
+		var stored *repository.Invitation
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return pendingInvitation(), nil
+		}
+		repo.AnswerInvitationFunc = func(ctx context.Context, invitation *repository.Invitation, fromStatus string) (bool, error) {
+			assert.Equal(t, InvitationStatusPending, fromStatus)
+			stored = invitation
+			return true, nil
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{Token: newToken(t)})
+		require.NoError(t, err)
+
+		assert.Equal(t, &RespondToInvitationOutput{Name: "123", Status: InvitationStatusDeclined}, observed)
+		require.NotNil(t, stored)
+		assert.Equal(t, InvitationStatusDeclined, stored.Status)
+	})
+
+	t.Run("adds the guest and records the answer in one transaction", func(t *testing.T) {
+		var committed bool
+
+		// Writes are only set on the transaction repository, and the transaction fails as the check-in code cannot be issued
+		tx := repository.Mock{}
+		tx.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) { return nil, nil }
+		tx.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, AvailableSeats: 3, Size: 3}, nil
+		}
+		tx.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
+		tx.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error { return nil }
+		tx.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+		tx.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+		tx.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
+		tx.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return nil, nil
+		}
+		tx.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			return errors.New("connection lost")
+		}
+		tx.AnswerInvitationFunc = func(ctx context.Context, invitation *repository.Invitation, fromStatus string) (bool, error) {
+			return true, nil
+		}
+
+		repo := repository.Mock{}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return pendingInvitation(), nil
+		}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			err := fn(&tx)
+			committed = err == nil
+			return err
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{
+			Token:              newToken(t),
+			Attending:          true,
+			AccompanyingGuests: 1,
+		})
+
+		assert.Nil(t, observed)
+		assert.Error(t, err)
+		assert.False(t, committed)
+	})
+
+	t.Run("turns down an answer to an invitation answered meanwhile", func(t *testing.T) {
+		var (
+			committed bool
+			added     bool
+		)
+
+		// The invitation is pending when loaded, but another answer is recorded before this one
+		tx := repository.Mock{}
+		tx.AnswerInvitationFunc = func(ctx context.Context, invitation *repository.Invitation, fromStatus string) (bool, error) {
+			return false, nil
+		}
+		tx.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+			added = true
+			return nil
+		}
+
+		repo := repository.Mock{}
+		repo.GetInvitationByIDFunc = func(ctx context.Context, id string) (*repository.Invitation, error) {
+			return pendingInvitation(), nil
+		}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			err := fn(&tx)
+			committed = err == nil
+			return err
+		}
+
+		party := newTestInvitationParty(&repo)
+		observed, err := party.RespondToInvitation(context.TODO(), &RespondToInvitationInput{
+			Token:              newToken(t),
+			Attending:          true,
+			AccompanyingGuests: 1,
+		})
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, ErrInvitationAlreadyAnswered))
+		assert.False(t, added)
+		assert.False(t, committed)
+	})
+}
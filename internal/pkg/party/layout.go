@@ -0,0 +1,178 @@
+package party
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+)
+
+// SetLayout replaces the venue layout. Every table of the layout must exist.
+// Tables left out of the layout are not drawn on the seating chart.
+func (p *Party) SetLayout(ctx context.Context, in *SetLayoutInput) (*GetLayoutOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.SetLayout")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return nil, fmt.Errorf("could not validate input for setting layout: %w", err)
+	}
+
+	var out GetLayoutOutput
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		tables, err := tx.GetTables(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get tables: %w", err)
+		}
+
+		sizes := tableSizes(tables)
+
+		layouts := make([]repository.TableLayout, 0, len(in.Tables))
+		for _, table := range in.Tables {
+			if _, ok := sizes[table.Number]; !ok {
+				return fmt.Errorf("table %d: %w", table.Number, ErrTableNumberNotFound)
+			}
+
+			layouts = append(layouts, repository.TableLayout{
+				Number: table.Number,
+				X:      table.X,
+				Y:      table.Y,
+				Shape:  table.Shape,
+				Width:  table.Width,
+				Height: table.Height,
+			})
+		}
+
+		sort.Slice(layouts, func(i, j int) bool {
+			return layouts[i].Number < layouts[j].Number
+		})
+
+		if err := tx.ReplaceTableLayouts(ctx, layouts); err != nil {
+			return fmt.Errorf("could not replace table layouts: %w", err)
+		}
+
+		out = layoutOutput(layouts, sizes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLayout returns the venue layout with the size of its tables.
+func (p *Party) GetLayout(ctx context.Context) (GetLayoutOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetLayout")
+	defer span.End()
+
+	layouts, err := p.repo.GetTableLayouts(ctx)
+	if err != nil {
+		return GetLayoutOutput{}, fmt.Errorf("could not get table layouts: %w", err)
+	}
+
+	tables, err := p.repo.GetTables(ctx)
+	if err != nil {
+		return GetLayoutOutput{}, fmt.Errorf("could not get tables: %w", err)
+	}
+	return layoutOutput(layouts, tableSizes(tables)), nil
+}
+
+// GetSeatingChart returns the tables of the venue layout with the seats of the guests arrived and expected.
+// The table of the highlighted guest is marked.
+func (p *Party) GetSeatingChart(ctx context.Context, in *GetSeatingChartInput) (*SeatingChart, error) {
+	ctx, span := tracer.Start(ctx, "party.GetSeatingChart")
+	defer span.End()
+
+	layout, err := p.GetLayout(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	guests, err := p.repo.ListGuests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get guest list: %w", err)
+	}
+
+	highlightTable := 0
+	if in.Highlight != "" {
+		for _, guest := range guests {
+			if guest.Name == in.Highlight {
+				highlightTable = guest.Table
+			}
+		}
+
+		if highlightTable == 0 {
+			return nil, ErrGuestNotInList
+		}
+	}
+
+	booked := make(map[int]int, len(layout.Tables))
+	arrived := make(map[int]int, len(layout.Tables))
+
+	for _, guest := range guests {
+		switch {
+		case IsPresent(guest):
+			arrived[guest.Table] += arrivedSeats(guest)
+		case guest.TimeArrival == nil:
+			booked[guest.Table] += guest.AccompanyingGuests + 1
+		}
+	}
+
+	out := SeatingChart{
+		Tables:    make([]ChartTable, 0, len(layout.Tables)),
+		Highlight: in.Highlight,
+	}
+
+	for _, table := range layout.Tables {
+		// Arrived guests may bring more companions than booked, seats never exceed the table size
+		arrivedSeats := minInt(arrived[table.Number], table.Size)
+		bookedSeats := minInt(booked[table.Number], table.Size-arrivedSeats)
+
+		out.Tables = append(out.Tables, ChartTable{
+			TableLayout:  table,
+			BookedSeats:  bookedSeats,
+			ArrivedSeats: arrivedSeats,
+			Highlighted:  table.Number == highlightTable,
+		})
+	}
+	return &out, nil
+}
+
+func tableSizes(tables []repository.Table) map[int]int {
+	sizes := make(map[int]int, len(tables))
+	for _, table := range tables {
+		sizes[table.Number] = table.Size
+	}
+	return sizes
+}
+
+// layoutOutput returns the layouts of the existing tables with their size.
+func layoutOutput(layouts []repository.TableLayout, sizes map[int]int) GetLayoutOutput {
+	out := GetLayoutOutput{Tables: make([]TableLayout, 0, len(layouts))}
+
+	for _, layout := range layouts {
+		size, ok := sizes[layout.Number]
+		if !ok {
+			continue
+		}
+
+		out.Tables = append(out.Tables, TableLayout{
+			Number: layout.Number,
+			Size:   size,
+			X:      layout.X,
+			Y:      layout.Y,
+			Shape:  layout.Shape,
+			Width:  layout.Width,
+			Height: layout.Height,
+		})
+	}
+	return out
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
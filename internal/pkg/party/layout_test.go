@@ -0,0 +1,223 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func layoutTestRepo() *repository.Mock {
+	repo := tablesTestRepo()
+	repo.GetTableLayoutsFunc = func(ctx context.Context) ([]repository.TableLayout, error) {
+		return []repository.TableLayout{
+			{Number: 1, X: 0, Y: 0, Shape: TableShapeRound, Width: 100, Height: 100},
+			{Number: 2, X: 200, Y: 0, Shape: TableShapeRectangle, Width: 160, Height: 80},
+			// The table of the layout no longer exists
+			{Number: 4, X: 400, Y: 0, Shape: TableShapeRound, Width: 100, Height: 100},
+		}, nil
+	}
+	repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(repo)
+	}
+	return repo
+}
+
+func TestSetLayout(t *testing.T) {
+	cases := []struct {
+		name            string
+		given           SetLayoutInput
+		expectedLayouts []repository.TableLayout
+		expectedOutput  *GetLayoutOutput
+		expectedErr     error
+	}{
+		{
+			name: "replaces the layout in table number order",
+			given: SetLayoutInput{Tables: []TableLayout{
+				{Number: 2, X: 200, Y: 10, Shape: TableShapeRectangle, Width: 160, Height: 80},
+				{Number: 1, X: 10, Y: 10, Shape: TableShapeRound, Width: 100, Size: 99},
+			}},
+			expectedLayouts: []repository.TableLayout{
+				{Number: 1, X: 10, Y: 10, Shape: TableShapeRound, Width: 100, Height: 100},
+				{Number: 2, X: 200, Y: 10, Shape: TableShapeRectangle, Width: 160, Height: 80},
+			},
+			expectedOutput: &GetLayoutOutput{Tables: []TableLayout{
+				{Number: 1, Size: 10, X: 10, Y: 10, Shape: TableShapeRound, Width: 100, Height: 100},
+				{Number: 2, Size: 10, X: 200, Y: 10, Shape: TableShapeRectangle, Width: 160, Height: 80},
+			}},
+		},
+		{
+			name:            "clears the layout",
+			given:           SetLayoutInput{},
+			expectedLayouts: []repository.TableLayout{},
+			expectedOutput:  &GetLayoutOutput{Tables: []TableLayout{}},
+		},
+		{
+			name:        "table number required",
+			given:       SetLayoutInput{Tables: []TableLayout{{Shape: TableShapeRound, Width: 100}}},
+			expectedErr: ErrTableNumberRequired,
+		},
+		{
+			name:        "table number invalid",
+			given:       SetLayoutInput{Tables: []TableLayout{{Number: -1, Shape: TableShapeRound, Width: 100}}},
+			expectedErr: ErrTableNumberInvalid,
+		},
+		{
+			name:        "table shape invalid",
+			given:       SetLayoutInput{Tables: []TableLayout{{Number: 1, Shape: "oval", Width: 100}}},
+			expectedErr: ErrTableShapeInvalid,
+		},
+		{
+			name:        "negative position",
+			given:       SetLayoutInput{Tables: []TableLayout{{Number: 1, X: -1, Shape: TableShapeRound, Width: 100}}},
+			expectedErr: ErrTableLayoutInvalid,
+		},
+		{
+			name:        "rectangle without height",
+			given:       SetLayoutInput{Tables: []TableLayout{{Number: 1, Shape: TableShapeRectangle, Width: 100}}},
+			expectedErr: ErrTableLayoutInvalid,
+		},
+		{
+			name: "table duplicated",
+			given: SetLayoutInput{Tables: []TableLayout{
+				{Number: 1, Shape: TableShapeRound, Width: 100},
+				{Number: 1, X: 200, Shape: TableShapeRound, Width: 100},
+			}},
+			expectedErr: ErrTableDuplicatedInLayout,
+		},
+		{
+			name:        "table not found",
+			given:       SetLayoutInput{Tables: []TableLayout{{Number: 3, Shape: TableShapeRound, Width: 100}}},
+			expectedErr: ErrTableNumberNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var observedLayouts []repository.TableLayout
+
+			repo := layoutTestRepo()
+			repo.ReplaceTableLayoutsFunc = func(ctx context.Context, layouts []repository.TableLayout) error {
+				observedLayouts = layouts
+				return nil
+			}
+
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			observed, err := party.SetLayout(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				assert.Nil(t, observedLayouts)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLayouts, observedLayouts)
+			assert.Equal(t, tc.expectedOutput, observed)
+		})
+	}
+
+	t.Run("returns an error when replace table layouts fails", func(t *testing.T) {
+		repo := layoutTestRepo()
+		repo.ReplaceTableLayoutsFunc = func(ctx context.Context, layouts []repository.TableLayout) error {
+			return errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+		_, err := party.SetLayout(context.TODO(), &SetLayoutInput{})
+
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+}
+
+func TestGetLayout(t *testing.T) {
+	t.Run("returns an error when get table layouts fails", func(t *testing.T) {
+		repo := layoutTestRepo()
+		repo.GetTableLayoutsFunc = func(ctx context.Context) ([]repository.TableLayout, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+		_, err := party.GetLayout(context.TODO())
+
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("returns the layout of the existing tables", func(t *testing.T) {
+		party := New(zap.NewNop(), layoutTestRepo(), testTableSize)
+
+		observed, err := party.GetLayout(context.TODO())
+		require.NoError(t, err)
+
+		assert.Equal(t, GetLayoutOutput{Tables: []TableLayout{
+			{Number: 1, Size: 10, X: 0, Y: 0, Shape: TableShapeRound, Width: 100, Height: 100},
+			{Number: 2, Size: 10, X: 200, Y: 0, Shape: TableShapeRectangle, Width: 160, Height: 80},
+		}}, observed)
+	})
+}
+
+func TestGetSeatingChart(t *testing.T) {
+	t.Run("counts the seats of the guests arrived and expected", func(t *testing.T) {
+		party := New(zap.NewNop(), layoutTestRepo(), testTableSize)
+
+		observed, err := party.GetSeatingChart(context.TODO(), &GetSeatingChartInput{})
+		require.NoError(t, err)
+
+		// Adam left and freed his seats, Bob sits at a table missing from the layout
+		assert.Equal(t, &SeatingChart{Tables: []ChartTable{
+			{
+				TableLayout:  TableLayout{Number: 1, Size: 10, X: 0, Y: 0, Shape: TableShapeRound, Width: 100, Height: 100},
+				BookedSeats:  1,
+				ArrivedSeats: 2,
+			},
+			{
+				TableLayout: TableLayout{Number: 2, Size: 10, X: 200, Y: 0, Shape: TableShapeRectangle, Width: 160, Height: 80},
+			},
+		}}, observed)
+	})
+
+	t.Run("highlights the table of the guest", func(t *testing.T) {
+		party := New(zap.NewNop(), layoutTestRepo(), testTableSize)
+
+		observed, err := party.GetSeatingChart(context.TODO(), &GetSeatingChartInput{Highlight: "eve"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "eve", observed.Highlight)
+		assert.True(t, observed.Tables[0].Highlighted)
+		assert.False(t, observed.Tables[1].Highlighted)
+	})
+
+	t.Run("does not exceed the table size", func(t *testing.T) {
+		arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+		repo := layoutTestRepo()
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return []repository.Guest{
+				{Name: "zoe", Table: 2, AccompanyingGuests: 8, ArrivedAccompanyingGuests: 8, TimeArrival: &arrived},
+				{Name: "eve", Table: 2, AccompanyingGuests: 2},
+			}, nil
+		}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		observed, err := party.GetSeatingChart(context.TODO(), &GetSeatingChartInput{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 9, observed.Tables[1].ArrivedSeats)
+		assert.Equal(t, 1, observed.Tables[1].BookedSeats)
+	})
+
+	t.Run("guest to highlight not in list", func(t *testing.T) {
+		party := New(zap.NewNop(), layoutTestRepo(), testTableSize)
+
+		_, err := party.GetSeatingChart(context.TODO(), &GetSeatingChartInput{Highlight: "mallory"})
+
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+	})
+}
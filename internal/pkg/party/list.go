@@ -0,0 +1,157 @@
+package party
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+)
+
+const maxListLimit = 1000
+
+var sortFields = map[string]string{
+	"":                repository.GuestSortName,
+	SortByName:        repository.GuestSortName,
+	SortByTable:       repository.GuestSortTable,
+	SortByTimeArrival: repository.GuestSortTimeArrival,
+}
+
+// listCursor defines the opaque position handed to clients as NextCursor.
+// It carries the sort order so it cannot be reused with another one.
+type listCursor struct {
+	Sort        string     `json:"s"`
+	Desc        bool       `json:"d,omitempty"`
+	Name        string     `json:"n"`
+	Table       int        `json:"t,omitempty"`
+	TimeArrival *time.Time `json:"a,omitempty"`
+}
+
+// queryGuests runs the guest query described by the input and returns the page
+// of guests and the cursor of the next page, if any. A nil input lists every guest.
+func (p *Party) queryGuests(ctx context.Context, in *ListGuestsInput) ([]repository.Guest, string, error) {
+	if in == nil {
+		in = &ListGuestsInput{}
+	}
+
+	query, err := in.query()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one more guest to know if there is a next page
+	if in.Limit > 0 {
+		query.Limit = in.Limit + 1
+	}
+
+	guests, err := p.repo.QueryGuests(ctx, query)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not query guests: %w", err)
+	}
+
+	if in.Limit == 0 || len(guests) <= in.Limit {
+		return guests, "", nil
+	}
+
+	guests = guests[:in.Limit]
+	last := guests[len(guests)-1]
+
+	cursor, err := encodeListCursor(&listCursor{
+		Sort:        query.Sort,
+		Desc:        query.Desc,
+		Name:        last.Name,
+		Table:       last.Table,
+		TimeArrival: last.TimeArrival,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("could not encode cursor: %w", err)
+	}
+	return guests, cursor, nil
+}
+
+func (in *ListGuestsInput) query() (*repository.GuestQuery, error) {
+	if in.Table < 0 {
+		return nil, ErrTableNumberInvalid
+	}
+
+	if in.Limit < 0 || in.Limit > maxListLimit {
+		return nil, ErrLimitInvalid
+	}
+
+	sort, ok := sortFields[in.Sort]
+	if !ok {
+		return nil, ErrSortInvalid
+	}
+
+	// Guests who did not arrive have no arrival time to sort by
+	if sort == repository.GuestSortTimeArrival && (in.Arrived == nil || !*in.Arrived) {
+		return nil, ErrSortInvalid
+	}
+
+	var desc bool
+	switch in.Order {
+	case "", SortOrderAsc:
+	case SortOrderDesc:
+		desc = true
+	default:
+		return nil, ErrSortInvalid
+	}
+
+	requirements, err := in.requirements()
+	if err != nil {
+		return nil, err
+	}
+
+	query := repository.GuestQuery{
+		Table:        in.Table,
+		Arrived:      in.Arrived,
+		NamePrefix:   in.NamePrefix,
+		Requirements: requirements,
+		Sort:         sort,
+		Desc:         desc,
+	}
+
+	if in.Cursor != "" {
+		cursor, err := decodeListCursor(in.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.Sort != sort || cursor.Desc != desc {
+			return nil, ErrCursorInvalid
+		}
+
+		query.After = &repository.GuestCursor{
+			Name:  cursor.Name,
+			Table: cursor.Table,
+		}
+
+		if cursor.TimeArrival != nil {
+			query.After.TimeArrival = *cursor.TimeArrival
+		}
+	}
+	return &query, nil
+}
+
+func encodeListCursor(cursor *listCursor) (string, error) {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeListCursor(s string) (*listCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrCursorInvalid
+	}
+
+	var cursor listCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, ErrCursorInvalid
+	}
+	return &cursor, nil
+}
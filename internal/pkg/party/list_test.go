@@ -0,0 +1,184 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetGuestListPagination(t *testing.T) {
+	arrived := true
+
+	cases := []struct {
+		name          string
+		given         *ListGuestsInput
+		expectedError error
+	}{
+		{
+			name:          "negative table",
+			given:         &ListGuestsInput{Table: -1},
+			expectedError: ErrTableNumberInvalid,
+		},
+		{
+			name:          "negative limit",
+			given:         &ListGuestsInput{Limit: -1},
+			expectedError: ErrLimitInvalid,
+		},
+		{
+			name:          "limit too large",
+			given:         &ListGuestsInput{Limit: maxListLimit + 1},
+			expectedError: ErrLimitInvalid,
+		},
+		{
+			name:          "unknown sort field",
+			given:         &ListGuestsInput{Sort: "age"},
+			expectedError: ErrSortInvalid,
+		},
+		{
+			name:          "unknown sort order",
+			given:         &ListGuestsInput{Order: "up"},
+			expectedError: ErrSortInvalid,
+		},
+		{
+			name:          "sort by arrival time without arrived filter",
+			given:         &ListGuestsInput{Sort: SortByTimeArrival},
+			expectedError: ErrSortInvalid,
+		},
+		{
+			name:          "malformed cursor",
+			given:         &ListGuestsInput{Cursor: "%%%"},
+			expectedError: ErrCursorInvalid,
+		},
+		{
+			name: "cursor from another sort order",
+			given: &ListGuestsInput{
+				Sort:   SortByTable,
+				Cursor: func() string { c, _ := encodeListCursor(&listCursor{Sort: repository.GuestSortName}); return c }(),
+			},
+			expectedError: ErrCursorInvalid,
+		},
+		{
+			name:          "unknown dietary requirement",
+			given:         &ListGuestsInput{Dietary: "keto"},
+			expectedError: ErrDietaryRequirementInvalid,
+		},
+		{
+			name:          "blank allergy",
+			given:         &ListGuestsInput{Allergy: " "},
+			expectedError: ErrAllergyInvalid,
+		},
+		{
+			name:          "unknown accessibility need",
+			given:         &ListGuestsInput{Accessibility: "stairs"},
+			expectedError: ErrAccessibilityNeedInvalid,
+		},
+		{
+			name:          "custom value without field",
+			given:         &ListGuestsInput{Custom: "fish"},
+			expectedError: ErrCustomFieldValueInvalid,
+		},
+		{
+			name:          "sort by arrival time with arrived filter",
+			given:         &ListGuestsInput{Sort: SortByTimeArrival, Arrived: &arrived},
+			expectedError: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := repository.Mock{}
+			repo.QueryGuestsFunc = func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+				return nil, nil
+			}
+
+			party := New(zap.NewNop(), &repo, testTableSize)
+			_, err := party.GetGuestList(context.TODO(), c.given)
+
+			assert.True(t, errors.Is(err, c.expectedError))
+		})
+	}
+
+	t.Run("pushes the filters down to the repository and pages with a cursor", func(t *testing.T) {
+		guests := []repository.Guest{
+			{Name: "a", Table: 1},
+			{Name: "b", Table: 1},
+			{Name: "c", Table: 2},
+		}
+
+		var queries []repository.GuestQuery
+
+		repo := repository.Mock{}
+		repo.QueryGuestsFunc = func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+			queries = append(queries, *query)
+
+			// Emulate the keyset pagination on (table, name)
+			var page []repository.Guest
+			for _, guest := range guests {
+				if query.After != nil && (guest.Table < query.After.Table ||
+					guest.Table == query.After.Table && guest.Name <= query.After.Name) {
+					continue
+				}
+				if len(page) < query.Limit {
+					page = append(page, guest)
+				}
+			}
+			return page, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+
+		first, err := party.GetGuestList(context.TODO(), &ListGuestsInput{
+			NamePrefix: "x",
+			Sort:       SortByTable,
+			Limit:      2,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Guest{{Name: "a", Table: 1}, {Name: "b", Table: 1}}, first.Guests)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := party.GetGuestList(context.TODO(), &ListGuestsInput{
+			NamePrefix: "x",
+			Sort:       SortByTable,
+			Cursor:     first.NextCursor,
+			Limit:      2,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Guest{{Name: "c", Table: 2}}, second.Guests)
+		assert.Empty(t, second.NextCursor)
+
+		require.Len(t, queries, 2)
+		assert.Equal(t, repository.GuestQuery{
+			NamePrefix: "x",
+			Sort:       repository.GuestSortTable,
+			Limit:      3,
+		}, queries[0])
+		assert.Equal(t, &repository.GuestCursor{Name: "b", Table: 1}, queries[1].After)
+	})
+
+	t.Run("always filters arrived guests when listing arrivals", func(t *testing.T) {
+		notArrived := false
+
+		var observed *repository.GuestQuery
+
+		repo := repository.Mock{}
+		repo.QueryGuestsFunc = func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+			observed = query
+			return nil, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		_, err := party.ListArrivedGuests(context.TODO(), &ListGuestsInput{Arrived: &notArrived, Sort: SortByTimeArrival})
+		require.NoError(t, err)
+
+		require.NotNil(t, observed.Arrived)
+		assert.True(t, *observed.Arrived)
+		assert.Equal(t, repository.GuestSortTimeArrival, observed.Sort)
+	})
+}
@@ -3,20 +3,55 @@ package party
 import "context"
 
 type Mock struct {
-	AddGuestToGuestListFunc func(ctx context.Context, in *AddGuestToGuestListInput) (*AddGuestToGuestListOutput, error)
-	GetGuestListFunc        func(ctx context.Context) (GetGuestListOutput, error)
-	WelcomeGuestFunc        func(ctx context.Context, in *WelcomeGuestInput) (*WelcomeGuestOutput, error)
-	GoodbyeGuestFunc        func(ctx context.Context, in *GoodbyeGuestInput) error
-	ListArrivedGuestsFunc   func(ctx context.Context) (ListArrivedGuestsOutput, error)
-	GetEmptySeatsFunc       func(ctx context.Context) (GetEmptySeatsOutput, error)
+	AddGuestToGuestListFunc      func(ctx context.Context, in *AddGuestToGuestListInput) (*AddGuestToGuestListOutput, error)
+	GetGuestListFunc             func(ctx context.Context, in *ListGuestsInput) (GetGuestListOutput, error)
+	WelcomeGuestFunc             func(ctx context.Context, in *WelcomeGuestInput) (*WelcomeGuestOutput, error)
+	GoodbyeGuestFunc             func(ctx context.Context, in *GoodbyeGuestInput) error
+	RemoveGuestFromGuestListFunc func(ctx context.Context, in *RemoveGuestFromGuestListInput) error
+	ListArrivedGuestsFunc        func(ctx context.Context, in *ListGuestsInput) (ListArrivedGuestsOutput, error)
+	GetEmptySeatsFunc            func(ctx context.Context) (GetEmptySeatsOutput, error)
+	ListTablesFunc               func(ctx context.Context) (ListTablesOutput, error)
+	GetStatsFunc                 func(ctx context.Context) (GetStatsOutput, error)
+	CreateTableFunc              func(ctx context.Context, in *CreateTableInput) (*Table, error)
+	ReserveTableFunc             func(ctx context.Context, in *ReserveTableInput) (*Table, error)
+	ReleaseTableFunc             func(ctx context.Context, number int) (*Table, error)
+	AddGroupToGuestListFunc      func(ctx context.Context, in *AddGroupToGuestListInput) (*Group, error)
+	GetGroupFunc                 func(ctx context.Context, name string) (*Group, error)
+	ListGroupsFunc               func(ctx context.Context) (ListGroupsOutput, error)
+	WelcomeGroupFunc             func(ctx context.Context, in *WelcomeGroupInput) (*Group, error)
+	GoodbyeGroupFunc             func(ctx context.Context, in *GoodbyeGroupInput) (*Group, error)
+	GetGuestRequirementsFunc     func(ctx context.Context, name string) (*GuestRequirements, error)
+	SetGuestRequirementsFunc     func(ctx context.Context, in *SetGuestRequirementsInput) (*GuestRequirements, error)
+	ListCustomFieldsFunc         func(ctx context.Context) (ListCustomFieldsOutput, error)
+	SetCustomFieldsFunc          func(ctx context.Context, in *SetCustomFieldsInput) (ListCustomFieldsOutput, error)
+	GetCateringSummaryFunc       func(ctx context.Context) (*CateringSummary, error)
+	AssignSeatsFunc              func(ctx context.Context, in *AssignSeatsInput) (*Table, error)
+	SwapSeatsFunc                func(ctx context.Context, in *SwapSeatsInput) (*Table, error)
+	CreateConstraintFunc         func(ctx context.Context, in *CreateConstraintInput) (*Constraint, error)
+	ListConstraintsFunc          func(ctx context.Context) (ListConstraintsOutput, error)
+	DeleteConstraintFunc         func(ctx context.Context, id string) error
+	PlanSeatingFunc              func(ctx context.Context) (*SeatingPlan, error)
+	CheckSeatingFunc             func(ctx context.Context, in *CheckSeatingInput) (*SeatingPlan, error)
+	SetLayoutFunc                func(ctx context.Context, in *SetLayoutInput) (*GetLayoutOutput, error)
+	GetLayoutFunc                func(ctx context.Context) (GetLayoutOutput, error)
+	GetSeatingChartFunc          func(ctx context.Context, in *GetSeatingChartInput) (*SeatingChart, error)
+	CreateInvitationFunc         func(ctx context.Context, in *CreateInvitationInput) (*CreateInvitationOutput, error)
+	GetInvitationFunc            func(ctx context.Context, token string) (*GetInvitationOutput, error)
+	RespondToInvitationFunc      func(ctx context.Context, in *RespondToInvitationInput) (*RespondToInvitationOutput, error)
+	IssueCheckInCodeFunc         func(ctx context.Context, in *IssueCheckInCodeInput) (*IssueCheckInCodeOutput, error)
+	GetCheckInCodeFunc           func(ctx context.Context, code string) (*GetCheckInCodeOutput, error)
+	RevokeCheckInCodeFunc        func(ctx context.Context, code string) error
+	CheckInFunc                  func(ctx context.Context, in *CheckInInput) (*CheckInOutput, error)
+	ImportGuestsFunc             func(ctx context.Context, in *ImportGuestsInput) (*ImportGuestsOutput, error)
+	GetSeatingReportFunc         func(ctx context.Context) (*GetSeatingReportOutput, error)
 }
 
 func (m *Mock) AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestListInput) (*AddGuestToGuestListOutput, error) {
 	return m.AddGuestToGuestListFunc(ctx, in)
 }
 
-func (m *Mock) GetGuestList(ctx context.Context) (GetGuestListOutput, error) {
-	return m.GetGuestListFunc(ctx)
+func (m *Mock) GetGuestList(ctx context.Context, in *ListGuestsInput) (GetGuestListOutput, error) {
+	return m.GetGuestListFunc(ctx, in)
 }
 
 func (m *Mock) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*WelcomeGuestOutput, error) {
@@ -27,10 +62,150 @@ func (m *Mock) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error {
 	return m.GoodbyeGuestFunc(ctx, in)
 }
 
-func (m *Mock) ListArrivedGuests(ctx context.Context) (ListArrivedGuestsOutput, error) {
-	return m.ListArrivedGuestsFunc(ctx)
+func (m *Mock) RemoveGuestFromGuestList(ctx context.Context, in *RemoveGuestFromGuestListInput) error {
+	return m.RemoveGuestFromGuestListFunc(ctx, in)
+}
+
+func (m *Mock) ListArrivedGuests(ctx context.Context, in *ListGuestsInput) (ListArrivedGuestsOutput, error) {
+	return m.ListArrivedGuestsFunc(ctx, in)
 }
 
 func (m *Mock) GetEmptySeats(ctx context.Context) (GetEmptySeatsOutput, error) {
 	return m.GetEmptySeatsFunc(ctx)
 }
+
+func (m *Mock) ListTables(ctx context.Context) (ListTablesOutput, error) {
+	return m.ListTablesFunc(ctx)
+}
+
+func (m *Mock) GetStats(ctx context.Context) (GetStatsOutput, error) {
+	return m.GetStatsFunc(ctx)
+}
+
+func (m *Mock) CreateTable(ctx context.Context, in *CreateTableInput) (*Table, error) {
+	return m.CreateTableFunc(ctx, in)
+}
+
+func (m *Mock) ReserveTable(ctx context.Context, in *ReserveTableInput) (*Table, error) {
+	return m.ReserveTableFunc(ctx, in)
+}
+
+func (m *Mock) ReleaseTable(ctx context.Context, number int) (*Table, error) {
+	return m.ReleaseTableFunc(ctx, number)
+}
+
+func (m *Mock) AddGroupToGuestList(ctx context.Context, in *AddGroupToGuestListInput) (*Group, error) {
+	return m.AddGroupToGuestListFunc(ctx, in)
+}
+
+func (m *Mock) GetGroup(ctx context.Context, name string) (*Group, error) {
+	return m.GetGroupFunc(ctx, name)
+}
+
+func (m *Mock) ListGroups(ctx context.Context) (ListGroupsOutput, error) {
+	return m.ListGroupsFunc(ctx)
+}
+
+func (m *Mock) WelcomeGroup(ctx context.Context, in *WelcomeGroupInput) (*Group, error) {
+	return m.WelcomeGroupFunc(ctx, in)
+}
+
+func (m *Mock) GoodbyeGroup(ctx context.Context, in *GoodbyeGroupInput) (*Group, error) {
+	return m.GoodbyeGroupFunc(ctx, in)
+}
+
+func (m *Mock) GetGuestRequirements(ctx context.Context, name string) (*GuestRequirements, error) {
+	return m.GetGuestRequirementsFunc(ctx, name)
+}
+
+func (m *Mock) SetGuestRequirements(ctx context.Context, in *SetGuestRequirementsInput) (*GuestRequirements, error) {
+	return m.SetGuestRequirementsFunc(ctx, in)
+}
+
+func (m *Mock) ListCustomFields(ctx context.Context) (ListCustomFieldsOutput, error) {
+	return m.ListCustomFieldsFunc(ctx)
+}
+
+func (m *Mock) SetCustomFields(ctx context.Context, in *SetCustomFieldsInput) (ListCustomFieldsOutput, error) {
+	return m.SetCustomFieldsFunc(ctx, in)
+}
+
+func (m *Mock) GetCateringSummary(ctx context.Context) (*CateringSummary, error) {
+	return m.GetCateringSummaryFunc(ctx)
+}
+
+func (m *Mock) AssignSeats(ctx context.Context, in *AssignSeatsInput) (*Table, error) {
+	return m.AssignSeatsFunc(ctx, in)
+}
+
+func (m *Mock) SwapSeats(ctx context.Context, in *SwapSeatsInput) (*Table, error) {
+	return m.SwapSeatsFunc(ctx, in)
+}
+
+func (m *Mock) CreateConstraint(ctx context.Context, in *CreateConstraintInput) (*Constraint, error) {
+	return m.CreateConstraintFunc(ctx, in)
+}
+
+func (m *Mock) ListConstraints(ctx context.Context) (ListConstraintsOutput, error) {
+	return m.ListConstraintsFunc(ctx)
+}
+
+func (m *Mock) DeleteConstraint(ctx context.Context, id string) error {
+	return m.DeleteConstraintFunc(ctx, id)
+}
+
+func (m *Mock) PlanSeating(ctx context.Context) (*SeatingPlan, error) {
+	return m.PlanSeatingFunc(ctx)
+}
+
+func (m *Mock) CheckSeating(ctx context.Context, in *CheckSeatingInput) (*SeatingPlan, error) {
+	return m.CheckSeatingFunc(ctx, in)
+}
+
+func (m *Mock) SetLayout(ctx context.Context, in *SetLayoutInput) (*GetLayoutOutput, error) {
+	return m.SetLayoutFunc(ctx, in)
+}
+
+func (m *Mock) GetLayout(ctx context.Context) (GetLayoutOutput, error) {
+	return m.GetLayoutFunc(ctx)
+}
+
+func (m *Mock) GetSeatingChart(ctx context.Context, in *GetSeatingChartInput) (*SeatingChart, error) {
+	return m.GetSeatingChartFunc(ctx, in)
+}
+
+func (m *Mock) CreateInvitation(ctx context.Context, in *CreateInvitationInput) (*CreateInvitationOutput, error) {
+	return m.CreateInvitationFunc(ctx, in)
+}
+
+func (m *Mock) GetInvitation(ctx context.Context, token string) (*GetInvitationOutput, error) {
+	return m.GetInvitationFunc(ctx, token)
+}
+
+func (m *Mock) RespondToInvitation(ctx context.Context, in *RespondToInvitationInput) (*RespondToInvitationOutput, error) {
+	return m.RespondToInvitationFunc(ctx, in)
+}
+
+func (m *Mock) IssueCheckInCode(ctx context.Context, in *IssueCheckInCodeInput) (*IssueCheckInCodeOutput, error) {
+	return m.IssueCheckInCodeFunc(ctx, in)
+}
+
+func (m *Mock) GetCheckInCode(ctx context.Context, code string) (*GetCheckInCodeOutput, error) {
+	return m.GetCheckInCodeFunc(ctx, code)
+}
+
+func (m *Mock) RevokeCheckInCode(ctx context.Context, code string) error {
+	return m.RevokeCheckInCodeFunc(ctx, code)
+}
+
+func (m *Mock) CheckIn(ctx context.Context, in *CheckInInput) (*CheckInOutput, error) {
+	return m.CheckInFunc(ctx, in)
+}
+
+func (m *Mock) ImportGuests(ctx context.Context, in *ImportGuestsInput) (*ImportGuestsOutput, error) {
+	return m.ImportGuestsFunc(ctx, in)
+}
+
+func (m *Mock) GetSeatingReport(ctx context.Context) (*GetSeatingReportOutput, error) {
+	return m.GetSeatingReportFunc(ctx)
+}
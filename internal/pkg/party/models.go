@@ -1,25 +1,38 @@
 package party
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/seating"
+)
 
 type (
 
 	// AddGuestToGuestListOutput defines the output struct for adding guests to the guestlist.
+	// CheckInCode is the check-in code issued to the guest.
 	AddGuestToGuestListOutput struct {
-		Name string
+		Name        string
+		CheckInCode string
 	}
 
 	// Guest defines the guest struct.
+	// Tier is omitted for the guests added without a tier, who are general guests.
 	Guest struct {
 		Name               string     `json:"name"`
 		Table              int        `json:"table"`
 		AccompanyingGuests int        `json:"accompanying_guests"`
+		Tier               string     `json:"tier,omitempty"`
 		TimeArrival        *time.Time `json:"time_arrived,omitempty"`
+		TimeDeparture      *time.Time `json:"time_departed,omitempty"`
 	}
 
 	// GuestList defines the guestlist output struct.
+	// NextCursor is set when more guests match the query.
 	GetGuestListOutput struct {
-		Guests []Guest `json:"guests"`
+		Guests     []Guest `json:"guests"`
+		NextCursor string  `json:"next_cursor,omitempty"`
 	}
 
 	// WelcomeGuestInput defines the input struct for welcoming guests.
@@ -38,6 +51,11 @@ type (
 		Name string
 	}
 
+	// RemoveGuestFromGuestListInput defines the input struct for removing guests from the guestlist.
+	RemoveGuestFromGuestListInput struct {
+		Name string
+	}
+
 	GuestArrived struct {
 		Name               string    `json:"name"`
 		AccompanyingGuests int       `json:"accompanying_guests"`
@@ -45,7 +63,8 @@ type (
 	}
 
 	ListArrivedGuestsOutput struct {
-		Guests []GuestArrived `json:"guests"`
+		Guests     []GuestArrived `json:"guests"`
+		NextCursor string         `json:"next_cursor,omitempty"`
 	}
 
 	GetEmptySeatsOutput struct {
@@ -53,11 +72,52 @@ type (
 	}
 )
 
+// Enumerate guest list sort fields and orders
+const (
+	SortByName        = "name"
+	SortByTable       = "table"
+	SortByTimeArrival = "time_arrived"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// ListGuestsInput defines the filters, sort order and page for listing guests.
+// Zero values mean no filter and Limit 0 returns every matching guest.
+// Cursor is the NextCursor of the previous page and must be used with the same sort order.
+// Requirement filters keep the guests who, or one of whose accompanying guests, have the requirement,
+// Custom is a custom field value as field:value.
+// Arrived true keeps the guests at the party, guests who left are not listed, and false the guests who did not arrive yet.
+type ListGuestsInput struct {
+	Table         int
+	Arrived       *bool
+	NamePrefix    string
+	Dietary       string
+	Allergy       string
+	Accessibility string
+	Custom        string
+	Sort          string
+	Order         string
+	Cursor        string
+	Limit         int
+}
+
+// Enumerate guest tiers, guests without a tier are general guests
+const (
+	TierVIP     = "vip"
+	TierStaff   = "staff"
+	TierGeneral = "general"
+)
+
 // AddGuestToGuestListInput defines the input struct for adding guests to the guestlist.
+// Seats are the seat numbers of the guest then of each accompanying guest.
+// Without seats, the guest and their accompanying guests are seated next to each other.
 type AddGuestToGuestListInput struct {
 	Name               string `json:"-"`
 	Table              int    `json:"table"` // Table Number
 	AccompanyingGuests int    `json:"accompanying_guests"`
+	Tier               string `json:"tier,omitempty"`
+	Seats              []int  `json:"seats,omitempty"`
 }
 
 func (r *AddGuestToGuestListInput) validate() error {
@@ -77,6 +137,747 @@ func (r *AddGuestToGuestListInput) validate() error {
 		return ErrAccompanyingGuestsNumberInvalid
 	}
 
+	if r.Tier != "" && !validTier(r.Tier) {
+		return ErrTierInvalid
+	}
+
+	if len(r.Seats) > 0 {
+		return validateSeats(r.Seats, r.AccompanyingGuests+1)
+	}
+
 	// TODO(:alesr): Validate table size
 	return nil
 }
+
+// Enumerate invitation statuses
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+)
+
+type (
+
+	// CreateInvitationOutput defines the output struct for creating invitations.
+	CreateInvitationOutput struct {
+		Name      string    `json:"name"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// GetInvitationOutput defines the invitation details visible to the token holder.
+	GetInvitationOutput struct {
+		Name               string    `json:"name"`
+		Table              int       `json:"table"`
+		MaxPartySize       int       `json:"max_party_size"`
+		ExpiresAt          time.Time `json:"expires_at"`
+		Status             string    `json:"status"`
+		AccompanyingGuests int       `json:"accompanying_guests"`
+	}
+
+	// RespondToInvitationInput defines the input struct for answering an invitation.
+	RespondToInvitationInput struct {
+		Token              string `json:"-"`
+		Attending          bool   `json:"attending"`
+		AccompanyingGuests int    `json:"accompanying_guests"`
+	}
+
+	// RespondToInvitationOutput defines the output struct for answering an invitation.
+	RespondToInvitationOutput struct {
+		Name        string `json:"name"`
+		Status      string `json:"status"`
+		CheckInCode string `json:"checkin_code,omitempty"`
+	}
+)
+
+// CreateInvitationInput defines the input struct for inviting a guest.
+// The guest joins the guest list with the tier of the invitation when accepting.
+type CreateInvitationInput struct {
+	Name         string     `json:"name"`
+	Table        int        `json:"table"`          // Table Number
+	MaxPartySize int        `json:"max_party_size"` // Guest included
+	Tier         string     `json:"tier,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+func (r *CreateInvitationInput) validate(now time.Time) error {
+	if r.Name == "" {
+		return ErrGuestNameRequired
+	}
+
+	if r.Table == 0 {
+		return ErrTableNumberRequired
+	}
+
+	if r.Table < 0 {
+		return ErrTableNumberInvalid
+	}
+
+	if r.MaxPartySize < 1 {
+		return ErrMaxPartySizeInvalid
+	}
+
+	if r.Tier != "" && !validTier(r.Tier) {
+		return ErrTierInvalid
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(now) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// Enumerate check-in code statuses
+const (
+	CheckInCodeStatusActive  = "active"
+	CheckInCodeStatusUsed    = "used"
+	CheckInCodeStatusRevoked = "revoked"
+)
+
+type (
+
+	// IssueCheckInCodeInput defines the input struct for issuing a guest check-in code.
+	IssueCheckInCodeInput struct {
+		Name string `json:"-"`
+	}
+
+	// IssueCheckInCodeOutput defines the output struct for issuing a guest check-in code.
+	IssueCheckInCodeOutput struct {
+		Name string `json:"name"`
+		Code string `json:"code"`
+	}
+
+	// GetCheckInCodeOutput defines the check-in code details.
+	GetCheckInCodeOutput struct {
+		Code   string `json:"code"`
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+
+	// CheckInInput defines the input struct for checking in with a code.
+	// When AccompanyingGuests is omitted the booked number is used.
+	CheckInInput struct {
+		Code               string `json:"-"`
+		AccompanyingGuests *int   `json:"accompanying_guests,omitempty"`
+	}
+
+	// CheckInOutput defines the output struct for checking in with a code.
+	CheckInOutput struct {
+		Name               string `json:"name"`
+		AccompanyingGuests int    `json:"accompanying_guests"`
+	}
+)
+
+type (
+
+	// ImportGuestRow defines a single guest in a guest list import.
+	ImportGuestRow struct {
+		Name               string `json:"name"`
+		Table              int    `json:"table"`
+		AccompanyingGuests int    `json:"accompanying_guests"`
+		Tier               string `json:"tier,omitempty"`
+	}
+
+	// ImportGuestsInput defines the input struct for importing guests to the guestlist.
+	// When DryRun is set the rows are only validated.
+	ImportGuestsInput struct {
+		Guests []ImportGuestRow
+		DryRun bool
+	}
+
+	// ImportRowError defines why a row of a guest list import was rejected.
+	// Rows are numbered from 1 in the order they were given.
+	ImportRowError struct {
+		Row   int    `json:"row"`
+		Name  string `json:"name"`
+		Error string `json:"error"`
+	}
+
+	// ImportGuestsOutput defines the output struct for importing guests to the guestlist.
+	// Either every row is imported or none is and Errors lists the rejected rows.
+	ImportGuestsOutput struct {
+		Rows     int              `json:"rows"`
+		Imported int              `json:"imported"`
+		DryRun   bool             `json:"dry_run"`
+		Errors   []ImportRowError `json:"errors,omitempty"`
+	}
+)
+
+type (
+
+	// ReportGuest defines a guest entry of the seating report.
+	ReportGuest struct {
+		Name               string     `json:"name"`
+		Table              int        `json:"table"`
+		AccompanyingGuests int        `json:"accompanying_guests"`
+		TimeArrival        *time.Time `json:"time_arrived,omitempty"`
+		TimeDeparture      *time.Time `json:"time_departed,omitempty"`
+	}
+
+	// ReportTable defines the booked and arrived seats of a table in the seating report.
+	ReportTable struct {
+		Number       int           `json:"number"`
+		Size         int           `json:"size"`
+		BookedSeats  int           `json:"booked_seats"`
+		ArrivedSeats int           `json:"arrived_seats"`
+		Guests       []ReportGuest `json:"guests"`
+	}
+
+	// GetSeatingReportOutput defines the seating report grouped by table.
+	// Seats count the guests and their accompanying guests.
+	GetSeatingReportOutput struct {
+		GeneratedAt   time.Time     `json:"generated_at"`
+		Tables        []ReportTable `json:"tables"`
+		GuestsBooked  int           `json:"guests_booked"`
+		GuestsArrived int           `json:"guests_arrived"`
+		GuestsLeft    int           `json:"guests_left"`
+		BookedSeats   int           `json:"booked_seats"`
+		ArrivedSeats  int           `json:"arrived_seats"`
+	}
+)
+
+// Enumerate table shapes
+const (
+	TableShapeRound     = "round"
+	TableShapeRectangle = "rectangle"
+)
+
+type (
+
+	// Table defines the seats of a table.
+	// Booked and arrived seats count the guests and their accompanying guests.
+	// Tiers restrict the table to their guests, held seats are empty seats kept from booking.
+	Table struct {
+		Number       int      `json:"number"`
+		Size         int      `json:"size"`
+		BookedSeats  int      `json:"booked_seats"`
+		ArrivedSeats int      `json:"arrived_seats"`
+		EmptySeats   int      `json:"empty_seats"`
+		Tiers        []string `json:"tiers,omitempty"`
+		HeldSeats    int      `json:"held_seats,omitempty"`
+		Seats        []Seat   `json:"seats"`
+	}
+
+	// Seat defines a seat of a table and who sits there, if anyone.
+	// Companion 0 is the guest, their accompanying guests are numbered from 1.
+	Seat struct {
+		Number    int    `json:"number"`
+		Guest     string `json:"guest,omitempty"`
+		Companion int    `json:"companion,omitempty"`
+	}
+
+	// AssignSeatsInput defines the seats of a guest then of each of their accompanying guests.
+	AssignSeatsInput struct {
+		Name  string `json:"-"`
+		Seats []int  `json:"seats"`
+	}
+
+	// SwapSeatsInput defines the two seats of a table whose occupants swap places.
+	// Swapping with a free seat moves the occupant.
+	SwapSeatsInput struct {
+		Table  int `json:"-"`
+		First  int `json:"first"`
+		Second int `json:"second"`
+	}
+
+	// ReserveTableInput defines the tiers a table is restricted to and its seats held back, replacing its reservation.
+	// A table without tiers seats guests of every tier.
+	ReserveTableInput struct {
+		Number    int      `json:"-"`
+		Tiers     []string `json:"tiers"`
+		HeldSeats int      `json:"held_seats"`
+	}
+
+	// CreateTableInput defines the input struct for creating tables.
+	CreateTableInput struct {
+		Number int `json:"number"`
+		Size   int `json:"size"`
+	}
+
+	// ListTablesOutput defines the tables sorted by number.
+	ListTablesOutput struct {
+		Tables []Table `json:"tables"`
+	}
+
+	// TableLayout defines where a table stands in the venue, its shape and its number of seats.
+	// Positions and sizes are in the units of the seating chart, from its top left corner.
+	// Round tables have a height of their width, the diameter.
+	TableLayout struct {
+		Number int    `json:"number"`
+		Size   int    `json:"size"`
+		X      int    `json:"x"`
+		Y      int    `json:"y"`
+		Shape  string `json:"shape"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	}
+
+	// SetLayoutInput defines the layout of the venue, replacing the previous one.
+	// The size of the tables is set when they are created and ignored here.
+	SetLayoutInput struct {
+		Tables []TableLayout `json:"tables"`
+	}
+
+	// GetLayoutOutput defines the layout of the venue sorted by table number.
+	GetLayoutOutput struct {
+		Tables []TableLayout `json:"tables"`
+	}
+
+	// GetSeatingChartInput defines the guest to highlight on the seating chart, if any.
+	GetSeatingChartInput struct {
+		Highlight string
+	}
+
+	// ChartTable defines a table of the seating chart with the seats of the guests arrived and expected.
+	// Guests that left free their seats.
+	ChartTable struct {
+		TableLayout
+		BookedSeats  int
+		ArrivedSeats int
+		Highlighted  bool
+	}
+
+	// SeatingChart defines the tables of the venue layout with their occupancy.
+	SeatingChart struct {
+		Tables    []ChartTable
+		Highlight string
+	}
+
+	// GetStatsOutput defines the party totals.
+	// Present guests arrived and did not leave since.
+	GetStatsOutput struct {
+		Tables        int `json:"tables"`
+		Seats         int `json:"seats"`
+		BookedSeats   int `json:"booked_seats"`
+		ArrivedSeats  int `json:"arrived_seats"`
+		EmptySeats    int `json:"empty_seats"`
+		GuestsBooked  int `json:"guests_booked"`
+		GuestsArrived int `json:"guests_arrived"`
+		GuestsPresent int `json:"guests_present"`
+		GuestsLeft    int `json:"guests_left"`
+	}
+)
+
+func (r *CreateTableInput) validate() error {
+	if r.Number == 0 {
+		return ErrTableNumberRequired
+	}
+
+	if r.Number < 0 {
+		return ErrTableNumberInvalid
+	}
+
+	if r.Size <= 0 {
+		return ErrTableSizeInvalid
+	}
+	return nil
+}
+
+func (r *ReserveTableInput) validate() error {
+	if r.Number <= 0 {
+		return ErrTableNumberInvalid
+	}
+
+	tiers := make(map[string]bool, len(r.Tiers))
+	for _, tier := range r.Tiers {
+		if !validTier(tier) || tiers[tier] {
+			return fmt.Errorf("tier %s: %w", tier, ErrTierInvalid)
+		}
+		tiers[tier] = true
+	}
+
+	if r.HeldSeats < 0 {
+		return ErrHeldSeatsInvalid
+	}
+	return nil
+}
+
+func (r *SwapSeatsInput) validate() error {
+	if r.First <= 0 || r.Second <= 0 || r.First == r.Second {
+		return ErrSeatNumberInvalid
+	}
+	return nil
+}
+
+// validateSeats checks that there is a distinct seat for each of the count guests.
+func validateSeats(seats []int, count int) error {
+	if len(seats) != count {
+		return ErrSeatsCountInvalid
+	}
+
+	numbers := make(map[int]bool, len(seats))
+	for _, number := range seats {
+		if number <= 0 || numbers[number] {
+			return fmt.Errorf("seat %d: %w", number, ErrSeatNumberInvalid)
+		}
+		numbers[number] = true
+	}
+	return nil
+}
+
+func (r *SetLayoutInput) validate() error {
+	numbers := make(map[int]bool, len(r.Tables))
+
+	for i := range r.Tables {
+		if err := r.Tables[i].validate(); err != nil {
+			return fmt.Errorf("table %d: %w", r.Tables[i].Number, err)
+		}
+
+		if numbers[r.Tables[i].Number] {
+			return fmt.Errorf("table %d: %w", r.Tables[i].Number, ErrTableDuplicatedInLayout)
+		}
+		numbers[r.Tables[i].Number] = true
+	}
+	return nil
+}
+
+// validate checks the table layout and sets the height of round tables to their diameter.
+func (r *TableLayout) validate() error {
+	if r.Number == 0 {
+		return ErrTableNumberRequired
+	}
+
+	if r.Number < 0 {
+		return ErrTableNumberInvalid
+	}
+
+	switch r.Shape {
+	case TableShapeRound:
+		r.Height = r.Width
+	case TableShapeRectangle:
+	default:
+		return ErrTableShapeInvalid
+	}
+
+	if r.X < 0 || r.Y < 0 || r.Width <= 0 || r.Height <= 0 {
+		return ErrTableLayoutInvalid
+	}
+	return nil
+}
+
+// Enumerate seating constraint kinds
+const (
+	ConstraintTogether = seating.KindTogether
+	ConstraintApart    = seating.KindApart
+	ConstraintPinned   = seating.KindPinned
+)
+
+type (
+
+	// Constraint defines a seating rule on the parties of its guests: sitting together at a table,
+	// apart at different tables, or pinned to the table of the constraint.
+	Constraint struct {
+		ID     string   `json:"id"`
+		Kind   string   `json:"kind"`
+		Guests []string `json:"guests"`
+		Table  int      `json:"table,omitempty"`
+	}
+
+	// CreateConstraintInput defines the input struct for adding a seating constraint.
+	// Table is required by pinned constraints only.
+	CreateConstraintInput struct {
+		Kind   string   `json:"kind"`
+		Guests []string `json:"guests"`
+		Table  int      `json:"table,omitempty"`
+	}
+
+	// ListConstraintsOutput defines the seating constraints in the order they were added.
+	ListConstraintsOutput struct {
+		Constraints []Constraint `json:"constraints"`
+	}
+
+	// SeatingAssignment defines the table of a guest and their accompanying guests in a seating plan.
+	// CurrentTable is the table of the guest on the guest list, it is ignored by plans to check.
+	SeatingAssignment struct {
+		Guest        string `json:"guest"`
+		Table        int    `json:"table"`
+		CurrentTable int    `json:"current_table,omitempty"`
+	}
+
+	// SeatingViolation defines why a seating plan breaks a constraint, or the table sizes when Constraint is empty.
+	SeatingViolation struct {
+		Constraint string `json:"constraint,omitempty"`
+		Reason     string `json:"reason"`
+	}
+
+	// SeatingPlan defines the table of every guest sorted by name.
+	// Unseated guests do not fit the tables and Violations lists the constraints the plan does not meet.
+	SeatingPlan struct {
+		Assignments []SeatingAssignment `json:"assignments"`
+		Unseated    []string            `json:"unseated,omitempty"`
+		Violations  []SeatingViolation  `json:"violations,omitempty"`
+	}
+
+	// CheckSeatingInput defines the seating plan to check, the tables of the guest list when empty.
+	CheckSeatingInput struct {
+		Assignments []SeatingAssignment `json:"assignments,omitempty"`
+	}
+)
+
+func (r *CreateConstraintInput) validate() error {
+	minGuests := 2
+
+	switch r.Kind {
+	case ConstraintTogether, ConstraintApart:
+		if r.Table != 0 {
+			return ErrTableNumberInvalid
+		}
+	case ConstraintPinned:
+		minGuests = 1
+
+		if r.Table == 0 {
+			return ErrTableNumberRequired
+		}
+
+		if r.Table < 0 {
+			return ErrTableNumberInvalid
+		}
+	default:
+		return ErrConstraintKindInvalid
+	}
+
+	if len(r.Guests) < minGuests {
+		return ErrConstraintGuestsInvalid
+	}
+
+	names := make(map[string]bool, len(r.Guests))
+	for _, name := range r.Guests {
+		if name == "" {
+			return ErrGuestNameRequired
+		}
+
+		if names[name] {
+			return fmt.Errorf("guest %s: %w", name, ErrConstraintGuestsInvalid)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+type (
+
+	// Group defines guests who come together, a household or a party too large for a table.
+	// Tables are the tables of its guests in number order, Guests are sorted by name.
+	Group struct {
+		Name   string  `json:"name"`
+		Tables []int   `json:"tables"`
+		Guests []Guest `json:"guests"`
+	}
+
+	// GroupGuest defines a guest of a group with their accompanying guests, seated next to them.
+	GroupGuest struct {
+		Name               string `json:"name"`
+		AccompanyingGuests int    `json:"accompanying_guests"`
+		Tier               string `json:"tier,omitempty"`
+	}
+
+	// AddGroupToGuestListInput defines the input struct for adding a group to the guestlist.
+	// Without a table, the group is seated at a table fitting them all or at adjacent tables.
+	AddGroupToGuestListInput struct {
+		Name   string       `json:"name"`
+		Table  int          `json:"table,omitempty"`
+		Guests []GroupGuest `json:"guests"`
+	}
+
+	// ListGroupsOutput defines the groups sorted by name.
+	ListGroupsOutput struct {
+		Groups []Group `json:"groups"`
+	}
+
+	// WelcomeGroupInput defines the input struct for welcoming the guests of a group.
+	WelcomeGroupInput struct {
+		Name string
+	}
+
+	// GoodbyeGroupInput defines the input struct for the leaving guests of a group.
+	GoodbyeGroupInput struct {
+		Name string
+	}
+)
+
+func (r *AddGroupToGuestListInput) validate() error {
+	if r.Name == "" {
+		return ErrGroupNameRequired
+	}
+
+	if r.Table < 0 {
+		return ErrTableNumberInvalid
+	}
+
+	if len(r.Guests) == 0 {
+		return ErrGroupGuestsRequired
+	}
+
+	names := make(map[string]bool, len(r.Guests))
+	for _, guest := range r.Guests {
+		if guest.Name == "" {
+			return ErrGuestNameRequired
+		}
+
+		if guest.AccompanyingGuests < 0 {
+			return ErrAccompanyingGuestsNumberInvalid
+		}
+
+		if guest.Tier != "" && !validTier(guest.Tier) {
+			return fmt.Errorf("guest %s: %w", guest.Name, ErrTierInvalid)
+		}
+
+		if names[guest.Name] {
+			return fmt.Errorf("guest %s: %w", guest.Name, ErrGuestDuplicatedInGroup)
+		}
+		names[guest.Name] = true
+	}
+	return nil
+}
+
+// Enumerate dietary requirements
+const (
+	DietaryVegetarian  = "vegetarian"
+	DietaryVegan       = "vegan"
+	DietaryPescatarian = "pescatarian"
+	DietaryHalal       = "halal"
+	DietaryKosher      = "kosher"
+	DietaryGlutenFree  = "gluten_free"
+	DietaryDairyFree   = "dairy_free"
+)
+
+// Enumerate accessibility needs
+const (
+	AccessibilityWheelchair       = "wheelchair"
+	AccessibilityStepFree         = "step_free"
+	AccessibilityHearing          = "hearing"
+	AccessibilityVisual           = "visual"
+	AccessibilityAssistanceAnimal = "assistance_animal"
+)
+
+// Enumerate custom field types
+const (
+	CustomFieldText    = "text"
+	CustomFieldNumber  = "number"
+	CustomFieldBoolean = "boolean"
+	CustomFieldChoice  = "choice"
+)
+
+type (
+
+	// Requirements defines the needs of a guest or of one of their accompanying guests, Companion 0 is the guest.
+	// Name names an accompanying guest, the guest is named by the guest list.
+	// Allergies are free text, such as nuts or shellfish, and stored in lower case.
+	Requirements struct {
+		Companion     int           `json:"companion"`
+		Name          string        `json:"name,omitempty"`
+		Dietary       []string      `json:"dietary"`
+		Allergies     []string      `json:"allergies"`
+		Accessibility []string      `json:"accessibility"`
+		Custom        []CustomValue `json:"custom"`
+	}
+
+	// CustomValue defines the value of a custom field, numbers and booleans in their text form.
+	CustomValue struct {
+		Field string `json:"field"`
+		Value string `json:"value"`
+	}
+
+	// GuestRequirements defines the needs of a guest then of each of their accompanying guests.
+	GuestRequirements struct {
+		Guest  string         `json:"guest"`
+		People []Requirements `json:"people"`
+	}
+
+	// SetGuestRequirementsInput defines the input struct for replacing the needs of a guest and of their
+	// accompanying guests. People left out have no needs.
+	SetGuestRequirementsInput struct {
+		Guest  string         `json:"-"`
+		People []Requirements `json:"people"`
+	}
+
+	// CustomField defines a guest attribute of the organiser. Choice fields take one of their options, and
+	// required fields need a value for every person of a guest when their requirements are set.
+	CustomField struct {
+		Name     string   `json:"name"`
+		Type     string   `json:"type"`
+		Options  []string `json:"options,omitempty"`
+		Required bool     `json:"required"`
+	}
+
+	// SetCustomFieldsInput defines the input struct for replacing the custom fields of the party.
+	SetCustomFieldsInput struct {
+		Fields []CustomField `json:"fields"`
+	}
+
+	// ListCustomFieldsOutput defines the custom fields in the order they were given.
+	ListCustomFieldsOutput struct {
+		Fields []CustomField `json:"fields"`
+	}
+
+	// CateringCount defines the number of people with a need, Field names the custom field of the custom values.
+	CateringCount struct {
+		Field string `json:"field,omitempty"`
+		Value string `json:"value"`
+		Count int    `json:"count"`
+	}
+
+	// CateringTable defines the needs of the people at a table, sorted by value.
+	// People counts the guests and their accompanying guests.
+	CateringTable struct {
+		Table         int             `json:"table,omitempty"`
+		People        int             `json:"people"`
+		Dietary       []CateringCount `json:"dietary"`
+		Allergies     []CateringCount `json:"allergies"`
+		Accessibility []CateringCount `json:"accessibility"`
+		Custom        []CateringCount `json:"custom"`
+	}
+
+	// CateringSummary defines the needs of the guest list by table in number order, and in total.
+	// Custom values are counted for the boolean and choice fields.
+	CateringSummary struct {
+		Total  CateringTable   `json:"total"`
+		Tables []CateringTable `json:"tables"`
+	}
+)
+
+func (r *SetCustomFieldsInput) validate() error {
+	names := make(map[string]bool, len(r.Fields))
+
+	for i := range r.Fields {
+		if err := r.Fields[i].validate(); err != nil {
+			return fmt.Errorf("field %s: %w", r.Fields[i].Name, err)
+		}
+
+		if names[r.Fields[i].Name] {
+			return fmt.Errorf("field %s: %w", r.Fields[i].Name, ErrCustomFieldInvalid)
+		}
+		names[r.Fields[i].Name] = true
+	}
+	return nil
+}
+
+func (r *CustomField) validate() error {
+	if !customFieldName.MatchString(r.Name) {
+		return ErrCustomFieldInvalid
+	}
+
+	switch r.Type {
+	case CustomFieldText, CustomFieldNumber, CustomFieldBoolean:
+		if len(r.Options) > 0 {
+			return ErrCustomFieldInvalid
+		}
+	case CustomFieldChoice:
+		if len(r.Options) == 0 {
+			return ErrCustomFieldInvalid
+		}
+
+		// Options are stored comma separated
+		options := make(map[string]bool, len(r.Options))
+		for _, option := range r.Options {
+			if option == "" || option != strings.TrimSpace(option) || strings.Contains(option, ",") ||
+				len(option) > maxCustomValueLength || options[option] {
+				return fmt.Errorf("option %s: %w", option, ErrCustomFieldInvalid)
+			}
+			options[option] = true
+		}
+	default:
+		return ErrCustomFieldInvalid
+	}
+	return nil
+}
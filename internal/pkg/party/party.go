@@ -14,31 +14,109 @@ import (
 type (
 	Service interface {
 		AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestListInput) (*AddGuestToGuestListOutput, error)
-		GetGuestList(ctx context.Context) (GetGuestListOutput, error)
+		GetGuestList(ctx context.Context, in *ListGuestsInput) (GetGuestListOutput, error)
 		WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*WelcomeGuestOutput, error)
 		GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error
-		ListArrivedGuests(ctx context.Context) (ListArrivedGuestsOutput, error)
+		RemoveGuestFromGuestList(ctx context.Context, in *RemoveGuestFromGuestListInput) error
+		ListArrivedGuests(ctx context.Context, in *ListGuestsInput) (ListArrivedGuestsOutput, error)
 		GetEmptySeats(ctx context.Context) (GetEmptySeatsOutput, error)
+		ListTables(ctx context.Context) (ListTablesOutput, error)
+		GetStats(ctx context.Context) (GetStatsOutput, error)
+		CreateTable(ctx context.Context, in *CreateTableInput) (*Table, error)
+		ReserveTable(ctx context.Context, in *ReserveTableInput) (*Table, error)
+		ReleaseTable(ctx context.Context, number int) (*Table, error)
+
+		AddGroupToGuestList(ctx context.Context, in *AddGroupToGuestListInput) (*Group, error)
+		GetGroup(ctx context.Context, name string) (*Group, error)
+		ListGroups(ctx context.Context) (ListGroupsOutput, error)
+		WelcomeGroup(ctx context.Context, in *WelcomeGroupInput) (*Group, error)
+		GoodbyeGroup(ctx context.Context, in *GoodbyeGroupInput) (*Group, error)
+
+		GetGuestRequirements(ctx context.Context, name string) (*GuestRequirements, error)
+		SetGuestRequirements(ctx context.Context, in *SetGuestRequirementsInput) (*GuestRequirements, error)
+		ListCustomFields(ctx context.Context) (ListCustomFieldsOutput, error)
+		SetCustomFields(ctx context.Context, in *SetCustomFieldsInput) (ListCustomFieldsOutput, error)
+		GetCateringSummary(ctx context.Context) (*CateringSummary, error)
+
+		AssignSeats(ctx context.Context, in *AssignSeatsInput) (*Table, error)
+		SwapSeats(ctx context.Context, in *SwapSeatsInput) (*Table, error)
+
+		CreateConstraint(ctx context.Context, in *CreateConstraintInput) (*Constraint, error)
+		ListConstraints(ctx context.Context) (ListConstraintsOutput, error)
+		DeleteConstraint(ctx context.Context, id string) error
+		PlanSeating(ctx context.Context) (*SeatingPlan, error)
+		CheckSeating(ctx context.Context, in *CheckSeatingInput) (*SeatingPlan, error)
+
+		SetLayout(ctx context.Context, in *SetLayoutInput) (*GetLayoutOutput, error)
+		GetLayout(ctx context.Context) (GetLayoutOutput, error)
+		GetSeatingChart(ctx context.Context, in *GetSeatingChartInput) (*SeatingChart, error)
+
+		CreateInvitation(ctx context.Context, in *CreateInvitationInput) (*CreateInvitationOutput, error)
+		GetInvitation(ctx context.Context, token string) (*GetInvitationOutput, error)
+		RespondToInvitation(ctx context.Context, in *RespondToInvitationInput) (*RespondToInvitationOutput, error)
+
+		IssueCheckInCode(ctx context.Context, in *IssueCheckInCodeInput) (*IssueCheckInCodeOutput, error)
+		GetCheckInCode(ctx context.Context, code string) (*GetCheckInCodeOutput, error)
+		RevokeCheckInCode(ctx context.Context, code string) error
+		CheckIn(ctx context.Context, in *CheckInInput) (*CheckInOutput, error)
+
+		ImportGuests(ctx context.Context, in *ImportGuestsInput) (*ImportGuestsOutput, error)
+
+		GetSeatingReport(ctx context.Context) (*GetSeatingReportOutput, error)
 	}
 
 	Party struct {
-		logger    *zap.Logger
-		repo      repository.Repository
-		tableSize int
-	}
+		logger           *zap.Logger
+		repo             repository.Repository
+		tableSize        int
+		invitationSecret []byte
+		invitationTTL    time.Duration
+		listeners        []Listener
+		heldEvents       *[]Event
+		now              func() time.Time
+	}
+
+	// Option configures optional Party behaviour.
+	Option func(*Party)
 )
 
 var _ Service = (*Party)(nil)
 
-func New(logger *zap.Logger, repo repository.Repository, tableSize int) *Party {
-	return &Party{
-		logger:    logger.Named("party_service"),
-		repo:      repo,
-		tableSize: tableSize,
+const defaultInvitationTTL = 14 * 24 * time.Hour
+
+// WithInvitationSecret sets the key used to sign and verify invitation tokens.
+func WithInvitationSecret(secret []byte) Option {
+	return func(p *Party) {
+		p.invitationSecret = secret
+	}
+}
+
+// WithInvitationTTL sets how long invitations are valid when the organiser does not set an expiry.
+func WithInvitationTTL(ttl time.Duration) Option {
+	return func(p *Party) {
+		p.invitationTTL = ttl
 	}
 }
 
+func New(logger *zap.Logger, repo repository.Repository, tableSize int, opts ...Option) *Party {
+	p := Party{
+		logger:        logger.Named("party_service"),
+		repo:          repo,
+		tableSize:     tableSize,
+		invitationTTL: defaultInvitationTTL,
+		now:           time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return &p
+}
+
 func (p *Party) AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestListInput) (*AddGuestToGuestListOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.AddGuestToGuestList")
+	defer span.End()
+
 	// Validate input
 	if err := in.validate(); err != nil {
 		return nil, fmt.Errorf("could not validate input for adding guest to list: %w", err)
@@ -68,22 +146,59 @@ func (p *Party) AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestList
 		if err := p.createTableAndAddToGuestList(ctx, in); err != nil {
 			return nil, fmt.Errorf("could not create table and add guest to guest list: %w", err)
 		}
+
+		code, err := issueCheckInCode(ctx, p.repo, in.Name, p.now())
+		if err != nil {
+			return nil, err
+		}
+
+		p.emit(ctx, Event{
+			Type:               EventGuestAdded,
+			Guest:              in.Name,
+			Table:              in.Table,
+			AccompanyingGuests: in.AccompanyingGuests,
+			AvailableSeats:     p.tableSize - (in.AccompanyingGuests + 1),
+		})
+
 		return &AddGuestToGuestListOutput{
-			Name: in.Name,
+			Name:        in.Name,
+			CheckInCode: code,
 		}, nil
 	}
 
-	// Check if table has enough available seats
+	reservations, err := getTableReservations(ctx, p.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := reservations[table.Number]
+	if !allowsTier(reservation, in.Tier) {
+		return nil, ErrTableTierRestricted
+	}
+
+	// Check if table has enough available seats, held seats are only released by organisers
 	requestedSeats := in.AccompanyingGuests + 1
-	if table.AvailableSeats < requestedSeats {
+	if bookableSeats(*table, reservation) < requestedSeats {
 		return nil, ErrTableNotEnoughSeats
 	}
 
+	// Find the seats of the party before writing, a seat taken leaves the guest list untouched
+	seats, err := p.repo.GetSeatsByTable(ctx, table.Number)
+	if err != nil {
+		return nil, fmt.Errorf("could not get seats: %w", err)
+	}
+
+	assigned, err := planSeats(seats, table, in.Name, in.Seats, requestedSeats)
+	if err != nil {
+		return nil, err
+	}
+
 	// Insert guest into guest list
 	guestStore := repository.Guest{
 		Name:               in.Name,
 		Table:              in.Table,
 		AccompanyingGuests: in.AccompanyingGuests,
+		Tier:               in.Tier,
 	}
 
 	if err := p.repo.UpsertGuest(ctx, &guestStore); err != nil {
@@ -95,21 +210,44 @@ func (p *Party) AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestList
 	tableStore := repository.Table{
 		Number:         table.Number,
 		AvailableSeats: table.AvailableSeats - requestedSeats,
-		Size:           p.tableSize,
+		Size:           table.Size,
 	}
 
 	if err := p.repo.UpsertTable(ctx, &tableStore); err != nil {
 		return nil, fmt.Errorf("could not upsert table: %w", err)
 	}
 
+	if err := p.repo.CreateSeats(ctx, assigned); err != nil {
+		return nil, fmt.Errorf("could not create seats: %w", err)
+	}
+
+	// Every guest in the list gets a check-in code
+	code, err := issueCheckInCode(ctx, p.repo, in.Name, p.now())
+	if err != nil {
+		return nil, err
+	}
+
+	p.emit(ctx, Event{
+		Type:               EventGuestAdded,
+		Guest:              in.Name,
+		Table:              in.Table,
+		AccompanyingGuests: in.AccompanyingGuests,
+		AvailableSeats:     tableStore.AvailableSeats,
+	})
+
 	// Return output
 	return &AddGuestToGuestListOutput{
-		Name: in.Name,
+		Name:        in.Name,
+		CheckInCode: code,
 	}, nil
 }
 
-func (p *Party) GetGuestList(ctx context.Context) (GetGuestListOutput, error) {
-	guests, err := p.repo.ListGuests(ctx)
+// GetGuestList returns the guests in the guest list matching the input filters.
+func (p *Party) GetGuestList(ctx context.Context, in *ListGuestsInput) (GetGuestListOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetGuestList")
+	defer span.End()
+
+	guests, nextCursor, err := p.queryGuests(ctx, in)
 	if err != nil {
 		return GetGuestListOutput{}, fmt.Errorf("could not get guestlist: %w", err)
 	}
@@ -120,15 +258,22 @@ func (p *Party) GetGuestList(ctx context.Context) (GetGuestListOutput, error) {
 			Name:               guest.Name,
 			Table:              guest.Table,
 			AccompanyingGuests: guest.AccompanyingGuests,
+			Tier:               guest.Tier,
+			TimeArrival:        guest.TimeArrival,
+			TimeDeparture:      guest.TimeDeparture,
 		})
 	}
 
 	return GetGuestListOutput{
-		Guests: list,
+		Guests:     list,
+		NextCursor: nextCursor,
 	}, nil
 }
 
 func (p *Party) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*WelcomeGuestOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.WelcomeGuest")
+	defer span.End()
+
 	// Get guest in guest list
 	guest, err := p.repo.GetGuestByName(ctx, in.Name)
 	if err != nil {
@@ -140,6 +285,11 @@ func (p *Party) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*Welco
 		return nil, ErrGuestNotInList
 	}
 
+	// Arrivals are recorded once, until the guest leaves
+	if IsPresent(*guest) {
+		return nil, ErrGuestPresent
+	}
+
 	// Get table by number
 	table, err := p.repo.GetTableByNumber(ctx, guest.Table)
 	if err != nil {
@@ -150,18 +300,36 @@ func (p *Party) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*Welco
 		return nil, ErrTableNumberNotFound
 	}
 
-	// Check if table has enough available seats
-
-	requestedSeats := in.AccompanyingGuests + 1
+	reservations, err := getTableReservations(ctx, p.repo)
+	if err != nil {
+		return nil, err
+	}
 
-	if table.AvailableSeats < requestedSeats {
+	// The booked seats are the guest's, only the accompanying guests beyond the booking need available seats
+	// and they do not take the held seats
+	if extraSeats := in.AccompanyingGuests - guest.AccompanyingGuests; extraSeats > 0 &&
+		bookableSeats(*table, reservations[table.Number]) < extraSeats {
 		return nil, ErrTableNotEnoughSeats
 	}
 
-	// Update table
+	// Seat the accompanying guests arriving, keeping the seats of the guest
+	seats, err := p.repo.GetSeatsByTable(ctx, table.Number)
+	if err != nil {
+		return nil, fmt.Errorf("could not get seats: %w", err)
+	}
+
+	assigned, err := planSeats(seats, table, guest.Name, nil, in.AccompanyingGuests+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update table, the booked seats were taken when adding the guest: the accompanying guests beyond
+	// the booking take more seats and the booked seats not used are released until the guest leaves
 
 	tableStore := repository.Table{
-		AvailableSeats: table.AvailableSeats - requestedSeats,
+		Number:         table.Number,
+		AvailableSeats: table.AvailableSeats - (in.AccompanyingGuests - guest.AccompanyingGuests),
+		Size:           table.Size,
 	}
 
 	if err := p.repo.UpsertTable(ctx, &tableStore); err != nil {
@@ -171,11 +339,27 @@ func (p *Party) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*Welco
 	// Mark guest as present
 	arrivalTime := time.Now() // TODO(alesr): Use timezone
 	guest.TimeArrival = &arrivalTime
+	guest.ArrivedAccompanyingGuests = in.AccompanyingGuests
 
 	if err := p.repo.UpsertGuest(ctx, guest); err != nil {
 		return nil, fmt.Errorf("could not upsert guest: %w", err)
 	}
 
+	if !sameSeats(guestSeats(seats, guest.Name), assigned) {
+		if err := replaceGuestSeats(ctx, p.repo, guest.Name, assigned); err != nil {
+			return nil, err
+		}
+	}
+
+	p.emit(ctx, Event{
+		Type:               EventGuestArrived,
+		Guest:              guest.Name,
+		Table:              guest.Table,
+		AccompanyingGuests: in.AccompanyingGuests,
+		AvailableSeats:     tableStore.AvailableSeats,
+		Time:               arrivalTime,
+	})
+
 	// Return output
 	return &WelcomeGuestOutput{
 		Name: in.Name,
@@ -183,6 +367,9 @@ func (p *Party) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*Welco
 }
 
 func (p *Party) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error {
+	ctx, span := tracer.Start(ctx, "party.GoodbyeGuest")
+	defer span.End()
+
 	// Get guest in guest list
 	guest, err := p.repo.GetGuestByName(ctx, in.Name)
 	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
@@ -194,6 +381,11 @@ func (p *Party) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error {
 		return ErrGuestNotInList
 	}
 
+	// Departures are recorded once, for guests at the party
+	if !IsPresent(*guest) {
+		return ErrGuestNotPresent
+	}
+
 	// Get table by number
 	table, err := p.repo.GetTableByNumber(ctx, guest.Table)
 	if err != nil {
@@ -204,11 +396,12 @@ func (p *Party) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error {
 		return ErrTableNumberNotFound
 	}
 
-	// Update table
+	// Update table, giving back the seats charged on arrival so that the booked seats stay taken
 
-	requestedSeats := guest.AccompanyingGuests + 1
 	tableStore := repository.Table{
-		AvailableSeats: table.AvailableSeats + requestedSeats,
+		Number:         table.Number,
+		AvailableSeats: table.AvailableSeats + (guest.ArrivedAccompanyingGuests - guest.AccompanyingGuests),
+		Size:           table.Size,
 	}
 
 	if err := p.repo.UpsertTable(ctx, &tableStore); err != nil {
@@ -223,11 +416,114 @@ func (p *Party) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error {
 	if err := p.repo.UpsertGuest(ctx, guest); err != nil {
 		return fmt.Errorf("could not upsert guest: %w", err)
 	}
+
+	// Free the seats of the party, they are assigned again if the guest comes back
+	if err := p.repo.DeleteGuestSeats(ctx, guest.Name); err != nil {
+		return fmt.Errorf("could not delete guest seats: %w", err)
+	}
+
+	p.emit(ctx, Event{
+		Type:               EventGuestLeft,
+		Guest:              guest.Name,
+		Table:              guest.Table,
+		AccompanyingGuests: guest.ArrivedAccompanyingGuests,
+		AvailableSeats:     tableStore.AvailableSeats,
+		Time:               timeDeparture,
+	})
+	return nil
+}
+
+// RemoveGuestFromGuestList removes a guest from the guest list, freeing their booked seats.
+// Guests present at the party must leave before being removed.
+func (p *Party) RemoveGuestFromGuestList(ctx context.Context, in *RemoveGuestFromGuestListInput) error {
+	ctx, span := tracer.Start(ctx, "party.RemoveGuestFromGuestList")
+	defer span.End()
+
+	if in.Name == "" {
+		return ErrGuestNameRequired
+	}
+
+	var event Event
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		guest, err := tx.GetGuestByName(ctx, in.Name)
+		if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+			return fmt.Errorf("could not get guest by name: %w", err)
+		}
+
+		// Missing guests are found with a zero name
+		if guest == nil || guest.Name == "" {
+			return ErrGuestNotInList
+		}
+
+		if IsPresent(*guest) {
+			return ErrGuestPresent
+		}
+
+		if err := tx.DeleteGuest(ctx, guest.Name); err != nil {
+			return fmt.Errorf("could not delete guest: %w", err)
+		}
+
+		if err := tx.DeleteGuestSeats(ctx, guest.Name); err != nil {
+			return fmt.Errorf("could not delete guest seats: %w", err)
+		}
+
+		if err := tx.ReplaceGuestRequirements(ctx, guest.Name, nil, nil); err != nil {
+			return fmt.Errorf("could not delete guest requirements: %w", err)
+		}
+
+		table, err := tx.GetTableByNumber(ctx, guest.Table)
+		if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+			return fmt.Errorf("could not get table by number: %w", err)
+		}
+
+		event = Event{
+			Type:               EventGuestRemoved,
+			Guest:              guest.Name,
+			Table:              guest.Table,
+			AccompanyingGuests: guest.AccompanyingGuests,
+		}
+
+		// Guests of a missing table have no seats to free
+		if table == nil {
+			return nil
+		}
+
+		tableStore := repository.Table{
+			Number:         table.Number,
+			AvailableSeats: table.AvailableSeats + guest.AccompanyingGuests + 1,
+			Size:           table.Size,
+		}
+
+		if err := tx.UpsertTable(ctx, &tableStore); err != nil {
+			return fmt.Errorf("could not upsert table: %w", err)
+		}
+
+		event.AvailableSeats = tableStore.AvailableSeats
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	p.emit(ctx, event)
 	return nil
 }
 
-func (p *Party) ListArrivedGuests(ctx context.Context) (ListArrivedGuestsOutput, error) {
-	guests, err := p.repo.GetArrivedGuests(ctx)
+// ListArrivedGuests returns the arrived guests still at the party matching the input filters.
+func (p *Party) ListArrivedGuests(ctx context.Context, in *ListGuestsInput) (ListArrivedGuestsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.ListArrivedGuests")
+	defer span.End()
+
+	arrived := true
+
+	query := ListGuestsInput{Arrived: &arrived}
+	if in != nil {
+		query = *in
+		query.Arrived = &arrived
+	}
+
+	guests, nextCursor, err := p.queryGuests(ctx, &query)
 	if err != nil {
 		return ListArrivedGuestsOutput{}, err
 	}
@@ -242,16 +538,20 @@ func (p *Party) ListArrivedGuests(ctx context.Context) (ListArrivedGuestsOutput,
 		list = append(list, GuestArrived{
 			Name:               guest.Name,
 			TimeArrival:        *guest.TimeArrival,
-			AccompanyingGuests: guest.AccompanyingGuests,
+			AccompanyingGuests: guest.ArrivedAccompanyingGuests,
 		})
 	}
 
 	return ListArrivedGuestsOutput{
-		Guests: list,
+		Guests:     list,
+		NextCursor: nextCursor,
 	}, nil
 }
 
 func (p *Party) GetEmptySeats(ctx context.Context) (GetEmptySeatsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetEmptySeats")
+	defer span.End()
+
 	tables, err := p.repo.GetTables(ctx)
 	if err != nil {
 		return GetEmptySeatsOutput{}, fmt.Errorf("could not get tables: %w", err)
@@ -279,6 +579,11 @@ func (p *Party) createTableAndAddToGuestList(ctx context.Context, in *AddGuestTo
 		Size:           p.tableSize,
 	}
 
+	assigned, err := planSeats(nil, &tableStore, in.Name, in.Seats, requestedSeats)
+	if err != nil {
+		return err
+	}
+
 	if err := p.repo.UpsertTable(ctx, &tableStore); err != nil {
 		return fmt.Errorf("could not upsert table: %w", err)
 	}
@@ -289,10 +594,15 @@ func (p *Party) createTableAndAddToGuestList(ctx context.Context, in *AddGuestTo
 		Name:               in.Name,
 		Table:              in.Table,
 		AccompanyingGuests: in.AccompanyingGuests,
+		Tier:               in.Tier,
 	}
 
 	if err := p.repo.UpsertGuest(ctx, &guestStore); err != nil {
 		return fmt.Errorf("could not upsert guest: %w", err)
 	}
+
+	if err := p.repo.CreateSeats(ctx, assigned); err != nil {
+		return fmt.Errorf("could not create seats: %w", err)
+	}
 	return nil
 }
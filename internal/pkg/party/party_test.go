@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/pkg/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -140,6 +142,9 @@ func TestAddGuestToGuestList(t *testing.T) {
 				AvailableSeats: 1,
 			}, nil
 		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
 
 		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
 			return ErrTableNotEnoughSeats
@@ -159,6 +164,11 @@ func TestAddGuestToGuestList(t *testing.T) {
 	})
 
 	t.Run("return no error when the table has enough available seats", func(t *testing.T) {
+		var (
+			createdSeats []repository.Seat
+			issuedCode   *repository.CheckInCode
+		)
+
 		repo := repository.Mock{}
 		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
 			return nil, nil
@@ -168,8 +178,12 @@ func TestAddGuestToGuestList(t *testing.T) {
 			return &repository.Table{
 				Number:         number,
 				AvailableSeats: 2,
+				Size:           4,
 			}, nil
 		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
 
 		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
 			return nil
@@ -179,6 +193,24 @@ func TestAddGuestToGuestList(t *testing.T) {
 			return nil
 		}
 
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+			return []repository.Seat{{Table: table, Number: 2, Guest: "456"}}, nil
+		}
+
+		repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+			createdSeats = seats
+			return nil
+		}
+
+		repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+			return nil, nil
+		}
+
+		repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error {
+			issuedCode = code
+			return nil
+		}
+
 		party := New(zap.NewNop(), &repo, testTableSize)
 		observed, err := party.AddGuestToGuestList(
 			context.TODO(),
@@ -189,7 +221,233 @@ func TestAddGuestToGuestList(t *testing.T) {
 			})
 
 		assert.Nil(t, err)
-		assert.NotNil(t, observed)
+		require.NotNil(t, observed)
+
+		// Every guest added gets a check-in code
+		require.NotNil(t, issuedCode)
+		assert.Equal(t, &AddGuestToGuestListOutput{Name: "123", CheckInCode: issuedCode.Code}, observed)
+		assert.Equal(t, CheckInCodeStatusActive, issuedCode.Status)
+
+		// Seat 1 is free but alone, the party sits together on the next free seats
+		assert.Equal(t, []repository.Seat{
+			{Table: 456, Number: 3, Guest: "123"},
+			{Table: 456, Number: 4, Guest: "123", Companion: 1},
+		}, createdSeats)
+	})
+
+	t.Run("seats the party on the requested seats", func(t *testing.T) {
+		cases := []struct {
+			name          string
+			givenSeats    []int
+			expectedSeats []repository.Seat
+			expectedError error
+		}{
+			{
+				name:       "free seats",
+				givenSeats: []int{4, 1},
+				expectedSeats: []repository.Seat{
+					{Table: 456, Number: 4, Guest: "123"},
+					{Table: 456, Number: 1, Guest: "123", Companion: 1},
+				},
+			},
+			{
+				name:          "seat taken",
+				givenSeats:    []int{1, 2},
+				expectedError: ErrSeatTaken,
+			},
+			{
+				name:          "seat beyond the table size",
+				givenSeats:    []int{1, 5},
+				expectedError: ErrSeatNumberInvalid,
+			},
+			{
+				name:          "seat twice",
+				givenSeats:    []int{1, 1},
+				expectedError: ErrSeatNumberInvalid,
+			},
+			{
+				name:          "seat missing for the accompanying guest",
+				givenSeats:    []int{1},
+				expectedError: ErrSeatsCountInvalid,
+			},
+		}
+
+		for _, tc := range cases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				var (
+					createdSeats []repository.Seat
+					guestAdded   bool
+				)
+
+				repo := repository.Mock{}
+				repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+					return nil, nil
+				}
+				repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+					return &repository.Table{Number: number, AvailableSeats: 2, Size: 4}, nil
+				}
+				repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+					return nil, nil
+				}
+				repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+					return []repository.Seat{{Table: table, Number: 2, Guest: "456"}}, nil
+				}
+				repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+					guestAdded = true
+					return nil
+				}
+				repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error {
+					return nil
+				}
+				repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+					createdSeats = seats
+					return nil
+				}
+				repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+					return nil, nil
+				}
+				repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error { return nil }
+
+				party := New(zap.NewNop(), &repo, testTableSize)
+				_, err := party.AddGuestToGuestList(context.TODO(), &AddGuestToGuestListInput{
+					Name:               "123",
+					Table:              456,
+					AccompanyingGuests: 1,
+					Seats:              tc.givenSeats,
+				})
+
+				if tc.expectedError != nil {
+					assert.True(t, errors.Is(err, tc.expectedError), err)
+					assert.False(t, guestAdded)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedSeats, createdSeats)
+			})
+		}
+	})
+}
+
+func TestRemoveGuestFromGuestList(t *testing.T) {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+	left := arrived.Add(time.Hour)
+
+	newRepo := func(guest *repository.Guest) (*repository.Mock, *[]string, *[]repository.Table) {
+		var (
+			deleted  []string
+			upserted []repository.Table
+		)
+
+		repo := repository.Mock{}
+		repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+			return fn(&repo)
+		}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			if guest == nil {
+				return nil, database.ErrRecordNotFound
+			}
+			return guest, nil
+		}
+		repo.DeleteGuestFunc = func(ctx context.Context, name string) error {
+			deleted = append(deleted, name)
+			return nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, Size: 10, AvailableSeats: 4}, nil
+		}
+		repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error {
+			return nil
+		}
+		repo.ReplaceGuestRequirementsFunc = func(ctx context.Context, guest string, requirements []repository.Requirement, companions []repository.Companion) error {
+			return nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error {
+			upserted = append(upserted, *table)
+			return nil
+		}
+		return &repo, &deleted, &upserted
+	}
+
+	t.Run("returns an error when name is missing", func(t *testing.T) {
+		party := New(zap.NewNop(), &repository.Mock{}, testTableSize)
+
+		err := party.RemoveGuestFromGuestList(context.TODO(), &RemoveGuestFromGuestListInput{})
+		assert.True(t, errors.Is(err, ErrGuestNameRequired))
+	})
+
+	t.Run("returns an error when guest is not in guest list", func(t *testing.T) {
+		repo, deleted, _ := newRepo(nil)
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		err := party.RemoveGuestFromGuestList(context.TODO(), &RemoveGuestFromGuestListInput{Name: "123"})
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+		assert.Empty(t, *deleted)
+	})
+
+	t.Run("returns an error when guest is present", func(t *testing.T) {
+		repo, deleted, _ := newRepo(&repository.Guest{Name: "123", Table: 1, TimeArrival: &arrived})
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		err := party.RemoveGuestFromGuestList(context.TODO(), &RemoveGuestFromGuestListInput{Name: "123"})
+		assert.True(t, errors.Is(err, ErrGuestPresent))
+		assert.Empty(t, *deleted)
+	})
+
+	t.Run("returns an error when delete guest fails", func(t *testing.T) {
+		repo, _, upserted := newRepo(&repository.Guest{Name: "123", Table: 1})
+		repo.DeleteGuestFunc = func(ctx context.Context, name string) error {
+			return errTestRepo
+		}
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		err := party.RemoveGuestFromGuestList(context.TODO(), &RemoveGuestFromGuestListInput{Name: "123"})
+		assert.True(t, errors.Is(err, errTestRepo))
+		assert.Empty(t, *upserted)
+	})
+
+	t.Run("removes the guest and frees the booked seats", func(t *testing.T) {
+		for _, guest := range []repository.Guest{
+			{Name: "123", Table: 1, AccompanyingGuests: 2},
+			{Name: "123", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived, TimeDeparture: &left},
+		} {
+			guest := guest
+
+			var (
+				events       []Event
+				freedSeats   []string
+				requirements []string
+			)
+
+			repo, deleted, upserted := newRepo(&guest)
+			repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error {
+				freedSeats = append(freedSeats, name)
+				return nil
+			}
+			repo.ReplaceGuestRequirementsFunc = func(ctx context.Context, guest string, stores []repository.Requirement, companions []repository.Companion) error {
+				assert.Empty(t, stores)
+				assert.Empty(t, companions)
+				requirements = append(requirements, guest)
+				return nil
+			}
+
+			party := New(zap.NewNop(), repo, testTableSize, WithListener(func(e Event) {
+				events = append(events, e)
+			}))
+
+			err := party.RemoveGuestFromGuestList(context.TODO(), &RemoveGuestFromGuestListInput{Name: "123"})
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"123"}, *deleted)
+			assert.Equal(t, []string{"123"}, freedSeats)
+			assert.Equal(t, []string{"123"}, requirements)
+			assert.Equal(t, []repository.Table{{Number: 1, Size: 10, AvailableSeats: 7}}, *upserted)
+
+			require.Len(t, events, 1)
+			assert.Equal(t, EventGuestRemoved, events[0].Type)
+			assert.Equal(t, 7, events[0].AvailableSeats)
+		}
 	})
 }
 
@@ -231,12 +489,12 @@ func TestGetGuestList(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			repo := repository.Mock{}
-			repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			repo.QueryGuestsFunc = func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
 				return c.givenMockResult, c.expectedError
 			}
 
 			party := New(zap.NewNop(), &repo, testTableSize)
-			observed, err := party.GetGuestList(context.TODO())
+			observed, err := party.GetGuestList(context.TODO(), nil)
 
 			assert.Equal(t, c.expectedResult, observed)
 			assert.True(t, errors.Is(err, c.expectedError))
@@ -310,6 +568,10 @@ func TestCreateTableAndAddToGuestList(t *testing.T) {
 				return tc.givenAddToGuestListMockErr
 			}
 
+			repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+				return nil
+			}
+
 			party := New(zap.NewNop(), &repo, testTableSize)
 			observedErr := party.createTableAndAddToGuestList(context.TODO(), tc.given)
 
@@ -411,9 +673,15 @@ func TestWelcomeGuest(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			repo := repository.Mock{}
 			repo.GetGuestByNameFunc = tc.givenGetGuestByNameMockFn
+			repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+				return nil, nil
+			}
 			repo.GetTableByNumberFunc = tc.givenGetTableByNumberMockFn
 			repo.UpsertTableFunc = tc.givenUpsertTableFn
 			repo.UpsertGuestFunc = tc.givenUpsertGuestFn
+			repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+			repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error { return nil }
+			repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
 
 			party := New(zap.NewNop(), &repo, testTableSize)
 			_, observedErr := party.WelcomeGuest(
@@ -427,11 +695,84 @@ func TestWelcomeGuest(t *testing.T) {
 		})
 	}
 
+	t.Run("keeps the seats of the guest and seats the accompanying guests arriving", func(t *testing.T) {
+		cases := []struct {
+			name                    string
+			givenAccompanyingGuests int
+			expectedSeats           []repository.Seat
+		}{
+			{
+				name:                    "as booked",
+				givenAccompanyingGuests: 1,
+			},
+			{
+				name:                    "one more next to them",
+				givenAccompanyingGuests: 2,
+				expectedSeats: []repository.Seat{
+					{Table: 1, Number: 4, Guest: "123"},
+					{Table: 1, Number: 5, Guest: "123", Companion: 1},
+					{Table: 1, Number: 3, Guest: "123", Companion: 2},
+				},
+			},
+			{
+				name:                    "alone",
+				givenAccompanyingGuests: 0,
+				expectedSeats: []repository.Seat{
+					{Table: 1, Number: 4, Guest: "123"},
+				},
+			},
+		}
+
+		for _, tc := range cases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				var createdSeats []repository.Seat
+
+				repo := repository.Mock{}
+				repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+					return &repository.Guest{Name: name, Table: 1, AccompanyingGuests: 1}, nil
+				}
+				repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+					return nil, nil
+				}
+				repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+					return &repository.Table{Number: number, Size: 6, AvailableSeats: 6}, nil
+				}
+				repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+					return []repository.Seat{
+						{Table: table, Number: 1, Guest: "456"},
+						{Table: table, Number: 4, Guest: "123"},
+						{Table: table, Number: 5, Guest: "123", Companion: 1},
+					}, nil
+				}
+				repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+				repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error { return nil }
+				repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error { return nil }
+				repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+					createdSeats = seats
+					return nil
+				}
+
+				party := New(zap.NewNop(), &repo, testTableSize)
+				_, err := party.WelcomeGuest(context.TODO(), &WelcomeGuestInput{
+					Name:               "123",
+					AccompanyingGuests: tc.givenAccompanyingGuests,
+				})
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.expectedSeats, createdSeats)
+			})
+		}
+	})
+
 	t.Run("returns an error when guest is not in the list", func(t *testing.T) {
 		repo := repository.Mock{}
 		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
 			return nil, nil
 		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
 		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
 			return &repository.Table{
 				Number:         456,
@@ -460,6 +801,9 @@ func TestWelcomeGuest(t *testing.T) {
 				Name: "123",
 			}, nil
 		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
 		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
 			return nil, nil
 		}
@@ -484,6 +828,9 @@ func TestWelcomeGuest(t *testing.T) {
 				Name: "123",
 			}, nil
 		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
 		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
 			return &repository.Table{
 				Number:         456,
@@ -505,6 +852,61 @@ func TestWelcomeGuest(t *testing.T) {
 		assert.Equal(t, ErrTableNotEnoughSeats, observedErr)
 	})
 
+	t.Run("returns an error when the extra companions need the held seats", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: "123", Table: 1, AccompanyingGuests: 1}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return []repository.TableReservation{{Table: 1, HeldSeats: 2}}, nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, Size: 6, AvailableSeats: 4}, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		_, observedErr := party.WelcomeGuest(
+			context.TODO(),
+			&WelcomeGuestInput{
+				Name:               "123",
+				AccompanyingGuests: 4,
+			})
+
+		assert.Equal(t, ErrTableNotEnoughSeats, observedErr)
+	})
+
+	t.Run("welcomes the booked party at a table with held seats", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+			return &repository.Guest{Name: "123", Table: 1, AccompanyingGuests: 1}, nil
+		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return []repository.TableReservation{{Table: 1, HeldSeats: 2}}, nil
+		}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, Size: 4, AvailableSeats: 2}, nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
+		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error { return nil }
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+			return []repository.Seat{
+				{Table: table, Number: 1, Guest: "123"},
+				{Table: table, Number: 2, Guest: "123", Companion: 1},
+			}, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observedOutput, observedErr := party.WelcomeGuest(
+			context.TODO(),
+			&WelcomeGuestInput{
+				Name:               "123",
+				AccompanyingGuests: 1,
+			})
+
+		require.NoError(t, observedErr)
+		assert.Equal(t, &WelcomeGuestOutput{Name: "123"}, observedOutput)
+	})
+
 	t.Run("returns no error and the expect output matches", func(t *testing.T) {
 		repo := repository.Mock{}
 		repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
@@ -512,6 +914,9 @@ func TestWelcomeGuest(t *testing.T) {
 				Name: "123",
 			}, nil
 		}
+		repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+			return nil, nil
+		}
 		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
 			return &repository.Table{
 				Number:         456,
@@ -521,6 +926,9 @@ func TestWelcomeGuest(t *testing.T) {
 		}
 		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error { return nil }
 		repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error { return nil }
+		repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) { return nil, nil }
+		repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error { return nil }
+		repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error { return nil }
 
 		party := New(zap.NewNop(), &repo, testTableSize)
 		observedOutput, observedErr := party.WelcomeGuest(
@@ -535,30 +943,69 @@ func TestWelcomeGuest(t *testing.T) {
 	})
 }
 
+func TestArrivalSeats(t *testing.T) {
+	cases := []struct {
+		name                 string
+		givenBooked          int
+		givenArrived         int
+		expectedAfterAdd     int
+		expectedAfterWelcome int
+		expectedAfterGoodbye int
+	}{
+		{name: "as booked", givenBooked: 2, givenArrived: 2, expectedAfterAdd: 9, expectedAfterWelcome: 9, expectedAfterGoodbye: 9},
+		{name: "fewer than booked", givenBooked: 3, givenArrived: 0, expectedAfterAdd: 8, expectedAfterWelcome: 11, expectedAfterGoodbye: 8},
+		{name: "more than booked", givenBooked: 2, givenArrived: 4, expectedAfterAdd: 9, expectedAfterWelcome: 7, expectedAfterGoodbye: 9},
+		{name: "every seat of the table", givenBooked: 11, givenArrived: 11, expectedAfterAdd: 0, expectedAfterWelcome: 0, expectedAfterGoodbye: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, state := groupsTestRepo()
+			state.guests = map[string]repository.Guest{}
+			state.tables = map[int]repository.Table{}
+
+			party := New(zap.NewNop(), repo, 12)
+
+			_, err := party.AddGuestToGuestList(context.TODO(), &AddGuestToGuestListInput{Name: "amy", Table: 5, AccompanyingGuests: tc.givenBooked})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAfterAdd, state.tables[5].AvailableSeats)
+
+			_, err = party.WelcomeGuest(context.TODO(), &WelcomeGuestInput{Name: "amy", AccompanyingGuests: tc.givenArrived})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAfterWelcome, state.tables[5].AvailableSeats)
+			assert.Equal(t, tc.givenArrived, state.guests["amy"].ArrivedAccompanyingGuests)
+
+			require.NoError(t, party.GoodbyeGuest(context.TODO(), &GoodbyeGuestInput{Name: "amy"}))
+			assert.Equal(t, tc.expectedAfterGoodbye, state.tables[5].AvailableSeats)
+		})
+	}
+}
+
 func TestListArrivedGuests(t *testing.T) {
 	testTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
 
 	cases := []struct {
-		name                  string
-		givenGetArrivedGuests func(ctx context.Context) ([]repository.Guest, error)
-		expectedOutput        ListArrivedGuestsOutput
-		expectedError         error
+		name             string
+		givenQueryGuests func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error)
+		expectedOutput   ListArrivedGuestsOutput
+		expectedError    error
 	}{
 		{
 			name: "returns an error when the repository returns an error",
-			givenGetArrivedGuests: func(ctx context.Context) ([]repository.Guest, error) {
-				return nil, errors.New("some error")
+			givenQueryGuests: func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+				return nil, errTestRepo
 			},
-			expectedError: errors.New("some error"),
+			expectedError: errTestRepo,
 		},
 		{
 			name: "returns no error and the expect output matches",
-			givenGetArrivedGuests: func(ctx context.Context) ([]repository.Guest, error) {
+			givenQueryGuests: func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
 				return []repository.Guest{
 					{
-						Name:               "123",
-						AccompanyingGuests: 456,
-						TimeArrival:        &testTime,
+						Name:                      "123",
+						AccompanyingGuests:        2,
+						ArrivedAccompanyingGuests: 456,
+						TimeArrival:               &testTime,
 					},
 				}, nil
 			},
@@ -574,7 +1021,7 @@ func TestListArrivedGuests(t *testing.T) {
 		},
 		{
 			name: "returns no error and the expect output matches when there are no guests",
-			givenGetArrivedGuests: func(ctx context.Context) ([]repository.Guest, error) {
+			givenQueryGuests: func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
 				return []repository.Guest{}, nil
 			},
 			expectedOutput: ListArrivedGuestsOutput{
@@ -586,13 +1033,13 @@ func TestListArrivedGuests(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			repo := repository.Mock{}
-			repo.GetArrivedGuestsFunc = c.givenGetArrivedGuests
+			repo.QueryGuestsFunc = c.givenQueryGuests
 
 			party := New(zap.NewNop(), &repo, testTableSize)
-			observedOutput, observedError := party.ListArrivedGuests(context.TODO())
+			observedOutput, observedError := party.ListArrivedGuests(context.TODO(), nil)
 
 			assert.Equal(t, c.expectedOutput, observedOutput)
-			assert.Equal(t, c.expectedError, observedError)
+			assert.True(t, errors.Is(observedError, c.expectedError))
 		})
 	}
 }
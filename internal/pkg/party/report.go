@@ -0,0 +1,83 @@
+package party
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GetSeatingReport returns the guest list grouped by table with booked and arrived seats.
+// Tables and guests are sorted by number and name.
+func (p *Party) GetSeatingReport(ctx context.Context) (*GetSeatingReportOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetSeatingReport")
+	defer span.End()
+
+	tables, err := p.repo.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get tables: %w", err)
+	}
+
+	guests, err := p.repo.ListGuests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get guest list: %w", err)
+	}
+
+	reportTables := make(map[int]*ReportTable, len(tables))
+	for _, table := range tables {
+		reportTables[table.Number] = &ReportTable{
+			Number: table.Number,
+			Size:   table.Size,
+			Guests: []ReportGuest{},
+		}
+	}
+
+	out := GetSeatingReportOutput{
+		GeneratedAt: p.now(),
+	}
+
+	for _, guest := range guests {
+		// Guests may reference a table that is missing, keep them in the report
+		table, ok := reportTables[guest.Table]
+		if !ok {
+			table = &ReportTable{Number: guest.Table, Guests: []ReportGuest{}}
+			reportTables[guest.Table] = table
+		}
+
+		seats := guest.AccompanyingGuests + 1
+
+		table.BookedSeats += seats
+		out.GuestsBooked++
+		out.BookedSeats += seats
+
+		if guest.TimeArrival != nil {
+			table.ArrivedSeats += arrivedSeats(guest)
+			out.GuestsArrived++
+			out.ArrivedSeats += arrivedSeats(guest)
+		}
+
+		if guest.TimeDeparture != nil {
+			out.GuestsLeft++
+		}
+
+		table.Guests = append(table.Guests, ReportGuest{
+			Name:               guest.Name,
+			Table:              guest.Table,
+			AccompanyingGuests: guest.AccompanyingGuests,
+			TimeArrival:        guest.TimeArrival,
+			TimeDeparture:      guest.TimeDeparture,
+		})
+	}
+
+	out.Tables = make([]ReportTable, 0, len(reportTables))
+	for _, table := range reportTables {
+		sort.Slice(table.Guests, func(i, j int) bool {
+			return table.Guests[i].Name < table.Guests[j].Name
+		})
+		out.Tables = append(out.Tables, *table)
+	}
+
+	sort.Slice(out.Tables, func(i, j int) bool {
+		return out.Tables[i].Number < out.Tables[j].Number
+	})
+	return &out, nil
+}
@@ -0,0 +1,81 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetSeatingReport(t *testing.T) {
+	t.Run("returns an error when get tables fails", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		observed, err := party.GetSeatingReport(context.TODO())
+
+		assert.Nil(t, observed)
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("groups guests by table with booked and arrived seats", func(t *testing.T) {
+		arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+		left := arrived.Add(3 * time.Hour)
+
+		repo := repository.Mock{}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return []repository.Table{
+				{Number: 2, Size: 10, AvailableSeats: 10},
+				{Number: 1, Size: 10, AvailableSeats: 3},
+			}, nil
+		}
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return []repository.Guest{
+				{Name: "zoe", Table: 1, AccompanyingGuests: 2, ArrivedAccompanyingGuests: 1, TimeArrival: &arrived},
+				{Name: "adam", Table: 1, AccompanyingGuests: 1, ArrivedAccompanyingGuests: 1, TimeArrival: &arrived, TimeDeparture: &left},
+				{Name: "eve", Table: 1},
+			}, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		party.now = func() time.Time { return testNow }
+
+		observed, err := party.GetSeatingReport(context.TODO())
+		require.NoError(t, err)
+
+		assert.Equal(t, &GetSeatingReportOutput{
+			GeneratedAt: testNow,
+			Tables: []ReportTable{
+				{
+					Number:       1,
+					Size:         10,
+					BookedSeats:  6,
+					ArrivedSeats: 4,
+					Guests: []ReportGuest{
+						{Name: "adam", Table: 1, AccompanyingGuests: 1, TimeArrival: &arrived, TimeDeparture: &left},
+						{Name: "eve", Table: 1},
+						{Name: "zoe", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived},
+					},
+				},
+				{
+					Number: 2,
+					Size:   10,
+					Guests: []ReportGuest{},
+				},
+			},
+			GuestsBooked:  3,
+			GuestsArrived: 2,
+			GuestsLeft:    1,
+			BookedSeats:   6,
+			ArrivedSeats:  4,
+		}, observed)
+	})
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/alesr/getground/pkg/database"
+)
+
+// Migrate creates the repository tables and adds their missing columns and indexes.
+func Migrate(dbConn *database.DBConn) error {
+	migrations := []struct {
+		table string
+		model interface{}
+	}{
+		{"guests", &Guest{}},
+		{"guest_groups", &Group{}},
+		{"tables", &Table{}},
+		{"table_reservations", &TableReservation{}},
+		{"table_layouts", &TableLayout{}},
+		{"seats", &Seat{}},
+		{"constraints", &Constraint{}},
+		{"constraint_guests", &ConstraintGuest{}},
+		{"guest_requirements", &Requirement{}},
+		{"companions", &Companion{}},
+		{"custom_fields", &CustomField{}},
+		{"invitations", &Invitation{}},
+		{"checkin_codes", &CheckInCode{}},
+	}
+
+	for _, m := range migrations {
+		if err := dbConn.Table(m.table).AutoMigrate(m.model).Error; err != nil {
+			return fmt.Errorf("could not migrate %s table: %w", m.table, err)
+		}
+	}
+	return nil
+}
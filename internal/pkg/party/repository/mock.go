@@ -8,10 +8,48 @@ type Mock struct {
 	GetArrivedGuestsFunc func(ctx context.Context) ([]Guest, error)
 	GetGuestByNameFunc   func(ctx context.Context, name string) (*Guest, error)
 	ListGuestsFunc       func(ctx context.Context) ([]Guest, error)
+	QueryGuestsFunc      func(ctx context.Context, query *GuestQuery) ([]Guest, error)
 	UpsertGuestFunc      func(ctx context.Context, guest *Guest) error
+	DeleteGuestFunc      func(ctx context.Context, name string) error
 	GetTableByNumberFunc func(ctx context.Context, number int) (*Table, error)
 	GetTablesFunc        func(ctx context.Context) ([]Table, error)
 	UpsertTableFunc      func(ctx context.Context, table *Table) error
+
+	GetTableReservationsFunc    func(ctx context.Context) ([]TableReservation, error)
+	ReplaceTableReservationFunc func(ctx context.Context, reservation *TableReservation) error
+	DeleteTableReservationFunc  func(ctx context.Context, table int) error
+	GetTableLayoutsFunc         func(ctx context.Context) ([]TableLayout, error)
+	ReplaceTableLayoutsFunc     func(ctx context.Context, layouts []TableLayout) error
+
+	GetSeatsFunc         func(ctx context.Context) ([]Seat, error)
+	GetSeatsByTableFunc  func(ctx context.Context, table int) ([]Seat, error)
+	CreateSeatsFunc      func(ctx context.Context, seats []Seat) error
+	DeleteGuestSeatsFunc func(ctx context.Context, name string) error
+
+	GetConstraintsFunc   func(ctx context.Context) ([]Constraint, error)
+	CreateConstraintFunc func(ctx context.Context, constraint *Constraint) error
+	DeleteConstraintFunc func(ctx context.Context, id string) error
+
+	GetGroupsFunc      func(ctx context.Context) ([]Group, error)
+	GetGroupByNameFunc func(ctx context.Context, name string) (*Group, error)
+	CreateGroupFunc    func(ctx context.Context, group *Group) error
+
+	GetRequirementsFunc          func(ctx context.Context) ([]Requirement, error)
+	GetGuestRequirementsFunc     func(ctx context.Context, guest string) ([]Requirement, []Companion, error)
+	ReplaceGuestRequirementsFunc func(ctx context.Context, guest string, requirements []Requirement, companions []Companion) error
+
+	GetCustomFieldsFunc     func(ctx context.Context) ([]CustomField, error)
+	ReplaceCustomFieldsFunc func(ctx context.Context, fields []CustomField) error
+
+	GetInvitationByIDFunc func(ctx context.Context, id string) (*Invitation, error)
+	UpsertInvitationFunc  func(ctx context.Context, invitation *Invitation) error
+	AnswerInvitationFunc  func(ctx context.Context, invitation *Invitation, fromStatus string) (bool, error)
+
+	GetCheckInCodeFunc             func(ctx context.Context, code string) (*CheckInCode, error)
+	GetCheckInCodesByGuestNameFunc func(ctx context.Context, name string) ([]CheckInCode, error)
+	UpsertCheckInCodeFunc          func(ctx context.Context, code *CheckInCode) error
+
+	TransactionFunc func(ctx context.Context, fn func(repo Repository) error) error
 }
 
 func (m *Mock) GetArrivedGuests(ctx context.Context) ([]Guest, error) {
@@ -26,10 +64,18 @@ func (m *Mock) ListGuests(ctx context.Context) ([]Guest, error) {
 	return m.ListGuestsFunc(ctx)
 }
 
+func (m *Mock) QueryGuests(ctx context.Context, query *GuestQuery) ([]Guest, error) {
+	return m.QueryGuestsFunc(ctx, query)
+}
+
 func (m *Mock) UpsertGuest(ctx context.Context, guest *Guest) error {
 	return m.UpsertGuestFunc(ctx, guest)
 }
 
+func (m *Mock) DeleteGuest(ctx context.Context, name string) error {
+	return m.DeleteGuestFunc(ctx, name)
+}
+
 func (m *Mock) GetTableByNumber(ctx context.Context, number int) (*Table, error) {
 	return m.GetTableByNumberFunc(ctx, number)
 }
@@ -41,3 +87,111 @@ func (m *Mock) GetTables(ctx context.Context) ([]Table, error) {
 func (m *Mock) UpsertTable(ctx context.Context, table *Table) error {
 	return m.UpsertTableFunc(ctx, table)
 }
+
+func (m *Mock) GetTableReservations(ctx context.Context) ([]TableReservation, error) {
+	return m.GetTableReservationsFunc(ctx)
+}
+
+func (m *Mock) ReplaceTableReservation(ctx context.Context, reservation *TableReservation) error {
+	return m.ReplaceTableReservationFunc(ctx, reservation)
+}
+
+func (m *Mock) DeleteTableReservation(ctx context.Context, table int) error {
+	return m.DeleteTableReservationFunc(ctx, table)
+}
+
+func (m *Mock) GetTableLayouts(ctx context.Context) ([]TableLayout, error) {
+	return m.GetTableLayoutsFunc(ctx)
+}
+
+func (m *Mock) ReplaceTableLayouts(ctx context.Context, layouts []TableLayout) error {
+	return m.ReplaceTableLayoutsFunc(ctx, layouts)
+}
+
+func (m *Mock) GetSeats(ctx context.Context) ([]Seat, error) {
+	return m.GetSeatsFunc(ctx)
+}
+
+func (m *Mock) GetSeatsByTable(ctx context.Context, table int) ([]Seat, error) {
+	return m.GetSeatsByTableFunc(ctx, table)
+}
+
+func (m *Mock) CreateSeats(ctx context.Context, seats []Seat) error {
+	return m.CreateSeatsFunc(ctx, seats)
+}
+
+func (m *Mock) DeleteGuestSeats(ctx context.Context, name string) error {
+	return m.DeleteGuestSeatsFunc(ctx, name)
+}
+
+func (m *Mock) GetConstraints(ctx context.Context) ([]Constraint, error) {
+	return m.GetConstraintsFunc(ctx)
+}
+
+func (m *Mock) CreateConstraint(ctx context.Context, constraint *Constraint) error {
+	return m.CreateConstraintFunc(ctx, constraint)
+}
+
+func (m *Mock) DeleteConstraint(ctx context.Context, id string) error {
+	return m.DeleteConstraintFunc(ctx, id)
+}
+
+func (m *Mock) GetGroups(ctx context.Context) ([]Group, error) {
+	return m.GetGroupsFunc(ctx)
+}
+
+func (m *Mock) GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	return m.GetGroupByNameFunc(ctx, name)
+}
+
+func (m *Mock) CreateGroup(ctx context.Context, group *Group) error {
+	return m.CreateGroupFunc(ctx, group)
+}
+
+func (m *Mock) GetRequirements(ctx context.Context) ([]Requirement, error) {
+	return m.GetRequirementsFunc(ctx)
+}
+
+func (m *Mock) GetGuestRequirements(ctx context.Context, guest string) ([]Requirement, []Companion, error) {
+	return m.GetGuestRequirementsFunc(ctx, guest)
+}
+
+func (m *Mock) ReplaceGuestRequirements(ctx context.Context, guest string, requirements []Requirement, companions []Companion) error {
+	return m.ReplaceGuestRequirementsFunc(ctx, guest, requirements, companions)
+}
+
+func (m *Mock) GetCustomFields(ctx context.Context) ([]CustomField, error) {
+	return m.GetCustomFieldsFunc(ctx)
+}
+
+func (m *Mock) ReplaceCustomFields(ctx context.Context, fields []CustomField) error {
+	return m.ReplaceCustomFieldsFunc(ctx, fields)
+}
+
+func (m *Mock) GetInvitationByID(ctx context.Context, id string) (*Invitation, error) {
+	return m.GetInvitationByIDFunc(ctx, id)
+}
+
+func (m *Mock) UpsertInvitation(ctx context.Context, invitation *Invitation) error {
+	return m.UpsertInvitationFunc(ctx, invitation)
+}
+
+func (m *Mock) AnswerInvitation(ctx context.Context, invitation *Invitation, fromStatus string) (bool, error) {
+	return m.AnswerInvitationFunc(ctx, invitation, fromStatus)
+}
+
+func (m *Mock) GetCheckInCode(ctx context.Context, code string) (*CheckInCode, error) {
+	return m.GetCheckInCodeFunc(ctx, code)
+}
+
+func (m *Mock) GetCheckInCodesByGuestName(ctx context.Context, name string) ([]CheckInCode, error) {
+	return m.GetCheckInCodesByGuestNameFunc(ctx, name)
+}
+
+func (m *Mock) UpsertCheckInCode(ctx context.Context, code *CheckInCode) error {
+	return m.UpsertCheckInCodeFunc(ctx, code)
+}
+
+func (m *Mock) Transaction(ctx context.Context, fn func(repo Repository) error) error {
+	return m.TransactionFunc(ctx, fn)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/alesr/getground/internal/pkg/logging"
 	"github.com/alesr/getground/pkg/database"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
@@ -13,11 +15,17 @@ import (
 
 var _ Repository = (*MySQL)(nil)
 
+// likeEscaper escapes the LIKE wildcards of user input.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type MySQL struct {
 	logger *zap.Logger
 	dbConn *database.DBConn
 }
 
+// presentCondition matches the guests who arrived and did not leave since, as a guest can arrive again after leaving.
+const presentCondition = "time_arrival IS NOT NULL AND (time_departure IS NULL OR time_departure < time_arrival)"
+
 func New(logger *zap.Logger, dbConn *database.DBConn) *MySQL {
 	return &MySQL{
 		logger: logger.Named("mysql_repository"),
@@ -26,95 +34,661 @@ func New(logger *zap.Logger, dbConn *database.DBConn) *MySQL {
 }
 
 func (m *MySQL) GetArrivedGuests(ctx context.Context) ([]Guest, error) {
+	_, span := startSpan(ctx, "GetArrivedGuests", "guests")
+	defer span.End()
+
 	var guests []Guest
-	result := m.dbConn.Table("guests").Where("time_arrival IS NOT NULL").Find(&guests)
+	result := m.dbConn.Table("guests").Where(presentCondition).Find(&guests)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, m.queryError(ctx, span, result.Error)
 	}
 
 	return guests, nil
 }
 
 func (m *MySQL) GetGuestByName(ctx context.Context, name string) (*Guest, error) {
+	_, span := startSpan(ctx, "GetGuestByName", "guests")
+	defer span.End()
+
 	var guest Guest
 	result := m.dbConn.Table("guests").Find(&guest, "name = ?", name)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, m.queryError(ctx, span, result.Error)
 	}
 	return &guest, nil
 }
 
 func (m *MySQL) ListGuests(ctx context.Context) ([]Guest, error) {
+	_, span := startSpan(ctx, "ListGuests", "guests")
+	defer span.End()
+
 	var guests []Guest
 	result := m.dbConn.Table("guests").Find(&guests)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return guests, nil
+}
+
+func (m *MySQL) QueryGuests(ctx context.Context, query *GuestQuery) ([]Guest, error) {
+	_, span := startSpan(ctx, "QueryGuests", "guests")
+	defer span.End()
+
+	db := m.dbConn.Table("guests")
+
+	if query.Table != 0 {
+		db = db.Where("`table` = ?", query.Table)
+	}
+
+	if query.Arrived != nil {
+		if *query.Arrived {
+			db = db.Where(presentCondition)
+		} else {
+			db = db.Where("time_arrival IS NULL")
+		}
+	}
+
+	if query.NamePrefix != "" {
+		db = db.Where("name LIKE ?", likeEscaper.Replace(query.NamePrefix)+"%")
+	}
+
+	if query.Group != "" {
+		db = db.Where("group_name = ?", query.Group)
+	}
+
+	// Requirements of accompanying guests no longer coming do not count
+	for _, requirement := range query.Requirements {
+		db = db.Where("EXISTS (SELECT 1 FROM guest_requirements r WHERE r.guest = guests.name"+
+			" AND r.companion <= guests.accompanying_guests AND r.kind = ? AND r.field = ? AND r.value = ?)",
+			requirement.Kind, requirement.Field, requirement.Value)
+	}
+
+	cmp, dir := ">", "ASC"
+	if query.Desc {
+		cmp, dir = "<", "DESC"
+	}
+
+	// Keyset pagination, names break ties between equal sort values
+	switch query.Sort {
+	case "", GuestSortName:
+		if query.After != nil {
+			db = db.Where("name "+cmp+" ?", query.After.Name)
+		}
+	case GuestSortTable:
+		if query.After != nil {
+			db = db.Where("(`table` "+cmp+" ? OR (`table` = ? AND name "+cmp+" ?))",
+				query.After.Table, query.After.Table, query.After.Name)
+		}
+		db = db.Order("`table` " + dir)
+	case GuestSortTimeArrival:
+		if query.After != nil {
+			db = db.Where("(time_arrival "+cmp+" ? OR (time_arrival = ? AND name "+cmp+" ?))",
+				query.After.TimeArrival, query.After.TimeArrival, query.After.Name)
+		}
+		db = db.Order("time_arrival " + dir)
+	default:
+		return nil, m.queryError(ctx, span, fmt.Errorf("unknown guest sort field: %s", query.Sort))
+	}
+
+	db = db.Order("name " + dir)
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var guests []Guest
+	if result := db.Find(&guests); result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
 	}
 	return guests, nil
 }
 
 func (m *MySQL) UpsertGuest(ctx context.Context, guest *Guest) error {
+	_, span := startSpan(ctx, "UpsertGuest", "guests")
+	defer span.End()
+
 	var g Guest
 	result := m.dbConn.Table("guests").Where("name = ?", guest.Name).Find(&g)
 	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("could not find guest: %w", result.Error)
+		return m.queryError(ctx, span, fmt.Errorf("could not find guest: %w", result.Error))
 	}
 
 	// if user not found, create new user
 	if result.RowsAffected == 0 {
 		result = m.dbConn.Table("guests").Create(guest)
 		if result.Error != nil {
-			return fmt.Errorf("could not create guest: %w", result.Error)
+			return m.queryError(ctx, span, fmt.Errorf("could not create guest: %w", result.Error))
 		}
 		return nil
 	}
 
-	// update user
-	result = m.dbConn.Table("guests").Where("name = ?", g.Name).Update(guest)
+	// update user, with a map so that counts back to zero are written too
+	result = m.dbConn.Table("guests").Where("name = ?", g.Name).Updates(map[string]interface{}{
+		"table":                       guest.Table,
+		"accompanying_guests":         guest.AccompanyingGuests,
+		"arrived_accompanying_guests": guest.ArrivedAccompanyingGuests,
+		"time_arrival":                guest.TimeArrival,
+		"time_departure":              guest.TimeDeparture,
+		"group_name":                  guest.Group,
+		"tier":                        guest.Tier,
+	})
 	if result.Error != nil {
-		return fmt.Errorf("could not update guest: %w", result.Error)
+		return m.queryError(ctx, span, fmt.Errorf("could not update guest: %w", result.Error))
+	}
+	return nil
+}
+
+func (m *MySQL) DeleteGuest(ctx context.Context, name string) error {
+	_, span := startSpan(ctx, "DeleteGuest", "guests")
+	defer span.End()
+
+	result := m.dbConn.Table("guests").Where("name = ?", name).Delete(&Guest{})
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete guest: %w", result.Error))
 	}
 	return nil
 }
 
 func (m *MySQL) GetTableByNumber(ctx context.Context, number int) (*Table, error) {
+	_, span := startSpan(ctx, "GetTableByNumber", "tables")
+	defer span.End()
+
 	var table Table
 	result := m.dbConn.Table("tables").Where("number = ?", number).Find(&table)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, m.queryError(ctx, span, result.Error)
 	}
 	return &table, nil
 }
 
 func (m *MySQL) GetTables(ctx context.Context) ([]Table, error) {
+	_, span := startSpan(ctx, "GetTables", "tables")
+	defer span.End()
+
 	var tables []Table
 	result := m.dbConn.Table("tables").Find(&tables)
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, m.queryError(ctx, span, result.Error)
 	}
 	return tables, nil
 }
 
 func (m *MySQL) UpsertTable(ctx context.Context, tbl *Table) error {
+	_, span := startSpan(ctx, "UpsertTable", "tables")
+	defer span.End()
+
 	var table Table
 	result := m.dbConn.Table("tables").Where("number = ?", tbl.Number).Find(&table)
 	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("could not find table: %w", result.Error)
+		return m.queryError(ctx, span, fmt.Errorf("could not find table: %w", result.Error))
 	}
 
 	// if user not found, create new user
 	if result.RowsAffected == 0 {
 		result = m.dbConn.Table("tables").Create(tbl)
 		if result.Error != nil {
-			return fmt.Errorf("could not create table: %w", result.Error)
+			return m.queryError(ctx, span, fmt.Errorf("could not create table: %w", result.Error))
 		}
 		return nil
 	}
 
-	// update user
-	result = m.dbConn.Table("tables").Where("number = ?", table.Number).Update(tbl)
+	// update user, with a map so that a table without available seats is written too
+	result = m.dbConn.Table("tables").Where("number = ?", table.Number).Updates(map[string]interface{}{
+		"available_seats": tbl.AvailableSeats,
+		"size":            tbl.Size,
+	})
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not update table: %w", result.Error))
+	}
+	return nil
+}
+
+func (m *MySQL) GetTableReservations(ctx context.Context) ([]TableReservation, error) {
+	_, span := startSpan(ctx, "GetTableReservations", "table_reservations")
+	defer span.End()
+
+	var reservations []TableReservation
+	result := m.dbConn.Table("table_reservations").Order("`table`").Find(&reservations)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return reservations, nil
+}
+
+// ReplaceTableReservation deletes the reservation of the table and creates the given one.
+// Run it in a transaction so the table is never left without its reservation.
+func (m *MySQL) ReplaceTableReservation(ctx context.Context, reservation *TableReservation) error {
+	_, span := startSpan(ctx, "ReplaceTableReservation", "table_reservations")
+	defer span.End()
+
+	result := m.dbConn.Table("table_reservations").Where("`table` = ?", reservation.Table).Delete(&TableReservation{})
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete table reservation: %w", result.Error))
+	}
+
+	if result := m.dbConn.Table("table_reservations").Create(reservation); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not create table reservation: %w", result.Error))
+	}
+	return nil
+}
+
+func (m *MySQL) DeleteTableReservation(ctx context.Context, table int) error {
+	_, span := startSpan(ctx, "DeleteTableReservation", "table_reservations")
+	defer span.End()
+
+	result := m.dbConn.Table("table_reservations").Where("`table` = ?", table).Delete(&TableReservation{})
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete table reservation: %w", result.Error))
+	}
+	return nil
+}
+
+func (m *MySQL) GetTableLayouts(ctx context.Context) ([]TableLayout, error) {
+	_, span := startSpan(ctx, "GetTableLayouts", "table_layouts")
+	defer span.End()
+
+	var layouts []TableLayout
+	result := m.dbConn.Table("table_layouts").Order("number").Find(&layouts)
 	if result.Error != nil {
-		return fmt.Errorf("could not update table: %w", result.Error)
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return layouts, nil
+}
+
+// ReplaceTableLayouts deletes the layout of every table and creates the given layouts.
+// Run it in a transaction so the layout is never left half replaced.
+func (m *MySQL) ReplaceTableLayouts(ctx context.Context, layouts []TableLayout) error {
+	_, span := startSpan(ctx, "ReplaceTableLayouts", "table_layouts")
+	defer span.End()
+
+	if result := m.dbConn.Table("table_layouts").Delete(&TableLayout{}); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete table layouts: %w", result.Error))
+	}
+
+	for i := range layouts {
+		if result := m.dbConn.Table("table_layouts").Create(&layouts[i]); result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create table layout: %w", result.Error))
+		}
+	}
+	return nil
+}
+
+func (m *MySQL) GetSeats(ctx context.Context) ([]Seat, error) {
+	_, span := startSpan(ctx, "GetSeats", "seats")
+	defer span.End()
+
+	var seats []Seat
+	result := m.dbConn.Table("seats").Order("`table`, number").Find(&seats)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return seats, nil
+}
+
+func (m *MySQL) GetSeatsByTable(ctx context.Context, table int) ([]Seat, error) {
+	_, span := startSpan(ctx, "GetSeatsByTable", "seats")
+	defer span.End()
+
+	var seats []Seat
+	result := m.dbConn.Table("seats").Where("`table` = ?", table).Order("number").Find(&seats)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return seats, nil
+}
+
+// CreateSeats creates the given seat assignments, failing on seats already taken.
+// Run it in a transaction so a party is never left half seated.
+func (m *MySQL) CreateSeats(ctx context.Context, seats []Seat) error {
+	_, span := startSpan(ctx, "CreateSeats", "seats")
+	defer span.End()
+
+	for i := range seats {
+		if result := m.dbConn.Table("seats").Create(&seats[i]); result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create seat: %w", result.Error))
+		}
+	}
+	return nil
+}
+
+func (m *MySQL) DeleteGuestSeats(ctx context.Context, name string) error {
+	_, span := startSpan(ctx, "DeleteGuestSeats", "seats")
+	defer span.End()
+
+	result := m.dbConn.Table("seats").Where("guest = ?", name).Delete(&Seat{})
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete guest seats: %w", result.Error))
+	}
+	return nil
+}
+
+// GetConstraints returns the constraints in the order they were added, with their guests.
+func (m *MySQL) GetConstraints(ctx context.Context) ([]Constraint, error) {
+	_, span := startSpan(ctx, "GetConstraints", "constraints")
+	defer span.End()
+
+	var constraints []Constraint
+	result := m.dbConn.Table("constraints").Order("time_added, id").Find(&constraints)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+
+	var guests []ConstraintGuest
+	result = m.dbConn.Table("constraint_guests").Order("constraint_id, position").Find(&guests)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+
+	byConstraint := make(map[string][]string, len(constraints))
+	for _, guest := range guests {
+		byConstraint[guest.Constraint] = append(byConstraint[guest.Constraint], guest.Guest)
+	}
+
+	for i := range constraints {
+		constraints[i].Guests = byConstraint[constraints[i].ID]
+	}
+	return constraints, nil
+}
+
+// CreateConstraint creates the constraint and the list of its guests.
+// Run it in a transaction so a constraint is never left without its guests.
+func (m *MySQL) CreateConstraint(ctx context.Context, constraint *Constraint) error {
+	_, span := startSpan(ctx, "CreateConstraint", "constraints")
+	defer span.End()
+
+	if result := m.dbConn.Table("constraints").Create(constraint); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not create constraint: %w", result.Error))
+	}
+
+	for i, guest := range constraint.Guests {
+		result := m.dbConn.Table("constraint_guests").Create(&ConstraintGuest{Constraint: constraint.ID, Position: i, Guest: guest})
+		if result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create constraint guest: %w", result.Error))
+		}
+	}
+	return nil
+}
+
+// DeleteConstraint deletes the constraint and the list of its guests.
+// Run it in a transaction so a constraint is never left without its guests.
+func (m *MySQL) DeleteConstraint(ctx context.Context, id string) error {
+	_, span := startSpan(ctx, "DeleteConstraint", "constraints")
+	defer span.End()
+
+	if result := m.dbConn.Table("constraint_guests").Where("constraint_id = ?", id).Delete(&ConstraintGuest{}); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete constraint guests: %w", result.Error))
+	}
+
+	if result := m.dbConn.Table("constraints").Where("id = ?", id).Delete(&Constraint{}); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete constraint: %w", result.Error))
+	}
+	return nil
+}
+
+// GetGroups returns the groups ordered by name.
+func (m *MySQL) GetGroups(ctx context.Context) ([]Group, error) {
+	_, span := startSpan(ctx, "GetGroups", "guest_groups")
+	defer span.End()
+
+	var groups []Group
+	result := m.dbConn.Table("guest_groups").Order("name").Find(&groups)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return groups, nil
+}
+
+func (m *MySQL) GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	_, span := startSpan(ctx, "GetGroupByName", "guest_groups")
+	defer span.End()
+
+	var group Group
+	result := m.dbConn.Table("guest_groups").Where("name = ?", name).Find(&group)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return &group, nil
+}
+
+func (m *MySQL) CreateGroup(ctx context.Context, group *Group) error {
+	_, span := startSpan(ctx, "CreateGroup", "guest_groups")
+	defer span.End()
+
+	if result := m.dbConn.Table("guest_groups").Create(group); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not create group: %w", result.Error))
+	}
+	return nil
+}
+
+// GetRequirements returns the requirements of every guest, ordered by guest and companion.
+func (m *MySQL) GetRequirements(ctx context.Context) ([]Requirement, error) {
+	_, span := startSpan(ctx, "GetRequirements", "guest_requirements")
+	defer span.End()
+
+	var requirements []Requirement
+	result := m.dbConn.Table("guest_requirements").Order("guest, companion, kind, field, value").Find(&requirements)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return requirements, nil
+}
+
+// GetGuestRequirements returns the requirements of a guest and the names of their accompanying guests.
+func (m *MySQL) GetGuestRequirements(ctx context.Context, guest string) ([]Requirement, []Companion, error) {
+	_, span := startSpan(ctx, "GetGuestRequirements", "guest_requirements")
+	defer span.End()
+
+	var requirements []Requirement
+	result := m.dbConn.Table("guest_requirements").Where("guest = ?", guest).Order("companion, kind, field, value").Find(&requirements)
+	if result.Error != nil {
+		return nil, nil, m.queryError(ctx, span, result.Error)
+	}
+
+	var companions []Companion
+	result = m.dbConn.Table("companions").Where("guest = ?", guest).Order("number").Find(&companions)
+	if result.Error != nil {
+		return nil, nil, m.queryError(ctx, span, result.Error)
+	}
+	return requirements, companions, nil
+}
+
+// ReplaceGuestRequirements deletes the requirements and companion names of a guest and creates the given ones.
+// Run it in a transaction so the requirements are never left half replaced.
+func (m *MySQL) ReplaceGuestRequirements(ctx context.Context, guest string, requirements []Requirement, companions []Companion) error {
+	_, span := startSpan(ctx, "ReplaceGuestRequirements", "guest_requirements")
+	defer span.End()
+
+	if result := m.dbConn.Table("guest_requirements").Where("guest = ?", guest).Delete(&Requirement{}); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete guest requirements: %w", result.Error))
+	}
+
+	if result := m.dbConn.Table("companions").Where("guest = ?", guest).Delete(&Companion{}); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete companions: %w", result.Error))
+	}
+
+	for i := range requirements {
+		if result := m.dbConn.Table("guest_requirements").Create(&requirements[i]); result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create guest requirement: %w", result.Error))
+		}
+	}
+
+	for i := range companions {
+		if result := m.dbConn.Table("companions").Create(&companions[i]); result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create companion: %w", result.Error))
+		}
+	}
+	return nil
+}
+
+// GetCustomFields returns the custom fields in the order they were given.
+func (m *MySQL) GetCustomFields(ctx context.Context) ([]CustomField, error) {
+	_, span := startSpan(ctx, "GetCustomFields", "custom_fields")
+	defer span.End()
+
+	var fields []CustomField
+	result := m.dbConn.Table("custom_fields").Order("position").Find(&fields)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return fields, nil
+}
+
+// ReplaceCustomFields deletes every custom field and creates the given fields.
+// Run it in a transaction so the fields are never left half replaced.
+func (m *MySQL) ReplaceCustomFields(ctx context.Context, fields []CustomField) error {
+	_, span := startSpan(ctx, "ReplaceCustomFields", "custom_fields")
+	defer span.End()
+
+	if result := m.dbConn.Table("custom_fields").Delete(&CustomField{}); result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not delete custom fields: %w", result.Error))
+	}
+
+	for i := range fields {
+		if result := m.dbConn.Table("custom_fields").Create(&fields[i]); result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create custom field: %w", result.Error))
+		}
+	}
+	return nil
+}
+
+func (m *MySQL) GetInvitationByID(ctx context.Context, id string) (*Invitation, error) {
+	_, span := startSpan(ctx, "GetInvitationByID", "invitations")
+	defer span.End()
+
+	var invitation Invitation
+	result := m.dbConn.Table("invitations").Where("id = ?", id).Find(&invitation)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return &invitation, nil
+}
+
+func (m *MySQL) UpsertInvitation(ctx context.Context, inv *Invitation) error {
+	_, span := startSpan(ctx, "UpsertInvitation", "invitations")
+	defer span.End()
+
+	var invitation Invitation
+	result := m.dbConn.Table("invitations").Where("id = ?", inv.ID).Find(&invitation)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return m.queryError(ctx, span, fmt.Errorf("could not find invitation: %w", result.Error))
+	}
+
+	// if invitation not found, create new invitation
+	if result.RowsAffected == 0 {
+		result = m.dbConn.Table("invitations").Create(inv)
+		if result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create invitation: %w", result.Error))
+		}
+		return nil
+	}
+
+	// update invitation
+	result = m.dbConn.Table("invitations").Where("id = ?", invitation.ID).Update(inv)
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not update invitation: %w", result.Error))
+	}
+	return nil
+}
+
+func (m *MySQL) AnswerInvitation(ctx context.Context, inv *Invitation, fromStatus string) (bool, error) {
+	_, span := startSpan(ctx, "AnswerInvitation", "invitations")
+	defer span.End()
+
+	// the status condition makes concurrent answers wait on the row and only the first one update it
+	result := m.dbConn.Table("invitations").
+		Where("id = ? AND status = ?", inv.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":              inv.Status,
+			"accompanying_guests": inv.AccompanyingGuests,
+			"time_responded":      inv.TimeResponded,
+		})
+	if result.Error != nil {
+		return false, m.queryError(ctx, span, fmt.Errorf("could not answer invitation: %w", result.Error))
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *MySQL) GetCheckInCode(ctx context.Context, code string) (*CheckInCode, error) {
+	_, span := startSpan(ctx, "GetCheckInCode", "checkin_codes")
+	defer span.End()
+
+	var checkInCode CheckInCode
+	result := m.dbConn.Table("checkin_codes").Where("code = ?", code).Find(&checkInCode)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return &checkInCode, nil
+}
+
+func (m *MySQL) GetCheckInCodesByGuestName(ctx context.Context, name string) ([]CheckInCode, error) {
+	_, span := startSpan(ctx, "GetCheckInCodesByGuestName", "checkin_codes")
+	defer span.End()
+
+	var codes []CheckInCode
+	result := m.dbConn.Table("checkin_codes").Where("guest_name = ?", name).Find(&codes)
+	if result.Error != nil {
+		return nil, m.queryError(ctx, span, result.Error)
+	}
+	return codes, nil
+}
+
+func (m *MySQL) UpsertCheckInCode(ctx context.Context, c *CheckInCode) error {
+	_, span := startSpan(ctx, "UpsertCheckInCode", "checkin_codes")
+	defer span.End()
+
+	var code CheckInCode
+	result := m.dbConn.Table("checkin_codes").Where("code = ?", c.Code).Find(&code)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return m.queryError(ctx, span, fmt.Errorf("could not find check-in code: %w", result.Error))
+	}
+
+	// if code not found, create new code
+	if result.RowsAffected == 0 {
+		result = m.dbConn.Table("checkin_codes").Create(c)
+		if result.Error != nil {
+			return m.queryError(ctx, span, fmt.Errorf("could not create check-in code: %w", result.Error))
+		}
+		return nil
+	}
+
+	// update code
+	result = m.dbConn.Table("checkin_codes").Where("code = ?", code.Code).Update(c)
+	if result.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not update check-in code: %w", result.Error))
+	}
+	return nil
+}
+
+func (m *MySQL) Transaction(ctx context.Context, fn func(repo Repository) error) (err error) {
+	_, span := startSpan(ctx, "Transaction", "")
+	defer span.End()
+
+	tx := m.dbConn.Begin()
+	if tx.Error != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not begin transaction: %w", tx.Error))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	txRepo := &MySQL{
+		logger: m.logger,
+		dbConn: &database.DBConn{DB: tx},
+	}
+
+	if err := fn(txRepo); err != nil {
+		if rbErr := tx.Rollback().Error; rbErr != nil {
+			logging.FromContext(ctx, m.logger).Error("could not rollback transaction", zap.Error(rbErr))
+		}
+		return m.queryError(ctx, span, err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return m.queryError(ctx, span, fmt.Errorf("could not commit transaction: %w", err))
 	}
 	return nil
 }
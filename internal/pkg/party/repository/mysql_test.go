@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -11,8 +12,19 @@ import (
 )
 
 const (
-	truncateGuestsQuery string = "TRUNCATE guests;"
-	truncateTablesQuery string = "TRUNCATE tables;"
+	truncateGuestsQuery       string = "TRUNCATE guests;"
+	truncateTablesQuery       string = "TRUNCATE tables;"
+	truncateInvitationsQuery  string = "TRUNCATE invitations;"
+	truncateCheckInCodesQuery string = "TRUNCATE checkin_codes;"
+	truncateTableLayoutsQuery string = "TRUNCATE table_layouts;"
+	truncateSeatsQuery        string = "TRUNCATE seats;"
+	truncateConstraintsQuery  string = "TRUNCATE constraints;"
+	truncateConstraintGuests  string = "TRUNCATE constraint_guests;"
+	truncateGroupsQuery       string = "TRUNCATE guest_groups;"
+	truncateRequirementsQuery string = "TRUNCATE guest_requirements;"
+	truncateCompanionsQuery   string = "TRUNCATE companions;"
+	truncateCustomFieldsQuery string = "TRUNCATE custom_fields;"
+	truncateReservationsQuery string = "TRUNCATE table_reservations;"
 )
 
 func TestGetArrivedGuests_INTEGRATION(t *testing.T) {
@@ -43,7 +55,15 @@ func TestGetArrivedGuests_INTEGRATION(t *testing.T) {
 		TimeArrival:        &now,
 	}
 
-	createGuestHelper(t, dbConn, &guest1, &guest2)
+	later := now.Add(time.Minute)
+	guest3 := Guest{
+		Name:          "Guest3",
+		Table:         2,
+		TimeArrival:   &now,
+		TimeDeparture: &later,
+	}
+
+	createGuestHelper(t, dbConn, &guest1, &guest2, &guest3)
 
 	repo := New(zap.NewNop(), dbConn)
 
@@ -126,6 +146,125 @@ func TestListGuests_INTEGRATION(t *testing.T) {
 	require.Equal(t, guest1.Name, observed[0].Name)
 }
 
+func TestQueryGuests_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	later := now.Add(time.Minute)
+
+	createGuestHelper(t, dbConn,
+		&Guest{Name: "anna", Table: 2, AccompanyingGuests: 1, TimeArrival: &later},
+		&Guest{Name: "bob", Table: 1, TimeArrival: &now},
+		&Guest{Name: "bo_b", Table: 1},
+		&Guest{Name: "carl", Table: 2, Group: "smith"},
+		// dan left, erin left and came back
+		&Guest{Name: "dan", Table: 3, TimeArrival: &now, TimeDeparture: &later},
+		&Guest{Name: "erin", Table: 3, TimeArrival: &later, TimeDeparture: &now},
+	)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	vegan := Requirement{Kind: RequirementDietary, Value: "vegan"}
+	nuts := Requirement{Kind: RequirementAllergy, Value: "nuts"}
+
+	require.NoError(t, repo.ReplaceGuestRequirements(context.TODO(), "anna", []Requirement{
+		{Guest: "anna", Companion: 1, Kind: nuts.Kind, Value: nuts.Value},
+	}, nil))
+
+	// The accompanying guest of carl is no longer coming
+	require.NoError(t, repo.ReplaceGuestRequirements(context.TODO(), "carl", []Requirement{
+		{Guest: "carl", Kind: vegan.Kind, Value: vegan.Value},
+		{Guest: "carl", Companion: 1, Kind: nuts.Kind, Value: nuts.Value},
+	}, nil))
+
+	arrived := true
+	notArrived := false
+
+	cases := []struct {
+		name          string
+		givenQuery    GuestQuery
+		expectedNames []string
+	}{
+		{
+			name:          "sorts by name",
+			givenQuery:    GuestQuery{},
+			expectedNames: []string{"anna", "bo_b", "bob", "carl", "dan", "erin"},
+		},
+		{
+			name:          "sorts by table and name descending",
+			givenQuery:    GuestQuery{Sort: GuestSortTable, Desc: true},
+			expectedNames: []string{"erin", "dan", "carl", "anna", "bob", "bo_b"},
+		},
+		{
+			name:          "filters by table",
+			givenQuery:    GuestQuery{Table: 1},
+			expectedNames: []string{"bo_b", "bob"},
+		},
+		{
+			name:          "filters guests at the party",
+			givenQuery:    GuestQuery{Arrived: &arrived, Sort: GuestSortTimeArrival},
+			expectedNames: []string{"bob", "anna", "erin"},
+		},
+		{
+			name:          "filters guests not arrived",
+			givenQuery:    GuestQuery{Arrived: &notArrived},
+			expectedNames: []string{"bo_b", "carl"},
+		},
+		{
+			name:          "filters by name prefix without wildcards",
+			givenQuery:    GuestQuery{NamePrefix: "bo_"},
+			expectedNames: []string{"bo_b"},
+		},
+		{
+			name:          "filters by group",
+			givenQuery:    GuestQuery{Group: "smith"},
+			expectedNames: []string{"carl"},
+		},
+		{
+			name:          "filters by requirements of accompanying guests",
+			givenQuery:    GuestQuery{Requirements: []Requirement{nuts}},
+			expectedNames: []string{"anna"},
+		},
+		{
+			name:          "filters by every requirement",
+			givenQuery:    GuestQuery{Requirements: []Requirement{vegan, nuts}},
+			expectedNames: []string{},
+		},
+		{
+			name:          "pages after a cursor",
+			givenQuery:    GuestQuery{Sort: GuestSortTable, After: &GuestCursor{Name: "bob", Table: 1}, Limit: 1},
+			expectedNames: []string{"anna"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Act
+
+			observed, err := repo.QueryGuests(context.TODO(), &c.givenQuery)
+			require.NoError(t, err)
+
+			// Assert
+
+			names := make([]string, 0, len(observed))
+			for _, guest := range observed {
+				names = append(names, guest.Name)
+			}
+			require.Equal(t, c.expectedNames, names)
+		})
+	}
+}
+
 func TestUpsertGuest_INTEGRATION(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode.")
@@ -196,6 +335,41 @@ func TestUpsertGuest_INTEGRATION(t *testing.T) {
 	})
 }
 
+func TestDeleteGuest_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	guest1 := Guest{Name: "Guest1", Table: 1}
+	guest2 := Guest{Name: "Guest2", Table: 1}
+
+	repo := New(zap.NewNop(), dbConn)
+
+	require.NoError(t, repo.UpsertGuest(context.TODO(), &guest1))
+	require.NoError(t, repo.UpsertGuest(context.TODO(), &guest2))
+
+	// Act
+
+	err := repo.DeleteGuest(context.TODO(), guest1.Name)
+	require.NoError(t, err)
+
+	// Assert
+
+	observed, err := repo.ListGuests(context.TODO())
+	require.NoError(t, err)
+
+	require.Len(t, observed, 1)
+	require.Equal(t, guest2.Name, observed[0].Name)
+}
+
 func TestGetTableByNumber_INTEGRATION(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode.")
@@ -347,6 +521,535 @@ func TestUpsertTable_INTEGRATION(t *testing.T) {
 	})
 }
 
+func TestReplaceTableLayouts_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	require.NoError(t, repo.ReplaceTableLayouts(context.TODO(), []TableLayout{
+		{Number: 1, X: 0, Y: 0, Shape: "round", Width: 100, Height: 100},
+		{Number: 2, X: 200, Y: 0, Shape: "round", Width: 100, Height: 100},
+	}))
+
+	expected := []TableLayout{
+		{Number: 2, X: 50, Y: 150, Shape: "rectangle", Width: 200, Height: 80},
+		{Number: 3, X: 300, Y: 150, Shape: "round", Width: 120, Height: 120},
+	}
+
+	// Act
+
+	err := repo.ReplaceTableLayouts(context.TODO(), expected)
+	require.NoError(t, err)
+
+	// Assert
+
+	observed, err := repo.GetTableLayouts(context.TODO())
+	require.NoError(t, err)
+
+	require.Equal(t, expected, observed)
+}
+
+func TestTableReservations_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	require.NoError(t, repo.ReplaceTableReservation(context.TODO(), &TableReservation{Table: 1, Tiers: "vip", HeldSeats: 2}))
+	require.NoError(t, repo.ReplaceTableReservation(context.TODO(), &TableReservation{Table: 2, Tiers: "staff"}))
+	require.NoError(t, repo.ReplaceTableReservation(context.TODO(), &TableReservation{Table: 3, HeldSeats: 4}))
+
+	// Act
+
+	err := repo.ReplaceTableReservation(context.TODO(), &TableReservation{Table: 1, Tiers: "vip,staff", HeldSeats: 1})
+	require.NoError(t, err)
+
+	err = repo.DeleteTableReservation(context.TODO(), 2)
+	require.NoError(t, err)
+
+	// Assert
+
+	observed, err := repo.GetTableReservations(context.TODO())
+	require.NoError(t, err)
+
+	require.Equal(t, []TableReservation{{Table: 1, Tiers: "vip,staff", HeldSeats: 1}, {Table: 3, HeldSeats: 4}}, observed)
+}
+
+func TestSeats_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	require.NoError(t, repo.CreateSeats(context.TODO(), []Seat{
+		{Table: 2, Number: 1, Guest: "bar"},
+		{Table: 1, Number: 2, Guest: "foo"},
+		{Table: 1, Number: 3, Guest: "foo", Companion: 1},
+	}))
+
+	t.Run("gets the seats in table and number order", func(t *testing.T) {
+		observed, err := repo.GetSeats(context.TODO())
+		require.NoError(t, err)
+
+		require.Equal(t, []Seat{
+			{Table: 1, Number: 2, Guest: "foo"},
+			{Table: 1, Number: 3, Guest: "foo", Companion: 1},
+			{Table: 2, Number: 1, Guest: "bar"},
+		}, observed)
+	})
+
+	t.Run("fails to take a seat twice", func(t *testing.T) {
+		err := repo.CreateSeats(context.TODO(), []Seat{{Table: 1, Number: 2, Guest: "baz"}})
+		require.Error(t, err)
+	})
+
+	t.Run("deletes the seats of a guest", func(t *testing.T) {
+		require.NoError(t, repo.DeleteGuestSeats(context.TODO(), "foo"))
+
+		observed, err := repo.GetSeatsByTable(context.TODO(), 1)
+		require.NoError(t, err)
+		require.Empty(t, observed)
+	})
+}
+
+func TestConstraints_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	added := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.CreateConstraint(context.TODO(), &Constraint{
+		ID: "b", Kind: "apart", TimeAdded: added, Guests: []string{"foo", "bar", "baz"},
+	}))
+	require.NoError(t, repo.CreateConstraint(context.TODO(), &Constraint{
+		ID: "a", Kind: "pinned", Table: 1, TimeAdded: added.Add(time.Minute), Guests: []string{"foo"},
+	}))
+
+	t.Run("gets the constraints in the order they were added, with their guests", func(t *testing.T) {
+		observed, err := repo.GetConstraints(context.TODO())
+		require.NoError(t, err)
+		require.Len(t, observed, 2)
+
+		require.Equal(t, "b", observed[0].ID)
+		require.Equal(t, "apart", observed[0].Kind)
+		require.Equal(t, []string{"foo", "bar", "baz"}, observed[0].Guests)
+
+		require.Equal(t, "a", observed[1].ID)
+		require.Equal(t, 1, observed[1].Table)
+		require.Equal(t, []string{"foo"}, observed[1].Guests)
+	})
+
+	t.Run("deletes a constraint and its guests", func(t *testing.T) {
+		require.NoError(t, repo.DeleteConstraint(context.TODO(), "b"))
+
+		observed, err := repo.GetConstraints(context.TODO())
+		require.NoError(t, err)
+		require.Len(t, observed, 1)
+		require.Equal(t, "a", observed[0].ID)
+
+		var count int
+		require.NoError(t, dbConn.Table("constraint_guests").Count(&count).Error)
+		require.Equal(t, 1, count)
+	})
+}
+
+func TestGroups_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	added := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.CreateGroup(context.TODO(), &Group{Name: "smith", TimeAdded: added}))
+	require.NoError(t, repo.CreateGroup(context.TODO(), &Group{Name: "doe", TimeAdded: added}))
+
+	t.Run("gets the groups ordered by name", func(t *testing.T) {
+		observed, err := repo.GetGroups(context.TODO())
+		require.NoError(t, err)
+		require.Len(t, observed, 2)
+		require.Equal(t, "doe", observed[0].Name)
+		require.Equal(t, "smith", observed[1].Name)
+	})
+
+	t.Run("gets a group by name", func(t *testing.T) {
+		observed, err := repo.GetGroupByName(context.TODO(), "smith")
+		require.NoError(t, err)
+		require.Equal(t, "smith", observed.Name)
+	})
+
+	t.Run("does not create a group twice", func(t *testing.T) {
+		require.Error(t, repo.CreateGroup(context.TODO(), &Group{Name: "smith", TimeAdded: added}))
+	})
+}
+
+func TestRequirements_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	require.NoError(t, repo.ReplaceGuestRequirements(context.TODO(), "john", []Requirement{
+		{Guest: "john", Companion: 1, Kind: RequirementAllergy, Value: "nuts"},
+		{Guest: "john", Kind: RequirementDietary, Value: "vegan"},
+	}, []Companion{{Guest: "john", Number: 1, Name: "jane"}}))
+
+	require.NoError(t, repo.ReplaceGuestRequirements(context.TODO(), "bob", []Requirement{
+		{Guest: "bob", Kind: RequirementCustom, Field: "meal", Value: "fish"},
+	}, nil))
+
+	t.Run("gets the requirements of a guest", func(t *testing.T) {
+		requirements, companions, err := repo.GetGuestRequirements(context.TODO(), "john")
+		require.NoError(t, err)
+		require.Equal(t, []Requirement{
+			{Guest: "john", Kind: RequirementDietary, Value: "vegan"},
+			{Guest: "john", Companion: 1, Kind: RequirementAllergy, Value: "nuts"},
+		}, requirements)
+		require.Equal(t, []Companion{{Guest: "john", Number: 1, Name: "jane"}}, companions)
+	})
+
+	t.Run("replaces the requirements of a guest", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceGuestRequirements(context.TODO(), "john", []Requirement{
+			{Guest: "john", Kind: RequirementAccessibility, Value: "wheelchair"},
+		}, nil))
+
+		requirements, companions, err := repo.GetGuestRequirements(context.TODO(), "john")
+		require.NoError(t, err)
+		require.Equal(t, []Requirement{{Guest: "john", Kind: RequirementAccessibility, Value: "wheelchair"}}, requirements)
+		require.Empty(t, companions)
+	})
+
+	t.Run("gets the requirements of every guest", func(t *testing.T) {
+		observed, err := repo.GetRequirements(context.TODO())
+		require.NoError(t, err)
+		require.Equal(t, []Requirement{
+			{Guest: "bob", Kind: RequirementCustom, Field: "meal", Value: "fish"},
+			{Guest: "john", Kind: RequirementAccessibility, Value: "wheelchair"},
+		}, observed)
+	})
+}
+
+func TestReplaceCustomFields_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	require.NoError(t, repo.ReplaceCustomFields(context.TODO(), []CustomField{
+		{Name: "shirt", Position: 0, Type: "text"},
+	}))
+
+	// Act
+
+	fields := []CustomField{
+		{Name: "meal", Position: 0, Type: "choice", Options: "fish,meat", Required: true},
+		{Name: "age", Position: 1, Type: "number"},
+	}
+	require.NoError(t, repo.ReplaceCustomFields(context.TODO(), fields))
+
+	// Assert
+
+	observed, err := repo.GetCustomFields(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, fields, observed)
+}
+
+func TestUpsertInvitation_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	t.Run("upserts an non existing invitation", func(t *testing.T) {
+		// Arrange
+
+		dbConn := setupDB(t)
+		defer dbConn.Close()
+		defer truncateHelper(t, dbConn)
+
+		truncateHelper(t, dbConn)
+
+		invitation := Invitation{
+			ID:           "abc",
+			GuestName:    "Guest1",
+			Table:        1,
+			MaxPartySize: 3,
+			ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+			Status:       "pending",
+		}
+
+		repo := New(zap.NewNop(), dbConn)
+
+		// Act
+
+		err := repo.UpsertInvitation(context.TODO(), &invitation)
+		require.NoError(t, err)
+
+		// Assert
+
+		observed, err := repo.GetInvitationByID(context.TODO(), invitation.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, invitation.GuestName, observed.GuestName)
+		require.Equal(t, invitation.Status, observed.Status)
+	})
+
+	t.Run("upserts an existing invitation", func(t *testing.T) {
+		// Arrange
+
+		dbConn := setupDB(t)
+		defer dbConn.Close()
+		defer truncateHelper(t, dbConn)
+
+		truncateHelper(t, dbConn)
+
+		invitation := Invitation{
+			ID:           "abc",
+			GuestName:    "Guest1",
+			Table:        1,
+			MaxPartySize: 3,
+			ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+			Status:       "pending",
+		}
+
+		require.NoError(t, dbConn.Table("invitations").Create(&invitation).Error)
+
+		repo := New(zap.NewNop(), dbConn)
+
+		// Act
+
+		invitation.Status = "accepted"
+
+		err := repo.UpsertInvitation(context.TODO(), &invitation)
+		require.NoError(t, err)
+
+		// Assert
+
+		observed, err := repo.GetInvitationByID(context.TODO(), invitation.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, "accepted", observed.Status)
+	})
+}
+
+func TestAnswerInvitation_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	invitation := Invitation{
+		ID:           "abc",
+		GuestName:    "Guest1",
+		Table:        1,
+		MaxPartySize: 3,
+		ExpiresAt:    time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		Status:       "pending",
+	}
+
+	require.NoError(t, dbConn.Table("invitations").Create(&invitation).Error)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	// Act
+
+	respondedAt := time.Now().UTC().Truncate(time.Second)
+
+	accepted := invitation
+	accepted.Status = "accepted"
+	accepted.AccompanyingGuests = 2
+	accepted.TimeResponded = &respondedAt
+
+	answered, err := repo.AnswerInvitation(context.TODO(), &accepted, "pending")
+	require.NoError(t, err)
+
+	declined := invitation
+	declined.Status = "declined"
+	declined.TimeResponded = &respondedAt
+
+	answeredAgain, err := repo.AnswerInvitation(context.TODO(), &declined, "pending")
+	require.NoError(t, err)
+
+	// Assert
+
+	require.True(t, answered)
+	require.False(t, answeredAgain)
+
+	observed, err := repo.GetInvitationByID(context.TODO(), invitation.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, "accepted", observed.Status)
+	require.Equal(t, 2, observed.AccompanyingGuests)
+}
+
+func TestGetCheckInCodesByGuestName_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	// Arrange
+
+	dbConn := setupDB(t)
+	defer dbConn.Close()
+	defer truncateHelper(t, dbConn)
+
+	truncateHelper(t, dbConn)
+
+	repo := New(zap.NewNop(), dbConn)
+
+	code1 := CheckInCode{Code: "CODE1", GuestName: "Guest1", Status: "active", TimeIssued: time.Now()}
+	code2 := CheckInCode{Code: "CODE2", GuestName: "Guest2", Status: "active", TimeIssued: time.Now()}
+
+	require.NoError(t, repo.UpsertCheckInCode(context.TODO(), &code1))
+	require.NoError(t, repo.UpsertCheckInCode(context.TODO(), &code2))
+
+	// Act
+
+	code1.Status = "used"
+	require.NoError(t, repo.UpsertCheckInCode(context.TODO(), &code1))
+
+	observed, err := repo.GetCheckInCodesByGuestName(context.TODO(), code1.GuestName)
+	require.NoError(t, err)
+
+	// Assert
+
+	require.Equal(t, 1, len(observed))
+	require.Equal(t, "used", observed[0].Status)
+}
+
+func TestTransaction_INTEGRATION(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode.")
+	}
+
+	t.Run("commits when fn succeeds", func(t *testing.T) {
+		// Arrange
+
+		dbConn := setupDB(t)
+		defer dbConn.Close()
+		defer truncateHelper(t, dbConn)
+
+		truncateHelper(t, dbConn)
+
+		repo := New(zap.NewNop(), dbConn)
+
+		// Act
+
+		err := repo.Transaction(context.TODO(), func(tx Repository) error {
+			return tx.UpsertGuest(context.TODO(), &Guest{Name: "Guest1", Table: 1})
+		})
+		require.NoError(t, err)
+
+		// Assert
+
+		observed, err := repo.ListGuests(context.TODO())
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(observed))
+	})
+
+	t.Run("rolls back when fn fails", func(t *testing.T) {
+		// Arrange
+
+		dbConn := setupDB(t)
+		defer dbConn.Close()
+		defer truncateHelper(t, dbConn)
+
+		truncateHelper(t, dbConn)
+
+		repo := New(zap.NewNop(), dbConn)
+
+		errTest := errors.New("test error")
+
+		// Act
+
+		err := repo.Transaction(context.TODO(), func(tx Repository) error {
+			require.NoError(t, tx.UpsertGuest(context.TODO(), &Guest{Name: "Guest1", Table: 1}))
+			return errTest
+		})
+		require.True(t, errors.Is(err, errTest))
+
+		// Assert
+
+		observed, err := repo.ListGuests(context.TODO())
+		require.NoError(t, err)
+
+		require.Equal(t, 0, len(observed))
+	})
+}
+
 func createGuestHelper(t *testing.T, dbConn *database.DBConn, guest ...*Guest) {
 	for _, g := range guest {
 		err := dbConn.Table("guests").Create(g).Error
@@ -370,8 +1073,7 @@ func setupDB(t *testing.T, tables ...interface{}) *database.DBConn {
 	dbConn, err := database.Connection(host, user, password, dbName)
 	require.NoError(t, err)
 
-	dbConn.Table("guests").AutoMigrate(&Guest{})
-	dbConn.Table("tables").AutoMigrate(&Table{})
+	require.NoError(t, Migrate(dbConn))
 
 	return dbConn
 }
@@ -379,4 +1081,15 @@ func setupDB(t *testing.T, tables ...interface{}) *database.DBConn {
 func truncateHelper(t *testing.T, dbConn *database.DBConn) {
 	dbConn.Exec(truncateGuestsQuery)
 	dbConn.Exec(truncateTablesQuery)
+	dbConn.Exec(truncateInvitationsQuery)
+	dbConn.Exec(truncateCheckInCodesQuery)
+	dbConn.Exec(truncateTableLayoutsQuery)
+	dbConn.Exec(truncateSeatsQuery)
+	dbConn.Exec(truncateConstraintsQuery)
+	dbConn.Exec(truncateConstraintGuests)
+	dbConn.Exec(truncateGroupsQuery)
+	dbConn.Exec(truncateRequirementsQuery)
+	dbConn.Exec(truncateCompanionsQuery)
+	dbConn.Exec(truncateCustomFieldsQuery)
+	dbConn.Exec(truncateReservationsQuery)
 }
@@ -5,13 +5,39 @@ import (
 	"time"
 )
 
+// Enumerate guest sort fields
+const (
+	GuestSortName        = "name"
+	GuestSortTable       = "table"
+	GuestSortTimeArrival = "time_arrival"
+)
+
+// Enumerate requirement kinds
+const (
+	RequirementDietary       = "dietary"
+	RequirementAllergy       = "allergy"
+	RequirementAccessibility = "accessibility"
+	RequirementCustom        = "custom"
+)
+
 type (
+	// Guest is a guest of the guest list, booked with AccompanyingGuests.
+	// ArrivedAccompanyingGuests are the accompanying guests who came with the guest on their last arrival.
 	Guest struct {
-		Name               string     `gorm:"uniqueIndex,unique,column:name"`
-		Table              int        `gorm:"column:table;not null"`
-		AccompanyingGuests int        `gorm:"column:accompanying_guests;not null"`
-		TimeArrival        *time.Time `db:"time_arrival"`
-		TimeDeparture      *time.Time `db:"time_departure"`
+		Name                      string     `gorm:"uniqueIndex,unique,column:name"`
+		Table                     int        `gorm:"column:table;not null;index"`
+		AccompanyingGuests        int        `gorm:"column:accompanying_guests;not null"`
+		ArrivedAccompanyingGuests int        `gorm:"column:arrived_accompanying_guests;not null"`
+		TimeArrival               *time.Time `gorm:"index" db:"time_arrival"`
+		TimeDeparture             *time.Time `db:"time_departure"`
+		Group                     string     `gorm:"column:group_name;index"`
+		Tier                      string     `gorm:"column:tier;not null"`
+	}
+
+	// Group gathers guests who come together, its guests name it in their Group.
+	Group struct {
+		Name      string    `gorm:"primary_key;column:name"`
+		TimeAdded time.Time `gorm:"column:time_added;not null"`
 	}
 
 	Table struct {
@@ -20,14 +46,175 @@ type (
 		Size           int `gorm:"column:size;not null"`
 	}
 
+	// TableReservation restricts a table to the guests of some tiers and holds back some of its available seats.
+	// Tiers are comma separated, a table without tiers seats every tier. Held seats are only released by organisers.
+	TableReservation struct {
+		Table     int    `gorm:"primary_key;column:table"`
+		Tiers     string `gorm:"column:tiers;not null"`
+		HeldSeats int    `gorm:"column:held_seats;not null"`
+	}
+
+	// TableLayout defines where a table stands in the venue and its shape.
+	// Positions and sizes are in the units of the seating chart, round tables have a width and height of their diameter.
+	TableLayout struct {
+		Number int    `gorm:"uniqueIndex;column:number"`
+		X      int    `gorm:"column:x;not null"`
+		Y      int    `gorm:"column:y;not null"`
+		Shape  string `gorm:"column:shape;not null"`
+		Width  int    `gorm:"column:width;not null"`
+		Height int    `gorm:"column:height;not null"`
+	}
+
+	// Seat assigns a seat of a table to a guest or one of their accompanying guests.
+	// Companion 0 is the guest, companions are numbered from 1. Seats without a record are free.
+	Seat struct {
+		Table     int    `gorm:"column:table;not null;unique_index:idx_seats_table_number"`
+		Number    int    `gorm:"column:number;not null;unique_index:idx_seats_table_number"`
+		Guest     string `gorm:"column:guest;not null;index"`
+		Companion int    `gorm:"column:companion;not null"`
+	}
+
+	// Constraint is a seating rule on the tables of its guests, see the seating package.
+	// Table is the table of the pinned constraints, 0 for the others.
+	Constraint struct {
+		ID        string    `gorm:"primary_key;column:id"`
+		Kind      string    `gorm:"column:kind;not null"`
+		Table     int       `gorm:"column:table;not null"`
+		TimeAdded time.Time `gorm:"column:time_added;not null"`
+		Guests    []string  `gorm:"-"`
+	}
+
+	// ConstraintGuest lists a guest of a constraint, Position keeps the guests in the order given.
+	ConstraintGuest struct {
+		Constraint string `gorm:"column:constraint_id;not null;unique_index:idx_constraint_guests_constraint_position"`
+		Position   int    `gorm:"column:position;not null;unique_index:idx_constraint_guests_constraint_position"`
+		Guest      string `gorm:"column:guest;not null"`
+	}
+
+	// Requirement is a need of a guest or of one of their accompanying guests, Companion 0 is the guest.
+	// Kind is one of the requirement kinds, Field names the custom field of the custom values.
+	Requirement struct {
+		Guest     string `gorm:"column:guest;not null;index"`
+		Companion int    `gorm:"column:companion;not null"`
+		Kind      string `gorm:"column:kind;not null"`
+		Field     string `gorm:"column:field;not null"`
+		Value     string `gorm:"column:value;not null"`
+	}
+
+	// Companion names an accompanying guest of a guest, numbered from 1 as their seats.
+	Companion struct {
+		Guest  string `gorm:"column:guest;not null;unique_index:idx_companions_guest_number"`
+		Number int    `gorm:"column:number;not null;unique_index:idx_companions_guest_number"`
+		Name   string `gorm:"column:name;not null"`
+	}
+
+	// CustomField defines a guest attribute of the organiser, Position keeps the fields in the order given.
+	// Options are the comma separated values of the choice fields.
+	CustomField struct {
+		Name     string `gorm:"primary_key;column:name"`
+		Position int    `gorm:"column:position;not null"`
+		Type     string `gorm:"column:type;not null"`
+		Options  string `gorm:"column:options;not null"`
+		Required bool   `gorm:"column:required;not null"`
+	}
+
+	Invitation struct {
+		ID                 string     `gorm:"primary_key;column:id"`
+		GuestName          string     `gorm:"column:guest_name;not null"`
+		Table              int        `gorm:"column:table;not null"`
+		MaxPartySize       int        `gorm:"column:max_party_size;not null"`
+		ExpiresAt          time.Time  `gorm:"column:expires_at;not null"`
+		Status             string     `gorm:"column:status;not null"`
+		AccompanyingGuests int        `gorm:"column:accompanying_guests;not null"`
+		TimeResponded      *time.Time `gorm:"column:time_responded"`
+		Tier               string     `gorm:"column:tier;not null"`
+	}
+
+	CheckInCode struct {
+		Code       string     `gorm:"primary_key;column:code"`
+		GuestName  string     `gorm:"index;column:guest_name;not null"`
+		Status     string     `gorm:"column:status;not null"`
+		TimeIssued time.Time  `gorm:"column:time_issued;not null"`
+		TimeUsed   *time.Time `gorm:"column:time_used"`
+	}
+
+	// GuestQuery defines the filters, sort order and page of a guest query.
+	// Zero values mean no filter, Limit 0 means no limit.
+	GuestQuery struct {
+		Table int
+		// Arrived true keeps the guests at the party, who arrived and did not leave since,
+		// false the guests who did not arrive yet.
+		Arrived    *bool
+		NamePrefix string
+		Group      string
+		// Requirements keeps the guests who, or one of whose accompanying guests, have all the requirements.
+		// Guest and Companion are ignored.
+		Requirements []Requirement
+		Sort         string
+		Desc         bool
+		After        *GuestCursor
+		Limit        int
+	}
+
+	// GuestCursor defines the position of the last guest of a page.
+	// Guest names are unique and break ties between equal sort values.
+	GuestCursor struct {
+		Name        string
+		Table       int
+		TimeArrival time.Time
+	}
+
 	Repository interface {
 		GetArrivedGuests(ctx context.Context) ([]Guest, error)
 		GetGuestByName(ctx context.Context, name string) (*Guest, error)
 		ListGuests(ctx context.Context) ([]Guest, error)
+		QueryGuests(ctx context.Context, query *GuestQuery) ([]Guest, error)
 		UpsertGuest(ctx context.Context, guest *Guest) error
+		DeleteGuest(ctx context.Context, name string) error
 
 		GetTableByNumber(ctx context.Context, number int) (*Table, error)
 		GetTables(ctx context.Context) ([]Table, error)
 		UpsertTable(ctx context.Context, table *Table) error
+
+		GetTableReservations(ctx context.Context) ([]TableReservation, error)
+		ReplaceTableReservation(ctx context.Context, reservation *TableReservation) error
+		DeleteTableReservation(ctx context.Context, table int) error
+
+		GetTableLayouts(ctx context.Context) ([]TableLayout, error)
+		ReplaceTableLayouts(ctx context.Context, layouts []TableLayout) error
+
+		GetSeats(ctx context.Context) ([]Seat, error)
+		GetSeatsByTable(ctx context.Context, table int) ([]Seat, error)
+		CreateSeats(ctx context.Context, seats []Seat) error
+		DeleteGuestSeats(ctx context.Context, name string) error
+
+		GetConstraints(ctx context.Context) ([]Constraint, error)
+		CreateConstraint(ctx context.Context, constraint *Constraint) error
+		DeleteConstraint(ctx context.Context, id string) error
+
+		GetGroups(ctx context.Context) ([]Group, error)
+		GetGroupByName(ctx context.Context, name string) (*Group, error)
+		CreateGroup(ctx context.Context, group *Group) error
+
+		GetRequirements(ctx context.Context) ([]Requirement, error)
+		GetGuestRequirements(ctx context.Context, guest string) ([]Requirement, []Companion, error)
+		ReplaceGuestRequirements(ctx context.Context, guest string, requirements []Requirement, companions []Companion) error
+
+		GetCustomFields(ctx context.Context) ([]CustomField, error)
+		ReplaceCustomFields(ctx context.Context, fields []CustomField) error
+
+		GetInvitationByID(ctx context.Context, id string) (*Invitation, error)
+		UpsertInvitation(ctx context.Context, invitation *Invitation) error
+		// AnswerInvitation records the answer of an invitation only while it is still in fromStatus,
+		// and reports whether it was.
+		AnswerInvitation(ctx context.Context, invitation *Invitation, fromStatus string) (bool, error)
+
+		GetCheckInCode(ctx context.Context, code string) (*CheckInCode, error)
+		GetCheckInCodesByGuestName(ctx context.Context, name string) ([]CheckInCode, error)
+		UpsertCheckInCode(ctx context.Context, code *CheckInCode) error
+
+		// Transaction runs fn against a repository bound to a single transaction.
+		// The transaction is rolled back if fn returns an error.
+		Transaction(ctx context.Context, fn func(repo Repository) error) error
 	}
 )
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alesr/getground/internal/pkg/logging"
+	"github.com/alesr/getground/internal/pkg/tracing"
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("github.com/alesr/getground/internal/pkg/party/repository")
+
+// startSpan starts the span of a MySQL repository method querying the table.
+func startSpan(ctx context.Context, method, table string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemMySQL,
+		semconv.DBOperationKey.String(method),
+	}
+
+	if table != "" {
+		attrs = append(attrs, semconv.DBSQLTableKey.String(table))
+	}
+
+	return tracer.Start(ctx, "repository.MySQL."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// queryError records the error on the span, logs it with the request logger and returns it.
+// Records not found are expected and do not fail the span.
+func (m *MySQL) queryError(ctx context.Context, span trace.Span, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	tracing.RecordError(span, err)
+	logging.FromContext(ctx, m.logger).Warn("mysql query failed", zap.Error(err))
+	return err
+}
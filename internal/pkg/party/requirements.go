@@ -0,0 +1,604 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/pkg/database"
+)
+
+const (
+	maxAllergyLength       = 64
+	maxCompanionNameLength = 255
+	maxCustomValueLength   = 255
+)
+
+var (
+	dietaryRequirements = map[string]bool{
+		DietaryVegetarian:  true,
+		DietaryVegan:       true,
+		DietaryPescatarian: true,
+		DietaryHalal:       true,
+		DietaryKosher:      true,
+		DietaryGlutenFree:  true,
+		DietaryDairyFree:   true,
+	}
+
+	accessibilityNeeds = map[string]bool{
+		AccessibilityWheelchair:       true,
+		AccessibilityStepFree:         true,
+		AccessibilityHearing:          true,
+		AccessibilityVisual:           true,
+		AccessibilityAssistanceAnimal: true,
+	}
+
+	// Custom field names are used as query parameters, field:value, and must not need escaping
+	customFieldName = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+)
+
+// GetGuestRequirements returns the needs of a guest and of each of their accompanying guests.
+func (p *Party) GetGuestRequirements(ctx context.Context, name string) (*GuestRequirements, error) {
+	ctx, span := tracer.Start(ctx, "party.GetGuestRequirements")
+	defer span.End()
+
+	guest, err := getGuest(ctx, p.repo, name)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, companions, err := p.repo.GetGuestRequirements(ctx, guest.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not get guest requirements: %w", err)
+	}
+	return requirementsOutput(guest, requirements, companions), nil
+}
+
+// SetGuestRequirements replaces the needs of a guest and of their accompanying guests.
+// Custom values must match the type of their field, and required fields need a value for every person.
+func (p *Party) SetGuestRequirements(ctx context.Context, in *SetGuestRequirementsInput) (*GuestRequirements, error) {
+	ctx, span := tracer.Start(ctx, "party.SetGuestRequirements")
+	defer span.End()
+
+	var out *GuestRequirements
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		guest, err := getGuest(ctx, tx, in.Guest)
+		if err != nil {
+			return err
+		}
+
+		fields, err := tx.GetCustomFields(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get custom fields: %w", err)
+		}
+
+		requirements, companions, err := in.stores(guest, fields)
+		if err != nil {
+			return fmt.Errorf("could not validate input for setting guest requirements: %w", err)
+		}
+
+		if err := tx.ReplaceGuestRequirements(ctx, guest.Name, requirements, companions); err != nil {
+			return fmt.Errorf("could not replace guest requirements: %w", err)
+		}
+
+		out = requirementsOutput(guest, requirements, companions)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomFields returns the custom fields of the party in the order they were given.
+func (p *Party) ListCustomFields(ctx context.Context) (ListCustomFieldsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.ListCustomFields")
+	defer span.End()
+
+	fields, err := p.repo.GetCustomFields(ctx)
+	if err != nil {
+		return ListCustomFieldsOutput{}, fmt.Errorf("could not get custom fields: %w", err)
+	}
+	return ListCustomFieldsOutput{Fields: customFieldsOutput(fields)}, nil
+}
+
+// SetCustomFields replaces the custom fields of the party. The values of the fields left out are deleted,
+// and the values stored for the other fields must match their new type and options.
+// Fields that become required are not enforced on the requirements already set.
+func (p *Party) SetCustomFields(ctx context.Context, in *SetCustomFieldsInput) (ListCustomFieldsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.SetCustomFields")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return ListCustomFieldsOutput{}, fmt.Errorf("could not validate input for setting custom fields: %w", err)
+	}
+
+	fields := make([]repository.CustomField, 0, len(in.Fields))
+	byName := make(map[string]repository.CustomField, len(in.Fields))
+
+	for i, field := range in.Fields {
+		fieldStore := repository.CustomField{
+			Name:     field.Name,
+			Position: i,
+			Type:     field.Type,
+			Options:  strings.Join(field.Options, ","),
+			Required: field.Required,
+		}
+		fields = append(fields, fieldStore)
+		byName[field.Name] = fieldStore
+	}
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		requirements, err := tx.GetRequirements(ctx)
+		if err != nil {
+			return fmt.Errorf("could not get requirements: %w", err)
+		}
+
+		dropped := make(map[string]bool)
+		for _, requirement := range requirements {
+			if requirement.Kind != repository.RequirementCustom {
+				continue
+			}
+
+			field, ok := byName[requirement.Field]
+			if !ok {
+				dropped[requirement.Guest] = true
+				continue
+			}
+
+			if _, err := customValue(field, requirement.Value); err != nil {
+				return fmt.Errorf("guest %s: field %s: %w", requirement.Guest, requirement.Field, err)
+			}
+		}
+
+		guests := make([]string, 0, len(dropped))
+		for guest := range dropped {
+			guests = append(guests, guest)
+		}
+		sort.Strings(guests)
+
+		for _, guest := range guests {
+			requirements, companions, err := tx.GetGuestRequirements(ctx, guest)
+			if err != nil {
+				return fmt.Errorf("could not get guest requirements: %w", err)
+			}
+
+			kept := requirements[:0]
+			for _, requirement := range requirements {
+				if _, ok := byName[requirement.Field]; requirement.Kind != repository.RequirementCustom || ok {
+					kept = append(kept, requirement)
+				}
+			}
+
+			if err := tx.ReplaceGuestRequirements(ctx, guest, kept, companions); err != nil {
+				return fmt.Errorf("could not replace guest requirements: %w", err)
+			}
+		}
+
+		if err := tx.ReplaceCustomFields(ctx, fields); err != nil {
+			return fmt.Errorf("could not replace custom fields: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return ListCustomFieldsOutput{}, err
+	}
+	return ListCustomFieldsOutput{Fields: customFieldsOutput(fields)}, nil
+}
+
+// GetCateringSummary counts the people of the guest list with each need, by table and in total.
+// The needs of accompanying guests no longer coming are left out.
+func (p *Party) GetCateringSummary(ctx context.Context) (*CateringSummary, error) {
+	ctx, span := tracer.Start(ctx, "party.GetCateringSummary")
+	defer span.End()
+
+	guests, err := p.repo.ListGuests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get guest list: %w", err)
+	}
+
+	requirements, err := p.repo.GetRequirements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get requirements: %w", err)
+	}
+
+	fields, err := p.repo.GetCustomFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get custom fields: %w", err)
+	}
+
+	// Free text and numbers do not add up to a catering need
+	counted := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		counted[field.Name] = field.Type == CustomFieldBoolean || field.Type == CustomFieldChoice
+	}
+
+	total := newCateringCounts()
+	tables := make(map[int]*cateringCounts)
+	byName := make(map[string]repository.Guest, len(guests))
+
+	for _, guest := range guests {
+		byName[guest.Name] = guest
+
+		if tables[guest.Table] == nil {
+			tables[guest.Table] = newCateringCounts()
+		}
+		tables[guest.Table].people += guest.AccompanyingGuests + 1
+		total.people += guest.AccompanyingGuests + 1
+	}
+
+	for _, requirement := range requirements {
+		guest, ok := byName[requirement.Guest]
+		if !ok || requirement.Companion > guest.AccompanyingGuests {
+			continue
+		}
+
+		if requirement.Kind == repository.RequirementCustom && !counted[requirement.Field] {
+			continue
+		}
+
+		tables[guest.Table].add(requirement)
+		total.add(requirement)
+	}
+
+	out := CateringSummary{
+		Total:  total.output(0),
+		Tables: make([]CateringTable, 0, len(tables)),
+	}
+
+	for number, counts := range tables {
+		out.Tables = append(out.Tables, counts.output(number))
+	}
+
+	sort.Slice(out.Tables, func(i, j int) bool {
+		return out.Tables[i].Table < out.Tables[j].Table
+	})
+	return &out, nil
+}
+
+// cateringCounts counts the people at a table and the people with each need, keyed by kind, field and value.
+type cateringCounts struct {
+	people int
+	needs  map[repository.Requirement]int
+}
+
+func newCateringCounts() *cateringCounts {
+	return &cateringCounts{needs: make(map[repository.Requirement]int)}
+}
+
+func (c *cateringCounts) add(requirement repository.Requirement) {
+	c.needs[repository.Requirement{Kind: requirement.Kind, Field: requirement.Field, Value: requirement.Value}]++
+}
+
+func (c *cateringCounts) output(table int) CateringTable {
+	out := CateringTable{
+		Table:         table,
+		People:        c.people,
+		Dietary:       []CateringCount{},
+		Allergies:     []CateringCount{},
+		Accessibility: []CateringCount{},
+		Custom:        []CateringCount{},
+	}
+
+	for need, count := range c.needs {
+		switch need.Kind {
+		case repository.RequirementDietary:
+			out.Dietary = append(out.Dietary, CateringCount{Value: need.Value, Count: count})
+		case repository.RequirementAllergy:
+			out.Allergies = append(out.Allergies, CateringCount{Value: need.Value, Count: count})
+		case repository.RequirementAccessibility:
+			out.Accessibility = append(out.Accessibility, CateringCount{Value: need.Value, Count: count})
+		case repository.RequirementCustom:
+			out.Custom = append(out.Custom, CateringCount{Field: need.Field, Value: need.Value, Count: count})
+		}
+	}
+
+	for _, counts := range [][]CateringCount{out.Dietary, out.Allergies, out.Accessibility, out.Custom} {
+		counts := counts
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].Field != counts[j].Field {
+				return counts[i].Field < counts[j].Field
+			}
+			return counts[i].Value < counts[j].Value
+		})
+	}
+	return out
+}
+
+// stores validates the needs of the input for the guest and returns them in their stored form.
+func (r *SetGuestRequirementsInput) stores(guest *repository.Guest, fields []repository.CustomField) ([]repository.Requirement, []repository.Companion, error) {
+	byName := make(map[string]repository.CustomField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	var (
+		requirements []repository.Requirement
+		companions   []repository.Companion
+	)
+
+	given := make(map[int]bool, len(r.People))
+	for _, person := range r.People {
+		if person.Companion < 0 || person.Companion > guest.AccompanyingGuests {
+			return nil, nil, fmt.Errorf("companion %d: %w", person.Companion, ErrCompanionNumberInvalid)
+		}
+
+		if given[person.Companion] {
+			return nil, nil, fmt.Errorf("companion %d: %w", person.Companion, ErrCompanionDuplicated)
+		}
+		given[person.Companion] = true
+
+		personRequirements, err := person.stores(guest.Name, byName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("companion %d: %w", person.Companion, err)
+		}
+		requirements = append(requirements, personRequirements...)
+
+		if name := strings.TrimSpace(person.Name); person.Companion > 0 && name != "" {
+			companions = append(companions, repository.Companion{Guest: guest.Name, Number: person.Companion, Name: name})
+		}
+	}
+
+	for _, field := range fields {
+		if !field.Required {
+			continue
+		}
+
+		for companion := 0; companion <= guest.AccompanyingGuests; companion++ {
+			if !hasCustomValue(requirements, companion, field.Name) {
+				return nil, nil, fmt.Errorf("companion %d: field %s: %w", companion, field.Name, ErrCustomFieldRequired)
+			}
+		}
+	}
+	return requirements, companions, nil
+}
+
+// stores validates the needs of a person and returns them in their stored form, without duplicates.
+func (r *Requirements) stores(guest string, fields map[string]repository.CustomField) ([]repository.Requirement, error) {
+	if len(r.Name) > maxCompanionNameLength {
+		return nil, ErrCompanionNameInvalid
+	}
+
+	var stores []repository.Requirement
+	seen := make(map[repository.Requirement]bool)
+
+	add := func(kind, field, value string) {
+		requirement := repository.Requirement{Guest: guest, Companion: r.Companion, Kind: kind, Field: field, Value: value}
+		if !seen[requirement] {
+			seen[requirement] = true
+			stores = append(stores, requirement)
+		}
+	}
+
+	for _, dietary := range r.Dietary {
+		if !dietaryRequirements[dietary] {
+			return nil, fmt.Errorf("dietary %s: %w", dietary, ErrDietaryRequirementInvalid)
+		}
+		add(repository.RequirementDietary, "", dietary)
+	}
+
+	for _, allergy := range r.Allergies {
+		value, err := normalizeAllergy(allergy)
+		if err != nil {
+			return nil, fmt.Errorf("allergy %s: %w", allergy, err)
+		}
+		add(repository.RequirementAllergy, "", value)
+	}
+
+	for _, need := range r.Accessibility {
+		if !accessibilityNeeds[need] {
+			return nil, fmt.Errorf("accessibility %s: %w", need, ErrAccessibilityNeedInvalid)
+		}
+		add(repository.RequirementAccessibility, "", need)
+	}
+
+	set := make(map[string]bool, len(r.Custom))
+	for _, custom := range r.Custom {
+		field, ok := fields[custom.Field]
+		if !ok {
+			return nil, fmt.Errorf("field %s: %w", custom.Field, ErrCustomFieldNotFound)
+		}
+
+		// A field takes a single value
+		if set[custom.Field] {
+			return nil, fmt.Errorf("field %s: %w", custom.Field, ErrCustomFieldValueInvalid)
+		}
+		set[custom.Field] = true
+
+		value, err := customValue(field, custom.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", custom.Field, err)
+		}
+		add(repository.RequirementCustom, field.Name, value)
+	}
+	return stores, nil
+}
+
+// requirements returns the requirement filters of the input.
+func (in *ListGuestsInput) requirements() ([]repository.Requirement, error) {
+	var requirements []repository.Requirement
+
+	if in.Dietary != "" {
+		if !dietaryRequirements[in.Dietary] {
+			return nil, ErrDietaryRequirementInvalid
+		}
+		requirements = append(requirements, repository.Requirement{Kind: repository.RequirementDietary, Value: in.Dietary})
+	}
+
+	if in.Allergy != "" {
+		allergy, err := normalizeAllergy(in.Allergy)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, repository.Requirement{Kind: repository.RequirementAllergy, Value: allergy})
+	}
+
+	if in.Accessibility != "" {
+		if !accessibilityNeeds[in.Accessibility] {
+			return nil, ErrAccessibilityNeedInvalid
+		}
+		requirements = append(requirements, repository.Requirement{Kind: repository.RequirementAccessibility, Value: in.Accessibility})
+	}
+
+	if in.Custom != "" {
+		i := strings.Index(in.Custom, ":")
+		if i <= 0 {
+			return nil, ErrCustomFieldValueInvalid
+		}
+		requirements = append(requirements, repository.Requirement{
+			Kind:  repository.RequirementCustom,
+			Field: in.Custom[:i],
+			Value: in.Custom[i+1:],
+		})
+	}
+	return requirements, nil
+}
+
+// getGuest returns the guest of a name, or ErrGuestNotInList.
+func getGuest(ctx context.Context, repo repository.Repository, name string) (*repository.Guest, error) {
+	if name == "" {
+		return nil, ErrGuestNameRequired
+	}
+
+	guest, err := repo.GetGuestByName(ctx, name)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return nil, fmt.Errorf("could not get guest by name: %w", err)
+	}
+
+	// Missing guests are found with a zero name
+	if guest == nil || guest.Name == "" {
+		return nil, ErrGuestNotInList
+	}
+	return guest, nil
+}
+
+func normalizeAllergy(allergy string) (string, error) {
+	allergy = strings.ToLower(strings.TrimSpace(allergy))
+	if allergy == "" || len(allergy) > maxAllergyLength {
+		return "", ErrAllergyInvalid
+	}
+	return allergy, nil
+}
+
+// customValue validates a value of the field and returns it in its stored form.
+func customValue(field repository.CustomField, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch field.Type {
+	case CustomFieldText:
+		if value == "" || len(value) > maxCustomValueLength {
+			return "", ErrCustomFieldValueInvalid
+		}
+		return value, nil
+	case CustomFieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", ErrCustomFieldValueInvalid
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case CustomFieldBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", ErrCustomFieldValueInvalid
+		}
+		return strconv.FormatBool(b), nil
+	case CustomFieldChoice:
+		for _, option := range customFieldOptions(field) {
+			if option == value {
+				return value, nil
+			}
+		}
+	}
+	return "", ErrCustomFieldValueInvalid
+}
+
+func customFieldOptions(field repository.CustomField) []string {
+	if field.Options == "" {
+		return nil
+	}
+	return strings.Split(field.Options, ",")
+}
+
+func hasCustomValue(requirements []repository.Requirement, companion int, field string) bool {
+	for _, requirement := range requirements {
+		if requirement.Companion == companion && requirement.Kind == repository.RequirementCustom && requirement.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func customFieldsOutput(fields []repository.CustomField) []CustomField {
+	out := make([]CustomField, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, CustomField{
+			Name:     field.Name,
+			Type:     field.Type,
+			Options:  customFieldOptions(field),
+			Required: field.Required,
+		})
+	}
+	return out
+}
+
+// requirementsOutput lists the needs of the guest then of each of their accompanying guests, sorted by value.
+// The needs of accompanying guests no longer coming are left out.
+func requirementsOutput(guest *repository.Guest, requirements []repository.Requirement, companions []repository.Companion) *GuestRequirements {
+	out := GuestRequirements{
+		Guest:  guest.Name,
+		People: make([]Requirements, guest.AccompanyingGuests+1),
+	}
+
+	for i := range out.People {
+		out.People[i] = Requirements{
+			Companion:     i,
+			Dietary:       []string{},
+			Allergies:     []string{},
+			Accessibility: []string{},
+			Custom:        []CustomValue{},
+		}
+	}
+
+	for _, companion := range companions {
+		if companion.Number <= guest.AccompanyingGuests {
+			out.People[companion.Number].Name = companion.Name
+		}
+	}
+
+	for _, requirement := range requirements {
+		if requirement.Companion > guest.AccompanyingGuests {
+			continue
+		}
+
+		person := &out.People[requirement.Companion]
+
+		switch requirement.Kind {
+		case repository.RequirementDietary:
+			person.Dietary = append(person.Dietary, requirement.Value)
+		case repository.RequirementAllergy:
+			person.Allergies = append(person.Allergies, requirement.Value)
+		case repository.RequirementAccessibility:
+			person.Accessibility = append(person.Accessibility, requirement.Value)
+		case repository.RequirementCustom:
+			person.Custom = append(person.Custom, CustomValue{Field: requirement.Field, Value: requirement.Value})
+		}
+	}
+
+	for i := range out.People {
+		person := &out.People[i]
+		sort.Strings(person.Dietary)
+		sort.Strings(person.Allergies)
+		sort.Strings(person.Accessibility)
+		sort.Slice(person.Custom, func(i, j int) bool {
+			return person.Custom[i].Field < person.Custom[j].Field
+		})
+	}
+	return &out
+}
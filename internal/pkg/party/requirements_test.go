@@ -0,0 +1,403 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// requirementsTestState is the guest list of requirementsTestRepo.
+type requirementsTestState struct {
+	guests       map[string]repository.Guest
+	requirements map[string][]repository.Requirement
+	companions   map[string][]repository.Companion
+	fields       []repository.CustomField
+}
+
+// requirementsTestRepo lists john with two accompanying guests at table 1, and jane alone at table 2.
+// Meal is a required choice field, and shirt a text field.
+func requirementsTestRepo() (*repository.Mock, *requirementsTestState) {
+	state := requirementsTestState{
+		guests: map[string]repository.Guest{
+			"john": {Name: "john", Table: 1, AccompanyingGuests: 2},
+			"jane": {Name: "jane", Table: 2},
+		},
+		requirements: map[string][]repository.Requirement{},
+		companions:   map[string][]repository.Companion{},
+		fields: []repository.CustomField{
+			{Name: "meal", Position: 0, Type: CustomFieldChoice, Options: "fish,meat", Required: true},
+			{Name: "shirt", Position: 1, Type: CustomFieldText},
+		},
+	}
+
+	repo := repository.Mock{}
+	repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(&repo)
+	}
+	repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+		guest := state.guests[name]
+		return &guest, nil
+	}
+	repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+		var guests []repository.Guest
+		for _, guest := range state.guests {
+			guests = append(guests, guest)
+		}
+		return guests, nil
+	}
+	repo.GetRequirementsFunc = func(ctx context.Context) ([]repository.Requirement, error) {
+		var requirements []repository.Requirement
+		for _, guestRequirements := range state.requirements {
+			requirements = append(requirements, guestRequirements...)
+		}
+		return requirements, nil
+	}
+	repo.GetGuestRequirementsFunc = func(ctx context.Context, guest string) ([]repository.Requirement, []repository.Companion, error) {
+		return state.requirements[guest], state.companions[guest], nil
+	}
+	repo.ReplaceGuestRequirementsFunc = func(ctx context.Context, guest string, requirements []repository.Requirement, companions []repository.Companion) error {
+		state.requirements[guest] = append([]repository.Requirement(nil), requirements...)
+		state.companions[guest] = companions
+		return nil
+	}
+	repo.GetCustomFieldsFunc = func(ctx context.Context) ([]repository.CustomField, error) {
+		return state.fields, nil
+	}
+	repo.ReplaceCustomFieldsFunc = func(ctx context.Context, fields []repository.CustomField) error {
+		state.fields = fields
+		return nil
+	}
+	return &repo, &state
+}
+
+// people returns the requirements of each person of a party, without needs and with the given meal.
+func people(count int, meal string) []Requirements {
+	out := make([]Requirements, 0, count)
+	for i := 0; i < count; i++ {
+		out = append(out, Requirements{
+			Companion:     i,
+			Dietary:       []string{},
+			Allergies:     []string{},
+			Accessibility: []string{},
+			Custom:        []CustomValue{{Field: "meal", Value: meal}},
+		})
+	}
+	return out
+}
+
+func TestSetGuestRequirements(t *testing.T) {
+	t.Run("replaces the needs of the guest and their accompanying guests", func(t *testing.T) {
+		repo, state := requirementsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		given := people(3, "fish")
+		given[0].Dietary = []string{DietaryVegan, DietaryGlutenFree, DietaryVegan}
+		given[0].Custom = append(given[0].Custom, CustomValue{Field: "shirt", Value: " XL "})
+		given[1].Name = " Mary "
+		given[1].Allergies = []string{" Nuts", "shellfish"}
+		given[2].Accessibility = []string{AccessibilityWheelchair}
+
+		observed, err := party.SetGuestRequirements(context.TODO(), &SetGuestRequirementsInput{Guest: "john", People: given})
+		require.NoError(t, err)
+
+		expected := people(3, "fish")
+		expected[0].Dietary = []string{DietaryGlutenFree, DietaryVegan}
+		expected[0].Custom = append(expected[0].Custom, CustomValue{Field: "shirt", Value: "XL"})
+		expected[1].Name = "Mary"
+		expected[1].Allergies = []string{"nuts", "shellfish"}
+		expected[2].Accessibility = []string{AccessibilityWheelchair}
+
+		assert.Equal(t, &GuestRequirements{Guest: "john", People: expected}, observed)
+		assert.Equal(t, []repository.Companion{{Guest: "john", Number: 1, Name: "Mary"}}, state.companions["john"])
+
+		stored, err := party.GetGuestRequirements(context.TODO(), "john")
+		require.NoError(t, err)
+		assert.Equal(t, observed, stored)
+	})
+
+	cases := []struct {
+		name        string
+		given       func() []Requirements
+		expectedErr error
+	}{
+		{
+			name: "companion beyond the accompanying guests",
+			given: func() []Requirements {
+				return append(people(3, "fish"), Requirements{Companion: 3})
+			},
+			expectedErr: ErrCompanionNumberInvalid,
+		},
+		{
+			name: "companion given twice",
+			given: func() []Requirements {
+				return append(people(3, "fish"), Requirements{Companion: 1})
+			},
+			expectedErr: ErrCompanionDuplicated,
+		},
+		{
+			name: "unknown dietary requirement",
+			given: func() []Requirements {
+				given := people(3, "fish")
+				given[0].Dietary = []string{"keto"}
+				return given
+			},
+			expectedErr: ErrDietaryRequirementInvalid,
+		},
+		{
+			name: "unknown accessibility need",
+			given: func() []Requirements {
+				given := people(3, "fish")
+				given[0].Accessibility = []string{"stairs"}
+				return given
+			},
+			expectedErr: ErrAccessibilityNeedInvalid,
+		},
+		{
+			name: "unknown custom field",
+			given: func() []Requirements {
+				given := people(3, "fish")
+				given[0].Custom = append(given[0].Custom, CustomValue{Field: "age", Value: "3"})
+				return given
+			},
+			expectedErr: ErrCustomFieldNotFound,
+		},
+		{
+			name: "custom value not an option",
+			given: func() []Requirements {
+				return people(3, "soup")
+			},
+			expectedErr: ErrCustomFieldValueInvalid,
+		},
+		{
+			name: "required field missing for an accompanying guest",
+			given: func() []Requirements {
+				return people(2, "fish")
+			},
+			expectedErr: ErrCustomFieldRequired,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, state := requirementsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			_, err := party.SetGuestRequirements(context.TODO(), &SetGuestRequirementsInput{Guest: "john", People: tc.given()})
+			assert.True(t, errors.Is(err, tc.expectedErr), err)
+			assert.Empty(t, state.requirements)
+		})
+	}
+
+	t.Run("guest not in list", func(t *testing.T) {
+		repo, _ := requirementsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		_, err := party.SetGuestRequirements(context.TODO(), &SetGuestRequirementsInput{Guest: "bob"})
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+	})
+}
+
+func TestGetGuestRequirements(t *testing.T) {
+	repo, state := requirementsTestRepo()
+	state.requirements["jane"] = []repository.Requirement{
+		{Guest: "jane", Kind: repository.RequirementDietary, Value: DietaryHalal},
+		// Jane no longer comes with an accompanying guest
+		{Guest: "jane", Companion: 1, Kind: repository.RequirementAllergy, Value: "nuts"},
+	}
+	state.companions["jane"] = []repository.Companion{{Guest: "jane", Number: 1, Name: "Joe"}}
+
+	party := New(zap.NewNop(), repo, testTableSize)
+
+	observed, err := party.GetGuestRequirements(context.TODO(), "jane")
+	require.NoError(t, err)
+	assert.Equal(t, &GuestRequirements{Guest: "jane", People: []Requirements{{
+		Dietary:       []string{DietaryHalal},
+		Allergies:     []string{},
+		Accessibility: []string{},
+		Custom:        []CustomValue{},
+	}}}, observed)
+
+	_, err = party.GetGuestRequirements(context.TODO(), "bob")
+	assert.True(t, errors.Is(err, ErrGuestNotInList))
+}
+
+func TestSetCustomFields(t *testing.T) {
+	t.Run("replaces the fields and deletes the values of the fields left out", func(t *testing.T) {
+		repo, state := requirementsTestRepo()
+		state.requirements["jane"] = []repository.Requirement{
+			{Guest: "jane", Kind: repository.RequirementDietary, Value: DietaryVegan},
+			{Guest: "jane", Kind: repository.RequirementCustom, Field: "meal", Value: "fish"},
+			{Guest: "jane", Kind: repository.RequirementCustom, Field: "shirt", Value: "XL"},
+		}
+		state.companions["jane"] = []repository.Companion{}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		observed, err := party.SetCustomFields(context.TODO(), &SetCustomFieldsInput{Fields: []CustomField{
+			{Name: "meal", Type: CustomFieldChoice, Options: []string{"fish", "meat", "vegetables"}},
+			{Name: "shuttle", Type: CustomFieldBoolean, Required: true},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, ListCustomFieldsOutput{Fields: []CustomField{
+			{Name: "meal", Type: CustomFieldChoice, Options: []string{"fish", "meat", "vegetables"}},
+			{Name: "shuttle", Type: CustomFieldBoolean, Required: true},
+		}}, observed)
+
+		assert.Equal(t, []repository.CustomField{
+			{Name: "meal", Position: 0, Type: CustomFieldChoice, Options: "fish,meat,vegetables"},
+			{Name: "shuttle", Position: 1, Type: CustomFieldBoolean, Required: true},
+		}, state.fields)
+
+		assert.Equal(t, []repository.Requirement{
+			{Guest: "jane", Kind: repository.RequirementDietary, Value: DietaryVegan},
+			{Guest: "jane", Kind: repository.RequirementCustom, Field: "meal", Value: "fish"},
+		}, state.requirements["jane"])
+
+		listed, err := party.ListCustomFields(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, observed, listed)
+	})
+
+	t.Run("keeps the fields when a stored value does not match the new options", func(t *testing.T) {
+		repo, state := requirementsTestRepo()
+		state.requirements["jane"] = []repository.Requirement{
+			{Guest: "jane", Kind: repository.RequirementCustom, Field: "meal", Value: "fish"},
+		}
+		fields := state.fields
+
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		_, err := party.SetCustomFields(context.TODO(), &SetCustomFieldsInput{Fields: []CustomField{
+			{Name: "meal", Type: CustomFieldChoice, Options: []string{"meat"}},
+		}})
+		assert.True(t, errors.Is(err, ErrCustomFieldValueInvalid))
+		assert.Equal(t, fields, state.fields)
+	})
+
+	cases := []struct {
+		name  string
+		given CustomField
+	}{
+		{name: "name not usable in a query", given: CustomField{Name: "Meal Choice", Type: CustomFieldText}},
+		{name: "unknown type", given: CustomField{Name: "meal", Type: "date"}},
+		{name: "choice without options", given: CustomField{Name: "meal", Type: CustomFieldChoice}},
+		{name: "option with a comma", given: CustomField{Name: "meal", Type: CustomFieldChoice, Options: []string{"fish, chips"}}},
+		{name: "options of a text field", given: CustomField{Name: "meal", Type: CustomFieldText, Options: []string{"fish"}}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, _ := requirementsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			_, err := party.SetCustomFields(context.TODO(), &SetCustomFieldsInput{Fields: []CustomField{tc.given}})
+			assert.True(t, errors.Is(err, ErrCustomFieldInvalid), err)
+		})
+	}
+
+	t.Run("field given twice", func(t *testing.T) {
+		repo, _ := requirementsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		_, err := party.SetCustomFields(context.TODO(), &SetCustomFieldsInput{Fields: []CustomField{
+			{Name: "meal", Type: CustomFieldText},
+			{Name: "meal", Type: CustomFieldNumber},
+		}})
+		assert.True(t, errors.Is(err, ErrCustomFieldInvalid))
+	})
+}
+
+func TestGetCateringSummary(t *testing.T) {
+	repo, state := requirementsTestRepo()
+	state.guests["bob"] = repository.Guest{Name: "bob", Table: 1}
+	state.requirements = map[string][]repository.Requirement{
+		"john": {
+			{Guest: "john", Kind: repository.RequirementDietary, Value: DietaryVegetarian},
+			{Guest: "john", Companion: 1, Kind: repository.RequirementDietary, Value: DietaryVegetarian},
+			{Guest: "john", Companion: 1, Kind: repository.RequirementAllergy, Value: "nuts"},
+			{Guest: "john", Companion: 2, Kind: repository.RequirementCustom, Field: "meal", Value: "fish"},
+			{Guest: "john", Companion: 2, Kind: repository.RequirementCustom, Field: "shirt", Value: "XL"},
+		},
+		"bob": {
+			{Guest: "bob", Kind: repository.RequirementDietary, Value: DietaryVegetarian},
+			// Bob no longer comes with an accompanying guest
+			{Guest: "bob", Companion: 1, Kind: repository.RequirementAllergy, Value: "nuts"},
+		},
+		"jane": {
+			{Guest: "jane", Kind: repository.RequirementAllergy, Value: "nuts"},
+			{Guest: "jane", Kind: repository.RequirementAccessibility, Value: AccessibilityWheelchair},
+		},
+		// Requirements of guests no longer on the list
+		"joe": {
+			{Guest: "joe", Kind: repository.RequirementDietary, Value: DietaryVegan},
+		},
+	}
+
+	party := New(zap.NewNop(), repo, testTableSize)
+
+	observed, err := party.GetCateringSummary(context.TODO())
+	require.NoError(t, err)
+
+	assert.Equal(t, &CateringSummary{
+		Total: CateringTable{
+			People:        5,
+			Dietary:       []CateringCount{{Value: DietaryVegetarian, Count: 3}},
+			Allergies:     []CateringCount{{Value: "nuts", Count: 2}},
+			Accessibility: []CateringCount{{Value: AccessibilityWheelchair, Count: 1}},
+			Custom:        []CateringCount{{Field: "meal", Value: "fish", Count: 1}},
+		},
+		Tables: []CateringTable{
+			{
+				Table:         1,
+				People:        4,
+				Dietary:       []CateringCount{{Value: DietaryVegetarian, Count: 3}},
+				Allergies:     []CateringCount{{Value: "nuts", Count: 1}},
+				Accessibility: []CateringCount{},
+				Custom:        []CateringCount{{Field: "meal", Value: "fish", Count: 1}},
+			},
+			{
+				Table:         2,
+				People:        1,
+				Dietary:       []CateringCount{},
+				Allergies:     []CateringCount{{Value: "nuts", Count: 1}},
+				Accessibility: []CateringCount{{Value: AccessibilityWheelchair, Count: 1}},
+				Custom:        []CateringCount{},
+			},
+		},
+	}, observed)
+}
+
+func TestGetGuestListRequirementFilters(t *testing.T) {
+	var observed *repository.GuestQuery
+
+	repo := repository.Mock{}
+	repo.QueryGuestsFunc = func(ctx context.Context, query *repository.GuestQuery) ([]repository.Guest, error) {
+		observed = query
+		return nil, nil
+	}
+
+	party := New(zap.NewNop(), &repo, testTableSize)
+
+	_, err := party.GetGuestList(context.TODO(), &ListGuestsInput{
+		Dietary:       DietaryVegan,
+		Allergy:       "Nuts",
+		Accessibility: AccessibilityHearing,
+		Custom:        "meal:fish",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []repository.Requirement{
+		{Kind: repository.RequirementDietary, Value: DietaryVegan},
+		{Kind: repository.RequirementAllergy, Value: "nuts"},
+		{Kind: repository.RequirementAccessibility, Value: AccessibilityHearing},
+		{Kind: repository.RequirementCustom, Field: "meal", Value: "fish"},
+	}, observed.Requirements)
+}
@@ -0,0 +1,132 @@
+package party
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+)
+
+// ReserveTable restricts a table to the guests of the input tiers and holds back some of its available seats,
+// replacing its reservation. Guests already at the table keep their seats, held seats must be available.
+func (p *Party) ReserveTable(ctx context.Context, in *ReserveTableInput) (*Table, error) {
+	ctx, span := tracer.Start(ctx, "party.ReserveTable")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return nil, fmt.Errorf("could not validate input for reserving table: %w", err)
+	}
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		table, err := getTable(ctx, tx, in.Number)
+		if err != nil {
+			return err
+		}
+
+		if in.HeldSeats > table.AvailableSeats {
+			return ErrTableNotEnoughSeats
+		}
+
+		// An empty reservation leaves the table open to every tier
+		if len(in.Tiers) == 0 && in.HeldSeats == 0 {
+			if err := tx.DeleteTableReservation(ctx, table.Number); err != nil {
+				return fmt.Errorf("could not delete table reservation: %w", err)
+			}
+			return nil
+		}
+
+		if err := tx.ReplaceTableReservation(ctx, &repository.TableReservation{
+			Table:     table.Number,
+			Tiers:     strings.Join(in.Tiers, ","),
+			HeldSeats: in.HeldSeats,
+		}); err != nil {
+			return fmt.Errorf("could not replace table reservation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.getTableWithSeats(ctx, in.Number)
+}
+
+// ReleaseTable opens a table to guests of every tier and releases its held seats.
+func (p *Party) ReleaseTable(ctx context.Context, number int) (*Table, error) {
+	ctx, span := tracer.Start(ctx, "party.ReleaseTable")
+	defer span.End()
+
+	if number <= 0 {
+		return nil, ErrTableNumberInvalid
+	}
+
+	if _, err := getTable(ctx, p.repo, number); err != nil {
+		return nil, err
+	}
+
+	if err := p.repo.DeleteTableReservation(ctx, number); err != nil {
+		return nil, fmt.Errorf("could not delete table reservation: %w", err)
+	}
+	return p.getTableWithSeats(ctx, number)
+}
+
+// getTableReservations returns the reservations by table number, tables without one are open to every tier.
+func getTableReservations(ctx context.Context, repo repository.Repository) (map[int]repository.TableReservation, error) {
+	reservations, err := repo.GetTableReservations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get table reservations: %w", err)
+	}
+
+	byTable := make(map[int]repository.TableReservation, len(reservations))
+	for _, reservation := range reservations {
+		byTable[reservation.Table] = reservation
+	}
+	return byTable, nil
+}
+
+// reservationTiers returns the tiers of a reservation, none for a table open to every tier.
+func reservationTiers(reservation repository.TableReservation) []string {
+	if reservation.Tiers == "" {
+		return nil
+	}
+	return strings.Split(reservation.Tiers, ",")
+}
+
+// allowsTier reports whether guests of the tier may book a table of the reservation.
+func allowsTier(reservation repository.TableReservation, tier string) bool {
+	tiers := reservationTiers(reservation)
+	if len(tiers) == 0 {
+		return true
+	}
+
+	for _, allowed := range tiers {
+		if allowed == guestTier(tier) {
+			return true
+		}
+	}
+	return false
+}
+
+// bookableSeats returns the available seats of a table that are not held back.
+func bookableSeats(table repository.Table, reservation repository.TableReservation) int {
+	if seats := table.AvailableSeats - reservation.HeldSeats; seats > 0 {
+		return seats
+	}
+	return 0
+}
+
+// guestTier returns the tier of a guest, general for guests without a tier.
+func guestTier(tier string) string {
+	if tier == "" {
+		return TierGeneral
+	}
+	return tier
+}
+
+func validTier(tier string) bool {
+	switch tier {
+	case TierVIP, TierStaff, TierGeneral:
+		return true
+	}
+	return false
+}
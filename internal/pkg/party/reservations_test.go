@@ -0,0 +1,256 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type reservationsTestState struct {
+	guests       map[string]repository.Guest
+	tables       map[int]repository.Table
+	reservations map[int]repository.TableReservation
+}
+
+func (s *reservationsTestState) reservationList() []repository.TableReservation {
+	out := make([]repository.TableReservation, 0, len(s.reservations))
+	for _, reservation := range s.reservations {
+		out = append(out, reservation)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Table < out[j].Table
+	})
+	return out
+}
+
+// reservationsTestRepo has two tables of 6 seats: table 1 restricted to vip guests with 2 seats held back,
+// where jim sits with 1 accompanying guest, and table 2 open to every tier.
+func reservationsTestRepo() (*repository.Mock, *reservationsTestState) {
+	state := reservationsTestState{
+		guests: map[string]repository.Guest{
+			"jim": {Name: "jim", Table: 1, AccompanyingGuests: 1, Tier: TierVIP},
+		},
+		tables: map[int]repository.Table{
+			1: {Number: 1, Size: 6, AvailableSeats: 4},
+			2: {Number: 2, Size: 6, AvailableSeats: 6},
+		},
+		reservations: map[int]repository.TableReservation{
+			1: {Table: 1, Tiers: TierVIP, HeldSeats: 2},
+		},
+	}
+
+	repo := repository.Mock{}
+	repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(&repo)
+	}
+	repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+		var out []repository.Guest
+		for _, guest := range state.guests {
+			out = append(out, guest)
+		}
+		return out, nil
+	}
+	repo.UpsertGuestFunc = func(ctx context.Context, guest *repository.Guest) error {
+		state.guests[guest.Name] = *guest
+		return nil
+	}
+	repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+		table := state.tables[number]
+		return &table, nil
+	}
+	repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+		var out []repository.Table
+		for _, table := range state.tables {
+			out = append(out, table)
+		}
+		return out, nil
+	}
+	repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error {
+		state.tables[table.Number] = *table
+		return nil
+	}
+	repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+		return state.reservationList(), nil
+	}
+	repo.ReplaceTableReservationFunc = func(ctx context.Context, reservation *repository.TableReservation) error {
+		state.reservations[reservation.Table] = *reservation
+		return nil
+	}
+	repo.DeleteTableReservationFunc = func(ctx context.Context, table int) error {
+		delete(state.reservations, table)
+		return nil
+	}
+	repo.GetSeatsFunc = func(ctx context.Context) ([]repository.Seat, error) {
+		return nil, nil
+	}
+	repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+		return nil, nil
+	}
+	repo.CreateSeatsFunc = func(ctx context.Context, seats []repository.Seat) error {
+		return nil
+	}
+	repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+		return nil, nil
+	}
+	repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error { return nil }
+	return &repo, &state
+}
+
+func TestReserveTable(t *testing.T) {
+	cases := []struct {
+		name                 string
+		given                ReserveTableInput
+		expectedReservations []repository.TableReservation
+		expectedErr          error
+	}{
+		{
+			name:  "restricts a table to tiers and holds seats",
+			given: ReserveTableInput{Number: 2, Tiers: []string{TierVIP, TierStaff}, HeldSeats: 3},
+			expectedReservations: []repository.TableReservation{
+				{Table: 1, Tiers: TierVIP, HeldSeats: 2},
+				{Table: 2, Tiers: "vip,staff", HeldSeats: 3},
+			},
+		},
+		{
+			name:  "replaces the reservation of a table",
+			given: ReserveTableInput{Number: 1, HeldSeats: 1},
+			expectedReservations: []repository.TableReservation{
+				{Table: 1, HeldSeats: 1},
+			},
+		},
+		{
+			name:                 "opens a table with an empty reservation",
+			given:                ReserveTableInput{Number: 1},
+			expectedReservations: []repository.TableReservation{},
+		},
+		{
+			name:        "invalid tier",
+			given:       ReserveTableInput{Number: 2, Tiers: []string{"gold"}},
+			expectedErr: ErrTierInvalid,
+		},
+		{
+			name:        "duplicated tier",
+			given:       ReserveTableInput{Number: 2, Tiers: []string{TierVIP, TierVIP}},
+			expectedErr: ErrTierInvalid,
+		},
+		{
+			name:        "negative held seats",
+			given:       ReserveTableInput{Number: 2, HeldSeats: -1},
+			expectedErr: ErrHeldSeatsInvalid,
+		},
+		{
+			name:        "more held seats than available",
+			given:       ReserveTableInput{Number: 1, Tiers: []string{TierVIP}, HeldSeats: 5},
+			expectedErr: ErrTableNotEnoughSeats,
+		},
+		{
+			name:        "unknown table",
+			given:       ReserveTableInput{Number: 3, Tiers: []string{TierVIP}},
+			expectedErr: ErrTableNumberNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, state := reservationsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			observed, err := party.ReserveTable(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReservations, state.reservationList())
+			assert.Equal(t, tc.given.Number, observed.Number)
+			assert.Equal(t, tc.given.HeldSeats, observed.HeldSeats)
+		})
+	}
+}
+
+func TestReleaseTable(t *testing.T) {
+	t.Run("opens the table and releases its held seats", func(t *testing.T) {
+		repo, state := reservationsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		observed, err := party.ReleaseTable(context.TODO(), 1)
+		require.NoError(t, err)
+
+		assert.Empty(t, state.reservations)
+		assert.Nil(t, observed.Tiers)
+		assert.Zero(t, observed.HeldSeats)
+		assert.Equal(t, 4, observed.EmptySeats)
+	})
+
+	t.Run("returns an error for an unknown table", func(t *testing.T) {
+		repo, _ := reservationsTestRepo()
+		party := New(zap.NewNop(), repo, testTableSize)
+
+		_, err := party.ReleaseTable(context.TODO(), 3)
+		assert.True(t, errors.Is(err, ErrTableNumberNotFound), err)
+	})
+}
+
+func TestAddGuestToGuestListReservations(t *testing.T) {
+	cases := []struct {
+		name        string
+		given       AddGuestToGuestListInput
+		expectedErr error
+	}{
+		{
+			name:  "books a table allowing the tier",
+			given: AddGuestToGuestListInput{Name: "amy", Table: 1, AccompanyingGuests: 1, Tier: TierVIP},
+		},
+		{
+			name:  "books a table open to every tier",
+			given: AddGuestToGuestListInput{Name: "amy", Table: 2, Tier: TierStaff},
+		},
+		{
+			name:        "refuses guests without a tier at a restricted table",
+			given:       AddGuestToGuestListInput{Name: "amy", Table: 1},
+			expectedErr: ErrTableTierRestricted,
+		},
+		{
+			name:        "refuses guests of another tier",
+			given:       AddGuestToGuestListInput{Name: "amy", Table: 1, Tier: TierStaff},
+			expectedErr: ErrTableTierRestricted,
+		},
+		{
+			name:        "keeps the held seats",
+			given:       AddGuestToGuestListInput{Name: "amy", Table: 1, AccompanyingGuests: 2, Tier: TierVIP},
+			expectedErr: ErrTableNotEnoughSeats,
+		},
+		{
+			name:        "invalid tier",
+			given:       AddGuestToGuestListInput{Name: "amy", Table: 2, Tier: "gold"},
+			expectedErr: ErrTierInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, state := reservationsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			_, err := party.AddGuestToGuestList(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				assert.NotContains(t, state.guests, tc.given.Name)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.given.Tier, state.guests[tc.given.Name].Tier)
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/pkg/database"
+)
+
+// AssignSeats moves a guest and their accompanying guests to the input seats of their table.
+// There must be a seat for everyone seated, or for the booked party when the guest has no seats.
+func (p *Party) AssignSeats(ctx context.Context, in *AssignSeatsInput) (*Table, error) {
+	ctx, span := tracer.Start(ctx, "party.AssignSeats")
+	defer span.End()
+
+	if in.Name == "" {
+		return nil, ErrGuestNameRequired
+	}
+
+	var tableNumber int
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		guest, err := tx.GetGuestByName(ctx, in.Name)
+		if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+			return fmt.Errorf("could not get guest by name: %w", err)
+		}
+
+		// Missing guests are found with a zero name
+		if guest == nil || guest.Name == "" {
+			return ErrGuestNotInList
+		}
+
+		table, err := getTable(ctx, tx, guest.Table)
+		if err != nil {
+			return err
+		}
+
+		seats, err := tx.GetSeatsByTable(ctx, table.Number)
+		if err != nil {
+			return fmt.Errorf("could not get seats: %w", err)
+		}
+
+		count := len(guestSeats(seats, guest.Name))
+		if count == 0 {
+			count = guest.AccompanyingGuests + 1
+		}
+
+		if err := validateSeats(in.Seats, count); err != nil {
+			return err
+		}
+
+		assigned, err := planSeats(seats, table, guest.Name, in.Seats, count)
+		if err != nil {
+			return err
+		}
+
+		tableNumber = table.Number
+		return replaceGuestSeats(ctx, tx, guest.Name, assigned)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.getTableWithSeats(ctx, tableNumber)
+}
+
+// SwapSeats swaps the occupants of two seats of a table. Either seat may be free.
+func (p *Party) SwapSeats(ctx context.Context, in *SwapSeatsInput) (*Table, error) {
+	ctx, span := tracer.Start(ctx, "party.SwapSeats")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return nil, fmt.Errorf("could not validate input for swapping seats: %w", err)
+	}
+
+	err := p.repo.Transaction(ctx, func(tx repository.Repository) error {
+		table, err := getTable(ctx, tx, in.Table)
+		if err != nil {
+			return err
+		}
+
+		if in.First > table.Size || in.Second > table.Size {
+			return ErrSeatNumberInvalid
+		}
+
+		seats, err := tx.GetSeatsByTable(ctx, table.Number)
+		if err != nil {
+			return fmt.Errorf("could not get seats: %w", err)
+		}
+
+		// Reseat every guest sitting on either seat, with the seats of their party that do not move
+		moved := make(map[string][]repository.Seat)
+		for _, seat := range seats {
+			if seat.Number == in.First || seat.Number == in.Second {
+				moved[seat.Guest] = nil
+			}
+		}
+
+		for _, seat := range seats {
+			if _, ok := moved[seat.Guest]; !ok {
+				continue
+			}
+
+			switch seat.Number {
+			case in.First:
+				seat.Number = in.Second
+			case in.Second:
+				seat.Number = in.First
+			}
+			moved[seat.Guest] = append(moved[seat.Guest], seat)
+		}
+
+		for name := range moved {
+			if err := tx.DeleteGuestSeats(ctx, name); err != nil {
+				return fmt.Errorf("could not delete guest seats: %w", err)
+			}
+		}
+
+		for _, seats := range moved {
+			if err := tx.CreateSeats(ctx, seats); err != nil {
+				return fmt.Errorf("could not create seats: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.getTableWithSeats(ctx, in.Table)
+}
+
+// planSeats returns the count seats of the guest at the table, in companion order.
+// Without requested seats, the guest keeps their seats and the missing ones are the free seats closest to them,
+// or the first free seats next to each other for a guest without seats.
+func planSeats(seats []repository.Seat, table *repository.Table, name string, requested []int, count int) ([]repository.Seat, error) {
+	taken := make(map[int]bool, len(seats))
+	for _, seat := range seats {
+		if seat.Guest != name {
+			taken[seat.Number] = true
+		}
+	}
+
+	numbers := requested
+	if len(numbers) == 0 {
+		var current []int
+		for _, seat := range guestSeats(seats, name) {
+			current = append(current, seat.Number)
+		}
+
+		var ok bool
+		if numbers, ok = pickSeats(table.Size, taken, current, count); !ok {
+			return nil, ErrTableNotEnoughSeats
+		}
+	}
+
+	assigned := make([]repository.Seat, 0, len(numbers))
+	for i, number := range numbers {
+		if number > table.Size {
+			return nil, fmt.Errorf("seat %d: %w", number, ErrSeatNumberInvalid)
+		}
+
+		if taken[number] {
+			return nil, fmt.Errorf("seat %d: %w", number, ErrSeatTaken)
+		}
+
+		assigned = append(assigned, repository.Seat{
+			Table:     table.Number,
+			Number:    number,
+			Guest:     name,
+			Companion: i,
+		})
+	}
+	return assigned, nil
+}
+
+// pickSeats returns n seats numbered from 1 to size, keeping the current seats first.
+// It reports false when there are not enough free seats.
+func pickSeats(size int, taken map[int]bool, current []int, n int) ([]int, bool) {
+	if len(current) >= n {
+		return current[:n], true
+	}
+
+	own := make(map[int]bool, len(current))
+	for _, number := range current {
+		own[number] = true
+	}
+
+	var free []int
+	for number := 1; number <= size; number++ {
+		if !taken[number] && !own[number] {
+			free = append(free, number)
+		}
+	}
+
+	missing := n - len(current)
+	if len(free) < missing {
+		return nil, false
+	}
+
+	if len(current) == 0 {
+		// The first run of n seats next to each other, or the first free seats
+		for i := 0; i+n <= len(free); i++ {
+			if free[i+n-1]-free[i] == n-1 {
+				return free[i : i+n], true
+			}
+		}
+		return free[:n], true
+	}
+
+	// Grow the party one seat at a time, on the free seat closest to anyone already seated
+	picked := append([]int{}, current...)
+	for len(picked) < n {
+		best, bestDistance := 0, size+1
+		for i, number := range free {
+			if d := distanceToSeats(number, picked); d < bestDistance {
+				best, bestDistance = i, d
+			}
+		}
+
+		picked = append(picked, free[best])
+		free = append(free[:best], free[best+1:]...)
+	}
+	return picked, true
+}
+
+func distanceToSeats(number int, seats []int) int {
+	closest := -1
+	for _, seat := range seats {
+		if d := absInt(number - seat); closest < 0 || d < closest {
+			closest = d
+		}
+	}
+	return closest
+}
+
+// guestSeats returns the seats of the guest in companion order.
+func guestSeats(seats []repository.Seat, name string) []repository.Seat {
+	var out []repository.Seat
+	for _, seat := range seats {
+		if seat.Guest == name {
+			out = append(out, seat)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Companion < out[j].Companion
+	})
+	return out
+}
+
+func sameSeats(a, b []repository.Seat) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func replaceGuestSeats(ctx context.Context, repo repository.Repository, name string, seats []repository.Seat) error {
+	if err := repo.DeleteGuestSeats(ctx, name); err != nil {
+		return fmt.Errorf("could not delete guest seats: %w", err)
+	}
+
+	if err := repo.CreateSeats(ctx, seats); err != nil {
+		return fmt.Errorf("could not create seats: %w", err)
+	}
+	return nil
+}
+
+// getTable returns the table of the number, or ErrTableNumberNotFound.
+func getTable(ctx context.Context, repo repository.Repository, number int) (*repository.Table, error) {
+	table, err := repo.GetTableByNumber(ctx, number)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return nil, fmt.Errorf("could not get table by number: %w", err)
+	}
+
+	// Missing tables are found with a zero number
+	if table == nil || table.Number == 0 {
+		return nil, ErrTableNumberNotFound
+	}
+	return table, nil
+}
+
+// getTableWithSeats returns the table of the number as listed by ListTables.
+func (p *Party) getTableWithSeats(ctx context.Context, number int) (*Table, error) {
+	tables, err := p.ListTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables.Tables {
+		if table.Number == number {
+			return &table, nil
+		}
+	}
+	return nil, ErrTableNumberNotFound
+}
+
+// seatMap returns every seat of a table of the size, with the guests sitting there.
+func seatMap(size int, seats []repository.Seat) []Seat {
+	out := make([]Seat, size)
+	for i := range out {
+		out[i].Number = i + 1
+	}
+
+	for _, seat := range seats {
+		// Seats beyond the size of the table are not drawn
+		if seat.Number < 1 || seat.Number > size {
+			continue
+		}
+
+		out[seat.Number-1].Guest = seat.Guest
+		out[seat.Number-1].Companion = seat.Companion
+	}
+	return out
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
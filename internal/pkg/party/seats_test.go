@@ -0,0 +1,296 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// seatsTestRepo seats john and his two accompanying guests on seats 1 to 3 of table 1, and jane on seat 5.
+// The seats written replace the seats of their guest.
+func seatsTestRepo() (*repository.Mock, *[]repository.Seat) {
+	seats := []repository.Seat{
+		{Table: 1, Number: 1, Guest: "john"},
+		{Table: 1, Number: 2, Guest: "john", Companion: 1},
+		{Table: 1, Number: 3, Guest: "john", Companion: 2},
+		{Table: 1, Number: 5, Guest: "jane"},
+	}
+
+	repo := repository.Mock{}
+	repo.TransactionFunc = func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(&repo)
+	}
+	repo.GetGuestByNameFunc = func(ctx context.Context, name string) (*repository.Guest, error) {
+		switch name {
+		case "john":
+			return &repository.Guest{Name: name, Table: 1, AccompanyingGuests: 2}, nil
+		case "jane", "joe":
+			return &repository.Guest{Name: name, Table: 1}, nil
+		}
+		return &repository.Guest{}, nil
+	}
+	repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+		if number != 1 {
+			return &repository.Table{}, nil
+		}
+		return &repository.Table{Number: 1, Size: 6, AvailableSeats: 2}, nil
+	}
+	repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+		return []repository.Table{{Number: 1, Size: 6, AvailableSeats: 2}}, nil
+	}
+	repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+		return nil, nil
+	}
+	repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+		return []repository.Guest{{Name: "john", Table: 1, AccompanyingGuests: 2}, {Name: "jane", Table: 1}}, nil
+	}
+	repo.GetSeatsByTableFunc = func(ctx context.Context, table int) ([]repository.Seat, error) {
+		return append([]repository.Seat{}, seats...), nil
+	}
+	repo.GetSeatsFunc = func(ctx context.Context) ([]repository.Seat, error) {
+		return append([]repository.Seat{}, seats...), nil
+	}
+	repo.DeleteGuestSeatsFunc = func(ctx context.Context, name string) error {
+		kept := seats[:0]
+		for _, seat := range seats {
+			if seat.Guest != name {
+				kept = append(kept, seat)
+			}
+		}
+		seats = kept
+		return nil
+	}
+	repo.CreateSeatsFunc = func(ctx context.Context, created []repository.Seat) error {
+		seats = append(seats, created...)
+		sort.Slice(seats, func(i, j int) bool {
+			return seats[i].Number < seats[j].Number
+		})
+		return nil
+	}
+	repo.GetCheckInCodesByGuestNameFunc = func(ctx context.Context, name string) ([]repository.CheckInCode, error) {
+		return nil, nil
+	}
+	repo.UpsertCheckInCodeFunc = func(ctx context.Context, code *repository.CheckInCode) error { return nil }
+	return &repo, &seats
+}
+
+func TestAssignSeats(t *testing.T) {
+	cases := []struct {
+		name          string
+		given         AssignSeatsInput
+		expectedSeats []repository.Seat
+		expectedErr   error
+	}{
+		{
+			name:  "moves the party",
+			given: AssignSeatsInput{Name: "john", Seats: []int{4, 6, 3}},
+			expectedSeats: []repository.Seat{
+				{Table: 1, Number: 3, Guest: "john", Companion: 2},
+				{Table: 1, Number: 4, Guest: "john"},
+				{Table: 1, Number: 5, Guest: "jane"},
+				{Table: 1, Number: 6, Guest: "john", Companion: 1},
+			},
+		},
+		{
+			name:  "seats a guest without seats",
+			given: AssignSeatsInput{Name: "joe", Seats: []int{4}},
+			expectedSeats: []repository.Seat{
+				{Table: 1, Number: 1, Guest: "john"},
+				{Table: 1, Number: 2, Guest: "john", Companion: 1},
+				{Table: 1, Number: 3, Guest: "john", Companion: 2},
+				{Table: 1, Number: 4, Guest: "joe"},
+				{Table: 1, Number: 5, Guest: "jane"},
+			},
+		},
+		{
+			name:        "guest name required",
+			given:       AssignSeatsInput{Seats: []int{4}},
+			expectedErr: ErrGuestNameRequired,
+		},
+		{
+			name:        "guest not in list",
+			given:       AssignSeatsInput{Name: "mallory", Seats: []int{4}},
+			expectedErr: ErrGuestNotInList,
+		},
+		{
+			name:        "seat missing for an accompanying guest",
+			given:       AssignSeatsInput{Name: "john", Seats: []int{4, 6}},
+			expectedErr: ErrSeatsCountInvalid,
+		},
+		{
+			name:        "seat taken",
+			given:       AssignSeatsInput{Name: "john", Seats: []int{4, 5, 6}},
+			expectedErr: ErrSeatTaken,
+		},
+		{
+			name:        "seat beyond the table size",
+			given:       AssignSeatsInput{Name: "jane", Seats: []int{7}},
+			expectedErr: ErrSeatNumberInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, seats := seatsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			observed, err := party.AssignSeats(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSeats, *seats)
+			assert.Equal(t, 1, observed.Number)
+			assert.Len(t, observed.Seats, 6)
+		})
+	}
+}
+
+func TestSwapSeats(t *testing.T) {
+	cases := []struct {
+		name          string
+		given         SwapSeatsInput
+		expectedSeats []repository.Seat
+		expectedErr   error
+	}{
+		{
+			name:  "swaps two guests",
+			given: SwapSeatsInput{Table: 1, First: 5, Second: 2},
+			expectedSeats: []repository.Seat{
+				{Table: 1, Number: 1, Guest: "john"},
+				{Table: 1, Number: 2, Guest: "jane"},
+				{Table: 1, Number: 3, Guest: "john", Companion: 2},
+				{Table: 1, Number: 5, Guest: "john", Companion: 1},
+			},
+		},
+		{
+			name:  "swaps a guest with their accompanying guest",
+			given: SwapSeatsInput{Table: 1, First: 1, Second: 3},
+			expectedSeats: []repository.Seat{
+				{Table: 1, Number: 1, Guest: "john", Companion: 2},
+				{Table: 1, Number: 2, Guest: "john", Companion: 1},
+				{Table: 1, Number: 3, Guest: "john"},
+				{Table: 1, Number: 5, Guest: "jane"},
+			},
+		},
+		{
+			name:  "moves a guest to a free seat",
+			given: SwapSeatsInput{Table: 1, First: 5, Second: 6},
+			expectedSeats: []repository.Seat{
+				{Table: 1, Number: 1, Guest: "john"},
+				{Table: 1, Number: 2, Guest: "john", Companion: 1},
+				{Table: 1, Number: 3, Guest: "john", Companion: 2},
+				{Table: 1, Number: 6, Guest: "jane"},
+			},
+		},
+		{
+			name:        "same seat",
+			given:       SwapSeatsInput{Table: 1, First: 2, Second: 2},
+			expectedErr: ErrSeatNumberInvalid,
+		},
+		{
+			name:        "seat beyond the table size",
+			given:       SwapSeatsInput{Table: 1, First: 2, Second: 7},
+			expectedErr: ErrSeatNumberInvalid,
+		},
+		{
+			name:        "table not found",
+			given:       SwapSeatsInput{Table: 2, First: 1, Second: 2},
+			expectedErr: ErrTableNumberNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			repo, seats := seatsTestRepo()
+			party := New(zap.NewNop(), repo, testTableSize)
+
+			observed, err := party.SwapSeats(context.TODO(), &tc.given)
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr), err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSeats, *seats)
+			assert.Equal(t, 1, observed.Number)
+		})
+	}
+}
+
+func TestPickSeats(t *testing.T) {
+	cases := []struct {
+		name          string
+		givenSize     int
+		givenTaken    []int
+		givenCurrent  []int
+		givenCount    int
+		expectedSeats []int
+		expectedOK    bool
+	}{
+		{
+			name:          "first seats next to each other",
+			givenSize:     8,
+			givenTaken:    []int{2, 5},
+			givenCount:    2,
+			expectedSeats: []int{3, 4},
+			expectedOK:    true,
+		},
+		{
+			name:          "first free seats when none are next to each other",
+			givenSize:     6,
+			givenTaken:    []int{2, 4, 6},
+			givenCount:    2,
+			expectedSeats: []int{1, 3},
+			expectedOK:    true,
+		},
+		{
+			name:          "keeps the current seats",
+			givenSize:     6,
+			givenCurrent:  []int{4, 5},
+			givenCount:    2,
+			expectedSeats: []int{4, 5},
+			expectedOK:    true,
+		},
+		{
+			name:          "adds the free seats closest to the current seats",
+			givenSize:     8,
+			givenTaken:    []int{3},
+			givenCurrent:  []int{4},
+			givenCount:    3,
+			expectedSeats: []int{4, 5, 6},
+			expectedOK:    true,
+		},
+		{
+			name:       "not enough free seats",
+			givenSize:  3,
+			givenTaken: []int{1, 2},
+			givenCount: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			taken := make(map[int]bool, len(tc.givenTaken))
+			for _, number := range tc.givenTaken {
+				taken[number] = true
+			}
+
+			observed, ok := pickSeats(tc.givenSize, taken, tc.givenCurrent, tc.givenCount)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedSeats, observed)
+		})
+	}
+}
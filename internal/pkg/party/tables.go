@@ -0,0 +1,185 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/alesr/getground/pkg/database"
+)
+
+// CreateTable creates an empty table of the input size.
+// Tables created when adding guests to the guest list have the default table size.
+func (p *Party) CreateTable(ctx context.Context, in *CreateTableInput) (*Table, error) {
+	ctx, span := tracer.Start(ctx, "party.CreateTable")
+	defer span.End()
+
+	if err := in.validate(); err != nil {
+		return nil, fmt.Errorf("could not validate input for creating table: %w", err)
+	}
+
+	table, err := p.repo.GetTableByNumber(ctx, in.Number)
+	if err != nil && !errors.Is(err, database.ErrRecordNotFound) {
+		return nil, fmt.Errorf("could not get table by number: %w", err)
+	}
+
+	if table != nil {
+		return nil, ErrTableAlreadyExists
+	}
+
+	tableStore := repository.Table{
+		Number:         in.Number,
+		AvailableSeats: in.Size,
+		Size:           in.Size,
+	}
+
+	if err := p.repo.UpsertTable(ctx, &tableStore); err != nil {
+		return nil, fmt.Errorf("could not upsert table: %w", err)
+	}
+
+	p.emit(ctx, Event{
+		Type:           EventTableCreated,
+		Table:          in.Number,
+		AvailableSeats: in.Size,
+	})
+
+	return &Table{
+		Number:     in.Number,
+		Size:       in.Size,
+		EmptySeats: in.Size,
+		Seats:      seatMap(in.Size, nil),
+	}, nil
+}
+
+// ListTables returns the booked, arrived and empty seats of every table, its reservation, and who sits on each seat.
+func (p *Party) ListTables(ctx context.Context) (ListTablesOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.ListTables")
+	defer span.End()
+
+	tables, guests, err := p.getTablesAndGuests(ctx)
+	if err != nil {
+		return ListTablesOutput{}, err
+	}
+
+	seats, err := p.repo.GetSeats(ctx)
+	if err != nil {
+		return ListTablesOutput{}, fmt.Errorf("could not get seats: %w", err)
+	}
+
+	reservations, err := getTableReservations(ctx, p.repo)
+	if err != nil {
+		return ListTablesOutput{}, err
+	}
+
+	seatsByTable := make(map[int][]repository.Seat, len(tables))
+	for _, seat := range seats {
+		seatsByTable[seat.Table] = append(seatsByTable[seat.Table], seat)
+	}
+
+	byNumber := make(map[int]*Table, len(tables))
+	for _, table := range tables {
+		byNumber[table.Number] = &Table{
+			Number:     table.Number,
+			Size:       table.Size,
+			EmptySeats: table.AvailableSeats,
+			Tiers:      reservationTiers(reservations[table.Number]),
+			HeldSeats:  reservations[table.Number].HeldSeats,
+			Seats:      seatMap(table.Size, seatsByTable[table.Number]),
+		}
+	}
+
+	for _, guest := range guests {
+		// Guests of a missing table have no seats to count against
+		table, ok := byNumber[guest.Table]
+		if !ok {
+			continue
+		}
+
+		table.BookedSeats += guest.AccompanyingGuests + 1
+		if guest.TimeArrival != nil {
+			table.ArrivedSeats += arrivedSeats(guest)
+		}
+	}
+
+	out := ListTablesOutput{Tables: make([]Table, 0, len(byNumber))}
+	for _, table := range byNumber {
+		out.Tables = append(out.Tables, *table)
+	}
+
+	sort.Slice(out.Tables, func(i, j int) bool {
+		return out.Tables[i].Number < out.Tables[j].Number
+	})
+	return out, nil
+}
+
+// GetStats returns the party totals of seats and guests.
+func (p *Party) GetStats(ctx context.Context) (GetStatsOutput, error) {
+	ctx, span := tracer.Start(ctx, "party.GetStats")
+	defer span.End()
+
+	tables, guests, err := p.getTablesAndGuests(ctx)
+	if err != nil {
+		return GetStatsOutput{}, err
+	}
+
+	out := GetStatsOutput{Tables: len(tables)}
+
+	for _, table := range tables {
+		out.Seats += table.Size
+		out.EmptySeats += table.AvailableSeats
+	}
+
+	for _, guest := range guests {
+		seats := guest.AccompanyingGuests + 1
+
+		out.GuestsBooked++
+		out.BookedSeats += seats
+
+		if guest.TimeArrival != nil {
+			out.GuestsArrived++
+			out.ArrivedSeats += arrivedSeats(guest)
+		}
+
+		if guest.TimeDeparture != nil {
+			out.GuestsLeft++
+		}
+
+		if IsPresent(guest) {
+			out.GuestsPresent++
+		}
+	}
+	return out, nil
+}
+
+func (p *Party) getTablesAndGuests(ctx context.Context) ([]repository.Table, []repository.Guest, error) {
+	tables, err := p.repo.GetTables(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get tables: %w", err)
+	}
+
+	guests, err := p.repo.ListGuests(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get guest list: %w", err)
+	}
+	return tables, guests, nil
+}
+
+// arrivedSeats returns the seats of the guest and the accompanying guests who came with them on their last arrival.
+func arrivedSeats(guest repository.Guest) int {
+	return guest.ArrivedAccompanyingGuests + 1
+}
+
+// IsPresent reports whether the guest arrived and did not leave since.
+func IsPresent(guest repository.Guest) bool {
+	if guest.TimeArrival == nil {
+		return false
+	}
+	return guest.TimeDeparture == nil || guest.TimeDeparture.Before(*guest.TimeArrival)
+}
+
+// Present reports whether the guest arrived and did not leave since.
+func (g Guest) Present() bool {
+	return IsPresent(repository.Guest{TimeArrival: g.TimeArrival, TimeDeparture: g.TimeDeparture})
+}
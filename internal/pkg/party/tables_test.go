@@ -0,0 +1,227 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/pkg/party/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func tablesTestRepo() *repository.Mock {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+	left := arrived.Add(3 * time.Hour)
+	earlier := arrived.Add(-time.Hour)
+
+	repo := repository.Mock{}
+	repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+		return []repository.Table{
+			{Number: 2, Size: 10, AvailableSeats: 10},
+			{Number: 1, Size: 10, AvailableSeats: 3},
+		}, nil
+	}
+	repo.GetTableReservationsFunc = func(ctx context.Context) ([]repository.TableReservation, error) {
+		return nil, nil
+	}
+	repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+		return []repository.Guest{
+			{Name: "zoe", Table: 1, AccompanyingGuests: 2, ArrivedAccompanyingGuests: 1, TimeArrival: &arrived},
+			{Name: "adam", Table: 1, AccompanyingGuests: 1, ArrivedAccompanyingGuests: 1, TimeArrival: &arrived, TimeDeparture: &left},
+			{Name: "eve", Table: 1},
+			// Left before arriving again
+			{Name: "bob", Table: 3, TimeArrival: &arrived, TimeDeparture: &earlier},
+		}, nil
+	}
+	repo.GetSeatsFunc = func(ctx context.Context) ([]repository.Seat, error) {
+		return []repository.Seat{
+			{Table: 1, Number: 1, Guest: "zoe"},
+			{Table: 1, Number: 2, Guest: "zoe", Companion: 1},
+			{Table: 1, Number: 3, Guest: "zoe", Companion: 2},
+			{Table: 1, Number: 5, Guest: "eve"},
+			{Table: 3, Number: 1, Guest: "bob"},
+		}, nil
+	}
+	return &repo
+}
+
+// freeSeats returns the free seats numbered from first to last.
+func freeSeats(first, last int) []Seat {
+	var seats []Seat
+	for number := first; number <= last; number++ {
+		seats = append(seats, Seat{Number: number})
+	}
+	return seats
+}
+
+func TestListTables(t *testing.T) {
+	t.Run("returns an error when get tables fails", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		_, err := party.ListTables(context.TODO())
+
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("returns an error when list guests fails", func(t *testing.T) {
+		repo := tablesTestRepo()
+		repo.ListGuestsFunc = func(ctx context.Context) ([]repository.Guest, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+		_, err := party.ListTables(context.TODO())
+
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("counts the seats of each table in number order", func(t *testing.T) {
+		party := New(zap.NewNop(), tablesTestRepo(), testTableSize)
+
+		observed, err := party.ListTables(context.TODO())
+		require.NoError(t, err)
+
+		table1Seats := append([]Seat{
+			{Number: 1, Guest: "zoe"},
+			{Number: 2, Guest: "zoe", Companion: 1},
+			{Number: 3, Guest: "zoe", Companion: 2},
+			{Number: 4},
+			{Number: 5, Guest: "eve"},
+		}, freeSeats(6, 10)...)
+
+		assert.Equal(t, ListTablesOutput{
+			Tables: []Table{
+				{Number: 1, Size: 10, BookedSeats: 6, ArrivedSeats: 4, EmptySeats: 3, Seats: table1Seats},
+				{Number: 2, Size: 10, EmptySeats: 10, Seats: freeSeats(1, 10)},
+			},
+		}, observed)
+	})
+
+	t.Run("returns an error when get seats fails", func(t *testing.T) {
+		repo := tablesTestRepo()
+		repo.GetSeatsFunc = func(ctx context.Context) ([]repository.Seat, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), repo, testTableSize)
+		_, err := party.ListTables(context.TODO())
+
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	t.Run("returns an error when get tables fails", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetTablesFunc = func(ctx context.Context) ([]repository.Table, error) {
+			return nil, errTestRepo
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		_, err := party.GetStats(context.TODO())
+
+		assert.True(t, errors.Is(err, errTestRepo))
+	})
+
+	t.Run("sums the seats and guests", func(t *testing.T) {
+		party := New(zap.NewNop(), tablesTestRepo(), testTableSize)
+
+		observed, err := party.GetStats(context.TODO())
+		require.NoError(t, err)
+
+		assert.Equal(t, GetStatsOutput{
+			Tables:        2,
+			Seats:         20,
+			BookedSeats:   7,
+			ArrivedSeats:  5,
+			EmptySeats:    13,
+			GuestsBooked:  4,
+			GuestsArrived: 3,
+			GuestsPresent: 2,
+			GuestsLeft:    2,
+		}, observed)
+	})
+}
+
+func TestCreateTable(t *testing.T) {
+	cases := []struct {
+		name        string
+		given       CreateTableInput
+		expectedErr error
+	}{
+		{
+			name:        "table number required",
+			given:       CreateTableInput{Size: 10},
+			expectedErr: ErrTableNumberRequired,
+		},
+		{
+			name:        "table number invalid",
+			given:       CreateTableInput{Number: -1, Size: 10},
+			expectedErr: ErrTableNumberInvalid,
+		},
+		{
+			name:        "table size invalid",
+			given:       CreateTableInput{Number: 1},
+			expectedErr: ErrTableSizeInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			party := New(zap.NewNop(), &repository.Mock{}, testTableSize)
+
+			_, err := party.CreateTable(context.TODO(), &tc.given)
+			assert.True(t, errors.Is(err, tc.expectedErr))
+		})
+	}
+
+	t.Run("returns an error when the table exists", func(t *testing.T) {
+		repo := repository.Mock{}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return &repository.Table{Number: number, Size: 10}, nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize)
+		_, err := party.CreateTable(context.TODO(), &CreateTableInput{Number: 1, Size: 4})
+
+		assert.True(t, errors.Is(err, ErrTableAlreadyExists))
+	})
+
+	t.Run("creates an empty table of the input size", func(t *testing.T) {
+		var (
+			upserted *repository.Table
+			events   []Event
+		)
+
+		repo := repository.Mock{}
+		repo.GetTableByNumberFunc = func(ctx context.Context, number int) (*repository.Table, error) {
+			return nil, nil
+		}
+		repo.UpsertTableFunc = func(ctx context.Context, table *repository.Table) error {
+			upserted = table
+			return nil
+		}
+
+		party := New(zap.NewNop(), &repo, testTableSize, WithListener(func(e Event) {
+			events = append(events, e)
+		}))
+
+		observed, err := party.CreateTable(context.TODO(), &CreateTableInput{Number: 7, Size: 4})
+		require.NoError(t, err)
+
+		assert.Equal(t, &Table{Number: 7, Size: 4, EmptySeats: 4, Seats: freeSeats(1, 4)}, observed)
+		assert.Equal(t, &repository.Table{Number: 7, Size: 4, AvailableSeats: 4}, upserted)
+
+		require.Len(t, events, 1)
+		assert.Equal(t, EventTableCreated, events[0].Type)
+		assert.Equal(t, 4, events[0].AvailableSeats)
+	})
+}
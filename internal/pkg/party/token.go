@@ -0,0 +1,72 @@
+package party
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// invitationClaims defines the payload carried by a signed invitation token.
+type invitationClaims struct {
+	ID           string `json:"id"`
+	GuestName    string `json:"guest"`
+	Table        int    `json:"table"`
+	MaxPartySize int    `json:"max"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// signInvitationToken encodes the claims and appends an HMAC-SHA256 signature.
+// The resulting token has the form base64url(claims).base64url(signature).
+func signInvitationToken(secret []byte, claims *invitationClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal invitation claims: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(tokenSignature(secret, encodedPayload))
+
+	return encodedPayload + "." + signature, nil
+}
+
+// parseInvitationToken verifies the token signature and expiry and returns its claims.
+func parseInvitationToken(secret []byte, token string, now time.Time) (*invitationClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvitationTokenInvalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvitationTokenInvalid
+	}
+
+	if !hmac.Equal(signature, tokenSignature(secret, parts[0])) {
+		return nil, ErrInvitationTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvitationTokenInvalid
+	}
+
+	var claims invitationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvitationTokenInvalid
+	}
+
+	if !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, ErrInvitationExpired
+	}
+	return &claims, nil
+}
+
+func tokenSignature(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,5 @@
+package party
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("github.com/alesr/getground/internal/pkg/party")
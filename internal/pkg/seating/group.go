@@ -0,0 +1,172 @@
+package seating
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PlaceGroup seats the parties of a group who come together, at a single table when one fits them all,
+// or else at the fewest tables nearest to each other. Table sizes are their free seats, parties only sit at
+// the tables allowing their tier, and distance tells how far two tables of the given numbers stand from each other.
+//
+// A single table is the one left with the fewest free seats, the first given on a tie. Otherwise tables are
+// taken around each table by distance, and the fewest tables with the shortest distance to their first win.
+// The assignments follow the order of the parties. It returns false when the tables cannot seat the group.
+func PlaceGroup(parties []Party, tables []Table, distance func(a, b int) int) ([]Assignment, bool) {
+	if len(parties) == 0 {
+		return nil, true
+	}
+
+	total := 0
+	for _, party := range parties {
+		total += party.Size
+	}
+
+	best := -1
+	for i, table := range tables {
+		if table.Size >= total && allowsAll(table, parties) && (best < 0 || table.Size < tables[best].Size) {
+			best = i
+		}
+	}
+
+	if best >= 0 {
+		assignments := make([]Assignment, 0, len(parties))
+		for _, party := range parties {
+			assignments = append(assignments, Assignment{Guest: party.Name, Table: tables[best].Number})
+		}
+		return assignments, true
+	}
+
+	var (
+		placed   []int
+		count    int
+		spread   int
+		steps    int
+		searched bool
+	)
+
+	for _, first := range tables {
+		if first.Size == 0 {
+			continue
+		}
+
+		around := tablesAround(first, tables, distance)
+
+		// More tables than the best found so far cannot win
+		for n := 1; n <= len(around) && (!searched || n <= count); n++ {
+			d := 0
+			for _, table := range around[:n] {
+				d += distance(first.Number, table.Number)
+			}
+
+			if searched && n == count && d >= spread {
+				continue
+			}
+
+			if seated, ok := pack(parties, around[:n], &steps); ok {
+				placed, count, spread, searched = seated, n, d, true
+				break
+			}
+		}
+	}
+
+	if !searched {
+		return nil, false
+	}
+
+	assignments := make([]Assignment, 0, len(parties))
+	for i, party := range parties {
+		assignments = append(assignments, Assignment{Guest: party.Name, Table: placed[i]})
+	}
+	return assignments, true
+}
+
+// allowsAll reports whether every party may sit at the table.
+func allowsAll(table Table, parties []Party) bool {
+	for _, party := range parties {
+		if !table.Allows(party) {
+			return false
+		}
+	}
+	return true
+}
+
+// tablesAround returns the tables ordered by their distance to the first, the first included.
+func tablesAround(first Table, tables []Table, distance func(a, b int) int) []Table {
+	around := make([]Table, 0, len(tables))
+	for _, table := range tables {
+		if table.Size > 0 || table.Number == first.Number {
+			around = append(around, table)
+		}
+	}
+
+	sort.SliceStable(around, func(i, j int) bool {
+		if around[i].Number == first.Number || around[j].Number == first.Number {
+			return around[i].Number == first.Number && around[j].Number != first.Number
+		}
+		return distance(first.Number, around[i].Number) < distance(first.Number, around[j].Number)
+	})
+	return around
+}
+
+// pack seats the parties at the tables, each party at a single table, and returns the table of each party.
+// Larger parties are seated first, the search gives up after maxSteps tables tried overall.
+func pack(parties []Party, tables []Table, steps *int) ([]int, bool) {
+	order := make([]int, len(parties))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return parties[order[i]].Size > parties[order[j]].Size
+	})
+
+	free := make([]int, len(tables))
+	for i, table := range tables {
+		free[i] = table.Size
+	}
+
+	seated := make([]int, len(parties))
+
+	var place func(k int) bool
+	place = func(k int) bool {
+		if k == len(order) {
+			return true
+		}
+
+		party := parties[order[k]]
+		tried := make(map[string]bool)
+
+		for i, table := range tables {
+			if free[i] < party.Size || !table.Allows(party) {
+				continue
+			}
+
+			// Tables with the same free seats and tiers are tried once
+			kind := fmt.Sprint(free[i], table.Tiers)
+			if tried[kind] {
+				continue
+			}
+			tried[kind] = true
+
+			*steps++
+			if *steps > maxSteps {
+				return false
+			}
+
+			free[i] -= party.Size
+			seated[order[k]] = table.Number
+
+			if place(k + 1) {
+				return true
+			}
+			free[i] += party.Size
+		}
+		return false
+	}
+
+	if !place(0) {
+		return nil, false
+	}
+	return seated, true
+}
@@ -0,0 +1,138 @@
+package seating
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceGroup(t *testing.T) {
+	byNumber := func(a, b int) int {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+
+	cases := []struct {
+		name         string
+		givenParties []Party
+		givenTables  []Table
+		expected     []Assignment
+		expectedOK   bool
+	}{
+		{
+			name:         "seats the group at the table left with the fewest free seats",
+			givenParties: []Party{{Name: "amy", Size: 2}, {Name: "bob", Size: 1}},
+			givenTables:  []Table{{Number: 1, Size: 8}, {Number: 2, Size: 3}, {Number: 3, Size: 4}},
+			expected:     []Assignment{{Guest: "amy", Table: 2}, {Guest: "bob", Table: 2}},
+			expectedOK:   true,
+		},
+		{
+			name:         "splits the group over adjacent tables",
+			givenParties: []Party{{Name: "amy", Size: 3}, {Name: "bob", Size: 2}, {Name: "sue", Size: 2}},
+			givenTables:  []Table{{Number: 1, Size: 4}, {Number: 2, Size: 1}, {Number: 3, Size: 3}, {Number: 4, Size: 4}},
+			expected:     []Assignment{{Guest: "amy", Table: 3}, {Guest: "bob", Table: 4}, {Guest: "sue", Table: 4}},
+			expectedOK:   true,
+		},
+		{
+			name:         "prefers fewer tables to nearer tables",
+			givenParties: []Party{{Name: "amy", Size: 3}, {Name: "bob", Size: 3}},
+			givenTables:  []Table{{Number: 1, Size: 3}, {Number: 2, Size: 2}, {Number: 3, Size: 2}, {Number: 9, Size: 3}},
+			expected:     []Assignment{{Guest: "amy", Table: 1}, {Guest: "bob", Table: 9}},
+			expectedOK:   true,
+		},
+		{
+			name:         "does not split a party",
+			givenParties: []Party{{Name: "amy", Size: 3}},
+			givenTables:  []Table{{Number: 1, Size: 2}, {Number: 2, Size: 2}},
+		},
+		{
+			name:         "seats the group at the tables allowing their tiers",
+			givenParties: []Party{{Name: "amy", Size: 2, Tier: "vip"}, {Name: "bob", Size: 2, Tier: "general"}},
+			givenTables:  []Table{{Number: 1, Size: 4, Tiers: []string{"vip"}}, {Number: 2, Size: 2}, {Number: 3, Size: 4, Tiers: []string{"staff"}}},
+			expected:     []Assignment{{Guest: "amy", Table: 1}, {Guest: "bob", Table: 2}},
+			expectedOK:   true,
+		},
+		{
+			name:         "does not seat a party at a table restricted to other tiers",
+			givenParties: []Party{{Name: "amy", Size: 1, Tier: "general"}},
+			givenTables:  []Table{{Number: 1, Size: 4, Tiers: []string{"vip"}}},
+		},
+		{
+			name:        "places an empty group",
+			givenTables: []Table{{Number: 1, Size: 2}},
+			expectedOK:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			observed, ok := PlaceGroup(tc.givenParties, tc.givenTables, byNumber)
+			require.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expected, observed)
+		})
+	}
+}
+
+func TestPlaceGroupGenerated(t *testing.T) {
+	byNumber := func(a, b int) int {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+
+	for seed := int64(1); seed <= 300; seed++ {
+		r := rand.New(rand.NewSource(seed))
+
+		var tables []Table
+		for number := 1; number <= 1+r.Intn(10); number++ {
+			tables = append(tables, Table{Number: number, Size: r.Intn(9)})
+		}
+
+		var parties []Party
+		for i := 0; i < 1+r.Intn(6); i++ {
+			parties = append(parties, Party{Name: fmt.Sprintf("guest-%d", i), Size: 1 + r.Intn(4)})
+		}
+
+		assignments, ok := PlaceGroup(parties, tables, byNumber)
+		if !ok {
+			continue
+		}
+
+		require.Len(t, assignments, len(parties), "seed %d", seed)
+
+		free := make(map[int]int)
+		for _, table := range tables {
+			free[table.Number] = table.Size
+		}
+
+		used := make(map[int]bool)
+		for i, assignment := range assignments {
+			require.Equal(t, parties[i].Name, assignment.Guest, "seed %d", seed)
+			free[assignment.Table] -= parties[i].Size
+			used[assignment.Table] = true
+		}
+
+		for number, seats := range free {
+			assert.GreaterOrEqual(t, seats, 0, "seed %d: table %d", seed, number)
+		}
+
+		// A group fitting a table is never split
+		total := 0
+		for _, party := range parties {
+			total += party.Size
+		}
+
+		for _, table := range tables {
+			if table.Size >= total {
+				assert.Len(t, used, 1, "seed %d", seed)
+			}
+		}
+	}
+}
@@ -0,0 +1,552 @@
+// Package seating plans which table each party of the guest list sits at.
+//
+// A plan seats every party at a single table within its size, and satisfies the constraints
+// keeping parties together at a table, apart at different tables, or pinned to a table.
+// The package has no dependency on the party storage, problems are built by its callers.
+package seating
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Enumerate constraint kinds
+const (
+	KindTogether = "together"
+	KindApart    = "apart"
+	KindPinned   = "pinned"
+)
+
+// maxSteps bounds the tables tried by a search. Problems not solved within the bound are reported unsatisfiable.
+const maxSteps = 50000
+
+type (
+	// Party is a guest with their accompanying guests, seated together at a table.
+	// Table is the table the party sits at now, 0 when they have none. Tier is the tier of the guest.
+	Party struct {
+		Name  string
+		Size  int
+		Table int
+		Tier  string
+	}
+
+	// Table is a table of the venue and its number of seats.
+	// Tiers restricts the table to the parties of those tiers, every party may sit at a table without tiers.
+	Table struct {
+		Number int
+		Size   int
+		Tiers  []string
+	}
+
+	// Constraint keeps the parties of the guests at one table, together, at different tables, apart,
+	// or at the table of the constraint, pinned.
+	Constraint struct {
+		ID     string
+		Kind   string
+		Guests []string
+		Table  int
+	}
+
+	// Problem is the guest list, the tables and the constraints of a plan.
+	Problem struct {
+		Parties     []Party
+		Tables      []Table
+		Constraints []Constraint
+	}
+
+	// Assignment seats the party of a guest at a table.
+	Assignment struct {
+		Guest string
+		Table int
+	}
+
+	// Violation explains why a plan does not hold.
+	// Constraint is the ID of the constraint not satisfied, empty when the plan breaks the table sizes.
+	Violation struct {
+		Constraint string
+		Reason     string
+	}
+
+	// Plan is the solution of a problem, with the assignments sorted by guest name.
+	// Unseated parties do not fit the tables, Unsatisfied constraints could not be met along with the others.
+	Plan struct {
+		Assignments []Assignment
+		Unseated    []string
+		Unsatisfied []Violation
+	}
+)
+
+// Allows reports whether the party may sit at the table.
+func (t Table) Allows(party Party) bool {
+	if len(t.Tiers) == 0 {
+		return true
+	}
+
+	for _, tier := range t.Tiers {
+		if tier == party.Tier {
+			return true
+		}
+	}
+	return false
+}
+
+// Solve plans the tables of the parties. Parties keep their table when the constraints allow it.
+//
+// When every constraint cannot be met, the constraints are added in order as long as the plan stays
+// feasible, and those left out are reported. Parties are seated in order as long as they fit the tables,
+// the rest are reported unseated.
+func Solve(p *Problem) *Plan {
+	s := newSolver(p)
+	plan := Plan{}
+
+	// Seat every party when they fit, otherwise first come first served.
+	// The constraints then only decide between plans seating the same parties.
+	parties := make([]int, 0, len(p.Parties))
+	for i := range p.Parties {
+		parties = append(parties, i)
+	}
+
+	fallback, ok := s.search(parties, nil)
+	if !ok {
+		parties = parties[:0]
+		fallback = make(map[int]int, len(p.Parties))
+
+		free := make(map[int]int, len(p.Tables))
+		for _, table := range p.Tables {
+			free[table.Number] = table.Size
+		}
+
+		for i, party := range p.Parties {
+			if number, ok := s.firstFit(party, free); ok {
+				free[number] -= party.Size
+				parties = append(parties, i)
+				fallback[i] = number
+				continue
+			}
+			plan.Unseated = append(plan.Unseated, party.Name)
+		}
+	}
+
+	var constraints []Constraint
+	for _, constraint := range p.Constraints {
+		if reason := s.check(constraint); reason != "" {
+			plan.Unsatisfied = append(plan.Unsatisfied, Violation{Constraint: constraint.ID, Reason: reason})
+			continue
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	tables, ok := s.search(parties, constraints)
+	if !ok {
+		var kept []Constraint
+		for _, constraint := range constraints {
+			if found, ok := s.search(parties, append(kept, constraint)); ok {
+				kept, tables = append(kept, constraint), found
+				continue
+			}
+			plan.Unsatisfied = append(plan.Unsatisfied, Violation{
+				Constraint: constraint.ID,
+				Reason:     "conflicts with the table sizes or the constraints before it",
+			})
+		}
+
+		if len(kept) == 0 {
+			tables = fallback
+		}
+	}
+
+	for _, i := range parties {
+		plan.Assignments = append(plan.Assignments, Assignment{Guest: p.Parties[i].Name, Table: tables[i]})
+	}
+
+	sort.Slice(plan.Assignments, func(i, j int) bool {
+		return plan.Assignments[i].Guest < plan.Assignments[j].Guest
+	})
+	return &plan
+}
+
+// Validate returns the violations of the assignments: parties without a table, at unknown tables or at tables
+// restricted to other tiers, tables seating more guests than their size and constraints not met.
+func Validate(p *Problem, assignments []Assignment) []Violation {
+	s := newSolver(p)
+
+	tables := make(map[string]int, len(assignments))
+	for _, assignment := range assignments {
+		tables[assignment.Guest] = assignment.Table
+	}
+
+	var violations []Violation
+
+	booked := make(map[int]int, len(p.Tables))
+	for _, party := range p.Parties {
+		number, ok := tables[party.Name]
+		if !ok || number == 0 {
+			violations = append(violations, Violation{Reason: fmt.Sprintf("guest %q has no table", party.Name)})
+			continue
+		}
+
+		if _, ok := s.tables[number]; !ok {
+			violations = append(violations, Violation{Reason: fmt.Sprintf("guest %q sits at table %d that does not exist", party.Name, number)})
+			continue
+		}
+
+		if table := p.Tables[s.tables[number]]; !table.Allows(party) {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("guest %q of tier %q sits at table %d restricted to %s", party.Name, party.Tier, number, strings.Join(table.Tiers, ", ")),
+			})
+		}
+		booked[number] += party.Size
+	}
+
+	for _, assignment := range assignments {
+		if _, ok := s.parties[assignment.Guest]; !ok {
+			violations = append(violations, Violation{Reason: fmt.Sprintf("guest %q is not on the guest list", assignment.Guest)})
+		}
+	}
+
+	for _, table := range p.Tables {
+		if booked[table.Number] > table.Size {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("table %d seats %d guests for %d seats", table.Number, booked[table.Number], table.Size),
+			})
+		}
+	}
+
+	for _, constraint := range p.Constraints {
+		if reason := s.check(constraint); reason != "" {
+			violations = append(violations, Violation{Constraint: constraint.ID, Reason: reason})
+			continue
+		}
+
+		if reason := broken(constraint, tables); reason != "" {
+			violations = append(violations, Violation{Constraint: constraint.ID, Reason: reason})
+		}
+	}
+	return violations
+}
+
+// broken returns why the constraint is not met by the tables of the guests, or an empty string.
+func broken(constraint Constraint, tables map[string]int) string {
+	switch constraint.Kind {
+	case KindTogether:
+		for _, guest := range constraint.Guests[1:] {
+			if tables[guest] != tables[constraint.Guests[0]] {
+				return fmt.Sprintf("guests %q and %q sit at different tables", constraint.Guests[0], guest)
+			}
+		}
+
+	case KindApart:
+		seen := make(map[int]string, len(constraint.Guests))
+		for _, guest := range constraint.Guests {
+			if other, ok := seen[tables[guest]]; ok && tables[guest] != 0 {
+				return fmt.Sprintf("guests %q and %q sit at table %d", other, guest, tables[guest])
+			}
+			seen[tables[guest]] = guest
+		}
+
+	case KindPinned:
+		for _, guest := range constraint.Guests {
+			if tables[guest] != constraint.Table {
+				return fmt.Sprintf("guest %q does not sit at table %d", guest, constraint.Table)
+			}
+		}
+	}
+	return ""
+}
+
+// solver indexes the parties and tables of a problem.
+type solver struct {
+	problem *Problem
+	parties map[string]int
+	tables  map[int]int
+}
+
+func newSolver(p *Problem) *solver {
+	s := solver{
+		problem: p,
+		parties: make(map[string]int, len(p.Parties)),
+		tables:  make(map[int]int, len(p.Tables)),
+	}
+
+	for i, party := range p.Parties {
+		s.parties[party.Name] = i
+	}
+
+	for i, table := range p.Tables {
+		s.tables[table.Number] = i
+	}
+	return &s
+}
+
+// check returns why the constraint can never be met, or an empty string.
+func (s *solver) check(constraint Constraint) string {
+	switch constraint.Kind {
+	case KindTogether, KindApart, KindPinned:
+	default:
+		return fmt.Sprintf("unknown constraint kind %q", constraint.Kind)
+	}
+
+	if len(constraint.Guests) == 0 {
+		return "no guest"
+	}
+
+	for _, guest := range constraint.Guests {
+		if _, ok := s.parties[guest]; !ok {
+			return fmt.Sprintf("guest %q is not on the guest list", guest)
+		}
+	}
+
+	if constraint.Kind == KindPinned {
+		if _, ok := s.tables[constraint.Table]; !ok {
+			return fmt.Sprintf("table %d does not exist", constraint.Table)
+		}
+	}
+	return ""
+}
+
+// firstFit returns the table of the party if it has room, or the first table with room for the party.
+// Tables restricted to other tiers are skipped.
+func (s *solver) firstFit(party Party, free map[int]int) (int, bool) {
+	if t, ok := s.tables[party.Table]; ok && free[party.Table] >= party.Size && s.problem.Tables[t].Allows(party) {
+		return party.Table, true
+	}
+
+	for _, table := range s.problem.Tables {
+		if free[table.Number] >= party.Size && table.Allows(party) {
+			return table.Number, true
+		}
+	}
+	return 0, false
+}
+
+// block is a set of parties kept together at a table.
+type block struct {
+	parties []int
+	size    int
+	pinned  int
+	apart   map[int]bool
+	current map[int]int
+}
+
+// search returns the table of each of the parties, by party index, satisfying the constraints.
+// It reports false when no plan was found.
+func (s *solver) search(parties []int, constraints []Constraint) (map[int]int, bool) {
+	blocks, ok := s.blocks(parties, constraints)
+	if !ok {
+		return nil, false
+	}
+
+	// Place the most constrained blocks first
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if (blocks[i].pinned != 0) != (blocks[j].pinned != 0) {
+			return blocks[i].pinned != 0
+		}
+		if blocks[i].size != blocks[j].size {
+			return blocks[i].size > blocks[j].size
+		}
+		return len(blocks[i].apart) > len(blocks[j].apart)
+	})
+
+	free := make([]int, len(s.problem.Tables))
+	for i, table := range s.problem.Tables {
+		free[i] = table.Size
+	}
+
+	// seated holds the table index of each placed block
+	seated := make([]int, len(blocks))
+	steps := 0
+
+	var place func(i int) bool
+	place = func(i int) bool {
+		if i == len(blocks) {
+			return true
+		}
+
+		b := blocks[i]
+		for _, t := range s.candidates(b, free) {
+			if steps++; steps > maxSteps {
+				return false
+			}
+
+			if !s.fits(b, t, blocks[:i], seated, free) {
+				continue
+			}
+
+			free[t] -= b.size
+			seated[i] = t
+			if place(i + 1) {
+				return true
+			}
+			free[t] += b.size
+		}
+		return false
+	}
+
+	if !place(0) {
+		return nil, false
+	}
+
+	tables := make(map[int]int, len(parties))
+	for i, b := range blocks {
+		for _, party := range b.parties {
+			tables[party] = s.problem.Tables[seated[i]].Number
+		}
+	}
+	return tables, true
+}
+
+// fits reports whether the block can sit at table t along with the blocks already seated.
+func (s *solver) fits(b *block, t int, placed []*block, seated []int, free []int) bool {
+	if free[t] < b.size {
+		return false
+	}
+
+	for _, i := range b.parties {
+		if !s.problem.Tables[t].Allows(s.problem.Parties[i]) {
+			return false
+		}
+	}
+
+	for j, other := range placed {
+		if seated[j] == t && b.apart[s.first(other)] {
+			return false
+		}
+	}
+	return true
+}
+
+// first returns the lowest party index of a block, identifying it in the apart sets.
+func (s *solver) first(b *block) int {
+	return b.parties[0]
+}
+
+// candidates returns the table indexes to try for the block, the tables the block sits at now first.
+// Empty tables of the same size and tiers lead to the same plans, only the first of them is tried.
+func (s *solver) candidates(b *block, free []int) []int {
+	if b.pinned != 0 {
+		return []int{s.tables[b.pinned]}
+	}
+
+	out := make([]int, 0, len(free))
+	for t := range s.problem.Tables {
+		out = append(out, t)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return b.current[s.problem.Tables[out[i]].Number] > b.current[s.problem.Tables[out[j]].Number]
+	})
+
+	emptyTried := make(map[string]bool)
+	candidates := out[:0]
+	for _, t := range out {
+		table := s.problem.Tables[t]
+		if free[t] == table.Size && b.current[table.Number] == 0 {
+			kind := fmt.Sprint(table.Size, table.Tiers)
+			if emptyTried[kind] {
+				continue
+			}
+			emptyTried[kind] = true
+		}
+		candidates = append(candidates, t)
+	}
+	return candidates
+}
+
+// blocks merges the parties kept together and collects the blocks to keep apart and the pinned tables.
+// It reports false when the constraints contradict each other.
+func (s *solver) blocks(parties []int, constraints []Constraint) ([]*block, bool) {
+	seated := make(map[int]bool, len(parties))
+	for _, i := range parties {
+		seated[i] = true
+	}
+
+	root := make(map[int]int, len(parties))
+	for _, i := range parties {
+		root[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if root[i] != i {
+			root[i] = find(root[i])
+		}
+		return root[i]
+	}
+
+	// Constraints on unseated parties do not bind the plan
+	guests := func(constraint Constraint) []int {
+		var out []int
+		for _, guest := range constraint.Guests {
+			if i := s.parties[guest]; seated[i] {
+				out = append(out, i)
+			}
+		}
+		return out
+	}
+
+	for _, constraint := range constraints {
+		if constraint.Kind != KindTogether {
+			continue
+		}
+
+		members := guests(constraint)
+		for _, i := range members {
+			a, b := find(members[0]), find(i)
+			if a < b {
+				root[b] = a
+			} else {
+				root[a] = b
+			}
+		}
+	}
+
+	byRoot := make(map[int]*block)
+	var out []*block
+	for _, i := range parties {
+		r := find(i)
+		b, ok := byRoot[r]
+		if !ok {
+			b = &block{apart: make(map[int]bool), current: make(map[int]int)}
+			byRoot[r] = b
+			out = append(out, b)
+		}
+
+		party := s.problem.Parties[i]
+		b.parties = append(b.parties, i)
+		b.size += party.Size
+		b.current[party.Table] += party.Size
+	}
+
+	for _, b := range out {
+		sort.Ints(b.parties)
+	}
+
+	for _, constraint := range constraints {
+		members := guests(constraint)
+
+		switch constraint.Kind {
+		case KindPinned:
+			for _, i := range members {
+				b := byRoot[find(i)]
+				if b.pinned != 0 && b.pinned != constraint.Table {
+					return nil, false
+				}
+				b.pinned = constraint.Table
+			}
+
+		case KindApart:
+			for x, i := range members {
+				for _, j := range members[x+1:] {
+					a, b := byRoot[find(i)], byRoot[find(j)]
+					if a == b {
+						return nil, false
+					}
+					a.apart[s.first(b)] = true
+					b.apart[s.first(a)] = true
+				}
+			}
+		}
+	}
+	return out, true
+}
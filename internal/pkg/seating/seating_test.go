@@ -0,0 +1,334 @@
+package seating
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolve(t *testing.T) {
+	tables := []Table{{Number: 1, Size: 4}, {Number: 2, Size: 4}}
+
+	cases := []struct {
+		name     string
+		given    Problem
+		expected *Plan
+	}{
+		{
+			name: "keeps the parties at their table",
+			given: Problem{
+				Parties: []Party{{Name: "bob", Size: 2, Table: 2}, {Name: "joe", Size: 2, Table: 1}},
+				Tables:  tables,
+			},
+			expected: &Plan{Assignments: []Assignment{{Guest: "bob", Table: 2}, {Guest: "joe", Table: 1}}},
+		},
+		{
+			name: "moves a party to keep guests together",
+			given: Problem{
+				Parties:     []Party{{Name: "bob", Size: 2, Table: 2}, {Name: "joe", Size: 2, Table: 1}},
+				Tables:      tables,
+				Constraints: []Constraint{{ID: "c1", Kind: KindTogether, Guests: []string{"bob", "joe"}}},
+			},
+			expected: &Plan{Assignments: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 1}}},
+		},
+		{
+			name: "moves a party to keep guests apart",
+			given: Problem{
+				Parties:     []Party{{Name: "bob", Size: 1, Table: 1}, {Name: "joe", Size: 1, Table: 1}},
+				Tables:      tables,
+				Constraints: []Constraint{{ID: "c1", Kind: KindApart, Guests: []string{"bob", "joe"}}},
+			},
+			expected: &Plan{Assignments: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 2}}},
+		},
+		{
+			name: "pins a party to a table",
+			given: Problem{
+				Parties:     []Party{{Name: "bob", Size: 3}, {Name: "joe", Size: 2}},
+				Tables:      tables,
+				Constraints: []Constraint{{ID: "c1", Kind: KindPinned, Guests: []string{"joe"}, Table: 2}},
+			},
+			expected: &Plan{Assignments: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 2}}},
+		},
+		{
+			name: "seats parties that do not fit as they are at other tables",
+			given: Problem{
+				Parties: []Party{{Name: "amy", Size: 2}, {Name: "bob", Size: 2}, {Name: "joe", Size: 3}, {Name: "sue", Size: 1}},
+				Tables:  tables,
+			},
+			expected: &Plan{Assignments: []Assignment{{Guest: "amy", Table: 2}, {Guest: "bob", Table: 2}, {Guest: "joe", Table: 1}, {Guest: "sue", Table: 1}}},
+		},
+		{
+			name: "reports the parties that do not fit",
+			given: Problem{
+				Parties: []Party{{Name: "bob", Size: 4}, {Name: "joe", Size: 3}, {Name: "sue", Size: 2}},
+				Tables:  tables,
+			},
+			expected: &Plan{
+				Assignments: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 2}},
+				Unseated:    []string{"sue"},
+			},
+		},
+		{
+			name: "reports the constraints contradicting the constraints before them",
+			given: Problem{
+				Parties: []Party{{Name: "bob", Size: 1}, {Name: "joe", Size: 1}, {Name: "sue", Size: 1}},
+				Tables:  tables,
+				Constraints: []Constraint{
+					{ID: "c1", Kind: KindTogether, Guests: []string{"bob", "joe"}},
+					{ID: "c2", Kind: KindPinned, Guests: []string{"bob"}, Table: 2},
+					{ID: "c3", Kind: KindApart, Guests: []string{"joe", "bob"}},
+					{ID: "c4", Kind: KindPinned, Guests: []string{"joe"}, Table: 1},
+					{ID: "c5", Kind: KindApart, Guests: []string{"sue", "joe"}},
+				},
+			},
+			expected: &Plan{
+				Assignments: []Assignment{{Guest: "bob", Table: 2}, {Guest: "joe", Table: 2}, {Guest: "sue", Table: 1}},
+				Unsatisfied: []Violation{
+					{Constraint: "c3", Reason: "conflicts with the table sizes or the constraints before it"},
+					{Constraint: "c4", Reason: "conflicts with the table sizes or the constraints before it"},
+				},
+			},
+		},
+		{
+			name: "reports the constraints on parties too large for their table",
+			given: Problem{
+				Parties:     []Party{{Name: "bob", Size: 3}, {Name: "joe", Size: 2}},
+				Tables:      tables,
+				Constraints: []Constraint{{ID: "c1", Kind: KindTogether, Guests: []string{"bob", "joe"}}},
+			},
+			expected: &Plan{
+				Assignments: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 2}},
+				Unsatisfied: []Violation{{Constraint: "c1", Reason: "conflicts with the table sizes or the constraints before it"}},
+			},
+		},
+		{
+			name: "reports the constraints on unknown guests and tables",
+			given: Problem{
+				Parties: []Party{{Name: "bob", Size: 1, Table: 1}},
+				Tables:  tables,
+				Constraints: []Constraint{
+					{ID: "c1", Kind: KindApart, Guests: []string{"bob", "eve"}},
+					{ID: "c2", Kind: KindPinned, Guests: []string{"bob"}, Table: 3},
+				},
+			},
+			expected: &Plan{
+				Assignments: []Assignment{{Guest: "bob", Table: 1}},
+				Unsatisfied: []Violation{
+					{Constraint: "c1", Reason: `guest "eve" is not on the guest list`},
+					{Constraint: "c2", Reason: "table 3 does not exist"},
+				},
+			},
+		},
+		{
+			name: "moves parties off tables restricted to other tiers",
+			given: Problem{
+				Parties: []Party{{Name: "bob", Size: 2, Table: 1, Tier: "general"}, {Name: "joe", Size: 2, Table: 2, Tier: "vip"}},
+				Tables:  []Table{{Number: 1, Size: 4, Tiers: []string{"vip"}}, {Number: 2, Size: 4}},
+			},
+			expected: &Plan{Assignments: []Assignment{{Guest: "bob", Table: 2}, {Guest: "joe", Table: 2}}},
+		},
+		{
+			name: "reports the parties without a table allowing their tier",
+			given: Problem{
+				Parties: []Party{{Name: "bob", Size: 1, Tier: "general"}, {Name: "joe", Size: 1, Tier: "staff"}},
+				Tables:  []Table{{Number: 1, Size: 4, Tiers: []string{"general", "vip"}}},
+			},
+			expected: &Plan{
+				Assignments: []Assignment{{Guest: "bob", Table: 1}},
+				Unseated:    []string{"joe"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Solve(&tc.given))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	problem := Problem{
+		Parties: []Party{{Name: "bob", Size: 3}, {Name: "joe", Size: 2}, {Name: "sue", Size: 1}},
+		Tables:  []Table{{Number: 1, Size: 4}, {Number: 2, Size: 4}},
+		Constraints: []Constraint{
+			{ID: "c1", Kind: KindTogether, Guests: []string{"bob", "sue"}},
+			{ID: "c2", Kind: KindApart, Guests: []string{"bob", "joe"}},
+			{ID: "c3", Kind: KindPinned, Guests: []string{"joe"}, Table: 2},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		given    []Assignment
+		expected []Violation
+	}{
+		{
+			name:  "valid plan",
+			given: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 2}, {Guest: "sue", Table: 1}},
+		},
+		{
+			name:  "constraints not met",
+			given: []Assignment{{Guest: "bob", Table: 2}, {Guest: "joe", Table: 2}, {Guest: "sue", Table: 1}},
+			expected: []Violation{
+				{Reason: "table 2 seats 5 guests for 4 seats"},
+				{Constraint: "c1", Reason: `guests "bob" and "sue" sit at different tables`},
+				{Constraint: "c2", Reason: `guests "bob" and "joe" sit at table 2`},
+			},
+		},
+		{
+			name:  "guests missing, unknown or at unknown tables",
+			given: []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 3}, {Guest: "eve", Table: 1}},
+			expected: []Violation{
+				{Reason: `guest "joe" sits at table 3 that does not exist`},
+				{Reason: `guest "sue" has no table`},
+				{Reason: `guest "eve" is not on the guest list`},
+				{Constraint: "c1", Reason: `guests "bob" and "sue" sit at different tables`},
+				{Constraint: "c3", Reason: `guest "joe" does not sit at table 2`},
+			},
+		},
+	}
+
+	restricted := Problem{
+		Parties: []Party{{Name: "bob", Size: 1, Tier: "general"}, {Name: "joe", Size: 1, Tier: "vip"}},
+		Tables:  []Table{{Number: 1, Size: 4, Tiers: []string{"vip", "staff"}}},
+	}
+
+	assert.Equal(t, []Violation{{Reason: `guest "bob" of tier "general" sits at table 1 restricted to vip, staff`}},
+		Validate(&restricted, []Assignment{{Guest: "bob", Table: 1}, {Guest: "joe", Table: 1}}))
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Validate(&problem, tc.given))
+		})
+	}
+}
+
+// generateProblem returns a problem solved by a hidden plan, with constraints met by that plan.
+// Parties start at random tables, so most of them have to move.
+func generateProblem(r *rand.Rand) (*Problem, map[string]int) {
+	var p Problem
+	planted := make(map[string]int)
+	byTable := make(map[int][]string)
+
+	tables := 2 + r.Intn(8)
+	for number := 1; number <= tables; number++ {
+		table := Table{Number: number, Size: 4 + r.Intn(7)}
+		p.Tables = append(p.Tables, table)
+
+		// Leave some seats free most of the time
+		free := table.Size - r.Intn(2)
+		for free > 0 && r.Intn(5) > 0 {
+			size := 1 + r.Intn(minInt(free, 4))
+			free -= size
+
+			name := fmt.Sprintf("guest-%d", len(p.Parties))
+			p.Parties = append(p.Parties, Party{Name: name, Size: size})
+			planted[name] = number
+			byTable[number] = append(byTable[number], name)
+		}
+	}
+
+	r.Shuffle(len(p.Parties), func(i, j int) {
+		p.Parties[i], p.Parties[j] = p.Parties[j], p.Parties[i]
+	})
+
+	for i := range p.Parties {
+		p.Parties[i].Table = r.Intn(len(p.Tables) + 1)
+	}
+
+	if len(p.Parties) < 2 {
+		return &p, planted
+	}
+
+	for i := 0; i < r.Intn(12); i++ {
+		id := fmt.Sprintf("c%d", i)
+		first := p.Parties[r.Intn(len(p.Parties))].Name
+
+		switch r.Intn(3) {
+		case 0:
+			guests := byTable[planted[first]]
+			if len(guests) < 2 {
+				continue
+			}
+			p.Constraints = append(p.Constraints, Constraint{ID: id, Kind: KindTogether, Guests: []string{guests[0], guests[len(guests)-1]}})
+
+		case 1:
+			second := p.Parties[r.Intn(len(p.Parties))].Name
+			if planted[first] == planted[second] {
+				continue
+			}
+			p.Constraints = append(p.Constraints, Constraint{ID: id, Kind: KindApart, Guests: []string{first, second}})
+
+		case 2:
+			p.Constraints = append(p.Constraints, Constraint{ID: id, Kind: KindPinned, Guests: []string{first}, Table: planted[first]})
+		}
+	}
+	return &p, planted
+}
+
+func TestSolveGenerated(t *testing.T) {
+	for seed := int64(1); seed <= 300; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		problem, planted := generateProblem(r)
+
+		require.Empty(t, Validate(problem, plantedAssignments(planted)), "seed %d: the generated problem is solved by its hidden plan", seed)
+
+		plan := Solve(problem)
+
+		assert.Empty(t, plan.Unseated, "seed %d", seed)
+		assert.Empty(t, plan.Unsatisfied, "seed %d", seed)
+		assert.Empty(t, Validate(problem, plan.Assignments), "seed %d", seed)
+	}
+}
+
+func TestSolveGeneratedContradictions(t *testing.T) {
+	for seed := int64(1); seed <= 300; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		problem, _ := generateProblem(r)
+		if len(problem.Parties) < 2 {
+			continue
+		}
+
+		// Keeping the first two parties together and apart cannot hold, one of them is reported
+		first, second := problem.Parties[0].Name, problem.Parties[1].Name
+		problem.Constraints = append(
+			[]Constraint{{ID: "together", Kind: KindTogether, Guests: []string{first, second}}},
+			append(problem.Constraints, Constraint{ID: "apart", Kind: KindApart, Guests: []string{second, first}})...,
+		)
+
+		plan := Solve(problem)
+		require.NotEmpty(t, plan.Unsatisfied, "seed %d", seed)
+
+		unsatisfied := make(map[string]bool)
+		for _, violation := range plan.Unsatisfied {
+			unsatisfied[violation.Constraint] = true
+		}
+		assert.True(t, unsatisfied["together"] || unsatisfied["apart"], "seed %d: %v", seed, plan.Unsatisfied)
+
+		// The plan meets every constraint not reported
+		for _, violation := range Validate(problem, plan.Assignments) {
+			assert.True(t, unsatisfied[violation.Constraint], "seed %d: %v", seed, violation)
+		}
+	}
+}
+
+func plantedAssignments(planted map[string]int) []Assignment {
+	var out []Assignment
+	for guest, table := range planted {
+		out = append(out, Assignment{Guest: guest, Table: table})
+	}
+	return out
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"fmt"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/alesr/getground/internal/pkg/tracing"
+
+var _ propagation.TextMapCarrier = (*headerCarrier)(nil)
+
+// headerCarrier adapts the fasthttp headers to the propagation carrier.
+type headerCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (h *headerCarrier) Get(key string) string {
+	return string(h.header.Peek(key))
+}
+
+func (h *headerCarrier) Set(key, value string) {
+	h.header.Set(key, value)
+}
+
+func (h *headerCarrier) Keys() []string {
+	var keys []string
+	h.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// Middleware starts a server span for each request, continuing the trace of the W3C trace-context headers.
+// The span is set on the request user context so handlers can start child spans from c.UserContext().
+func Middleware() fiber.Handler {
+	tracer := otel.Tracer(instrumentationName)
+
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), &headerCarrier{header: &c.Request().Header})
+
+		ctx, span := tracer.Start(ctx, "HTTP "+c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(c.Method()),
+				semconv.HTTPTargetKey.String(c.OriginalURL()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			status = fiberErr.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+
+		// The route is only known once the request is routed
+		route := c.Route().Path
+		span.SetName(fmt.Sprintf("%s %s", c.Method(), route))
+		span.SetAttributes(
+			semconv.HTTPRouteKey.String(route),
+			semconv.HTTPStatusCodeKey.Int(status),
+		)
+
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+		return err
+	}
+}
+
+// RecordError records the error on the span and marks it as failed.
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID    = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentSpan = "00f067aa0ba902b7"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("returns an error for unknown exporters", func(t *testing.T) {
+		shutdown, err := Setup(context.TODO(), Config{Exporter: "foo"})
+
+		assert.Nil(t, shutdown)
+		assert.True(t, errors.Is(err, ErrExporterUnknown))
+	})
+
+	t.Run("does not export spans with the none exporter", func(t *testing.T) {
+		shutdown, err := Setup(context.TODO(), Config{Exporter: ExporterNone})
+		require.NoError(t, err)
+
+		assert.NoError(t, shutdown(context.TODO()))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	_, err := Setup(context.TODO(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+
+	var handlerSpan trace.SpanContext
+
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/guests/:name", func(c *fiber.Ctx) error {
+		handlerSpan = trace.SpanContextFromContext(c.UserContext())
+		return c.SendStatus(fiber.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/guests/foo", nil)
+	req.Header.Set("traceparent", "00-"+testTraceID+"-"+testParentSpan+"-01")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	assert.Equal(t, "GET /guests/:name", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, testTraceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, testParentSpan, span.Parent().SpanID().String())
+	assert.True(t, span.Parent().IsRemote())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", fiber.StatusInternalServerError))
+	assert.Contains(t, span.Attributes(), attribute.String("http.route", "/guests/:name"))
+
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+}
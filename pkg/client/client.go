@@ -0,0 +1,264 @@
+// Package client is the Go client of the party REST API.
+//
+// The methods mirror the party service and return its model types.
+// Errors answered by the API wrap the matching party errors,
+// so errors.Is(err, client.ErrGuestNotInList) works as with the service.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 200 * time.Millisecond
+	defaultMaxBackoff   = 2 * time.Second
+)
+
+var ErrBaseURLInvalid = errors.New("invalid base url")
+
+type (
+	// Client calls the party REST API.
+	Client struct {
+		baseURL      *url.URL
+		httpClient   *http.Client
+		organiserKey string
+		maxRetries   int
+		retryBackoff time.Duration
+		maxBackoff   time.Duration
+	}
+
+	// Option configures optional Client behaviour.
+	Option func(*Client)
+)
+
+// WithHTTPClient sets the HTTP client of the requests, defaults to a client with a 30s timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithOrganiserKey sets the API key sent on organiser-only routes.
+func WithOrganiserKey(key string) Option {
+	return func(c *Client) {
+		c.organiserKey = key
+	}
+}
+
+// WithRetries sets how many times failed requests are retried, doubling the wait
+// between attempts from backoff up to maxBackoff. Zero retries disables retries.
+func WithRetries(retries int, backoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = retries
+		c.retryBackoff = backoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// New creates a client of the party API served at baseURL, e.g. http://localhost:3000.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %s", ErrBaseURLInvalid, baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := Client{
+		baseURL:      u,
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+		maxBackoff:   defaultMaxBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &c, nil
+}
+
+// request defines a call to the API. Organiser requests carry the organiser key.
+type request struct {
+	method      string
+	path        string // Escaped, see escapePath
+	query       url.Values
+	body        interface{}
+	contentType string
+	accept      string
+	organiser   bool
+}
+
+// response is a successful answer of the API.
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// decode decodes the JSON body of the response into v.
+func (r *response) decode(v interface{}) error {
+	if err := json.Unmarshal(r.body, v); err != nil {
+		return fmt.Errorf("could not decode response body: %w", err)
+	}
+	return nil
+}
+
+// do sends the request, retrying it while retryable.
+// Statuses in accepted are answered as responses, others as errors.
+func (c *Client) do(ctx context.Context, req request, accepted ...int) (*response, error) {
+	body, err := encodeBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := c.retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, req, body)
+
+		if attempt >= c.maxRetries || !retryable(req.method, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			return resp.result(accepted)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err != nil {
+				return nil, err
+			}
+			return resp.result(accepted)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request, body []byte) (*response, error) {
+	u := *c.baseURL
+	u.RawPath = c.baseURL.EscapedPath() + req.path
+	u.RawQuery = req.query.Encode()
+
+	var err error
+	if u.Path, err = url.PathUnescape(u.RawPath); err != nil {
+		return nil, fmt.Errorf("could not build request path: %w", err)
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+
+	if body != nil {
+		contentType := req.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	accept := req.accept
+	if accept == "" {
+		accept = "application/json"
+	}
+	httpReq.Header.Set("Accept", accept)
+
+	if req.organiser && c.organiserKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.organiserKey)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
+	}
+
+	return &response{
+		statusCode: httpResp.StatusCode,
+		header:     httpResp.Header,
+		body:       respBody,
+	}, nil
+}
+
+// result returns the response when its status is accepted, or the error it describes.
+func (r *response) result(accepted []int) (*response, error) {
+	for _, status := range accepted {
+		if r.statusCode == status {
+			return r, nil
+		}
+	}
+	return nil, newError(r)
+}
+
+// escapePath formats a path, escaping the segments given as arguments.
+func escapePath(format string, segments ...string) string {
+	args := make([]interface{}, 0, len(segments))
+	for _, segment := range segments {
+		args = append(args, url.PathEscape(segment))
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func encodeBody(req request) ([]byte, error) {
+	switch body := req.body.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return body, nil
+	default:
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode request body: %w", err)
+		}
+		return b, nil
+	}
+}
+
+// retryable reports whether a request can be sent again after the given outcome.
+// Read requests are retried on network errors and unavailable servers. Other requests are only retried
+// when the server answered it did not process them, as the arrivals and departures are not idempotent.
+func retryable(method string, resp *response, err error) bool {
+	safe := method == http.MethodGet || method == http.MethodHead
+
+	if err != nil {
+		// The context errors are final
+		return safe && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.statusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return safe
+	}
+	return false
+}
@@ -0,0 +1,704 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alesr/getground/internal/app"
+	"github.com/alesr/getground/internal/app/partyctrl"
+	"github.com/alesr/getground/internal/pkg/party"
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testOrganiserKey = "organiser-key"
+
+// startTestApp serves the party app on a free port until the test ends and returns its URL.
+func startTestApp(t *testing.T, service party.Service, opts ...app.Option) string {
+	t.Helper()
+
+	fiberApp := fiber.New(fiber.Config{DisableStartupMessage: true, UnescapePath: true})
+
+	opts = append([]app.Option{
+		app.WithOrganiserKey(testOrganiserKey),
+		app.WithRequestValidation(),
+	}, opts...)
+
+	a := app.New(zap.NewNop(), fiberApp, partyctrl.New(zap.NewNop(), service), opts...)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, ln.Close())
+
+	go func() {
+		_ = a.Run(port)
+	}()
+
+	t.Cleanup(func() {
+		_ = a.Shutdown(context.Background())
+	})
+
+	addr := "127.0.0.1:" + port
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return "http://" + addr
+}
+
+func TestClient(t *testing.T) {
+	arrived := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	service := party.Mock{
+		AddGuestToGuestListFunc: func(ctx context.Context, in *party.AddGuestToGuestListInput) (*party.AddGuestToGuestListOutput, error) {
+			if in.Name == "John" {
+				return nil, party.ErrGuestAlreadyInList
+			}
+			if in.Tier == party.TierStaff {
+				return nil, party.ErrTableTierRestricted
+			}
+			return &party.AddGuestToGuestListOutput{Name: in.Name}, nil
+		},
+		GetGuestListFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.GetGuestListOutput, error) {
+			if in.Table != 1 || in.Arrived == nil || !*in.Arrived || in.NamePrefix != "Jo" || in.Sort != party.SortByName || in.Limit != 1 {
+				return party.GetGuestListOutput{}, errors.New("unexpected query")
+			}
+			return party.GetGuestListOutput{
+				Guests:     []party.Guest{{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived}},
+				NextCursor: "next",
+			}, nil
+		},
+		WelcomeGuestFunc: func(ctx context.Context, in *party.WelcomeGuestInput) (*party.WelcomeGuestOutput, error) {
+			if in.Name != "John Doe" {
+				return nil, party.ErrGuestNotInList
+			}
+			if in.AccompanyingGuests > 2 {
+				return nil, party.ErrTableNotEnoughSeats
+			}
+			return &party.WelcomeGuestOutput{Name: in.Name}, nil
+		},
+		GoodbyeGuestFunc: func(ctx context.Context, in *party.GoodbyeGuestInput) error {
+			return errors.New("connection refused")
+		},
+		RemoveGuestFromGuestListFunc: func(ctx context.Context, in *party.RemoveGuestFromGuestListInput) error {
+			if in.Name == "John" {
+				return party.ErrGuestPresent
+			}
+			return nil
+		},
+		GetEmptySeatsFunc: func(ctx context.Context) (party.GetEmptySeatsOutput, error) {
+			return party.GetEmptySeatsOutput{EmptySeats: 7}, nil
+		},
+		ListTablesFunc: func(ctx context.Context) (party.ListTablesOutput, error) {
+			return party.ListTablesOutput{Tables: []party.Table{{Number: 1, Size: 10, BookedSeats: 3, ArrivedSeats: 3, EmptySeats: 7}}}, nil
+		},
+		CreateTableFunc: func(ctx context.Context, in *party.CreateTableInput) (*party.Table, error) {
+			return &party.Table{Number: in.Number, Size: in.Size, EmptySeats: in.Size}, nil
+		},
+		AssignSeatsFunc: func(ctx context.Context, in *party.AssignSeatsInput) (*party.Table, error) {
+			if in.Name != "John Doe" {
+				return nil, party.ErrGuestNotInList
+			}
+			if in.Seats[0] == 1 {
+				return nil, party.ErrSeatTaken
+			}
+			return &party.Table{Number: 1, Size: 2, BookedSeats: 1, EmptySeats: 1, Seats: []party.Seat{{Number: 1}, {Number: in.Seats[0], Guest: in.Name}}}, nil
+		},
+		SwapSeatsFunc: func(ctx context.Context, in *party.SwapSeatsInput) (*party.Table, error) {
+			if in.Table != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			return &party.Table{Number: 1, Size: 2, BookedSeats: 1, EmptySeats: 1, Seats: []party.Seat{{Number: in.First, Guest: "John Doe"}, {Number: in.Second}}}, nil
+		},
+		ReserveTableFunc: func(ctx context.Context, in *party.ReserveTableInput) (*party.Table, error) {
+			if in.HeldSeats > 2 {
+				return nil, party.ErrTableNotEnoughSeats
+			}
+			return &party.Table{Number: in.Number, Size: 2, EmptySeats: 2, Tiers: in.Tiers, HeldSeats: in.HeldSeats}, nil
+		},
+		ReleaseTableFunc: func(ctx context.Context, number int) (*party.Table, error) {
+			if number != 1 {
+				return nil, party.ErrTableNumberNotFound
+			}
+			return &party.Table{Number: 1, Size: 2, EmptySeats: 2}, nil
+		},
+		ListGroupsFunc: func(ctx context.Context) (party.ListGroupsOutput, error) {
+			return party.ListGroupsOutput{Groups: []party.Group{{Name: "Doe", Tables: []int{2}, Guests: []party.Guest{{Name: "Jane Doe", Table: 2}}}}}, nil
+		},
+		AddGroupToGuestListFunc: func(ctx context.Context, in *party.AddGroupToGuestListInput) (*party.Group, error) {
+			if in.Name == "Doe" {
+				return nil, party.ErrGroupAlreadyExists
+			}
+			group := party.Group{Name: in.Name, Tables: []int{3}}
+			for _, guest := range in.Guests {
+				group.Guests = append(group.Guests, party.Guest{Name: guest.Name, Table: 3, AccompanyingGuests: guest.AccompanyingGuests})
+			}
+			return &group, nil
+		},
+		GetGroupFunc: func(ctx context.Context, name string) (*party.Group, error) {
+			if name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			return &party.Group{Name: name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane Doe", Table: 2}}}, nil
+		},
+		WelcomeGroupFunc: func(ctx context.Context, in *party.WelcomeGroupInput) (*party.Group, error) {
+			if in.Name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			arrived := time.Date(2021, 12, 24, 20, 0, 0, 0, time.UTC)
+			return &party.Group{Name: in.Name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane Doe", Table: 2, TimeArrival: &arrived}}}, nil
+		},
+		GoodbyeGroupFunc: func(ctx context.Context, in *party.GoodbyeGroupInput) (*party.Group, error) {
+			if in.Name != "Doe" {
+				return nil, party.ErrGroupNotFound
+			}
+			return &party.Group{Name: in.Name, Tables: []int{2}, Guests: []party.Guest{{Name: "Jane Doe", Table: 2}}}, nil
+		},
+		ListArrivedGuestsFunc: func(ctx context.Context, in *party.ListGuestsInput) (party.ListArrivedGuestsOutput, error) {
+			if in.Dietary != party.DietaryVegan || in.Allergy != "nuts" || in.Accessibility != party.AccessibilityWheelchair || in.Custom != "meal:fish" {
+				return party.ListArrivedGuestsOutput{}, errors.New("unexpected query")
+			}
+			return party.ListArrivedGuestsOutput{Guests: []party.GuestArrived{{Name: "John", AccompanyingGuests: 2, TimeArrival: arrived}}}, nil
+		},
+		GetGuestRequirementsFunc: func(ctx context.Context, name string) (*party.GuestRequirements, error) {
+			if name != "John Doe" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.GuestRequirements{Guest: name, People: []party.Requirements{{Dietary: []string{party.DietaryVegan}}}}, nil
+		},
+		SetGuestRequirementsFunc: func(ctx context.Context, in *party.SetGuestRequirementsInput) (*party.GuestRequirements, error) {
+			for _, person := range in.People {
+				for _, value := range person.Custom {
+					if value.Field != "meal" {
+						return nil, party.ErrCustomFieldNotFound
+					}
+				}
+			}
+			return &party.GuestRequirements{Guest: in.Guest, People: in.People}, nil
+		},
+		ListCustomFieldsFunc: func(ctx context.Context) (party.ListCustomFieldsOutput, error) {
+			return party.ListCustomFieldsOutput{Fields: []party.CustomField{{Name: "meal", Type: party.CustomFieldChoice, Options: []string{"fish", "meat"}}}}, nil
+		},
+		SetCustomFieldsFunc: func(ctx context.Context, in *party.SetCustomFieldsInput) (party.ListCustomFieldsOutput, error) {
+			return party.ListCustomFieldsOutput{Fields: in.Fields}, nil
+		},
+		GetCateringSummaryFunc: func(ctx context.Context) (*party.CateringSummary, error) {
+			return &party.CateringSummary{
+				Total:  party.CateringTable{People: 3, Dietary: []party.CateringCount{{Value: party.DietaryVegan, Count: 1}}},
+				Tables: []party.CateringTable{{Table: 1, People: 3, Dietary: []party.CateringCount{{Value: party.DietaryVegan, Count: 1}}}},
+			}, nil
+		},
+		ListConstraintsFunc: func(ctx context.Context) (party.ListConstraintsOutput, error) {
+			return party.ListConstraintsOutput{Constraints: []party.Constraint{{ID: "c1", Kind: party.ConstraintApart, Guests: []string{"John", "Jane"}}}}, nil
+		},
+		CreateConstraintFunc: func(ctx context.Context, in *party.CreateConstraintInput) (*party.Constraint, error) {
+			if in.Kind != party.ConstraintTogether {
+				return nil, party.ErrConstraintKindInvalid
+			}
+			return &party.Constraint{ID: "c2", Kind: in.Kind, Guests: in.Guests}, nil
+		},
+		DeleteConstraintFunc: func(ctx context.Context, id string) error {
+			if id != "c1" {
+				return party.ErrConstraintNotFound
+			}
+			return nil
+		},
+		PlanSeatingFunc: func(ctx context.Context) (*party.SeatingPlan, error) {
+			return &party.SeatingPlan{Assignments: []party.SeatingAssignment{{Guest: "Jane", Table: 2, CurrentTable: 1}, {Guest: "John", Table: 1, CurrentTable: 1}}}, nil
+		},
+		CheckSeatingFunc: func(ctx context.Context, in *party.CheckSeatingInput) (*party.SeatingPlan, error) {
+			out := party.SeatingPlan{Assignments: in.Assignments}
+			for _, assignment := range in.Assignments {
+				if assignment.Table == 1 {
+					out.Violations = append(out.Violations, party.SeatingViolation{Constraint: "c1", Reason: "guests sit at table 1"})
+				}
+			}
+			return &out, nil
+		},
+		GetLayoutFunc: func(ctx context.Context) (party.GetLayoutOutput, error) {
+			return party.GetLayoutOutput{Tables: []party.TableLayout{{Number: 1, Size: 10, Shape: party.TableShapeRound, Width: 100, Height: 100}}}, nil
+		},
+		SetLayoutFunc: func(ctx context.Context, in *party.SetLayoutInput) (*party.GetLayoutOutput, error) {
+			for _, table := range in.Tables {
+				if table.Number > 1 {
+					return nil, party.ErrTableNumberNotFound
+				}
+			}
+			return &party.GetLayoutOutput{Tables: in.Tables}, nil
+		},
+		GetSeatingChartFunc: func(ctx context.Context, in *party.GetSeatingChartInput) (*party.SeatingChart, error) {
+			if in.Highlight != "" && in.Highlight != "John" {
+				return nil, party.ErrGuestNotInList
+			}
+			return &party.SeatingChart{Highlight: in.Highlight}, nil
+		},
+		IssueCheckInCodeFunc: func(ctx context.Context, in *party.IssueCheckInCodeInput) (*party.IssueCheckInCodeOutput, error) {
+			return &party.IssueCheckInCodeOutput{Name: in.Name, Code: "code"}, nil
+		},
+		CheckInFunc: func(ctx context.Context, in *party.CheckInInput) (*party.CheckInOutput, error) {
+			if in.Code == "used" {
+				return nil, party.ErrCheckInCodeUsed
+			}
+			return &party.CheckInOutput{Name: "John", AccompanyingGuests: *in.AccompanyingGuests}, nil
+		},
+		ImportGuestsFunc: func(ctx context.Context, in *party.ImportGuestsInput) (*party.ImportGuestsOutput, error) {
+			out := party.ImportGuestsOutput{Rows: len(in.Guests), DryRun: in.DryRun}
+			for i, guest := range in.Guests {
+				if guest.Table == 0 {
+					out.Errors = append(out.Errors, party.ImportRowError{Row: i + 1, Name: guest.Name, Error: party.ErrTableNumberRequired.Error()})
+				}
+			}
+			if len(out.Errors) == 0 && !in.DryRun {
+				out.Imported = len(in.Guests)
+			}
+			return &out, nil
+		},
+	}
+
+	baseURL := startTestApp(t, &service)
+
+	c, err := New(baseURL, WithOrganiserKey(testOrganiserKey), WithRetries(0, 0, 0))
+	require.NoError(t, err)
+
+	unauthorized, err := New(baseURL, WithRetries(0, 0, 0))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("adds a guest", func(t *testing.T) {
+		out, err := c.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "Jane", Table: 1, AccompanyingGuests: 2})
+		require.NoError(t, err)
+		assert.Equal(t, &AddGuestToGuestListOutput{Name: "Jane"}, out)
+	})
+
+	t.Run("decodes party errors", func(t *testing.T) {
+		_, err := c.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "John", Table: 1})
+		assert.True(t, errors.Is(err, party.ErrGuestAlreadyInList))
+
+		var apiErr *Error
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+
+		_, err = c.WelcomeGuest(ctx, &WelcomeGuestInput{Name: "Jane"})
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+
+		_, err = c.WelcomeGuest(ctx, &WelcomeGuestInput{Name: "John Doe", AccompanyingGuests: 3})
+		assert.True(t, errors.Is(err, ErrTableNotEnoughSeats))
+
+		_, err = c.CheckIn(ctx, &CheckInInput{Code: "used"})
+		assert.True(t, errors.Is(err, ErrCheckInCodeUsed))
+	})
+
+	t.Run("reports requests rejected by the validation", func(t *testing.T) {
+		_, err := c.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "Jane", Table: 1, AccompanyingGuests: -1})
+
+		var apiErr *Error
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		assert.Nil(t, errors.Unwrap(apiErr))
+	})
+
+	t.Run("does not decode unexpected errors", func(t *testing.T) {
+		err := c.GoodbyeGuest(ctx, &GoodbyeGuestInput{Name: "John"})
+
+		var apiErr *Error
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Nil(t, errors.Unwrap(apiErr))
+	})
+
+	t.Run("gets the guest list with the filters", func(t *testing.T) {
+		yes := true
+		out, err := c.GetGuestList(ctx, &ListGuestsInput{Table: 1, Arrived: &yes, NamePrefix: "Jo", Sort: SortByName, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, GetGuestListOutput{
+			Guests:     []Guest{{Name: "John", Table: 1, AccompanyingGuests: 2, TimeArrival: &arrived}},
+			NextCursor: "next",
+		}, out)
+	})
+
+	t.Run("escapes the guest names", func(t *testing.T) {
+		out, err := c.WelcomeGuest(ctx, &WelcomeGuestInput{Name: "John Doe", AccompanyingGuests: 1})
+		require.NoError(t, err)
+		assert.Equal(t, &WelcomeGuestOutput{Name: "John Doe"}, out)
+	})
+
+	t.Run("gets the seats", func(t *testing.T) {
+		seats, err := c.GetEmptySeats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, GetEmptySeatsOutput{EmptySeats: 7}, seats)
+
+		tables, err := c.ListTables(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ListTablesOutput{Tables: []Table{{Number: 1, Size: 10, BookedSeats: 3, ArrivedSeats: 3, EmptySeats: 7}}}, tables)
+	})
+
+	t.Run("checks in with a code", func(t *testing.T) {
+		companions := 1
+		out, err := c.CheckIn(ctx, &CheckInInput{Code: "code", AccompanyingGuests: &companions})
+		require.NoError(t, err)
+		assert.Equal(t, &CheckInOutput{Name: "John", AccompanyingGuests: 1}, out)
+	})
+
+	t.Run("sends the organiser key", func(t *testing.T) {
+		out, err := c.IssueCheckInCode(ctx, &IssueCheckInCodeInput{Name: "John"})
+		require.NoError(t, err)
+		assert.Equal(t, &IssueCheckInCodeOutput{Name: "John", Code: "code"}, out)
+
+		_, err = unauthorized.IssueCheckInCode(ctx, &IssueCheckInCodeInput{Name: "John"})
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	})
+
+	t.Run("manages the guest list and tables", func(t *testing.T) {
+		require.NoError(t, c.RemoveGuestFromGuestList(ctx, &RemoveGuestFromGuestListInput{Name: "Jane"}))
+
+		err := c.RemoveGuestFromGuestList(ctx, &RemoveGuestFromGuestListInput{Name: "John"})
+		assert.True(t, errors.Is(err, ErrGuestPresent))
+
+		table, err := c.CreateTable(ctx, &CreateTableInput{Number: 2, Size: 4})
+		require.NoError(t, err)
+		assert.Equal(t, &Table{Number: 2, Size: 4, EmptySeats: 4}, table)
+
+		_, err = unauthorized.CreateTable(ctx, &CreateTableInput{Number: 2, Size: 4})
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	})
+
+	t.Run("moves guests between seats", func(t *testing.T) {
+		table, err := c.AssignSeats(ctx, &AssignSeatsInput{Name: "John Doe", Seats: []int{2}})
+		require.NoError(t, err)
+		assert.Equal(t, []Seat{{Number: 1}, {Number: 2, Guest: "John Doe"}}, table.Seats)
+
+		_, err = c.AssignSeats(ctx, &AssignSeatsInput{Name: "John Doe", Seats: []int{1}})
+		assert.True(t, errors.Is(err, ErrSeatTaken))
+
+		table, err = c.SwapSeats(ctx, &SwapSeatsInput{Table: 1, First: 1, Second: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []Seat{{Number: 1, Guest: "John Doe"}, {Number: 2}}, table.Seats)
+
+		_, err = c.SwapSeats(ctx, &SwapSeatsInput{Table: 2, First: 1, Second: 2})
+		assert.True(t, errors.Is(err, ErrTableNumberNotFound))
+
+		_, err = unauthorized.SwapSeats(ctx, &SwapSeatsInput{Table: 1, First: 1, Second: 2})
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	})
+
+	t.Run("reserves tables for tiers", func(t *testing.T) {
+		table, err := c.ReserveTable(ctx, &ReserveTableInput{Number: 1, Tiers: []string{TierVIP}, HeldSeats: 1})
+		require.NoError(t, err)
+		assert.Equal(t, &Table{Number: 1, Size: 2, EmptySeats: 2, Tiers: []string{TierVIP}, HeldSeats: 1}, table)
+
+		_, err = c.ReserveTable(ctx, &ReserveTableInput{Number: 1, HeldSeats: 3})
+		assert.True(t, errors.Is(err, ErrTableNotEnoughSeats))
+
+		_, err = unauthorized.ReserveTable(ctx, &ReserveTableInput{Number: 1, Tiers: []string{TierVIP}})
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+
+		table, err = c.ReleaseTable(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &Table{Number: 1, Size: 2, EmptySeats: 2}, table)
+
+		_, err = c.ReleaseTable(ctx, 2)
+		assert.True(t, errors.Is(err, ErrTableNumberNotFound))
+
+		out, err := c.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "Jane", Table: 1, Tier: TierVIP})
+		require.NoError(t, err)
+		assert.Equal(t, &AddGuestToGuestListOutput{Name: "Jane"}, out)
+
+		_, err = c.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "Jane", Table: 1, Tier: TierStaff})
+		assert.True(t, errors.Is(err, ErrTableTierRestricted))
+
+		_, err = unauthorized.AddGuestToGuestList(ctx, &AddGuestToGuestListInput{Name: "Jane", Table: 1, Tier: TierVIP})
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+
+		_, err = unauthorized.AddGroupToGuestList(ctx, &AddGroupToGuestListInput{Name: "Smith", Guests: []GroupGuest{{Name: "Amy Smith", Tier: TierStaff}}})
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	})
+
+	t.Run("manages groups", func(t *testing.T) {
+		groups, err := c.ListGroups(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ListGroupsOutput{Groups: []Group{{Name: "Doe", Tables: []int{2}, Guests: []Guest{{Name: "Jane Doe", Table: 2}}}}}, groups)
+
+		group, err := c.AddGroupToGuestList(ctx, &AddGroupToGuestListInput{Name: "Smith", Guests: []GroupGuest{{Name: "Amy Smith", AccompanyingGuests: 1}}})
+		require.NoError(t, err)
+		assert.Equal(t, &Group{Name: "Smith", Tables: []int{3}, Guests: []Guest{{Name: "Amy Smith", Table: 3, AccompanyingGuests: 1}}}, group)
+
+		_, err = c.AddGroupToGuestList(ctx, &AddGroupToGuestListInput{Name: "Doe", Guests: []GroupGuest{{Name: "Jim Doe"}}})
+		assert.True(t, errors.Is(err, ErrGroupAlreadyExists))
+
+		group, err = c.GetGroup(ctx, "Doe")
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, group.Tables)
+
+		_, err = c.GetGroup(ctx, "Smith Jones")
+		assert.True(t, errors.Is(err, ErrGroupNotFound))
+
+		group, err = c.WelcomeGroup(ctx, &WelcomeGroupInput{Name: "Doe"})
+		require.NoError(t, err)
+		assert.NotNil(t, group.Guests[0].TimeArrival)
+
+		require.NoError(t, c.GoodbyeGroup(ctx, &GoodbyeGroupInput{Name: "Doe"}))
+		assert.True(t, errors.Is(c.GoodbyeGroup(ctx, &GoodbyeGroupInput{Name: "Smith"}), ErrGroupNotFound))
+	})
+
+	t.Run("manages guest requirements", func(t *testing.T) {
+		filters := &ListGuestsInput{Dietary: DietaryVegan, Allergy: "nuts", Accessibility: AccessibilityWheelchair, Custom: "meal:fish"}
+
+		arrivals, err := c.ListArrivedGuests(ctx, filters)
+		require.NoError(t, err)
+		assert.Equal(t, ListArrivedGuestsOutput{Guests: []GuestArrived{{Name: "John", AccompanyingGuests: 2, TimeArrival: arrived}}}, arrivals)
+
+		_, err = unauthorized.ListArrivedGuests(ctx, filters)
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+
+		requirements, err := c.GetGuestRequirements(ctx, "John Doe")
+		require.NoError(t, err)
+		assert.Equal(t, &GuestRequirements{Guest: "John Doe", People: []Requirements{{Dietary: []string{DietaryVegan}}}}, requirements)
+
+		_, err = c.GetGuestRequirements(ctx, "Jane")
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+
+		people := []Requirements{{Allergies: []string{"nuts"}}, {Companion: 1, Name: "Mary", Custom: []CustomValue{{Field: "meal", Value: "fish"}}}}
+		requirements, err = c.SetGuestRequirements(ctx, &SetGuestRequirementsInput{Guest: "John", People: people})
+		require.NoError(t, err)
+		assert.Equal(t, &GuestRequirements{Guest: "John", People: people}, requirements)
+
+		_, err = c.SetGuestRequirements(ctx, &SetGuestRequirementsInput{Guest: "John", People: []Requirements{{Custom: []CustomValue{{Field: "shirt", Value: "XL"}}}}})
+		assert.True(t, errors.Is(err, ErrCustomFieldNotFound))
+
+		fields, err := c.ListCustomFields(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ListCustomFieldsOutput{Fields: []CustomField{{Name: "meal", Type: CustomFieldChoice, Options: []string{"fish", "meat"}}}}, fields)
+
+		fields, err = c.SetCustomFields(ctx, &SetCustomFieldsInput{Fields: []CustomField{{Name: "shuttle", Type: CustomFieldBoolean, Required: true}}})
+		require.NoError(t, err)
+		assert.Equal(t, ListCustomFieldsOutput{Fields: []CustomField{{Name: "shuttle", Type: CustomFieldBoolean, Required: true}}}, fields)
+
+		catering, err := c.GetCateringSummary(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, catering.Total.People)
+		assert.Equal(t, []CateringCount{{Value: DietaryVegan, Count: 1}}, catering.Tables[0].Dietary)
+
+		_, err = unauthorized.GetCateringSummary(ctx)
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	})
+
+	t.Run("plans the seating with constraints", func(t *testing.T) {
+		constraints, err := c.ListConstraints(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ListConstraintsOutput{Constraints: []Constraint{{ID: "c1", Kind: ConstraintApart, Guests: []string{"John", "Jane"}}}}, constraints)
+
+		constraint, err := c.CreateConstraint(ctx, &CreateConstraintInput{Kind: ConstraintTogether, Guests: []string{"John", "Joe"}})
+		require.NoError(t, err)
+		assert.Equal(t, &Constraint{ID: "c2", Kind: ConstraintTogether, Guests: []string{"John", "Joe"}}, constraint)
+
+		_, err = c.CreateConstraint(ctx, &CreateConstraintInput{Kind: ConstraintPinned, Guests: []string{"John"}, Table: 1})
+		assert.True(t, errors.Is(err, ErrConstraintKindInvalid))
+
+		require.NoError(t, c.DeleteConstraint(ctx, "c1"))
+		assert.True(t, errors.Is(c.DeleteConstraint(ctx, "c3"), ErrConstraintNotFound))
+
+		plan, err := c.PlanSeating(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &SeatingPlan{Assignments: []SeatingAssignment{{Guest: "Jane", Table: 2, CurrentTable: 1}, {Guest: "John", Table: 1, CurrentTable: 1}}}, plan)
+
+		plan, err = c.CheckSeating(ctx, &CheckSeatingInput{Assignments: []SeatingAssignment{{Guest: "Jane", Table: 1}, {Guest: "John", Table: 2}}})
+		require.NoError(t, err)
+		assert.Equal(t, []SeatingViolation{{Constraint: "c1", Reason: "guests sit at table 1"}}, plan.Violations)
+
+		_, err = unauthorized.PlanSeating(ctx)
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	})
+
+	t.Run("manages the venue layout", func(t *testing.T) {
+		layout, err := c.GetLayout(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, GetLayoutOutput{Tables: []TableLayout{{Number: 1, Size: 10, Shape: TableShapeRound, Width: 100, Height: 100}}}, layout)
+
+		in := SetLayoutInput{Tables: []TableLayout{{Number: 1, Shape: TableShapeRectangle, Width: 160, Height: 80}}}
+
+		out, err := c.SetLayout(ctx, &in)
+		require.NoError(t, err)
+		assert.Equal(t, &GetLayoutOutput{Tables: in.Tables}, out)
+
+		_, err = c.SetLayout(ctx, &SetLayoutInput{Tables: []TableLayout{{Number: 2, Shape: TableShapeRound, Width: 100}}})
+		assert.True(t, errors.Is(err, ErrTableNumberNotFound))
+
+		_, err = unauthorized.SetLayout(ctx, &in)
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+
+		chart, err := c.GetSeatingChart(ctx, "John")
+		require.NoError(t, err)
+		assert.Contains(t, string(chart), "John sits at the highlighted table")
+
+		_, err = c.GetSeatingChart(ctx, "Jane")
+		assert.True(t, errors.Is(err, ErrGuestNotInList))
+	})
+
+	t.Run("returns the rejected import rows", func(t *testing.T) {
+		out, err := c.ImportGuests(ctx, &ImportGuestsInput{Guests: []ImportGuestRow{{Name: "John", Table: 1}, {Name: "Jane"}}})
+		require.NoError(t, err)
+		assert.Equal(t, &ImportGuestsOutput{
+			Rows:   2,
+			Errors: []ImportRowError{{Row: 2, Name: "Jane", Error: party.ErrTableNumberRequired.Error()}},
+		}, out)
+
+		out, err = c.ImportGuestsCSV(ctx, []byte("name,table\nJohn,1\n"), true)
+		require.NoError(t, err)
+		assert.Equal(t, &ImportGuestsOutput{Rows: 1, DryRun: true}, out)
+	})
+}
+
+func TestWatchEvents(t *testing.T) {
+	broadcaster := party.NewBroadcaster(0)
+	baseURL := startTestApp(t, &party.Mock{}, app.WithEvents(app.NewEvents(broadcaster)))
+
+	c, err := New(baseURL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := c.WatchEvents(ctx, EventGuestArrived)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	eventTime := time.Date(2021, time.November, 23, 20, 0, 0, 0, time.UTC)
+
+	// The stream is subscribed once WatchEvents returns
+	publish := broadcaster.Listener()
+	publish(party.Event{Type: party.EventGuestAdded, Guest: "Jane", Time: eventTime})
+	publish(party.Event{Type: party.EventGuestArrived, Guest: "John", Table: 1, AccompanyingGuests: 2, AvailableSeats: 7, Time: eventTime})
+
+	e, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, Event{Type: EventGuestArrived, Guest: "John", Table: 1, AccompanyingGuests: 2, AvailableSeats: 7, Time: eventTime}, e)
+
+	cancel()
+
+	_, err = stream.Recv()
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestClientRetries(t *testing.T) {
+	cases := []struct {
+		name              string
+		givenMethod       string
+		givenStatuses     []int
+		expectedCalls     int32
+		expectedErrStatus int
+	}{
+		{
+			name:          "retries reads on unavailable servers",
+			givenMethod:   http.MethodGet,
+			givenStatuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 3,
+		},
+		{
+			name:              "gives up after the retries",
+			givenMethod:       http.MethodGet,
+			givenStatuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectedCalls:     3,
+			expectedErrStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:              "does not retry client errors",
+			givenMethod:       http.MethodGet,
+			givenStatuses:     []int{http.StatusNotFound, http.StatusOK},
+			expectedCalls:     1,
+			expectedErrStatus: http.StatusNotFound,
+		},
+		{
+			name:              "does not retry writes the server may have applied",
+			givenMethod:       http.MethodPut,
+			givenStatuses:     []int{http.StatusBadGateway, http.StatusOK},
+			expectedCalls:     1,
+			expectedErrStatus: http.StatusBadGateway,
+		},
+		{
+			name:          "retries writes the server did not process",
+			givenMethod:   http.MethodPut,
+			givenStatuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tc.givenStatuses[call-1])
+				_, _ = w.Write([]byte(`{"empty_seats": 7, "name": "John"}`))
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, WithRetries(2, time.Millisecond, time.Millisecond))
+			require.NoError(t, err)
+
+			if tc.givenMethod == http.MethodGet {
+				_, err = c.GetEmptySeats(context.Background())
+			} else {
+				_, err = c.WelcomeGuest(context.Background(), &WelcomeGuestInput{Name: "John"})
+			}
+
+			assert.Equal(t, tc.expectedCalls, atomic.LoadInt32(&calls))
+
+			if tc.expectedErrStatus == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var apiErr *Error
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tc.expectedErrStatus, apiErr.StatusCode)
+		})
+	}
+
+	t.Run("stops retrying when the context is done", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithRetries(10, time.Hour, time.Hour))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = c.GetEmptySeats(ctx)
+
+		var apiErr *Error
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	})
+}
+
+func TestNew(t *testing.T) {
+	for _, baseURL := range []string{"", "localhost:3000", "ftp://localhost", "http://"} {
+		_, err := New(baseURL)
+		assert.True(t, errors.Is(err, ErrBaseURLInvalid), baseURL)
+	}
+
+	c, err := New("http://localhost:3000/party/")
+	require.NoError(t, err)
+	assert.Equal(t, "/party", c.baseURL.Path)
+}
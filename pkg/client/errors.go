@@ -0,0 +1,169 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/alesr/getground/internal/pkg/party"
+)
+
+// Enumerate the party errors answered by the API
+var (
+	ErrAccessibilityNeedInvalid        = party.ErrAccessibilityNeedInvalid
+	ErrAccompanyingGuestsNumberInvalid = party.ErrAccompanyingGuestsNumberInvalid
+	ErrAllergyInvalid                  = party.ErrAllergyInvalid
+	ErrCheckInCodeNotFound             = party.ErrCheckInCodeNotFound
+	ErrCheckInCodeRevoked              = party.ErrCheckInCodeRevoked
+	ErrCheckInCodeUsed                 = party.ErrCheckInCodeUsed
+	ErrCompanionDuplicated             = party.ErrCompanionDuplicated
+	ErrCompanionNameInvalid            = party.ErrCompanionNameInvalid
+	ErrCompanionNumberInvalid          = party.ErrCompanionNumberInvalid
+	ErrConstraintGuestsInvalid         = party.ErrConstraintGuestsInvalid
+	ErrConstraintKindInvalid           = party.ErrConstraintKindInvalid
+	ErrConstraintNotFound              = party.ErrConstraintNotFound
+	ErrCursorInvalid                   = party.ErrCursorInvalid
+	ErrCustomFieldInvalid              = party.ErrCustomFieldInvalid
+	ErrCustomFieldNotFound             = party.ErrCustomFieldNotFound
+	ErrCustomFieldRequired             = party.ErrCustomFieldRequired
+	ErrCustomFieldValueInvalid         = party.ErrCustomFieldValueInvalid
+	ErrDietaryRequirementInvalid       = party.ErrDietaryRequirementInvalid
+	ErrGroupAlreadyExists              = party.ErrGroupAlreadyExists
+	ErrGroupGuestsRequired             = party.ErrGroupGuestsRequired
+	ErrGroupNameRequired               = party.ErrGroupNameRequired
+	ErrGroupNotFound                   = party.ErrGroupNotFound
+	ErrGuestAlreadyInList              = party.ErrGuestAlreadyInList
+	ErrGuestDuplicatedInGroup          = party.ErrGuestDuplicatedInGroup
+	ErrGuestNameRequired               = party.ErrGuestNameRequired
+	ErrGuestNotInList                  = party.ErrGuestNotInList
+	ErrGuestNotPresent                 = party.ErrGuestNotPresent
+	ErrGuestPresent                    = party.ErrGuestPresent
+	ErrHeldSeatsInvalid                = party.ErrHeldSeatsInvalid
+	ErrImportEmpty                     = party.ErrImportEmpty
+	ErrInvitationAlreadyAnswered       = party.ErrInvitationAlreadyAnswered
+	ErrInvitationExpired               = party.ErrInvitationExpired
+	ErrInvitationNotFound              = party.ErrInvitationNotFound
+	ErrInvitationPartyTooLarge         = party.ErrInvitationPartyTooLarge
+	ErrInvitationTokenInvalid          = party.ErrInvitationTokenInvalid
+	ErrLimitInvalid                    = party.ErrLimitInvalid
+	ErrMaxPartySizeInvalid             = party.ErrMaxPartySizeInvalid
+	ErrSeatNumberInvalid               = party.ErrSeatNumberInvalid
+	ErrSeatTaken                       = party.ErrSeatTaken
+	ErrSeatsCountInvalid               = party.ErrSeatsCountInvalid
+	ErrSortInvalid                     = party.ErrSortInvalid
+	ErrTableAlreadyExists              = party.ErrTableAlreadyExists
+	ErrTableDuplicatedInLayout         = party.ErrTableDuplicatedInLayout
+	ErrTableLayoutInvalid              = party.ErrTableLayoutInvalid
+	ErrTableNotEnoughSeats             = party.ErrTableNotEnoughSeats
+	ErrTableNumberInvalid              = party.ErrTableNumberInvalid
+	ErrTableNumberNotFound             = party.ErrTableNumberNotFound
+	ErrTableNumberRequired             = party.ErrTableNumberRequired
+	ErrTableShapeInvalid               = party.ErrTableShapeInvalid
+	ErrTableSizeInvalid                = party.ErrTableSizeInvalid
+	ErrTableTierRestricted             = party.ErrTableTierRestricted
+	ErrTierInvalid                     = party.ErrTierInvalid
+)
+
+// ErrUnauthorized is wrapped by the errors of organiser-only requests sent without a valid organiser key.
+var ErrUnauthorized = errors.New("organiser credentials required")
+
+// apiErrors are the errors matched by message against the error responses.
+var apiErrors = []error{
+	ErrAccessibilityNeedInvalid,
+	ErrAccompanyingGuestsNumberInvalid,
+	ErrAllergyInvalid,
+	ErrCheckInCodeNotFound,
+	ErrCheckInCodeRevoked,
+	ErrCheckInCodeUsed,
+	ErrCompanionDuplicated,
+	ErrCompanionNameInvalid,
+	ErrCompanionNumberInvalid,
+	ErrConstraintGuestsInvalid,
+	ErrConstraintKindInvalid,
+	ErrConstraintNotFound,
+	ErrCursorInvalid,
+	ErrCustomFieldInvalid,
+	ErrCustomFieldNotFound,
+	ErrCustomFieldRequired,
+	ErrCustomFieldValueInvalid,
+	ErrDietaryRequirementInvalid,
+	ErrGroupAlreadyExists,
+	ErrGroupGuestsRequired,
+	ErrGroupNameRequired,
+	ErrGroupNotFound,
+	ErrGuestAlreadyInList,
+	ErrGuestDuplicatedInGroup,
+	ErrGuestNameRequired,
+	ErrGuestNotInList,
+	ErrGuestNotPresent,
+	ErrGuestPresent,
+	ErrHeldSeatsInvalid,
+	ErrImportEmpty,
+	ErrInvitationAlreadyAnswered,
+	ErrInvitationExpired,
+	ErrInvitationNotFound,
+	ErrInvitationPartyTooLarge,
+	ErrInvitationTokenInvalid,
+	ErrLimitInvalid,
+	ErrMaxPartySizeInvalid,
+	ErrSeatNumberInvalid,
+	ErrSeatTaken,
+	ErrSeatsCountInvalid,
+	ErrSortInvalid,
+	ErrTableAlreadyExists,
+	ErrTableDuplicatedInLayout,
+	ErrTableLayoutInvalid,
+	ErrTableNotEnoughSeats,
+	ErrTableNumberInvalid,
+	ErrTableNumberNotFound,
+	ErrTableNumberRequired,
+	ErrTableShapeInvalid,
+	ErrTableSizeInvalid,
+	ErrTableTierRestricted,
+	ErrTierInvalid,
+}
+
+// Error is an error answered by the API.
+// It wraps the party error of the message, if any, or ErrUnauthorized.
+type Error struct {
+	StatusCode int
+	Message    string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("party api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// newError decodes the {"error": "..."} body of an error response.
+func newError(r *response) error {
+	apiErr := Error{StatusCode: r.statusCode}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+
+	if err := json.Unmarshal(r.body, &body); err == nil && body.Error != "" {
+		apiErr.Message = body.Error
+	} else {
+		apiErr.Message = http.StatusText(r.statusCode)
+	}
+
+	for _, err := range apiErrors {
+		if apiErr.Message == err.Error() {
+			apiErr.err = err
+			return &apiErr
+		}
+	}
+
+	if r.statusCode == http.StatusUnauthorized {
+		apiErr.err = ErrUnauthorized
+	}
+	return &apiErr
+}
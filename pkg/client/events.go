@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EventStream receives the party events streamed by the API.
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// WatchEvents subscribes to the party events of the given types, or all events when none is given.
+// The events are received with Recv until the context is done or the stream is closed.
+func (c *Client) WatchEvents(ctx context.Context, types ...string) (*EventStream, error) {
+	u := *c.baseURL
+	u.RawPath = c.baseURL.EscapedPath() + "/v1/events"
+	u.Path = c.baseURL.Path + "/v1/events"
+
+	if len(types) > 0 {
+		u.RawQuery = url.Values{"types": {strings.Join(types, ",")}}.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	// The stream is open for as long as the context, the client timeout would end it
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+
+	httpResp, err := streamClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
+
+		body, err := ioutil.ReadAll(httpResp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not read response body: %w", err)
+		}
+		return nil, newError(&response{statusCode: httpResp.StatusCode, header: httpResp.Header, body: body})
+	}
+
+	return &EventStream{
+		body:    httpResp.Body,
+		scanner: bufio.NewScanner(httpResp.Body),
+	}, nil
+}
+
+// Recv returns the next event. It returns io.EOF once the API ended the stream,
+// and an error wrapping the context error once the context of the stream is done.
+func (s *EventStream) Recv() (Event, error) {
+	var data []string
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		switch {
+		case line == "":
+			// A blank line dispatches the event, comments alone are not events
+			if len(data) == 0 {
+				continue
+			}
+
+			var e Event
+			if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &e); err != nil {
+				return Event{}, fmt.Errorf("could not decode event: %w", err)
+			}
+			return e, nil
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return Event{}, fmt.Errorf("could not read event stream: %w", err)
+	}
+	return Event{}, io.EOF
+}
+
+// Close ends the stream.
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
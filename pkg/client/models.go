@@ -0,0 +1,148 @@
+package client
+
+import "github.com/alesr/getground/internal/pkg/party"
+
+// The party model types, aliased for the modules that cannot import the party package.
+type (
+	AddGuestToGuestListInput      = party.AddGuestToGuestListInput
+	AddGuestToGuestListOutput     = party.AddGuestToGuestListOutput
+	Guest                         = party.Guest
+	GetGuestListOutput            = party.GetGuestListOutput
+	ListGuestsInput               = party.ListGuestsInput
+	WelcomeGuestInput             = party.WelcomeGuestInput
+	WelcomeGuestOutput            = party.WelcomeGuestOutput
+	GoodbyeGuestInput             = party.GoodbyeGuestInput
+	RemoveGuestFromGuestListInput = party.RemoveGuestFromGuestListInput
+	GuestArrived                  = party.GuestArrived
+	ListArrivedGuestsOutput       = party.ListArrivedGuestsOutput
+	GetEmptySeatsOutput           = party.GetEmptySeatsOutput
+	Table                         = party.Table
+	CreateTableInput              = party.CreateTableInput
+	ListTablesOutput              = party.ListTablesOutput
+	GetStatsOutput                = party.GetStatsOutput
+
+	Seat             = party.Seat
+	AssignSeatsInput = party.AssignSeatsInput
+	SwapSeatsInput   = party.SwapSeatsInput
+
+	ReserveTableInput = party.ReserveTableInput
+
+	Group                    = party.Group
+	GroupGuest               = party.GroupGuest
+	AddGroupToGuestListInput = party.AddGroupToGuestListInput
+	ListGroupsOutput         = party.ListGroupsOutput
+	WelcomeGroupInput        = party.WelcomeGroupInput
+	GoodbyeGroupInput        = party.GoodbyeGroupInput
+
+	Requirements              = party.Requirements
+	CustomValue               = party.CustomValue
+	GuestRequirements         = party.GuestRequirements
+	SetGuestRequirementsInput = party.SetGuestRequirementsInput
+	CustomField               = party.CustomField
+	SetCustomFieldsInput      = party.SetCustomFieldsInput
+	ListCustomFieldsOutput    = party.ListCustomFieldsOutput
+	CateringCount             = party.CateringCount
+	CateringTable             = party.CateringTable
+	CateringSummary           = party.CateringSummary
+
+	Constraint            = party.Constraint
+	CreateConstraintInput = party.CreateConstraintInput
+	ListConstraintsOutput = party.ListConstraintsOutput
+	SeatingAssignment     = party.SeatingAssignment
+	SeatingViolation      = party.SeatingViolation
+	SeatingPlan           = party.SeatingPlan
+	CheckSeatingInput     = party.CheckSeatingInput
+
+	TableLayout     = party.TableLayout
+	SetLayoutInput  = party.SetLayoutInput
+	GetLayoutOutput = party.GetLayoutOutput
+
+	CreateInvitationInput     = party.CreateInvitationInput
+	CreateInvitationOutput    = party.CreateInvitationOutput
+	GetInvitationOutput       = party.GetInvitationOutput
+	RespondToInvitationInput  = party.RespondToInvitationInput
+	RespondToInvitationOutput = party.RespondToInvitationOutput
+
+	IssueCheckInCodeInput  = party.IssueCheckInCodeInput
+	IssueCheckInCodeOutput = party.IssueCheckInCodeOutput
+	CheckInInput           = party.CheckInInput
+	CheckInOutput          = party.CheckInOutput
+
+	ImportGuestRow     = party.ImportGuestRow
+	ImportGuestsInput  = party.ImportGuestsInput
+	ImportRowError     = party.ImportRowError
+	ImportGuestsOutput = party.ImportGuestsOutput
+
+	ReportGuest            = party.ReportGuest
+	ReportTable            = party.ReportTable
+	GetSeatingReportOutput = party.GetSeatingReportOutput
+
+	Event = party.Event
+)
+
+// Enumerate event types
+const (
+	EventGuestAdded   = party.EventGuestAdded
+	EventGuestArrived = party.EventGuestArrived
+	EventGuestLeft    = party.EventGuestLeft
+	EventGuestRemoved = party.EventGuestRemoved
+	EventTableCreated = party.EventTableCreated
+)
+
+// Enumerate guest tiers, guests without a tier are general guests
+const (
+	TierVIP     = party.TierVIP
+	TierStaff   = party.TierStaff
+	TierGeneral = party.TierGeneral
+)
+
+// Enumerate table shapes
+const (
+	TableShapeRound     = party.TableShapeRound
+	TableShapeRectangle = party.TableShapeRectangle
+)
+
+// Enumerate seating constraint kinds
+const (
+	ConstraintTogether = party.ConstraintTogether
+	ConstraintApart    = party.ConstraintApart
+	ConstraintPinned   = party.ConstraintPinned
+)
+
+// Enumerate dietary requirements
+const (
+	DietaryVegetarian  = party.DietaryVegetarian
+	DietaryVegan       = party.DietaryVegan
+	DietaryPescatarian = party.DietaryPescatarian
+	DietaryHalal       = party.DietaryHalal
+	DietaryKosher      = party.DietaryKosher
+	DietaryGlutenFree  = party.DietaryGlutenFree
+	DietaryDairyFree   = party.DietaryDairyFree
+)
+
+// Enumerate accessibility needs
+const (
+	AccessibilityWheelchair       = party.AccessibilityWheelchair
+	AccessibilityStepFree         = party.AccessibilityStepFree
+	AccessibilityHearing          = party.AccessibilityHearing
+	AccessibilityVisual           = party.AccessibilityVisual
+	AccessibilityAssistanceAnimal = party.AccessibilityAssistanceAnimal
+)
+
+// Enumerate custom field types
+const (
+	CustomFieldText    = party.CustomFieldText
+	CustomFieldNumber  = party.CustomFieldNumber
+	CustomFieldBoolean = party.CustomFieldBoolean
+	CustomFieldChoice  = party.CustomFieldChoice
+)
+
+// Enumerate guest list sort fields and orders
+const (
+	SortByName        = party.SortByName
+	SortByTable       = party.SortByTable
+	SortByTimeArrival = party.SortByTimeArrival
+
+	SortOrderAsc  = party.SortOrderAsc
+	SortOrderDesc = party.SortOrderDesc
+)
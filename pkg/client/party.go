@@ -0,0 +1,789 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const mimeTextCSV = "text/csv"
+
+// AddGuestToGuestList adds a guest and their accompanying guests to a table of the guest list.
+// Adding a guest of a tier other than general is organiser only.
+func (c *Client) AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestListInput) (*AddGuestToGuestListOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      escapePath("/v1/guest_list/%s", in.Name),
+		body:      in,
+		organiser: tiered(in.Tier),
+	}, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var out AddGuestToGuestListOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGuestList returns the guests in the guest list matching the input filters.
+// Filtering by requirements is organiser only.
+func (c *Client) GetGuestList(ctx context.Context, in *ListGuestsInput) (GetGuestListOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v1/guest_list",
+		query:     listGuestsQuery(in),
+		organiser: filtersRequirements(in),
+	}, http.StatusOK)
+	if err != nil {
+		return GetGuestListOutput{}, err
+	}
+
+	var out GetGuestListOutput
+	if err := resp.decode(&out); err != nil {
+		return GetGuestListOutput{}, err
+	}
+	return out, nil
+}
+
+// WelcomeGuest records the arrival of a guest with the accompanying guests actually coming.
+func (c *Client) WelcomeGuest(ctx context.Context, in *WelcomeGuestInput) (*WelcomeGuestOutput, error) {
+	resp, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   escapePath("/v1/guests/%s", in.Name),
+		body:   in,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out WelcomeGuestOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GoodbyeGuest records the departure of a guest, freeing their seats.
+func (c *Client) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestInput) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   escapePath("/v1/guests/%s", in.Name),
+	}, http.StatusOK)
+	return err
+}
+
+// RemoveGuestFromGuestList removes a guest from the guest list, freeing their booked seats, organiser only.
+func (c *Client) RemoveGuestFromGuestList(ctx context.Context, in *RemoveGuestFromGuestListInput) error {
+	_, err := c.do(ctx, request{
+		method:    http.MethodDelete,
+		path:      escapePath("/v1/guest_list/%s", in.Name),
+		organiser: true,
+	}, http.StatusOK)
+	return err
+}
+
+// ListArrivedGuests returns the arrived guests matching the input filters.
+// Filtering by requirements is organiser only.
+func (c *Client) ListArrivedGuests(ctx context.Context, in *ListGuestsInput) (ListArrivedGuestsOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v1/guests",
+		query:     listGuestsQuery(in),
+		organiser: filtersRequirements(in),
+	}, http.StatusOK)
+	if err != nil {
+		return ListArrivedGuestsOutput{}, err
+	}
+
+	var out ListArrivedGuestsOutput
+	if err := resp.decode(&out); err != nil {
+		return ListArrivedGuestsOutput{}, err
+	}
+	return out, nil
+}
+
+// GetEmptySeats returns the number of empty seats of the party.
+func (c *Client) GetEmptySeats(ctx context.Context) (GetEmptySeatsOutput, error) {
+	var out GetEmptySeatsOutput
+	if err := c.get(ctx, "/v1/seats_empty", &out); err != nil {
+		return GetEmptySeatsOutput{}, err
+	}
+	return out, nil
+}
+
+// ListTables returns the booked, arrived and empty seats of every table.
+func (c *Client) ListTables(ctx context.Context) (ListTablesOutput, error) {
+	var out ListTablesOutput
+	if err := c.get(ctx, "/v2/tables", &out); err != nil {
+		return ListTablesOutput{}, err
+	}
+	return out, nil
+}
+
+// CreateTable creates an empty table, organiser only.
+func (c *Client) CreateTable(ctx context.Context, in *CreateTableInput) (*Table, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v2/tables",
+		body:      in,
+		organiser: true,
+	}, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Table
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssignSeats moves a guest and their accompanying guests to other seats of their table, organiser only.
+func (c *Client) AssignSeats(ctx context.Context, in *AssignSeatsInput) (*Table, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPut,
+		path:      escapePath("/v2/guests/%s/seats", in.Name),
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Table
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SwapSeats swaps the occupants of two seats of a table, organiser only.
+func (c *Client) SwapSeats(ctx context.Context, in *SwapSeatsInput) (*Table, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v2/tables/" + strconv.Itoa(in.Table) + "/swap_seats",
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Table
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReserveTable restricts a table to guests of the input tiers and holds back some of its seats, organiser only.
+// An empty reservation opens the table to every tier.
+func (c *Client) ReserveTable(ctx context.Context, in *ReserveTableInput) (*Table, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPut,
+		path:      "/v2/tables/" + strconv.Itoa(in.Number) + "/reservation",
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Table
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReleaseTable opens a table to every tier and releases its held seats, organiser only.
+func (c *Client) ReleaseTable(ctx context.Context, number int) (*Table, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodDelete,
+		path:      "/v2/tables/" + strconv.Itoa(number) + "/reservation",
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Table
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListGroups returns the groups with their guests.
+func (c *Client) ListGroups(ctx context.Context) (ListGroupsOutput, error) {
+	var out ListGroupsOutput
+	if err := c.get(ctx, "/v2/groups", &out); err != nil {
+		return ListGroupsOutput{}, err
+	}
+	return out, nil
+}
+
+// AddGroupToGuestList adds a group and its guests to the guest list, seated together when no table is given.
+// Adding guests of a tier other than general is organiser only.
+func (c *Client) AddGroupToGuestList(ctx context.Context, in *AddGroupToGuestListInput) (*Group, error) {
+	organiser := false
+	for _, guest := range in.Guests {
+		organiser = organiser || tiered(guest.Tier)
+	}
+
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v2/groups",
+		body:      in,
+		organiser: organiser,
+	}, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Group
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGroup returns a group with its guests.
+func (c *Client) GetGroup(ctx context.Context, name string) (*Group, error) {
+	var out Group
+	if err := c.get(ctx, escapePath("/v2/groups/%s", name), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WelcomeGroup records the arrival of the guests of a group with their booked accompanying guests.
+func (c *Client) WelcomeGroup(ctx context.Context, in *WelcomeGroupInput) (*Group, error) {
+	resp, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   escapePath("/v2/groups/%s/arrival", in.Name),
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Group
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GoodbyeGroup records the departure of the guests of a group, freeing their seats.
+func (c *Client) GoodbyeGroup(ctx context.Context, in *GoodbyeGroupInput) error {
+	_, err := c.do(ctx, request{
+		method: http.MethodDelete,
+		path:   escapePath("/v2/groups/%s/arrival", in.Name),
+	}, http.StatusNoContent)
+	return err
+}
+
+// GetGuestRequirements returns the needs of a guest and their accompanying guests, organiser only.
+func (c *Client) GetGuestRequirements(ctx context.Context, name string) (*GuestRequirements, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      escapePath("/v2/guests/%s/requirements", name),
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out GuestRequirements
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetGuestRequirements replaces the needs of a guest and their accompanying guests, organiser only.
+func (c *Client) SetGuestRequirements(ctx context.Context, in *SetGuestRequirementsInput) (*GuestRequirements, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPut,
+		path:      escapePath("/v2/guests/%s/requirements", in.Guest),
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out GuestRequirements
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListCustomFields returns the custom guest attributes of the party.
+func (c *Client) ListCustomFields(ctx context.Context) (ListCustomFieldsOutput, error) {
+	var out ListCustomFieldsOutput
+	if err := c.get(ctx, "/v2/custom_fields", &out); err != nil {
+		return ListCustomFieldsOutput{}, err
+	}
+	return out, nil
+}
+
+// SetCustomFields replaces the custom guest attributes of the party, organiser only.
+// The values of the fields left out are deleted.
+func (c *Client) SetCustomFields(ctx context.Context, in *SetCustomFieldsInput) (ListCustomFieldsOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPut,
+		path:      "/v2/custom_fields",
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return ListCustomFieldsOutput{}, err
+	}
+
+	var out ListCustomFieldsOutput
+	if err := resp.decode(&out); err != nil {
+		return ListCustomFieldsOutput{}, err
+	}
+	return out, nil
+}
+
+// GetCateringSummary counts the dietary requirements, allergies and accessibility needs per table, organiser only.
+func (c *Client) GetCateringSummary(ctx context.Context) (*CateringSummary, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v2/catering",
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out CateringSummary
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListConstraints returns the seating constraints in the order they were added, organiser only.
+func (c *Client) ListConstraints(ctx context.Context) (ListConstraintsOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v2/constraints",
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return ListConstraintsOutput{}, err
+	}
+
+	var out ListConstraintsOutput
+	if err := resp.decode(&out); err != nil {
+		return ListConstraintsOutput{}, err
+	}
+	return out, nil
+}
+
+// CreateConstraint adds a seating constraint on guests of the guest list, organiser only.
+func (c *Client) CreateConstraint(ctx context.Context, in *CreateConstraintInput) (*Constraint, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v2/constraints",
+		body:      in,
+		organiser: true,
+	}, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Constraint
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteConstraint deletes a seating constraint, organiser only.
+func (c *Client) DeleteConstraint(ctx context.Context, id string) error {
+	_, err := c.do(ctx, request{
+		method:    http.MethodDelete,
+		path:      escapePath("/v2/constraints/%s", id),
+		organiser: true,
+	}, http.StatusNoContent)
+	return err
+}
+
+// PlanSeating computes a seating plan meeting the table sizes and the constraints, organiser only.
+// The plan is not applied.
+func (c *Client) PlanSeating(ctx context.Context) (*SeatingPlan, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v2/seating_plan",
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out SeatingPlan
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CheckSeating checks a seating plan against the table sizes and the constraints, organiser only.
+// An input without assignments checks the tables of the guest list.
+func (c *Client) CheckSeating(ctx context.Context, in *CheckSeatingInput) (*SeatingPlan, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v2/seating_plan/check",
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out SeatingPlan
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLayout returns the venue layout with the size of its tables.
+func (c *Client) GetLayout(ctx context.Context) (GetLayoutOutput, error) {
+	var out GetLayoutOutput
+	if err := c.get(ctx, "/v2/layout", &out); err != nil {
+		return GetLayoutOutput{}, err
+	}
+	return out, nil
+}
+
+// SetLayout replaces the venue layout, organiser only.
+func (c *Client) SetLayout(ctx context.Context, in *SetLayoutInput) (*GetLayoutOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPut,
+		path:      "/v2/layout",
+		body:      in,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out GetLayoutOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSeatingChart returns the seating chart of the venue layout as an SVG image.
+// A non-empty highlight marks the table of the guest.
+func (c *Client) GetSeatingChart(ctx context.Context, highlight string) ([]byte, error) {
+	query := url.Values{}
+	if highlight != "" {
+		query.Set("highlight", highlight)
+	}
+
+	resp, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/v2/layout/seating_chart",
+		query:  query,
+		accept: "image/svg+xml",
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// GetStats returns the party totals of seats and guests.
+func (c *Client) GetStats(ctx context.Context) (GetStatsOutput, error) {
+	var out GetStatsOutput
+	if err := c.get(ctx, "/v2/stats", &out); err != nil {
+		return GetStatsOutput{}, err
+	}
+	return out, nil
+}
+
+// CreateInvitation invites a guest, organiser only.
+func (c *Client) CreateInvitation(ctx context.Context, in *CreateInvitationInput) (*CreateInvitationOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v1/invitations",
+		body:      in,
+		organiser: true,
+	}, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var out CreateInvitationOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetInvitation returns the invitation of a token.
+func (c *Client) GetInvitation(ctx context.Context, token string) (*GetInvitationOutput, error) {
+	var out GetInvitationOutput
+	if err := c.get(ctx, escapePath("/v1/rsvp/%s", token), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RespondToInvitation accepts or declines the invitation of a token.
+func (c *Client) RespondToInvitation(ctx context.Context, in *RespondToInvitationInput) (*RespondToInvitationOutput, error) {
+	resp, err := c.do(ctx, request{
+		method: http.MethodPost,
+		path:   escapePath("/v1/rsvp/%s", in.Token),
+		body:   in,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out RespondToInvitationOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// IssueCheckInCode issues a check-in code for a guest, organiser only.
+func (c *Client) IssueCheckInCode(ctx context.Context, in *IssueCheckInCodeInput) (*IssueCheckInCodeOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      escapePath("/v1/guest_list/%s/checkin_code", in.Name),
+		organiser: true,
+	}, http.StatusCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	var out IssueCheckInCodeOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCheckInCodeQR returns an active check-in code as a PNG QR code of size pixels, organiser only.
+// A zero size uses the default size of the API.
+func (c *Client) GetCheckInCodeQR(ctx context.Context, code string, size int) ([]byte, error) {
+	query := url.Values{}
+	if size != 0 {
+		query.Set("size", strconv.Itoa(size))
+	}
+
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      escapePath("/v1/checkin/%s/qr", code),
+		query:     query,
+		accept:    "image/png",
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// RevokeCheckInCode revokes a check-in code, organiser only.
+func (c *Client) RevokeCheckInCode(ctx context.Context, code string) error {
+	_, err := c.do(ctx, request{
+		method:    http.MethodDelete,
+		path:      escapePath("/v1/checkin/%s", code),
+		organiser: true,
+	}, http.StatusOK)
+	return err
+}
+
+// CheckIn records the arrival of the guest of a check-in code.
+func (c *Client) CheckIn(ctx context.Context, in *CheckInInput) (*CheckInOutput, error) {
+	resp, err := c.do(ctx, request{
+		method: http.MethodPut,
+		path:   escapePath("/v1/checkin/%s", in.Code),
+		body:   in,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out CheckInOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportGuests adds the guests to the guest list, organiser only.
+// As with the service, rejected rows are reported in the output errors and nothing is imported.
+func (c *Client) ImportGuests(ctx context.Context, in *ImportGuestsInput) (*ImportGuestsOutput, error) {
+	query := url.Values{}
+	if in.DryRun {
+		query.Set("dry_run", "true")
+	}
+
+	guests := in.Guests
+	if guests == nil {
+		guests = []ImportGuestRow{}
+	}
+
+	resp, err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/v1/guest_list/import",
+		query:     query,
+		body:      guests,
+		organiser: true,
+	}, http.StatusOK, http.StatusCreated, http.StatusUnprocessableEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	var out ImportGuestsOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ImportGuestsCSV adds the guests of a CSV file with a name, table and accompanying_guests header, organiser only.
+func (c *Client) ImportGuestsCSV(ctx context.Context, csv []byte, dryRun bool) (*ImportGuestsOutput, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	resp, err := c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        "/v1/guest_list/import",
+		query:       query,
+		body:        csv,
+		contentType: mimeTextCSV,
+		organiser:   true,
+	}, http.StatusOK, http.StatusCreated, http.StatusUnprocessableEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	var out ImportGuestsOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSeatingReport returns the guests grouped by table, organiser only.
+func (c *Client) GetSeatingReport(ctx context.Context) (*GetSeatingReportOutput, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v1/export/seating_report",
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var out GetSeatingReportOutput
+	if err := resp.decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportGuestListCSV returns the guest list as CSV, organiser only.
+func (c *Client) ExportGuestListCSV(ctx context.Context) ([]byte, error) {
+	resp, err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/v1/export/guest_list",
+		accept:    mimeTextCSV,
+		organiser: true,
+	}, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: path}, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return resp.decode(out)
+}
+
+// listGuestsQuery encodes the guest list filters, sort order and page as query parameters.
+func listGuestsQuery(in *ListGuestsInput) url.Values {
+	query := url.Values{}
+	if in == nil {
+		return query
+	}
+
+	if in.Table != 0 {
+		query.Set("table", strconv.Itoa(in.Table))
+	}
+
+	if in.Arrived != nil {
+		query.Set("arrived", strconv.FormatBool(*in.Arrived))
+	}
+
+	if in.NamePrefix != "" {
+		query.Set("name_prefix", in.NamePrefix)
+	}
+
+	if in.Dietary != "" {
+		query.Set("dietary", in.Dietary)
+	}
+
+	if in.Allergy != "" {
+		query.Set("allergy", in.Allergy)
+	}
+
+	if in.Accessibility != "" {
+		query.Set("accessibility", in.Accessibility)
+	}
+
+	if in.Custom != "" {
+		query.Set("custom", in.Custom)
+	}
+
+	if in.Sort != "" {
+		query.Set("sort", in.Sort)
+	}
+
+	if in.Order != "" {
+		query.Set("order", in.Order)
+	}
+
+	if in.Cursor != "" {
+		query.Set("cursor", in.Cursor)
+	}
+
+	if in.Limit != 0 {
+		query.Set("limit", strconv.Itoa(in.Limit))
+	}
+	return query
+}
+
+// filtersRequirements reports whether the guest list query filters by the private guest requirements.
+func filtersRequirements(in *ListGuestsInput) bool {
+	return in != nil && (in.Dietary != "" || in.Allergy != "" || in.Accessibility != "" || in.Custom != "")
+}
+
+// tiered reports whether adding a guest of the tier is kept to organisers, as for every tier but general.
+func tiered(tier string) bool {
+	return tier != "" && tier != TierGeneral
+}
@@ -1,25 +1,211 @@
 package database
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
-	"log"
+	"io/ioutil"
+	"net"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
 )
 
-var ErrRecordNotFound = gorm.ErrRecordNotFound
+const (
+	defaultConnectTimeout = 30 * time.Second
+	defaultRetryBackoff   = 500 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+	defaultDialTimeout    = 5 * time.Second
+
+	// tlsConfigName is the name the custom CA TLS configuration is registered with in the MySQL driver
+	tlsConfigName = "custom"
+)
+
+var (
+	ErrRecordNotFound = gorm.ErrRecordNotFound
+
+	ErrTLSCACertInvalid = errors.New("invalid tls ca certificate")
+)
 
 type DBConn struct {
 	*gorm.DB
 }
 
+// Config defines the connection to a MySQL database.
+// Zero values of the retry settings use the defaults, zero values of the pool settings keep the driver defaults.
+type Config struct {
+	Host     string
+	User     string
+	Password string
+	Name     string
+
+	// Params are extra DSN parameters, e.g. charset or readTimeout, they take precedence over the defaults
+	Params map[string]string
+
+	// TLS is the MySQL driver TLS mode: true, false, skip-verify or preferred.
+	// When TLSCACert is set, the server certificate is verified against the CA certificate file.
+	TLS       string
+	TLSCACert string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// ConnectTimeout bounds the time spent retrying to connect
+	ConnectTimeout time.Duration
+	RetryBackoff   time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Connection connects to the database with the default settings.
 func Connection(host, user, password, dbName string) (*DBConn, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true", user, password, host, dbName)
-	conn, err := gorm.Open("mysql", dsn)
+	return Connect(context.Background(), Config{
+		Host:     host,
+		User:     user,
+		Password: password,
+		Name:     dbName,
+	})
+}
+
+// Connect connects to the database, retrying with exponential backoff
+// until the database answers or the connect timeout expires.
+func Connect(ctx context.Context, cfg Config) (*DBConn, error) {
+	dsn, err := cfg.dsn()
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("could not build dsn: %w", err)
 	}
+
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancel()
+
+	var conn *gorm.DB
+	err = retry(ctx, cfg.RetryBackoff, cfg.MaxBackoff, func() error {
+		var err error
+		conn, err = gorm.Open("mysql", dsn)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to database: %w", err)
+	}
+
 	conn.SingularTable(true)
+
+	db := conn.DB()
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
 	return &DBConn{conn}, nil
 }
+
+// Ping checks that the database is reachable.
+func (c *DBConn) Ping(ctx context.Context) error {
+	return c.DB.DB().PingContext(ctx)
+}
+
+func (cfg Config) dsn() (string, error) {
+	mysqlCfg := mysql.NewConfig()
+	mysqlCfg.User = cfg.User
+	mysqlCfg.Passwd = cfg.Password
+	mysqlCfg.Net = "tcp"
+	mysqlCfg.Addr = cfg.Host
+	mysqlCfg.DBName = cfg.Name
+	mysqlCfg.ParseTime = true
+	mysqlCfg.Timeout = defaultDialTimeout
+	mysqlCfg.TLSConfig = cfg.TLS
+
+	if len(cfg.Params) > 0 {
+		mysqlCfg.Params = make(map[string]string, len(cfg.Params))
+		for k, v := range cfg.Params {
+			mysqlCfg.Params[k] = v
+		}
+	}
+
+	if cfg.TLSCACert != "" {
+		tlsCfg, err := cfg.tlsConfig()
+		if err != nil {
+			return "", err
+		}
+
+		if err := mysql.RegisterTLSConfig(tlsConfigName, tlsCfg); err != nil {
+			return "", fmt.Errorf("could not register tls config: %w", err)
+		}
+		mysqlCfg.TLSConfig = tlsConfigName
+	}
+	return mysqlCfg.FormatDSN(), nil
+}
+
+func (cfg Config) tlsConfig() (*tls.Config, error) {
+	pem, err := ioutil.ReadFile(cfg.TLSCACert)
+	if err != nil {
+		return nil, fmt.Errorf("could not read tls ca certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, ErrTLSCACertInvalid
+	}
+
+	serverName, _, err := net.SplitHostPort(cfg.Host)
+	if err != nil {
+		serverName = cfg.Host
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// retry calls fn until it succeeds or the context is done, doubling the wait between attempts up to maxBackoff.
+// It returns the last error of fn when the context is done.
+func retry(ctx context.Context, backoff, maxBackoff time.Duration, fn func() error) error {
+	var attempts int
+
+	if backoff == 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	if maxBackoff == 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		attempts++
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up after %d attempts: %w", attempts, err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDSN(t *testing.T) {
+	t.Run("builds the dsn with the extra parameters", func(t *testing.T) {
+		cfg := Config{
+			Host:     "db:3306",
+			User:     "user",
+			Password: "p@ss:word/",
+			Name:     "party_db",
+			Params:   map[string]string{"charset": "utf8mb4", "timeout": "1s"},
+			TLS:      "skip-verify",
+		}
+
+		dsn, err := cfg.dsn()
+		require.NoError(t, err)
+
+		observed, err := mysql.ParseDSN(dsn)
+		require.NoError(t, err)
+
+		assert.Equal(t, "user", observed.User)
+		assert.Equal(t, "p@ss:word/", observed.Passwd)
+		assert.Equal(t, "db:3306", observed.Addr)
+		assert.Equal(t, "party_db", observed.DBName)
+		assert.True(t, observed.ParseTime)
+		assert.Equal(t, "skip-verify", observed.TLSConfig)
+		assert.Equal(t, time.Second, observed.Timeout)
+		assert.Equal(t, "utf8mb4", observed.Params["charset"])
+	})
+
+	t.Run("returns an error for an invalid ca certificate", func(t *testing.T) {
+		caCert := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, ioutil.WriteFile(caCert, []byte("not a certificate"), 0o600))
+
+		_, err := Config{Host: "db", TLS: "true", TLSCACert: caCert}.dsn()
+		assert.True(t, errors.Is(err, ErrTLSCACertInvalid))
+	})
+}
+
+func TestRetry(t *testing.T) {
+	errConn := errors.New("connection refused")
+
+	t.Run("retries until the call succeeds", func(t *testing.T) {
+		var attempts int
+
+		err := retry(context.Background(), time.Millisecond, 4*time.Millisecond, func() error {
+			attempts++
+			if attempts < 4 {
+				return errConn
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("returns the last error once the deadline expires", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var attempts int
+
+		err := retry(ctx, time.Millisecond, 10*time.Millisecond, func() error {
+			attempts++
+			return errConn
+		})
+
+		assert.True(t, errors.Is(err, errConn))
+		assert.Greater(t, attempts, 1)
+	})
+}
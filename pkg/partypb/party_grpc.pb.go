@@ -0,0 +1,365 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: party/v1/party.proto
+
+package partypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PartyServiceClient is the client API for PartyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PartyServiceClient interface {
+	// AddGuestToGuestList books seats at a table for a guest and their accompanying guests.
+	// The table is created with the default size when it does not exist.
+	AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestListRequest, opts ...grpc.CallOption) (*AddGuestToGuestListResponse, error)
+	// GetGuestList lists the guests matching the filters.
+	GetGuestList(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*GetGuestListResponse, error)
+	// WelcomeGuest records the arrival of a guest with the actual number of accompanying guests.
+	WelcomeGuest(ctx context.Context, in *WelcomeGuestRequest, opts ...grpc.CallOption) (*WelcomeGuestResponse, error)
+	// GoodbyeGuest records the departure of a guest and frees their seats.
+	GoodbyeGuest(ctx context.Context, in *GoodbyeGuestRequest, opts ...grpc.CallOption) (*GoodbyeGuestResponse, error)
+	// ListArrivedGuests lists the arrived guests still at the party matching the filters, the arrived filter is ignored.
+	ListArrivedGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListArrivedGuestsResponse, error)
+	// GetEmptySeats counts the empty seats of every table.
+	GetEmptySeats(ctx context.Context, in *GetEmptySeatsRequest, opts ...grpc.CallOption) (*GetEmptySeatsResponse, error)
+	// WatchEvents streams the party events from the time of the call until the client cancels.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (PartyService_WatchEventsClient, error)
+}
+
+type partyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPartyServiceClient(cc grpc.ClientConnInterface) PartyServiceClient {
+	return &partyServiceClient{cc}
+}
+
+func (c *partyServiceClient) AddGuestToGuestList(ctx context.Context, in *AddGuestToGuestListRequest, opts ...grpc.CallOption) (*AddGuestToGuestListResponse, error) {
+	out := new(AddGuestToGuestListResponse)
+	err := c.cc.Invoke(ctx, "/getground.party.v1.PartyService/AddGuestToGuestList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) GetGuestList(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*GetGuestListResponse, error) {
+	out := new(GetGuestListResponse)
+	err := c.cc.Invoke(ctx, "/getground.party.v1.PartyService/GetGuestList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) WelcomeGuest(ctx context.Context, in *WelcomeGuestRequest, opts ...grpc.CallOption) (*WelcomeGuestResponse, error) {
+	out := new(WelcomeGuestResponse)
+	err := c.cc.Invoke(ctx, "/getground.party.v1.PartyService/WelcomeGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) GoodbyeGuest(ctx context.Context, in *GoodbyeGuestRequest, opts ...grpc.CallOption) (*GoodbyeGuestResponse, error) {
+	out := new(GoodbyeGuestResponse)
+	err := c.cc.Invoke(ctx, "/getground.party.v1.PartyService/GoodbyeGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) ListArrivedGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListArrivedGuestsResponse, error) {
+	out := new(ListArrivedGuestsResponse)
+	err := c.cc.Invoke(ctx, "/getground.party.v1.PartyService/ListArrivedGuests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) GetEmptySeats(ctx context.Context, in *GetEmptySeatsRequest, opts ...grpc.CallOption) (*GetEmptySeatsResponse, error) {
+	out := new(GetEmptySeatsResponse)
+	err := c.cc.Invoke(ctx, "/getground.party.v1.PartyService/GetEmptySeats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (PartyService_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PartyService_ServiceDesc.Streams[0], "/getground.party.v1.PartyService/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &partyServiceWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PartyService_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type partyServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *partyServiceWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PartyServiceServer is the server API for PartyService service.
+// All implementations must embed UnimplementedPartyServiceServer
+// for forward compatibility
+type PartyServiceServer interface {
+	// AddGuestToGuestList books seats at a table for a guest and their accompanying guests.
+	// The table is created with the default size when it does not exist.
+	AddGuestToGuestList(context.Context, *AddGuestToGuestListRequest) (*AddGuestToGuestListResponse, error)
+	// GetGuestList lists the guests matching the filters.
+	GetGuestList(context.Context, *ListGuestsRequest) (*GetGuestListResponse, error)
+	// WelcomeGuest records the arrival of a guest with the actual number of accompanying guests.
+	WelcomeGuest(context.Context, *WelcomeGuestRequest) (*WelcomeGuestResponse, error)
+	// GoodbyeGuest records the departure of a guest and frees their seats.
+	GoodbyeGuest(context.Context, *GoodbyeGuestRequest) (*GoodbyeGuestResponse, error)
+	// ListArrivedGuests lists the arrived guests still at the party matching the filters, the arrived filter is ignored.
+	ListArrivedGuests(context.Context, *ListGuestsRequest) (*ListArrivedGuestsResponse, error)
+	// GetEmptySeats counts the empty seats of every table.
+	GetEmptySeats(context.Context, *GetEmptySeatsRequest) (*GetEmptySeatsResponse, error)
+	// WatchEvents streams the party events from the time of the call until the client cancels.
+	WatchEvents(*WatchEventsRequest, PartyService_WatchEventsServer) error
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+// UnimplementedPartyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPartyServiceServer struct {
+}
+
+func (UnimplementedPartyServiceServer) AddGuestToGuestList(context.Context, *AddGuestToGuestListRequest) (*AddGuestToGuestListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGuestToGuestList not implemented")
+}
+func (UnimplementedPartyServiceServer) GetGuestList(context.Context, *ListGuestsRequest) (*GetGuestListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGuestList not implemented")
+}
+func (UnimplementedPartyServiceServer) WelcomeGuest(context.Context, *WelcomeGuestRequest) (*WelcomeGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WelcomeGuest not implemented")
+}
+func (UnimplementedPartyServiceServer) GoodbyeGuest(context.Context, *GoodbyeGuestRequest) (*GoodbyeGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GoodbyeGuest not implemented")
+}
+func (UnimplementedPartyServiceServer) ListArrivedGuests(context.Context, *ListGuestsRequest) (*ListArrivedGuestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArrivedGuests not implemented")
+}
+func (UnimplementedPartyServiceServer) GetEmptySeats(context.Context, *GetEmptySeatsRequest) (*GetEmptySeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmptySeats not implemented")
+}
+func (UnimplementedPartyServiceServer) WatchEvents(*WatchEventsRequest, PartyService_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedPartyServiceServer) mustEmbedUnimplementedPartyServiceServer() {}
+
+// UnsafePartyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PartyServiceServer will
+// result in compilation errors.
+type UnsafePartyServiceServer interface {
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+func RegisterPartyServiceServer(s grpc.ServiceRegistrar, srv PartyServiceServer) {
+	s.RegisterService(&PartyService_ServiceDesc, srv)
+}
+
+func _PartyService_AddGuestToGuestList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddGuestToGuestListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).AddGuestToGuestList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.party.v1.PartyService/AddGuestToGuestList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).AddGuestToGuestList(ctx, req.(*AddGuestToGuestListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_GetGuestList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).GetGuestList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.party.v1.PartyService/GetGuestList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).GetGuestList(ctx, req.(*ListGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_WelcomeGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WelcomeGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).WelcomeGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.party.v1.PartyService/WelcomeGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).WelcomeGuest(ctx, req.(*WelcomeGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_GoodbyeGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoodbyeGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).GoodbyeGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.party.v1.PartyService/GoodbyeGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).GoodbyeGuest(ctx, req.(*GoodbyeGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_ListArrivedGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).ListArrivedGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.party.v1.PartyService/ListArrivedGuests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).ListArrivedGuests(ctx, req.(*ListGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_GetEmptySeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmptySeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).GetEmptySeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/getground.party.v1.PartyService/GetEmptySeats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).GetEmptySeats(ctx, req.(*GetEmptySeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PartyServiceServer).WatchEvents(m, &partyServiceWatchEventsServer{stream})
+}
+
+type PartyService_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type partyServiceWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *partyServiceWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PartyService_ServiceDesc is the grpc.ServiceDesc for PartyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PartyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "getground.party.v1.PartyService",
+	HandlerType: (*PartyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddGuestToGuestList",
+			Handler:    _PartyService_AddGuestToGuestList_Handler,
+		},
+		{
+			MethodName: "GetGuestList",
+			Handler:    _PartyService_GetGuestList_Handler,
+		},
+		{
+			MethodName: "WelcomeGuest",
+			Handler:    _PartyService_WelcomeGuest_Handler,
+		},
+		{
+			MethodName: "GoodbyeGuest",
+			Handler:    _PartyService_GoodbyeGuest_Handler,
+		},
+		{
+			MethodName: "ListArrivedGuests",
+			Handler:    _PartyService_ListArrivedGuests_Handler,
+		},
+		{
+			MethodName: "GetEmptySeats",
+			Handler:    _PartyService_GetEmptySeats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _PartyService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "party/v1/party.proto",
+}